@@ -68,6 +68,25 @@ func TestHarnessCapella(t *testing.T) {
 		append(
 			defaultHarnessOpts(harnessConfig{t: t, v: clparams.CapellaVersion, finalized: true}),
 			beacontest.WithTestFromFs(Harnesses, "expected_withdrawals"),
+			beacontest.WithTestFromFs(Harnesses, "pending_queues"),
+		)...,
+	)
+}
+
+func TestHarnessFulu(t *testing.T) {
+	beacontest.Execute(
+		append(
+			defaultHarnessOpts(harnessConfig{t: t, v: clparams.FuluVersion}),
+			beacontest.WithTestFromFs(Harnesses, "pending_queues_fulu"),
+		)...,
+	)
+}
+
+func TestHarnessFuluFinalized(t *testing.T) {
+	beacontest.Execute(
+		append(
+			defaultHarnessOpts(harnessConfig{t: t, v: clparams.FuluVersion, finalized: true}),
+			beacontest.WithTestFromFs(Harnesses, "pending_queues_fulu_f"),
 		)...,
 	)
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
 	"github.com/erigontech/erigon/cl/utils"
 )
 
@@ -451,3 +452,126 @@ func (a *ApiHandler) getRandao(w http.ResponseWriter, r *http.Request) (*beaconh
 		WithFinalized(slot <= a.forkchoiceStore.FinalizedSlot()).
 		WithOptimistic(isOptimistic), nil
 }
+
+// readStateField resolves the state_id of r and returns a field of that state: fromState picks it
+// from states still held by fork choice, fromHistory reads it for canonical states processed by
+// the antiquary and returns nil if it is not available. States older than minVersion do not have
+// the field.
+func (a *ApiHandler) readStateField(
+	r *http.Request,
+	minVersion clparams.StateVersion,
+	fromState func(s *state.CachingBeaconState) any,
+	fromHistory func(stateGetter state_accessors.GetValFn, slot uint64) (any, error),
+) (*beaconhttp.BeaconResponse, error) {
+	ctx := r.Context()
+
+	tx, err := a.indiciesDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockId, err := beaconhttp.StateIdFromRequest(r)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(httpStatus, err)
+	}
+
+	isOptimistic := a.forkchoiceStore.IsRootOptimistic(blockRoot)
+	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read block slot: %x", blockRoot))
+	}
+	version := a.beaconChainCfg.GetCurrentStateVersion(*slot / a.beaconChainCfg.SlotsPerEpoch)
+	if version < minVersion {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("state at slot %d is pre-%s", *slot, minVersion))
+	}
+	canonicalRoot, err := beacon_indicies.ReadCanonicalBlockRoot(tx, *slot)
+	if err != nil {
+		return nil, err
+	}
+	isFinalized := canonicalRoot == blockRoot && *slot <= a.forkchoiceStore.FinalizedSlot()
+
+	s, err := a.forkchoiceStore.GetStateAtBlockRoot(blockRoot, true)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	if s != nil {
+		return newBeaconResponse(fromState(s)).
+			WithVersion(version).
+			WithOptimistic(isOptimistic).
+			WithFinalized(isFinalized), nil
+	}
+
+	if canonicalRoot != blockRoot {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
+	}
+	snRoTx := a.caplinStateSnapshots.View()
+	defer snRoTx.Close()
+
+	data, err := fromHistory(state_accessors.GetValFnTxAndSnapshot(tx, snRoTx), *slot)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
+	}
+	return newBeaconResponse(data).
+		WithVersion(version).
+		WithOptimistic(isOptimistic).
+		WithFinalized(isFinalized), nil
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesPendingDeposits(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.readStateField(r, clparams.ElectraVersion,
+		func(s *state.CachingBeaconState) any { return s.PendingDeposits() },
+		func(stateGetter state_accessors.GetValFn, slot uint64) (any, error) {
+			pendingDeposits, err := a.stateReader.ReadPendingDeposits(stateGetter, slot)
+			if err != nil || pendingDeposits == nil {
+				return nil, err
+			}
+			return pendingDeposits, nil
+		})
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.readStateField(r, clparams.ElectraVersion,
+		func(s *state.CachingBeaconState) any { return s.PendingPartialWithdrawals() },
+		func(stateGetter state_accessors.GetValFn, slot uint64) (any, error) {
+			pendingWithdrawals, err := a.stateReader.ReadPendingPartialWithdrawals(stateGetter, slot)
+			if err != nil || pendingWithdrawals == nil {
+				return nil, err
+			}
+			return pendingWithdrawals, nil
+		})
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesPendingConsolidations(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.readStateField(r, clparams.ElectraVersion,
+		func(s *state.CachingBeaconState) any { return s.PendingConsolidations() },
+		func(stateGetter state_accessors.GetValFn, slot uint64) (any, error) {
+			pendingConsolidations, err := a.stateReader.ReadPendingConsolidations(stateGetter, slot)
+			if err != nil || pendingConsolidations == nil {
+				return nil, err
+			}
+			return pendingConsolidations, nil
+		})
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesProposerLookahead(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.readStateField(r, clparams.FuluVersion,
+		func(s *state.CachingBeaconState) any { return s.ProposerLookahead() },
+		func(stateGetter state_accessors.GetValFn, slot uint64) (any, error) {
+			proposerLookahead, err := a.stateReader.ReadProposerLookahead(stateGetter, slot)
+			if err != nil || proposerLookahead == nil {
+				return nil, err
+			}
+			return proposerLookahead, nil
+		})
+}
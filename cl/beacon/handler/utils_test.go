@@ -65,6 +65,29 @@ func setupTestingHandler(t *testing.T, v clparams.StateVersion, logger log.Logge
 		bcfg.BellatrixForkEpoch = 1
 		bcfg.CapellaForkEpoch = 1
 		blocks, preState, postState = tests.GetCapellaRandom()
+	} else if v >= clparams.ElectraVersion {
+		bcfg.AltairForkEpoch = 1
+		bcfg.BellatrixForkEpoch = 1
+		bcfg.CapellaForkEpoch = 1
+		bcfg.DenebForkEpoch = 1
+		bcfg.ElectraForkEpoch = 1
+		blocks, preState, postState = tests.GetElectraRandom()
+		if v == clparams.FuluVersion {
+			// the electra chain is replayed on top of its pre-state upgraded to fulu, keeping
+			// the electra fork version the blocks are signed with
+			bcfg.FuluForkEpoch = preState.Slot() / bcfg.SlotsPerEpoch
+			for _, s := range []*state.CachingBeaconState{preState, postState} {
+				fork := *s.Fork()
+				require.NoError(t, s.UpgradeToFulu())
+				s.SetFork(&fork)
+			}
+			for i, block := range blocks {
+				enc, err := block.EncodeSSZ(nil)
+				require.NoError(t, err)
+				blocks[i] = cltypes.NewSignedBeaconBlock(&bcfg, clparams.FuluVersion)
+				require.NoError(t, blocks[i].DecodeSSZ(enc, int(clparams.FuluVersion)))
+			}
+		}
 	}
 	fcu = mock_services2.NewForkChoiceStorageMock(t)
 	db = memdb.NewTestDB(t, kv.ChainDB)
@@ -87,6 +87,14 @@ type CaplinConfig struct {
 
 	// Extra
 	EnableEngineAPI bool
+
+	// Built-in validator client, it requires the Beacon API to be enabled
+	ValidatorClient       bool
+	ValidatorKeystoresDir string
+	ValidatorFeeRecipient common.Address
+	ValidatorGraffiti     string
+	KeymanagerAddr        string
+	KeymanagerTokenFile   string
 }
 
 func (c CaplinConfig) IsDevnet() bool {
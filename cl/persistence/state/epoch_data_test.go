@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/stretchr/testify/require"
@@ -47,3 +48,29 @@ func TestEpochData(t *testing.T) {
 
 	require.Equal(t, e, e2)
 }
+
+func TestEpochDataFulu(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	lookahead := solid.NewUint64VectorSSZ(int((1 + cfg.MinSeedLookahead) * cfg.SlotsPerEpoch))
+	for i := 0; i < lookahead.Length(); i++ {
+		lookahead.Set(i, uint64(i*7))
+	}
+	e := &EpochData{
+		TotalActiveBalance:          123,
+		JustificationBits:           &cltypes.JustificationBits{true},
+		CurrentJustifiedCheckpoint:  solid.Checkpoint{Epoch: 123},
+		PreviousJustifiedCheckpoint: solid.Checkpoint{Epoch: 123},
+		FinalizedCheckpoint:         solid.Checkpoint{Epoch: 123},
+		HistoricalSummariesLength:   235,
+		HistoricalRootsLength:       345,
+		ProposerLookahead:           lookahead,
+		BeaconConfig:                cfg,
+		Version:                     clparams.FuluVersion,
+	}
+	var b bytes.Buffer
+	require.NoError(t, e.WriteTo(&b))
+
+	e2 := &EpochData{BeaconConfig: cfg, Version: clparams.FuluVersion}
+	require.NoError(t, e2.ReadFrom(&b))
+	require.Equal(t, e, e2)
+}
@@ -989,6 +989,62 @@ func (r *HistoricalStatesReader) ReadRandaoMixBySlotAndIndex(tx kv.Tx, kvGetter
 	return common.BytesToHash(mixBytes), nil
 }
 
+// ReadPendingDeposits reads the pending deposits queue of the state at slot, or nil if the state is not available.
+func (r *HistoricalStatesReader) ReadPendingDeposits(kvGetter state_accessors.GetValFn, slot uint64) (*solid.ListSSZ[*solid.PendingDeposit], error) {
+	sd, err := state_accessors.ReadSlotData(kvGetter, slot, r.cfg)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	pendingDeposits := solid.NewPendingDepositList(r.cfg)
+	if err := readQueueSSZ(kvGetter, slot, kv.PendingDepositsDump, kv.PendingDeposits, pendingDeposits); err != nil {
+		return nil, fmt.Errorf("failed to read pending deposits: %w", err)
+	}
+	return pendingDeposits, nil
+}
+
+// ReadPendingPartialWithdrawals reads the pending partial withdrawals queue of the state at slot, or nil if the state is not available.
+func (r *HistoricalStatesReader) ReadPendingPartialWithdrawals(kvGetter state_accessors.GetValFn, slot uint64) (*solid.ListSSZ[*solid.PendingPartialWithdrawal], error) {
+	sd, err := state_accessors.ReadSlotData(kvGetter, slot, r.cfg)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	pendingWithdrawals := solid.NewPendingWithdrawalList(r.cfg)
+	if err := readQueueSSZ(kvGetter, slot, kv.PendingPartialWithdrawalsDump, kv.PendingPartialWithdrawals, pendingWithdrawals); err != nil {
+		return nil, fmt.Errorf("failed to read pending withdrawals: %w", err)
+	}
+	return pendingWithdrawals, nil
+}
+
+// ReadPendingConsolidations reads the pending consolidations queue of the state at slot, or nil if the state is not available.
+func (r *HistoricalStatesReader) ReadPendingConsolidations(kvGetter state_accessors.GetValFn, slot uint64) (*solid.ListSSZ[*solid.PendingConsolidation], error) {
+	sd, err := state_accessors.ReadSlotData(kvGetter, slot, r.cfg)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	pendingConsolidations := solid.NewPendingConsolidationList(r.cfg)
+	if err := readQueueSSZ(kvGetter, slot, kv.PendingConsolidationsDump, kv.PendingConsolidations, pendingConsolidations); err != nil {
+		return nil, fmt.Errorf("failed to read pending consolidations: %w", err)
+	}
+	return pendingConsolidations, nil
+}
+
+// ReadProposerLookahead reads the proposer lookahead of the state at slot, or nil if the state is not available.
+func (r *HistoricalStatesReader) ReadProposerLookahead(kvGetter state_accessors.GetValFn, slot uint64) (solid.Uint64VectorSSZ, error) {
+	sd, err := state_accessors.ReadSlotData(kvGetter, slot, r.cfg)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	// the lookahead only changes at epoch boundaries, so it is kept with the epoch data
+	epochData, err := state_accessors.ReadEpochData(kvGetter, r.cfg.RoundSlotToEpoch(slot), r.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read epoch data: %w", err)
+	}
+	if epochData == nil {
+		return nil, nil
+	}
+	return epochData.ProposerLookahead, nil
+}
+
 func readQueueSSZ[T solid.EncodableHashableSSZ](kvGetter state_accessors.GetValFn, slot uint64, dumpTable, diffsTable string, out *solid.ListSSZ[T]) error {
 	remainder := slot % clparams.SlotsPerDump
 	freshDumpSlot := slot - remainder
@@ -0,0 +1,383 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package keymanager
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/validator/keystore"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+)
+
+// Statuses reported per key by the import and delete endpoints.
+const (
+	StatusImported  = "imported"
+	StatusDuplicate = "duplicate"
+	StatusDeleted   = "deleted"
+	StatusNotActive = "not_active"
+	StatusNotFound  = "not_found"
+	StatusError     = "error"
+)
+
+// Api serves the keymanager REST API (https://github.com/ethereum/keymanager-APIs). Every
+// request must carry the bearer token the Api was created with.
+type Api struct {
+	keys               *KeyManager
+	slashingProtection *slashing_protection.SlashingProtection
+	token              string
+	logger             log.Logger
+	router             chi.Router
+}
+
+func NewApi(keys *KeyManager, slashingProtection *slashing_protection.SlashingProtection, token string, logger log.Logger) *Api {
+	a := &Api{keys: keys, slashingProtection: slashingProtection, token: token, logger: logger}
+	r := chi.NewRouter()
+	r.Use(a.authenticate)
+	r.Route("/eth/v1", func(r chi.Router) {
+		r.Get("/keystores", a.listKeystores)
+		r.Post("/keystores", a.importKeystores)
+		r.Delete("/keystores", a.deleteKeystores)
+		r.Get("/remotekeys", a.listRemoteKeys)
+		r.Post("/remotekeys", a.importRemoteKeys)
+		r.Delete("/remotekeys", a.deleteRemoteKeys)
+		r.Get("/validator/{pubkey}/feerecipient", a.getFeeRecipient)
+		r.Post("/validator/{pubkey}/feerecipient", a.setFeeRecipient)
+		r.Delete("/validator/{pubkey}/feerecipient", a.deleteFeeRecipient)
+	})
+	a.router = r
+	return a
+}
+
+func (a *Api) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.router.ServeHTTP(w, r)
+}
+
+// LoadOrCreateToken reads the API token from path, generating a random one on first use.
+func LoadOrCreateToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := "api-token-0x" + hex.EncodeToString(buf)
+	if err := os.WriteFile(path, []byte(token), 0o600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (a *Api) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			writeError(w, http.StatusUnauthorized, errors.New("missing bearer token"))
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			writeError(w, http.StatusForbidden, errors.New("invalid bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debug("[Validator] failed to write keymanager response", "err", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"message": err.Error()})
+}
+
+type statusMessage struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func statusOf(status string, err error) statusMessage {
+	if err != nil {
+		return statusMessage{Status: status, Message: err.Error()}
+	}
+	return statusMessage{Status: status}
+}
+
+type keystoreEntry struct {
+	ValidatingPubkey common.Bytes48 `json:"validating_pubkey"`
+	DerivationPath   string         `json:"derivation_path"`
+	Readonly         bool           `json:"readonly"`
+}
+
+func (a *Api) listKeystores(w http.ResponseWriter, r *http.Request) {
+	data := []keystoreEntry{}
+	for _, key := range a.keys.LocalKeys() {
+		data = append(data, keystoreEntry{ValidatingPubkey: key.Pubkey, DerivationPath: key.DerivationPath})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+
+func (a *Api) importKeystores(w http.ResponseWriter, r *http.Request) {
+	var req importKeystoresRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		writeError(w, http.StatusBadRequest, errors.New("keystores and passwords have different lengths"))
+		return
+	}
+	// the history is imported before any key, so that no key can sign without it
+	if req.SlashingProtection != "" {
+		var interchange slashing_protection.Interchange
+		if err := json.Unmarshal([]byte(req.SlashingProtection), &interchange); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := a.slashingProtection.Import(r.Context(), &interchange); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	data := make([]statusMessage, len(req.Keystores))
+	for i := range req.Keystores {
+		ks, err := keystore.Parse([]byte(req.Keystores[i]))
+		if err != nil {
+			data[i] = statusOf(StatusError, err)
+			continue
+		}
+		pubkey, err := a.keys.ImportKeystore(r.Context(), ks, req.Passwords[i])
+		switch {
+		case errors.Is(err, ErrDuplicateKey):
+			data[i] = statusOf(StatusDuplicate, nil)
+		case err != nil:
+			data[i] = statusOf(StatusError, err)
+		default:
+			a.logger.Info("[Validator] Imported keystore", "pubkey", pubkey)
+			data[i] = statusOf(StatusImported, nil)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+type pubkeysRequest struct {
+	Pubkeys []common.Bytes48 `json:"pubkeys"`
+}
+
+func (a *Api) deleteKeystores(w http.ResponseWriter, r *http.Request) {
+	var req pubkeysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	data := make([]statusMessage, len(req.Pubkeys))
+	exported := make([]common.Bytes48, 0, len(req.Pubkeys))
+	for i, pubkey := range req.Pubkeys {
+		err := a.keys.DeleteKeystore(pubkey)
+		switch {
+		case errors.Is(err, ErrUnknownKey):
+			data[i] = statusOf(StatusNotFound, nil)
+		case err != nil:
+			data[i] = statusOf(StatusError, err)
+			continue
+		default:
+			a.logger.Info("[Validator] Deleted keystore", "pubkey", pubkey)
+			data[i] = statusOf(StatusDeleted, nil)
+		}
+		exported = append(exported, pubkey)
+	}
+	// the key is gone by now, so the exported history is final
+	interchange, err := a.slashingProtection.Export(r.Context(), exported)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	for i, pubkey := range req.Pubkeys {
+		if data[i].Status != StatusNotFound {
+			continue
+		}
+		for _, d := range interchange.Data {
+			if d.Pubkey == pubkey && (len(d.SignedBlocks) > 0 || len(d.SignedAttestations) > 0) {
+				data[i] = statusOf(StatusNotActive, nil)
+			}
+		}
+	}
+	enc, err := json.Marshal(interchange)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data, "slashing_protection": string(enc)})
+}
+
+type remoteKeyEntry struct {
+	Pubkey   common.Bytes48 `json:"pubkey"`
+	URL      string         `json:"url"`
+	Readonly bool           `json:"readonly,omitempty"`
+}
+
+func (a *Api) listRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := a.keys.RemoteKeys(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	data := []remoteKeyEntry{}
+	for _, key := range keys {
+		data = append(data, remoteKeyEntry{Pubkey: key.Pubkey, URL: key.URL})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+func (a *Api) importRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RemoteKeys []remoteKeyEntry `json:"remote_keys"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	data := make([]statusMessage, len(req.RemoteKeys))
+	for i, key := range req.RemoteKeys {
+		err := a.keys.ImportRemoteKey(r.Context(), RemoteKey{Pubkey: key.Pubkey, URL: key.URL})
+		switch {
+		case errors.Is(err, ErrDuplicateKey):
+			data[i] = statusOf(StatusDuplicate, nil)
+		case err != nil:
+			data[i] = statusOf(StatusError, err)
+		default:
+			data[i] = statusOf(StatusImported, nil)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+func (a *Api) deleteRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	var req pubkeysRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	data := make([]statusMessage, len(req.Pubkeys))
+	for i, pubkey := range req.Pubkeys {
+		err := a.keys.DeleteRemoteKey(r.Context(), pubkey)
+		switch {
+		case errors.Is(err, ErrUnknownKey):
+			data[i] = statusOf(StatusNotFound, nil)
+		case err != nil:
+			data[i] = statusOf(StatusError, err)
+		default:
+			data[i] = statusOf(StatusDeleted, nil)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+// knownPubkey parses the {pubkey} path parameter and writes an error response if it is
+// invalid or not managed by this client.
+func (a *Api) knownPubkey(w http.ResponseWriter, r *http.Request) (common.Bytes48, bool) {
+	var pubkey common.Bytes48
+	if err := pubkey.UnmarshalText([]byte(chi.URLParam(r, "pubkey"))); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return pubkey, false
+	}
+	known, err := a.keys.IsKnown(r.Context(), pubkey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return pubkey, false
+	}
+	if !known {
+		writeError(w, http.StatusNotFound, ErrUnknownKey)
+		return pubkey, false
+	}
+	return pubkey, true
+}
+
+func (a *Api) getFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	pubkey, ok := a.knownPubkey(w, r)
+	if !ok {
+		return
+	}
+	feeRecipient, err := a.keys.FeeRecipient(r.Context(), pubkey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"pubkey": pubkey, "ethaddress": feeRecipient}})
+}
+
+func (a *Api) setFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	pubkey, ok := a.knownPubkey(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		EthAddress hexutil.Bytes `json:"ethaddress"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(req.EthAddress) != len(common.Address{}) {
+		writeError(w, http.StatusBadRequest, errors.New("invalid ethaddress"))
+		return
+	}
+	if err := a.keys.SetFeeRecipient(r.Context(), pubkey, common.BytesToAddress(req.EthAddress)); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *Api) deleteFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	pubkey, ok := a.knownPubkey(w, r)
+	if !ok {
+		return
+	}
+	if err := a.keys.DeleteFeeRecipient(r.Context(), pubkey); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
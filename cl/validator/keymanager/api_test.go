@@ -0,0 +1,215 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/utils/bls"
+	"github.com/erigontech/erigon/cl/validator/keystore"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+)
+
+const testToken = "secret-token"
+
+type testApi struct {
+	t      *testing.T
+	dir    string
+	db     kv.RwDB
+	sp     *slashing_protection.SlashingProtection
+	keys   *KeyManager
+	server *httptest.Server
+}
+
+func newTestApi(t *testing.T) *testApi {
+	t.Helper()
+	dir := t.TempDir()
+	db := memdb.NewTestDB(t, kv.CaplinValidatorDB)
+	sp, err := slashing_protection.New(context.Background(), db, common.Hash{1})
+	require.NoError(t, err)
+	keys, err := New(dir, db, common.Address{0xfe}, log.New())
+	require.NoError(t, err)
+	server := httptest.NewServer(NewApi(keys, sp, testToken, log.New()))
+	t.Cleanup(server.Close)
+	return &testApi{t: t, dir: dir, db: db, sp: sp, keys: keys, server: server}
+}
+
+func (a *testApi) do(method, path string, body any, out any) int {
+	a.t.Helper()
+	var reader *bytes.Reader
+	if body != nil {
+		enc, err := json.Marshal(body)
+		require.NoError(a.t, err)
+		reader = bytes.NewReader(enc)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, a.server.URL+path, reader)
+	require.NoError(a.t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(a.t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(a.t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func newTestKeystore(t *testing.T, password string) (string, common.Bytes48) {
+	t.Helper()
+	sk, err := bls.GenerateKey()
+	require.NoError(t, err)
+	ks, err := keystore.Encrypt(sk.Bytes(), password, 1<<10)
+	require.NoError(t, err)
+	enc, err := json.Marshal(ks)
+	require.NoError(t, err)
+	return string(enc), common.Bytes48(bls.CompressPublicKey(sk.PublicKey()))
+}
+
+type statusResponse struct {
+	Data               []statusMessage `json:"data"`
+	SlashingProtection string          `json:"slashing_protection"`
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+	a := newTestApi(t)
+	resp, err := http.Get(a.server.URL + "/eth/v1/keystores")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, a.server.URL+"/eth/v1/keystores", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestKeystores(t *testing.T) {
+	t.Parallel()
+	a := newTestApi(t)
+	ks1, pk1 := newTestKeystore(t, "password1")
+	ks2, pk2 := newTestKeystore(t, "password2")
+
+	var imported statusResponse
+	require.Equal(t, http.StatusOK, a.do(http.MethodPost, "/eth/v1/keystores", importKeystoresRequest{
+		Keystores: []string{ks1, ks2, ks1},
+		Passwords: []string{"password1", "wrong", "password1"},
+		SlashingProtection: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},
+			"data":[{"pubkey":"` + pk1.Hex() + `","signed_blocks":[{"slot":"10"}],"signed_attestations":[]}]}`,
+	}, &imported))
+	require.Equal(t, []string{StatusImported, StatusError, StatusDuplicate}, []string{imported.Data[0].Status, imported.Data[1].Status, imported.Data[2].Status})
+
+	var listed struct {
+		Data []keystoreEntry `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, a.do(http.MethodGet, "/eth/v1/keystores", nil, &listed))
+	require.Len(t, listed.Data, 1)
+	require.Equal(t, pk1, listed.Data[0].ValidatingPubkey)
+
+	// the imported history protects the key
+	require.ErrorIs(t, a.sp.CheckAndInsertBlockProposal(context.Background(), pk1, 10, common.Hash{1}), slashing_protection.ErrBelowWatermark)
+
+	// keystores are reloaded from disk
+	reloaded, err := New(a.dir, a.db, common.Address{}, log.New())
+	require.NoError(t, err)
+	require.True(t, reloaded.HasLocalKey(pk1))
+
+	var deleted statusResponse
+	require.Equal(t, http.StatusOK, a.do(http.MethodDelete, "/eth/v1/keystores", pubkeysRequest{Pubkeys: []common.Bytes48{pk1, pk2}}, &deleted))
+	require.Equal(t, StatusDeleted, deleted.Data[0].Status)
+	require.Equal(t, StatusNotFound, deleted.Data[1].Status)
+	var interchange slashing_protection.Interchange
+	require.NoError(t, json.Unmarshal([]byte(deleted.SlashingProtection), &interchange))
+	require.Len(t, interchange.Data, 2)
+	for _, data := range interchange.Data {
+		if data.Pubkey == pk1 {
+			require.Equal(t, []slashing_protection.InterchangeBlock{{Slot: 10}}, data.SignedBlocks)
+		} else {
+			require.Empty(t, data.SignedBlocks)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(a.dir, "*"))
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	// the history outlives the key
+	require.Equal(t, http.StatusOK, a.do(http.MethodDelete, "/eth/v1/keystores", pubkeysRequest{Pubkeys: []common.Bytes48{pk1}}, &deleted))
+	require.Equal(t, StatusNotActive, deleted.Data[0].Status)
+}
+
+func TestRemoteKeysAndFeeRecipient(t *testing.T) {
+	t.Parallel()
+	a := newTestApi(t)
+	remote := common.Bytes48{0xab}
+
+	var imported statusResponse
+	body := map[string]any{"remote_keys": []remoteKeyEntry{{Pubkey: remote, URL: "http://signer:9000"}}}
+	require.Equal(t, http.StatusOK, a.do(http.MethodPost, "/eth/v1/remotekeys", body, &imported))
+	require.Equal(t, StatusImported, imported.Data[0].Status)
+	require.Equal(t, http.StatusOK, a.do(http.MethodPost, "/eth/v1/remotekeys", body, &imported))
+	require.Equal(t, StatusDuplicate, imported.Data[0].Status)
+
+	invalid := map[string]any{"remote_keys": []remoteKeyEntry{{Pubkey: common.Bytes48{0xac}, URL: "signer:9000"}}}
+	require.Equal(t, http.StatusOK, a.do(http.MethodPost, "/eth/v1/remotekeys", invalid, &imported))
+	require.Equal(t, StatusError, imported.Data[0].Status)
+
+	var listed struct {
+		Data []remoteKeyEntry `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, a.do(http.MethodGet, "/eth/v1/remotekeys", nil, &listed))
+	require.Equal(t, []remoteKeyEntry{{Pubkey: remote, URL: "http://signer:9000"}}, listed.Data)
+
+	path := "/eth/v1/validator/" + remote.Hex() + "/feerecipient"
+	var feeRecipient struct {
+		Data struct {
+			Pubkey     common.Bytes48 `json:"pubkey"`
+			EthAddress common.Address `json:"ethaddress"`
+		} `json:"data"`
+	}
+	require.Equal(t, http.StatusOK, a.do(http.MethodGet, path, nil, &feeRecipient))
+	require.Equal(t, common.Address{0xfe}, feeRecipient.Data.EthAddress)
+	require.Equal(t, http.StatusAccepted, a.do(http.MethodPost, path, map[string]string{"ethaddress": common.Address{0x11}.Hex()}, nil))
+	require.Equal(t, http.StatusOK, a.do(http.MethodGet, path, nil, &feeRecipient))
+	require.Equal(t, common.Address{0x11}, feeRecipient.Data.EthAddress)
+	require.Equal(t, http.StatusNoContent, a.do(http.MethodDelete, path, nil, nil))
+	require.Equal(t, http.StatusOK, a.do(http.MethodGet, path, nil, &feeRecipient))
+	require.Equal(t, common.Address{0xfe}, feeRecipient.Data.EthAddress)
+
+	require.Equal(t, http.StatusNotFound, a.do(http.MethodGet, "/eth/v1/validator/"+common.Bytes48{0xcd}.Hex()+"/feerecipient", nil, nil))
+
+	var deleted statusResponse
+	require.Equal(t, http.StatusOK, a.do(http.MethodDelete, "/eth/v1/remotekeys", pubkeysRequest{Pubkeys: []common.Bytes48{remote, remote}}, &deleted))
+	require.Equal(t, []statusMessage{{Status: StatusDeleted}, {Status: StatusNotFound}}, deleted.Data)
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package keymanager holds the keys of the built-in validator client and serves the
+// standard keymanager REST API on top of them.
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/utils/bls"
+	"github.com/erigontech/erigon/cl/validator/keystore"
+)
+
+var (
+	ErrDuplicateKey = errors.New("key already exists")
+	ErrUnknownKey   = errors.New("unknown validator key")
+)
+
+// LocalKey is a validator key decrypted from an EIP-2335 keystore.
+type LocalKey struct {
+	Pubkey         common.Bytes48
+	DerivationPath string
+
+	secretKey *bls.PrivateKey
+	file      string
+}
+
+// RemoteKey is a validator key held by a remote signer.
+type RemoteKey struct {
+	Pubkey common.Bytes48
+	URL    string
+}
+
+// KeyManager owns the local keys loaded from the keystores directory, and the remote keys
+// and fee recipients kept in the validator database. Every keystore <name>.json in the
+// directory is unlocked with the password stored in <name>.txt next to it.
+type KeyManager struct {
+	dir    string
+	db     kv.RwDB
+	logger log.Logger
+
+	defaultFeeRecipient common.Address
+
+	mu    sync.RWMutex
+	local map[common.Bytes48]*LocalKey
+}
+
+func New(dir string, db kv.RwDB, defaultFeeRecipient common.Address, logger log.Logger) (*KeyManager, error) {
+	k := &KeyManager{
+		dir:                 dir,
+		db:                  db,
+		logger:              logger,
+		defaultFeeRecipient: defaultFeeRecipient,
+		local:               map[common.Bytes48]*LocalKey{},
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		ks, err := keystore.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		password, err := os.ReadFile(passwordFile(file))
+		if err != nil {
+			return nil, fmt.Errorf("%s: password: %w", file, err)
+		}
+		key, err := decrypt(ks, strings.TrimRight(string(password), "\r\n"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		key.file = file
+		if _, ok := k.local[key.Pubkey]; ok {
+			logger.Warn("[Validator] Duplicate keystore skipped", "file", file, "pubkey", key.Pubkey)
+			continue
+		}
+		k.local[key.Pubkey] = key
+	}
+	logger.Info("[Validator] Loaded keystores", "dir", dir, "count", len(k.local))
+	return k, nil
+}
+
+func passwordFile(keystoreFile string) string {
+	return strings.TrimSuffix(keystoreFile, ".json") + ".txt"
+}
+
+func decrypt(ks *keystore.Keystore, password string) (*LocalKey, error) {
+	secret, err := ks.Decrypt(password)
+	if err != nil {
+		return nil, err
+	}
+	sk, err := bls.NewPrivateKeyFromBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKey{
+		Pubkey:         common.Bytes48(bls.CompressPublicKey(sk.PublicKey())),
+		DerivationPath: ks.Path,
+		secretKey:      sk,
+	}, nil
+}
+
+// LocalKeys returns the local keys ordered by pubkey.
+func (k *KeyManager) LocalKeys() []LocalKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	keys := make([]LocalKey, 0, len(k.local))
+	for _, key := range k.local {
+		keys = append(keys, *key)
+	}
+	slices.SortFunc(keys, func(a, b LocalKey) int { return bytes.Compare(a.Pubkey[:], b.Pubkey[:]) })
+	return keys
+}
+
+func (k *KeyManager) HasLocalKey(pubkey common.Bytes48) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	_, ok := k.local[pubkey]
+	return ok
+}
+
+// ImportKeystore decrypts ks and persists it, with its password, to the keystores directory.
+func (k *KeyManager) ImportKeystore(ctx context.Context, ks *keystore.Keystore, password string) (common.Bytes48, error) {
+	key, err := decrypt(ks, password)
+	if err != nil {
+		return common.Bytes48{}, err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.local[key.Pubkey]; ok {
+		return key.Pubkey, ErrDuplicateKey
+	}
+	if ok, err := k.hasRemoteKey(ctx, key.Pubkey); err != nil {
+		return key.Pubkey, err
+	} else if ok {
+		return key.Pubkey, ErrDuplicateKey
+	}
+
+	key.file = filepath.Join(k.dir, key.Pubkey.Hex()+".json")
+	if err := writeKeystore(key.file, ks, password); err != nil {
+		return key.Pubkey, err
+	}
+	k.local[key.Pubkey] = key
+	return key.Pubkey, nil
+}
+
+func writeKeystore(file string, ks *keystore.Keystore, password string) error {
+	enc, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+	// the password goes first: a keystore without its password would fail the next start
+	if err := os.WriteFile(passwordFile(file), []byte(password), 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, enc, 0o600); err != nil {
+		_ = os.Remove(passwordFile(file))
+		return err
+	}
+	return nil
+}
+
+// DeleteKeystore stops using the local key and removes its keystore from disk.
+func (k *KeyManager) DeleteKeystore(pubkey common.Bytes48) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, ok := k.local[pubkey]
+	if !ok {
+		return ErrUnknownKey
+	}
+	delete(k.local, pubkey)
+	if err := os.Remove(key.file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(passwordFile(key.file)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Sign signs root with the local key of pubkey. Callers are responsible for checking the
+// slashing protection database first, see signer.WithSlashingProtection.
+func (k *KeyManager) Sign(pubkey common.Bytes48, root [32]byte) ([]byte, error) {
+	k.mu.RLock()
+	key, ok := k.local[pubkey]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, pubkey)
+	}
+	return key.secretKey.Sign(root[:]).Bytes(), nil
+}
+
+// RemoteKeys returns the remote keys ordered by pubkey.
+func (k *KeyManager) RemoteKeys(ctx context.Context) ([]RemoteKey, error) {
+	var keys []RemoteKey
+	if err := k.db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(kv.ValidatorRemoteKeys, nil, func(key, url []byte) error {
+			keys = append(keys, RemoteKey{Pubkey: common.Bytes48(key), URL: string(url)})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (k *KeyManager) hasRemoteKey(ctx context.Context, pubkey common.Bytes48) (ok bool, err error) {
+	err = k.db.View(ctx, func(tx kv.Tx) error {
+		ok, err = tx.Has(kv.ValidatorRemoteKeys, pubkey[:])
+		return err
+	})
+	return ok, err
+}
+
+// ImportRemoteKey adds a key held by the Web3Signer-compatible remote signer at key.URL.
+func (k *KeyManager) ImportRemoteKey(ctx context.Context, key RemoteKey) error {
+	if u, err := url.Parse(key.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid remote signer url %q", key.URL)
+	}
+	if k.HasLocalKey(key.Pubkey) {
+		return ErrDuplicateKey
+	}
+	return k.db.Update(ctx, func(tx kv.RwTx) error {
+		ok, err := tx.Has(kv.ValidatorRemoteKeys, key.Pubkey[:])
+		if err != nil {
+			return err
+		}
+		if ok {
+			return ErrDuplicateKey
+		}
+		return tx.Put(kv.ValidatorRemoteKeys, key.Pubkey[:], []byte(key.URL))
+	})
+}
+
+func (k *KeyManager) DeleteRemoteKey(ctx context.Context, pubkey common.Bytes48) error {
+	return k.db.Update(ctx, func(tx kv.RwTx) error {
+		ok, err := tx.Has(kv.ValidatorRemoteKeys, pubkey[:])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrUnknownKey
+		}
+		return tx.Delete(kv.ValidatorRemoteKeys, pubkey[:])
+	})
+}
+
+// FeeRecipient returns the fee recipient configured for pubkey, or the default one.
+func (k *KeyManager) FeeRecipient(ctx context.Context, pubkey common.Bytes48) (common.Address, error) {
+	feeRecipient := k.defaultFeeRecipient
+	if err := k.db.View(ctx, func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.ValidatorFeeRecipients, pubkey[:])
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			feeRecipient = common.BytesToAddress(v)
+		}
+		return nil
+	}); err != nil {
+		return common.Address{}, err
+	}
+	return feeRecipient, nil
+}
+
+func (k *KeyManager) SetFeeRecipient(ctx context.Context, pubkey common.Bytes48, feeRecipient common.Address) error {
+	return k.db.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.ValidatorFeeRecipients, pubkey[:], feeRecipient[:])
+	})
+}
+
+func (k *KeyManager) DeleteFeeRecipient(ctx context.Context, pubkey common.Bytes48) error {
+	return k.db.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Delete(kv.ValidatorFeeRecipients, pubkey[:])
+	})
+}
+
+// IsKnown reports whether pubkey is managed by this key manager, locally or remotely.
+func (k *KeyManager) IsKnown(ctx context.Context, pubkey common.Bytes48) (bool, error) {
+	if k.HasLocalKey(pubkey) {
+		return true, nil
+	}
+	return k.hasRemoteKey(ctx, pubkey)
+}
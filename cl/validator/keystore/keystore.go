@@ -0,0 +1,313 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package keystore implements the EIP-2335 BLS12-381 keystore format used by validator clients.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/erigontech/erigon/cl/utils/bls"
+)
+
+const (
+	Version = 4
+
+	kdfScrypt   = "scrypt"
+	kdfPBKDF2   = "pbkdf2"
+	checksumFn  = "sha256"
+	cipherFn    = "aes-128-ctr"
+	pbkdf2PRF   = "hmac-sha256"
+	keyLen      = 32
+	secretLen   = 32
+	saltLen     = 32
+	ivLen       = 16
+	defaultPath = "m/12381/3600/0/0/0"
+)
+
+var (
+	ErrInvalidPassword = errors.New("keystore: invalid password")
+	ErrUnsupported     = errors.New("keystore: unsupported module")
+)
+
+// Module is one of the kdf, checksum or cipher steps of a keystore.
+type Module struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  string          `json:"message"`
+}
+
+type Crypto struct {
+	KDF      Module `json:"kdf"`
+	Checksum Module `json:"checksum"`
+	Cipher   Module `json:"cipher"`
+}
+
+// Keystore is the JSON representation of an EIP-2335 keystore. Hex fields carry no 0x prefix.
+type Keystore struct {
+	Crypto      Crypto `json:"crypto"`
+	Description string `json:"description"`
+	Pubkey      string `json:"pubkey"`
+	Path        string `json:"path"`
+	UUID        string `json:"uuid"`
+	Version     int    `json:"version"`
+}
+
+type scryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2Params struct {
+	DKLen int    `json:"dklen"`
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+// Parse decodes a keystore and checks its version.
+func Parse(data []byte) (*Keystore, error) {
+	ks := &Keystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	if ks.Version != Version {
+		return nil, fmt.Errorf("keystore: unsupported version %d", ks.Version)
+	}
+	return ks, nil
+}
+
+// ReadFile reads and parses the keystore at path.
+func ReadFile(path string) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// PublicKey returns the compressed public key the keystore claims to hold.
+func (ks *Keystore) PublicKey() ([]byte, error) {
+	pk, err := decodeHex(ks.Pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: pubkey: %w", err)
+	}
+	if len(pk) != 48 {
+		return nil, fmt.Errorf("keystore: pubkey has %d bytes", len(pk))
+	}
+	return pk, nil
+}
+
+// Decrypt returns the secret key sealed in the keystore. The password is normalized as
+// described in EIP-2335 before it is fed to the kdf.
+func (ks *Keystore) Decrypt(password string) ([]byte, error) {
+	key, err := ks.decryptionKey(password)
+	if err != nil {
+		return nil, err
+	}
+	if ks.Crypto.Checksum.Function != checksumFn {
+		return nil, fmt.Errorf("%w: checksum %s", ErrUnsupported, ks.Crypto.Checksum.Function)
+	}
+	cipherText, err := decodeHex(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: cipher message: %w", err)
+	}
+	checksum, err := decodeHex(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: checksum message: %w", err)
+	}
+	h := sha256.New()
+	h.Write(key[16:32])
+	h.Write(cipherText)
+	if !bytes.Equal(h.Sum(nil), checksum) {
+		return nil, ErrInvalidPassword
+	}
+
+	if ks.Crypto.Cipher.Function != cipherFn {
+		return nil, fmt.Errorf("%w: cipher %s", ErrUnsupported, ks.Crypto.Cipher.Function)
+	}
+	var params cipherParams
+	if err := json.Unmarshal(ks.Crypto.Cipher.Params, &params); err != nil {
+		return nil, fmt.Errorf("keystore: cipher params: %w", err)
+	}
+	iv, err := decodeHex(params.IV)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: iv: %w", err)
+	}
+	secret, err := aes128CTR(key[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	// a keystore whose pubkey does not match its secret is treated as corrupted
+	if ks.Pubkey != "" {
+		pk, err := ks.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		sk, err := bls.NewPrivateKeyFromBytes(secret)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: secret: %w", err)
+		}
+		if !bytes.Equal(bls.CompressPublicKey(sk.PublicKey()), pk) {
+			return nil, fmt.Errorf("keystore: secret does not match pubkey %s", ks.Pubkey)
+		}
+	}
+	return secret, nil
+}
+
+func (ks *Keystore) decryptionKey(password string) ([]byte, error) {
+	pass := NormalizePassword(password)
+	switch ks.Crypto.KDF.Function {
+	case kdfScrypt:
+		var params scryptParams
+		if err := json.Unmarshal(ks.Crypto.KDF.Params, &params); err != nil {
+			return nil, fmt.Errorf("keystore: kdf params: %w", err)
+		}
+		if params.DKLen < keyLen {
+			return nil, fmt.Errorf("keystore: dklen %d too short", params.DKLen)
+		}
+		salt, err := decodeHex(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: salt: %w", err)
+		}
+		return scrypt.Key(pass, salt, params.N, params.R, params.P, params.DKLen)
+	case kdfPBKDF2:
+		var params pbkdf2Params
+		if err := json.Unmarshal(ks.Crypto.KDF.Params, &params); err != nil {
+			return nil, fmt.Errorf("keystore: kdf params: %w", err)
+		}
+		if params.PRF != pbkdf2PRF {
+			return nil, fmt.Errorf("%w: prf %s", ErrUnsupported, params.PRF)
+		}
+		if params.DKLen < keyLen {
+			return nil, fmt.Errorf("keystore: dklen %d too short", params.DKLen)
+		}
+		salt, err := decodeHex(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: salt: %w", err)
+		}
+		return pbkdf2.Key(pass, salt, params.C, params.DKLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("%w: kdf %s", ErrUnsupported, ks.Crypto.KDF.Function)
+	}
+}
+
+// Encrypt seals secret into a new scrypt keystore. n is the scrypt cost parameter; EIP-2335
+// recommends 262144, lower values are only meant for tests.
+func Encrypt(secret []byte, password string, n int) (*Keystore, error) {
+	if len(secret) != secretLen {
+		return nil, fmt.Errorf("keystore: secret has %d bytes", len(secret))
+	}
+	sk, err := bls.NewPrivateKeyFromBytes(secret)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: secret: %w", err)
+	}
+	salt := make([]byte, saltLen)
+	iv := make([]byte, ivLen)
+	id := make([]byte, 16)
+	for _, b := range [][]byte{salt, iv, id} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+
+	kdfParams := scryptParams{DKLen: keyLen, N: n, P: 1, R: 8, Salt: hex.EncodeToString(salt)}
+	key, err := scrypt.Key(NormalizePassword(password), salt, kdfParams.N, kdfParams.R, kdfParams.P, kdfParams.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := aes128CTR(key[:16], iv, secret)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write(key[16:32])
+	h.Write(cipherText)
+
+	encodedKdf, err := json.Marshal(kdfParams)
+	if err != nil {
+		return nil, err
+	}
+	encodedCipher, err := json.Marshal(cipherParams{IV: hex.EncodeToString(iv)})
+	if err != nil {
+		return nil, err
+	}
+	// random (version 4) uuid
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return &Keystore{
+		Crypto: Crypto{
+			KDF:      Module{Function: kdfScrypt, Params: encodedKdf},
+			Checksum: Module{Function: checksumFn, Params: json.RawMessage("{}"), Message: hex.EncodeToString(h.Sum(nil))},
+			Cipher:   Module{Function: cipherFn, Params: encodedCipher, Message: hex.EncodeToString(cipherText)},
+		},
+		Pubkey:  hex.EncodeToString(bls.CompressPublicKey(sk.PublicKey())),
+		Path:    defaultPath,
+		UUID:    fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Version: Version,
+	}, nil
+}
+
+// NormalizePassword applies the EIP-2335 password processing: NFKD normalization followed
+// by the removal of the C0, C1 and Delete control codes.
+func NormalizePassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+	return []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+		return r
+	}, normalized))
+}
+
+func aes128CTR(key, iv, in []byte) ([]byte, error) {
+	if len(iv) != ivLen {
+		return nil, fmt.Errorf("keystore: iv has %d bytes", len(iv))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
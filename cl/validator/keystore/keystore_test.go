@@ -0,0 +1,118 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from EIP-2335
+const (
+	testPassword = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+	testSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+	scryptKeystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "scrypt",
+            "params": {"dklen": 32, "n": 262144, "p": 1, "r": 8, "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"},
+            "message": ""
+        },
+        "checksum": {"function": "sha256", "params": {}, "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"},
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {"iv": "264daa3f303d7259501c93d997d84fe6"},
+            "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+        }
+    },
+    "description": "This is a test keystore that uses scrypt to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/3141592653/589793238",
+    "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+    "version": 4
+}`
+
+	pbkdf2Keystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {"dklen": 32, "c": 262144, "prf": "hmac-sha256", "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"},
+            "message": ""
+        },
+        "checksum": {"function": "sha256", "params": {}, "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"},
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {"iv": "264daa3f303d7259501c93d997d84fe6"},
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+)
+
+func TestDecryptVectors(t *testing.T) {
+	t.Parallel()
+	for name, vector := range map[string]string{"scrypt": scryptKeystore, "pbkdf2": pbkdf2Keystore} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ks, err := Parse([]byte(vector))
+			require.NoError(t, err)
+			secret, err := ks.Decrypt(testPassword)
+			require.NoError(t, err)
+			require.Equal(t, testSecret, hex.EncodeToString(secret))
+
+			_, err = ks.Decrypt("testpassword")
+			require.ErrorIs(t, err, ErrInvalidPassword)
+		})
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	t.Parallel()
+	secret, err := hex.DecodeString(testSecret)
+	require.NoError(t, err)
+	ks, err := Encrypt(secret, "password\x7f", 1<<10)
+	require.NoError(t, err)
+	require.Equal(t, "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07", ks.Pubkey)
+
+	enc, err := json.Marshal(ks)
+	require.NoError(t, err)
+	ks, err = Parse(enc)
+	require.NoError(t, err)
+	// control codes are stripped from the password
+	decrypted, err := ks.Decrypt("password")
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+
+	ks.Pubkey = "a" + ks.Pubkey[1:]
+	_, err = ks.Decrypt("password")
+	require.ErrorContains(t, err, "does not match pubkey")
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+	_, err := Parse([]byte(`{"version": 3}`))
+	require.ErrorContains(t, err, "unsupported version")
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/fork"
+	"github.com/erigontech/erigon/cl/utils"
+)
+
+// ErrIncompleteBlock is returned for blocks whose body misses fields of the block version.
+var ErrIncompleteBlock = errors.New("signer: incomplete block body")
+
+// RequestBuilder builds signing requests, computing their signing roots with the fork
+// schedule of the chain.
+type RequestBuilder struct {
+	beaconCfg             *clparams.BeaconChainConfig
+	genesisValidatorsRoot common.Hash
+}
+
+func NewRequestBuilder(beaconCfg *clparams.BeaconChainConfig, genesisValidatorsRoot common.Hash) *RequestBuilder {
+	return &RequestBuilder{beaconCfg: beaconCfg, genesisValidatorsRoot: genesisValidatorsRoot}
+}
+
+// ForkInfo returns the fork active at epoch.
+func (b *RequestBuilder) ForkInfo(epoch uint64) ForkInfo {
+	return b.forkInfoOf(b.beaconCfg.GetCurrentStateVersion(epoch))
+}
+
+func (b *RequestBuilder) forkInfoOf(version clparams.StateVersion) ForkInfo {
+	previous := version
+	if version > clparams.Phase0Version {
+		previous--
+	}
+	return ForkInfo{
+		Fork: Fork{
+			PreviousVersion: utils.Uint32ToBytes4(b.beaconCfg.GetForkVersionByVersion(previous)),
+			CurrentVersion:  utils.Uint32ToBytes4(b.beaconCfg.GetForkVersionByVersion(version)),
+			Epoch:           b.beaconCfg.GetForkEpochByVersion(version),
+		},
+		GenesisValidatorsRoot: b.genesisValidatorsRoot,
+	}
+}
+
+// Domain computes the signature domain of domainType at epoch, as get_domain does with the
+// fork of forkInfo.
+func (f ForkInfo) Domain(domainType common.Bytes4, epoch uint64) ([]byte, error) {
+	version := f.Fork.CurrentVersion
+	if epoch < f.Fork.Epoch {
+		version = f.Fork.PreviousVersion
+	}
+	return fork.ComputeDomain(domainType[:], version, f.GenesisValidatorsRoot)
+}
+
+func (b *RequestBuilder) request(typ Type, forkInfo ForkInfo, domainType common.Bytes4, epoch uint64, obj ssz.HashableSSZ) (*Request, error) {
+	domain, err := forkInfo.Domain(domainType, epoch)
+	if err != nil {
+		return nil, err
+	}
+	root, err := fork.ComputeSigningRoot(obj, domain)
+	if err != nil {
+		return nil, err
+	}
+	return &Request{Type: typ, ForkInfo: forkInfo, SigningRoot: root}, nil
+}
+
+// rootObject is a 32 bytes value, its own hash tree root.
+type rootObject [32]byte
+
+func (r rootObject) HashSSZ() ([32]byte, error) { return r, nil }
+
+func uint64Object(v uint64) (r rootObject) {
+	binary.LittleEndian.PutUint64(r[:], v)
+	return r
+}
+
+func versionName(version clparams.StateVersion) string {
+	return strings.ToUpper(version.String())
+}
+
+func (b *RequestBuilder) epoch(slot uint64) uint64 {
+	return slot / b.beaconCfg.SlotsPerEpoch
+}
+
+// Block builds the request signing block, whose signing root is the one of its header.
+func (b *RequestBuilder) Block(block *cltypes.BeaconBlock) (*Request, error) {
+	if err := checkBlockBody(block.Body); err != nil {
+		return nil, err
+	}
+	bodyRoot, err := block.Body.HashSSZ()
+	if err != nil {
+		return nil, err
+	}
+	header := &cltypes.BeaconBlockHeader{
+		Slot:          block.Slot,
+		ProposerIndex: block.ProposerIndex,
+		ParentRoot:    block.ParentRoot,
+		Root:          block.StateRoot,
+		BodyRoot:      bodyRoot,
+	}
+	epoch := b.epoch(block.Slot)
+	req, err := b.request(TypeBlockV2, b.ForkInfo(epoch), b.beaconCfg.DomainBeaconProposer, epoch, header)
+	if err != nil {
+		return nil, err
+	}
+	req.Block = &BlockRequest{Version: versionName(block.Version()), BlockHeader: header}
+	return req, nil
+}
+
+// checkBlockBody checks that body has all the fields hashed for its version.
+func checkBlockBody(body *cltypes.BeaconBody) error {
+	if body == nil {
+		return fmt.Errorf("%w: no body", ErrIncompleteBlock)
+	}
+	var missing string
+	switch {
+	case body.Eth1Data == nil:
+		missing = "eth1_data"
+	case body.ProposerSlashings == nil:
+		missing = "proposer_slashings"
+	case body.AttesterSlashings == nil:
+		missing = "attester_slashings"
+	case body.Attestations == nil:
+		missing = "attestations"
+	case body.Deposits == nil:
+		missing = "deposits"
+	case body.VoluntaryExits == nil:
+		missing = "voluntary_exits"
+	case body.Version >= clparams.AltairVersion && body.SyncAggregate == nil:
+		missing = "sync_aggregate"
+	case body.Version >= clparams.BellatrixVersion && body.ExecutionPayload == nil:
+		missing = "execution_payload"
+	case body.Version >= clparams.CapellaVersion && body.ExecutionChanges == nil:
+		missing = "bls_to_execution_changes"
+	case body.Version >= clparams.DenebVersion && body.BlobKzgCommitments == nil:
+		missing = "blob_kzg_commitments"
+	case body.Version >= clparams.ElectraVersion && body.ExecutionRequests == nil:
+		missing = "execution_requests"
+	default:
+		return nil
+	}
+	return fmt.Errorf("%w: no %s", ErrIncompleteBlock, missing)
+}
+
+func (b *RequestBuilder) Attestation(data *solid.AttestationData) (*Request, error) {
+	epoch := data.Target.Epoch
+	req, err := b.request(TypeAttestation, b.ForkInfo(epoch), b.beaconCfg.DomainBeaconAttester, epoch, data)
+	if err != nil {
+		return nil, err
+	}
+	req.Attestation = data
+	return req, nil
+}
+
+func (b *RequestBuilder) AggregateAndProof(version clparams.StateVersion, aggregateAndProof *cltypes.AggregateAndProof) (*Request, error) {
+	epoch := b.epoch(aggregateAndProof.Aggregate.Data.Slot)
+	req, err := b.request(TypeAggregateAndProofV2, b.ForkInfo(epoch), b.beaconCfg.DomainAggregateAndProof, epoch, aggregateAndProof)
+	if err != nil {
+		return nil, err
+	}
+	req.AggregateAndProof = &AggregateAndProofRequest{Version: versionName(version), Data: aggregateAndProof}
+	return req, nil
+}
+
+// AggregationSlot builds the request of the selection proof of slot.
+func (b *RequestBuilder) AggregationSlot(slot uint64) (*Request, error) {
+	epoch := b.epoch(slot)
+	req, err := b.request(TypeAggregationSlot, b.ForkInfo(epoch), b.beaconCfg.DomainSelectionProof, epoch, uint64Object(slot))
+	if err != nil {
+		return nil, err
+	}
+	req.AggregationSlot = &SlotRequest{Slot: slot}
+	return req, nil
+}
+
+func (b *RequestBuilder) RandaoReveal(epoch uint64) (*Request, error) {
+	req, err := b.request(TypeRandaoReveal, b.ForkInfo(epoch), b.beaconCfg.DomainRandao, epoch, uint64Object(epoch))
+	if err != nil {
+		return nil, err
+	}
+	req.RandaoReveal = &EpochRequest{Epoch: epoch}
+	return req, nil
+}
+
+func (b *RequestBuilder) SyncCommitteeMessage(slot uint64, blockRoot common.Hash) (*Request, error) {
+	epoch := b.epoch(slot)
+	req, err := b.request(TypeSyncCommitteeMessage, b.ForkInfo(epoch), b.beaconCfg.DomainSyncCommittee, epoch, rootObject(blockRoot))
+	if err != nil {
+		return nil, err
+	}
+	req.SyncCommitteeMessage = &SyncCommitteeMessageRequest{BeaconBlockRoot: blockRoot, Slot: slot}
+	return req, nil
+}
+
+// VoluntaryExit builds the request signing exit. From Deneb, exits are signed with the
+// Capella fork version (EIP-7044).
+func (b *RequestBuilder) VoluntaryExit(exit *cltypes.VoluntaryExit) (*Request, error) {
+	forkInfo := b.ForkInfo(exit.Epoch)
+	epoch := exit.Epoch
+	if b.beaconCfg.GetCurrentStateVersion(exit.Epoch) >= clparams.DenebVersion {
+		forkInfo = b.forkInfoOf(clparams.CapellaVersion)
+		epoch = forkInfo.Fork.Epoch
+	}
+	req, err := b.request(TypeVoluntaryExit, forkInfo, b.beaconCfg.DomainVoluntaryExit, epoch, exit)
+	if err != nil {
+		return nil, err
+	}
+	req.VoluntaryExit = exit
+	return req, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package signer abstracts where the validator client signs: with a local key or with a
+// remote signer speaking the Web3Signer API. Requests carry both the signing root and the
+// typed object it was computed from, so that remote signers can check one against the other
+// and keep their own slashing protection.
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+)
+
+// Signer signs requests on behalf of the validator key pubkey.
+type Signer interface {
+	Sign(ctx context.Context, pubkey common.Bytes48, req *Request) (common.Bytes96, error)
+}
+
+// Type is the Web3Signer type of a signing request.
+type Type string
+
+const (
+	TypeBlockV2              Type = "BLOCK_V2"
+	TypeAttestation          Type = "ATTESTATION"
+	TypeAggregateAndProofV2  Type = "AGGREGATE_AND_PROOF_V2"
+	TypeAggregationSlot      Type = "AGGREGATION_SLOT"
+	TypeRandaoReveal         Type = "RANDAO_REVEAL"
+	TypeSyncCommitteeMessage Type = "SYNC_COMMITTEE_MESSAGE"
+	TypeVoluntaryExit        Type = "VOLUNTARY_EXIT"
+)
+
+type Fork struct {
+	PreviousVersion common.Bytes4 `json:"previous_version"`
+	CurrentVersion  common.Bytes4 `json:"current_version"`
+	Epoch           uint64        `json:"epoch,string"`
+}
+
+type ForkInfo struct {
+	Fork                  Fork        `json:"fork"`
+	GenesisValidatorsRoot common.Hash `json:"genesis_validators_root"`
+}
+
+type BlockRequest struct {
+	Version     string                     `json:"version"`
+	BlockHeader *cltypes.BeaconBlockHeader `json:"block_header"`
+}
+
+type AggregateAndProofRequest struct {
+	Version string                     `json:"version"`
+	Data    *cltypes.AggregateAndProof `json:"data"`
+}
+
+type SlotRequest struct {
+	Slot uint64 `json:"slot,string"`
+}
+
+type EpochRequest struct {
+	Epoch uint64 `json:"epoch,string"`
+}
+
+type SyncCommitteeMessageRequest struct {
+	BeaconBlockRoot common.Hash `json:"beacon_block_root"`
+	Slot            uint64      `json:"slot,string"`
+}
+
+// Request is a signing request in the Web3Signer format: the fork info and signing root,
+// plus the typed object matching Type.
+type Request struct {
+	Type        Type        `json:"type"`
+	ForkInfo    ForkInfo    `json:"fork_info"`
+	SigningRoot common.Hash `json:"signingRoot"`
+
+	Block                *BlockRequest                `json:"beacon_block,omitempty"`
+	Attestation          *solid.AttestationData       `json:"attestation,omitempty"`
+	AggregateAndProof    *AggregateAndProofRequest    `json:"aggregate_and_proof,omitempty"`
+	AggregationSlot      *SlotRequest                 `json:"aggregation_slot,omitempty"`
+	RandaoReveal         *EpochRequest                `json:"randao_reveal,omitempty"`
+	SyncCommitteeMessage *SyncCommitteeMessageRequest `json:"sync_committee_message,omitempty"`
+	VoluntaryExit        *cltypes.VoluntaryExit       `json:"voluntary_exit,omitempty"`
+}
+
+// KeySigner signs signing roots with local keys.
+type KeySigner interface {
+	Sign(pubkey common.Bytes48, root [32]byte) ([]byte, error)
+}
+
+type localSigner struct {
+	keys KeySigner
+}
+
+// NewLocal returns a signer using the local keys of keys.
+func NewLocal(keys KeySigner) Signer {
+	return localSigner{keys: keys}
+}
+
+func (s localSigner) Sign(_ context.Context, pubkey common.Bytes48, req *Request) (common.Bytes96, error) {
+	signature, err := s.keys.Sign(pubkey, req.SigningRoot)
+	if err != nil {
+		return common.Bytes96{}, err
+	}
+	if len(signature) != len(common.Bytes96{}) {
+		return common.Bytes96{}, fmt.Errorf("signer: invalid signature length %d", len(signature))
+	}
+	return common.Bytes96(signature), nil
+}
+
+type protectedSigner struct {
+	signer             Signer
+	slashingProtection *slashing_protection.SlashingProtection
+}
+
+// WithSlashingProtection returns a signer checking, and recording, blocks and attestations
+// in the slashing protection database before handing them to signer.
+func WithSlashingProtection(signer Signer, slashingProtection *slashing_protection.SlashingProtection) Signer {
+	return protectedSigner{signer: signer, slashingProtection: slashingProtection}
+}
+
+func (s protectedSigner) Sign(ctx context.Context, pubkey common.Bytes48, req *Request) (common.Bytes96, error) {
+	switch req.Type {
+	case TypeBlockV2:
+		if req.Block == nil || req.Block.BlockHeader == nil {
+			return common.Bytes96{}, errors.New("signer: block request without block header")
+		}
+		if err := s.slashingProtection.CheckAndInsertBlockProposal(ctx, pubkey, req.Block.BlockHeader.Slot, req.SigningRoot); err != nil {
+			return common.Bytes96{}, err
+		}
+	case TypeAttestation:
+		if req.Attestation == nil {
+			return common.Bytes96{}, errors.New("signer: attestation request without attestation data")
+		}
+		if err := s.slashingProtection.CheckAndInsertAttestation(ctx, pubkey, req.Attestation.Source.Epoch, req.Attestation.Target.Epoch, req.SigningRoot); err != nil {
+			return common.Bytes96{}, err
+		}
+	}
+	return s.signer.Sign(ctx, pubkey, req)
+}
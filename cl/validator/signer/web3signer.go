@@ -0,0 +1,113 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+var (
+	ErrRemoteKeyNotFound = errors.New("web3signer: key not found")
+	// ErrRemoteSlashingProtection is returned when the remote signer refuses to sign a
+	// slashable message according to its own slashing protection database.
+	ErrRemoteSlashingProtection = errors.New("web3signer: refused by slashing protection")
+)
+
+const web3SignerTimeout = 4 * time.Second
+
+type web3Signer struct {
+	// ref: https://consensys.github.io/web3signer/web3signer-eth2.html
+	httpClient *http.Client
+	url        *url.URL
+}
+
+// NewWeb3Signer returns a signer sending requests to the Web3Signer-compatible remote signer
+// at baseUrl.
+func NewWeb3Signer(baseUrl string) (Signer, error) {
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("web3signer: unsupported url %q", baseUrl)
+	}
+	return &web3Signer{httpClient: &http.Client{Timeout: web3SignerTimeout}, url: u}, nil
+}
+
+func (s *web3Signer) Sign(ctx context.Context, pubkey common.Bytes48, req *Request) (common.Bytes96, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return common.Bytes96{}, err
+	}
+	u := s.url.JoinPath("/api/v1/eth2/sign", pubkey.Hex()).String()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(payload))
+	if err != nil {
+		return common.Bytes96{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return common.Bytes96{}, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return common.Bytes96{}, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return common.Bytes96{}, fmt.Errorf("%w: %s", ErrRemoteKeyNotFound, pubkey)
+	case http.StatusPreconditionFailed:
+		return common.Bytes96{}, ErrRemoteSlashingProtection
+	default:
+		return common.Bytes96{}, fmt.Errorf("web3signer: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	// the signature is returned as JSON, or as plain text by older signers
+	signature := strings.TrimSpace(string(body))
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var out struct {
+			Signature string `json:"signature"`
+		}
+		if err := json.Unmarshal(body, &out); err != nil {
+			return common.Bytes96{}, fmt.Errorf("web3signer: %w", err)
+		}
+		signature = out.Signature
+	}
+	decoded, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Bytes96{}, fmt.Errorf("web3signer: signature: %w", err)
+	}
+	if len(decoded) != len(common.Bytes96{}) {
+		return common.Bytes96{}, fmt.Errorf("web3signer: invalid signature length %d", len(decoded))
+	}
+	return common.Bytes96(decoded), nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/fork"
+	"github.com/erigontech/erigon/cl/utils"
+	"github.com/erigontech/erigon/cl/utils/bls"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+)
+
+var testGenesisValidatorsRoot = common.Hash{0x42}
+
+// testWeb3Signer is a stand-in for Web3Signer: like the real one it recomputes the signing
+// root from the typed object and the fork info, and refuses requests where they disagree.
+type testWeb3Signer struct {
+	cfg      *clparams.BeaconChainConfig
+	key      *bls.PrivateKey
+	pubkey   common.Bytes48
+	requests atomic.Int32
+	server   *httptest.Server
+}
+
+func newTestWeb3Signer(t *testing.T, cfg *clparams.BeaconChainConfig) *testWeb3Signer {
+	t.Helper()
+	key, err := bls.GenerateKey()
+	require.NoError(t, err)
+	s := &testWeb3Signer{cfg: cfg, key: key, pubkey: common.Bytes48(bls.CompressPublicKey(key.PublicKey()))}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveSign))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *testWeb3Signer) expectedRoot(req *Request) (common.Hash, error) {
+	var (
+		domainType common.Bytes4
+		epoch      uint64
+		obj        ssz.HashableSSZ
+	)
+	switch req.Type {
+	case TypeBlockV2:
+		domainType, epoch, obj = s.cfg.DomainBeaconProposer, req.Block.BlockHeader.Slot/s.cfg.SlotsPerEpoch, req.Block.BlockHeader
+	case TypeAttestation:
+		domainType, epoch, obj = s.cfg.DomainBeaconAttester, req.Attestation.Target.Epoch, req.Attestation
+	case TypeRandaoReveal:
+		domainType, epoch, obj = s.cfg.DomainRandao, req.RandaoReveal.Epoch, uint64Object(req.RandaoReveal.Epoch)
+	case TypeSyncCommitteeMessage:
+		domainType, epoch, obj = s.cfg.DomainSyncCommittee, req.SyncCommitteeMessage.Slot/s.cfg.SlotsPerEpoch, rootObject(req.SyncCommitteeMessage.BeaconBlockRoot)
+	case TypeVoluntaryExit:
+		domainType, epoch, obj = s.cfg.DomainVoluntaryExit, req.ForkInfo.Fork.Epoch, req.VoluntaryExit
+	default:
+		return req.SigningRoot, nil
+	}
+	domain, err := req.ForkInfo.Domain(domainType, epoch)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return fork.ComputeSigningRoot(obj, domain)
+}
+
+func (s *testWeb3Signer) serveSign(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/api/v1/eth2/sign/") {
+		http.NotFound(w, r)
+		return
+	}
+	if strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/") != s.pubkey.Hex() {
+		http.Error(w, "Public Key not found", http.StatusNotFound)
+		return
+	}
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ForkInfo.GenesisValidatorsRoot != testGenesisValidatorsRoot {
+		http.Error(w, "wrong genesis validators root", http.StatusBadRequest)
+		return
+	}
+	root, err := s.expectedRoot(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if root != req.SigningRoot {
+		http.Error(w, "signing root mismatch", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"signature": hexutil.Encode(s.key.Sign(root[:]).Bytes())})
+}
+
+// newTestBlock returns an Electra block with all the body fields set, as proposed blocks have.
+func newTestBlock(cfg *clparams.BeaconChainConfig, slot uint64) *cltypes.BeaconBlock {
+	block := cltypes.NewBeaconBlock(cfg, clparams.ElectraVersion)
+	block.Slot, block.ProposerIndex, block.StateRoot = slot, 7, common.Hash{8}
+	body := block.Body
+	body.RandaoReveal = common.Bytes96{9}
+	body.Eth1Data = &cltypes.Eth1Data{Root: common.Hash{10}, DepositCount: 11, BlockHash: common.Hash{12}}
+	body.Graffiti = common.Hash{13}
+	body.SyncAggregate = cltypes.NewSyncAggregate()
+	var blobGasUsed, excessBlobGas uint64
+	withdrawalsHash := common.Hash{18}
+	header := &types.Header{
+		ParentHash:      common.Hash{14},
+		Number:          big.NewInt(15),
+		GasLimit:        30_000_000,
+		Time:            16,
+		BaseFee:         big.NewInt(17),
+		WithdrawalsHash: &withdrawalsHash,
+		BlobGasUsed:     &blobGasUsed,
+		ExcessBlobGas:   &excessBlobGas,
+	}
+	body.ExecutionPayload = cltypes.NewEth1BlockFromHeaderAndBody(header, &types.RawBody{}, cfg)
+	return block
+}
+
+func TestBlockRequestIncompleteBody(t *testing.T) {
+	t.Parallel()
+	cfg := clparams.MainnetBeaconConfig
+	builder := NewRequestBuilder(&cfg, testGenesisValidatorsRoot)
+
+	block := cltypes.NewBeaconBlock(&cfg, clparams.ElectraVersion)
+	_, err := builder.Block(block)
+	require.ErrorIs(t, err, ErrIncompleteBlock)
+	require.ErrorContains(t, err, "sync_aggregate")
+
+	block = newTestBlock(&cfg, cfg.ElectraForkEpoch*cfg.SlotsPerEpoch)
+	block.Body.ExecutionRequests = nil
+	_, err = builder.Block(block)
+	require.ErrorIs(t, err, ErrIncompleteBlock)
+	require.ErrorContains(t, err, "execution_requests")
+
+	block.Body = nil
+	_, err = builder.Block(block)
+	require.ErrorIs(t, err, ErrIncompleteBlock)
+}
+
+func TestWeb3Signer(t *testing.T) {
+	t.Parallel()
+	cfg := clparams.MainnetBeaconConfig
+	remote := newTestWeb3Signer(t, &cfg)
+	signer, err := NewWeb3Signer(remote.server.URL)
+	require.NoError(t, err)
+	builder := NewRequestBuilder(&cfg, testGenesisValidatorsRoot)
+
+	slot := cfg.ElectraForkEpoch*cfg.SlotsPerEpoch + 3
+	block := newTestBlock(&cfg, slot)
+	block.ParentRoot = common.Hash{1}
+	blockReq, err := builder.Block(block)
+	require.NoError(t, err)
+	blockRoot, err := block.HashSSZ()
+	require.NoError(t, err)
+	headerRoot, err := blockReq.Block.BlockHeader.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, blockRoot, headerRoot)
+	require.Equal(t, "ELECTRA", blockReq.Block.Version)
+
+	attestationReq, err := builder.Attestation(&solid.AttestationData{
+		Slot:            slot,
+		BeaconBlockRoot: common.Hash{2},
+		Source:          solid.Checkpoint{Epoch: cfg.ElectraForkEpoch - 1, Root: common.Hash{3}},
+		Target:          solid.Checkpoint{Epoch: cfg.ElectraForkEpoch, Root: common.Hash{4}},
+	})
+	require.NoError(t, err)
+	randaoReq, err := builder.RandaoReveal(cfg.ElectraForkEpoch)
+	require.NoError(t, err)
+	syncReq, err := builder.SyncCommitteeMessage(slot, common.Hash{5})
+	require.NoError(t, err)
+	exitReq, err := builder.VoluntaryExit(&cltypes.VoluntaryExit{Epoch: cfg.ElectraForkEpoch, ValidatorIndex: 7})
+	require.NoError(t, err)
+	require.Equal(t, common.Bytes4(utils.Uint32ToBytes4(uint32(cfg.CapellaForkVersion))), exitReq.ForkInfo.Fork.CurrentVersion)
+
+	for _, req := range []*Request{blockReq, attestationReq, randaoReq, syncReq, exitReq} {
+		signature, err := signer.Sign(context.Background(), remote.pubkey, req)
+		require.NoError(t, err, req.Type)
+		ok, err := bls.Verify(signature[:], req.SigningRoot[:], remote.pubkey[:])
+		require.NoError(t, err)
+		require.True(t, ok, req.Type)
+	}
+
+	// the stand-in checks typed signing roots
+	tampered := *randaoReq
+	tampered.RandaoReveal = &EpochRequest{Epoch: cfg.ElectraForkEpoch + 1}
+	_, err = signer.Sign(context.Background(), remote.pubkey, &tampered)
+	require.ErrorContains(t, err, "signing root mismatch")
+
+	_, err = signer.Sign(context.Background(), common.Bytes48{1}, randaoReq)
+	require.ErrorIs(t, err, ErrRemoteKeyNotFound)
+}
+
+func TestSlashingProtectionBeforeSigning(t *testing.T) {
+	t.Parallel()
+	cfg := clparams.MainnetBeaconConfig
+	remote := newTestWeb3Signer(t, &cfg)
+	web3Signer, err := NewWeb3Signer(remote.server.URL)
+	require.NoError(t, err)
+	sp, err := slashing_protection.New(context.Background(), memdb.NewTestDB(t, kv.CaplinValidatorDB), testGenesisValidatorsRoot)
+	require.NoError(t, err)
+	signer := WithSlashingProtection(web3Signer, sp)
+	builder := NewRequestBuilder(&cfg, testGenesisValidatorsRoot)
+
+	// sign a lower slot first, so that the double proposal is not also below the low watermark
+	slot := cfg.ElectraForkEpoch*cfg.SlotsPerEpoch + 3
+	earlier, err := builder.Block(newTestBlock(&cfg, slot-1))
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), remote.pubkey, earlier)
+	require.NoError(t, err)
+
+	block := newTestBlock(&cfg, slot)
+	first, err := builder.Block(block)
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), remote.pubkey, first)
+	require.NoError(t, err)
+
+	block.ParentRoot = common.Hash{1}
+	second, err := builder.Block(block)
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), remote.pubkey, second)
+	require.ErrorIs(t, err, slashing_protection.ErrDoubleProposal)
+
+	data := &solid.AttestationData{
+		Slot:   slot,
+		Source: solid.Checkpoint{Epoch: 10},
+		Target: solid.Checkpoint{Epoch: 20},
+	}
+	attestation, err := builder.Attestation(data)
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), remote.pubkey, attestation)
+	require.NoError(t, err)
+	surrounding, err := builder.Attestation(&solid.AttestationData{
+		Slot:   slot,
+		Source: solid.Checkpoint{Epoch: 9},
+		Target: solid.Checkpoint{Epoch: 21},
+	})
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), remote.pubkey, surrounding)
+	require.ErrorIs(t, err, slashing_protection.ErrSurroundVote)
+
+	// refused requests never reach the remote signer
+	require.Equal(t, int32(3), remote.requests.Load())
+}
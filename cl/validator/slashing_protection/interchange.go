@@ -0,0 +1,180 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package slashing_protection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+)
+
+const InterchangeFormatVersion = "5"
+
+// Interchange is the EIP-3076 slashing protection interchange format (version 5).
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    common.Hash `json:"genesis_validators_root"`
+}
+
+type InterchangeData struct {
+	Pubkey             common.Bytes48           `json:"pubkey"`
+	SignedBlocks       []InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeAttestation `json:"signed_attestations"`
+}
+
+type InterchangeBlock struct {
+	Slot        uint64       `json:"slot,string"`
+	SigningRoot *common.Hash `json:"signing_root,omitempty"`
+}
+
+type InterchangeAttestation struct {
+	SourceEpoch uint64       `json:"source_epoch,string"`
+	TargetEpoch uint64       `json:"target_epoch,string"`
+	SigningRoot *common.Hash `json:"signing_root,omitempty"`
+}
+
+func signingRootOrZero(root *common.Hash) common.Hash {
+	if root == nil {
+		return common.Hash{}
+	}
+	return *root
+}
+
+func signingRootOrNil(root []byte) *common.Hash {
+	if bytes.Equal(root, make([]byte, 32)) {
+		return nil
+	}
+	h := common.BytesToHash(root)
+	return &h
+}
+
+// Import merges the history of another client into the database. Entries that conflict
+// with the local history are kept with an unknown signing root, which makes any future
+// signing at that slot or target epoch fail.
+func (s *SlashingProtection) Import(ctx context.Context, interchange *Interchange) error {
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("slashing protection: unsupported interchange format version %q", interchange.Metadata.InterchangeFormatVersion)
+	}
+	if interchange.Metadata.GenesisValidatorsRoot != s.genesisValidatorsRoot {
+		return fmt.Errorf("%w: interchange is for %x", ErrGenesisRootMismatch, interchange.Metadata.GenesisValidatorsRoot)
+	}
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		for _, data := range interchange.Data {
+			for _, block := range data.SignedBlocks {
+				key := blockKey(data.Pubkey, block.Slot)
+				root := signingRootOrZero(block.SigningRoot)
+				existing, err := tx.GetOne(kv.ValidatorSignedBlocks, key)
+				if err != nil {
+					return err
+				}
+				if existing != nil && !bytes.Equal(existing, root[:]) {
+					root = common.Hash{}
+				}
+				if err := tx.Put(kv.ValidatorSignedBlocks, key, root[:]); err != nil {
+					return err
+				}
+			}
+			for _, att := range data.SignedAttestations {
+				if att.SourceEpoch > att.TargetEpoch {
+					return fmt.Errorf("%w: source %d, target %d", ErrInvalidAttestation, att.SourceEpoch, att.TargetEpoch)
+				}
+				key := attestationKey(data.Pubkey, att.TargetEpoch)
+				value := attestationValue(att.SourceEpoch, signingRootOrZero(att.SigningRoot))
+				existing, err := tx.GetOne(kv.ValidatorSignedAttestations, key)
+				if err != nil {
+					return err
+				}
+				if existing != nil && !bytes.Equal(existing, value) {
+					// keep the higher source, it is the more restrictive one
+					value = attestationValue(max(att.SourceEpoch, binary.BigEndian.Uint64(existing[:8])), common.Hash{})
+				}
+				if err := tx.Put(kv.ValidatorSignedAttestations, key, value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Export returns the history of pubkeys, or of all validators if pubkeys is empty.
+// Validators without any history are still listed when requested explicitly.
+func (s *SlashingProtection) Export(ctx context.Context, pubkeys []common.Bytes48) (*Interchange, error) {
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    s.genesisValidatorsRoot,
+		},
+		Data: []InterchangeData{},
+	}
+	byPubkey := map[common.Bytes48]*InterchangeData{}
+	entry := func(pubkey common.Bytes48) *InterchangeData {
+		data, ok := byPubkey[pubkey]
+		if !ok {
+			data = &InterchangeData{Pubkey: pubkey, SignedBlocks: []InterchangeBlock{}, SignedAttestations: []InterchangeAttestation{}}
+			byPubkey[pubkey] = data
+		}
+		return data
+	}
+	for _, pubkey := range pubkeys {
+		entry(pubkey)
+	}
+	wanted := func(k []byte) bool {
+		return len(pubkeys) == 0 || slices.Contains(pubkeys, common.Bytes48(k[:48]))
+	}
+
+	if err := s.db.View(ctx, func(tx kv.Tx) error {
+		if err := tx.ForEach(kv.ValidatorSignedBlocks, nil, func(k, v []byte) error {
+			if wanted(k) {
+				data := entry(common.Bytes48(k[:48]))
+				data.SignedBlocks = append(data.SignedBlocks, InterchangeBlock{Slot: binary.BigEndian.Uint64(k[48:]), SigningRoot: signingRootOrNil(v)})
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.ForEach(kv.ValidatorSignedAttestations, nil, func(k, v []byte) error {
+			if wanted(k) {
+				data := entry(common.Bytes48(k[:48]))
+				data.SignedAttestations = append(data.SignedAttestations, InterchangeAttestation{
+					SourceEpoch: binary.BigEndian.Uint64(v[:8]),
+					TargetEpoch: binary.BigEndian.Uint64(k[48:]),
+					SigningRoot: signingRootOrNil(v[8:]),
+				})
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, data := range byPubkey {
+		interchange.Data = append(interchange.Data, *data)
+	}
+	slices.SortFunc(interchange.Data, func(a, b InterchangeData) int { return bytes.Compare(a.Pubkey[:], b.Pubkey[:]) })
+	return interchange, nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package slashing_protection keeps the history of the blocks and attestations signed by
+// the validator client and refuses to sign anything slashable. The rules and the
+// interchange format follow EIP-3076.
+package slashing_protection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+var (
+	ErrDoubleProposal        = errors.New("slashing protection: double block proposal")
+	ErrDoubleVote            = errors.New("slashing protection: double vote")
+	ErrSurroundVote          = errors.New("slashing protection: surround vote")
+	ErrBelowWatermark        = errors.New("slashing protection: below the low watermark")
+	ErrInvalidAttestation    = errors.New("slashing protection: source epoch after target epoch")
+	ErrGenesisRootMismatch   = errors.New("slashing protection: genesis validators root mismatch")
+	genesisValidatorsRootKey = []byte("genesis_validators_root")
+)
+
+// SlashingProtection is safe for concurrent use: every check runs in the same write
+// transaction as the insertion of the signed message, and mdbx serializes writers.
+type SlashingProtection struct {
+	db                    kv.RwDB
+	genesisValidatorsRoot common.Hash
+}
+
+// OpenDB opens the validator database at path.
+func OpenDB(ctx context.Context, path string, logger log.Logger) (kv.RwDB, error) {
+	return mdbx.New(kv.CaplinValidatorDB, logger).
+		WithTableCfg(func(defaultBuckets kv.TableCfg) kv.TableCfg { return kv.CaplinValidatorTablesCfg }).
+		GrowthStep(16 * datasize.MB).
+		MapSize(16 * datasize.GB).
+		Path(path).
+		Open(ctx)
+}
+
+// New binds db to the chain of genesisValidatorsRoot. A database that was used on another
+// chain is rejected.
+func New(ctx context.Context, db kv.RwDB, genesisValidatorsRoot common.Hash) (*SlashingProtection, error) {
+	if err := db.Update(ctx, func(tx kv.RwTx) error {
+		return checkGenesisValidatorsRoot(tx, genesisValidatorsRoot)
+	}); err != nil {
+		return nil, err
+	}
+	return &SlashingProtection{db: db, genesisValidatorsRoot: genesisValidatorsRoot}, nil
+}
+
+func checkGenesisValidatorsRoot(tx kv.RwTx, genesisValidatorsRoot common.Hash) error {
+	stored, err := tx.GetOne(kv.ValidatorInfo, genesisValidatorsRootKey)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return tx.Put(kv.ValidatorInfo, genesisValidatorsRootKey, genesisValidatorsRoot[:])
+	}
+	if !bytes.Equal(stored, genesisValidatorsRoot[:]) {
+		return fmt.Errorf("%w: have %x, want %x", ErrGenesisRootMismatch, stored, genesisValidatorsRoot)
+	}
+	return nil
+}
+
+func blockKey(pubkey common.Bytes48, slot uint64) []byte {
+	return binary.BigEndian.AppendUint64(common.Copy(pubkey[:]), slot)
+}
+
+func attestationKey(pubkey common.Bytes48, targetEpoch uint64) []byte {
+	return binary.BigEndian.AppendUint64(common.Copy(pubkey[:]), targetEpoch)
+}
+
+func attestationValue(sourceEpoch uint64, signingRoot common.Hash) []byte {
+	return append(binary.BigEndian.AppendUint64(nil, sourceEpoch), signingRoot[:]...)
+}
+
+// CheckAndInsertBlockProposal records a block about to be signed, or returns an error if
+// signing it could get the validator slashed. Signing the same block again is allowed.
+func (s *SlashingProtection) CheckAndInsertBlockProposal(ctx context.Context, pubkey common.Bytes48, slot uint64, signingRoot common.Hash) error {
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		key := blockKey(pubkey, slot)
+		existing, err := tx.GetOne(kv.ValidatorSignedBlocks, key)
+		if err != nil {
+			return err
+		}
+		if existing != nil && isRepeat(existing, signingRoot) {
+			return nil
+		}
+		c, err := tx.Cursor(kv.ValidatorSignedBlocks)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		k, _, err := c.Seek(pubkey[:])
+		if err != nil {
+			return err
+		}
+		if k != nil && bytes.HasPrefix(k, pubkey[:]) && slot <= binary.BigEndian.Uint64(k[48:]) {
+			return fmt.Errorf("%w: slot %d", ErrBelowWatermark, slot)
+		}
+		if existing != nil {
+			return fmt.Errorf("%w: slot %d", ErrDoubleProposal, slot)
+		}
+		return tx.Put(kv.ValidatorSignedBlocks, key, signingRoot[:])
+	})
+}
+
+// CheckAndInsertAttestation records an attestation about to be signed, or returns an error
+// if signing it could get the validator slashed. Signing the same attestation again is allowed.
+func (s *SlashingProtection) CheckAndInsertAttestation(ctx context.Context, pubkey common.Bytes48, sourceEpoch, targetEpoch uint64, signingRoot common.Hash) error {
+	if sourceEpoch > targetEpoch {
+		return fmt.Errorf("%w: source %d, target %d", ErrInvalidAttestation, sourceEpoch, targetEpoch)
+	}
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		key := attestationKey(pubkey, targetEpoch)
+		existing, err := tx.GetOne(kv.ValidatorSignedAttestations, key)
+		if err != nil {
+			return err
+		}
+		if existing != nil && binary.BigEndian.Uint64(existing[:8]) == sourceEpoch && isRepeat(existing[8:], signingRoot) {
+			return nil
+		}
+		c, err := tx.Cursor(kv.ValidatorSignedAttestations)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		first := true
+		for k, v, err := c.Seek(pubkey[:]); ; k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			if k == nil || !bytes.HasPrefix(k, pubkey[:]) {
+				break
+			}
+			prevTarget, prevSource := binary.BigEndian.Uint64(k[48:]), binary.BigEndian.Uint64(v[:8])
+			if prevTarget == targetEpoch {
+				return fmt.Errorf("%w: target epoch %d", ErrDoubleVote, targetEpoch)
+			}
+			if (sourceEpoch < prevSource && prevTarget < targetEpoch) || (prevSource < sourceEpoch && targetEpoch < prevTarget) {
+				return fmt.Errorf("%w: (%d, %d) and (%d, %d)", ErrSurroundVote, sourceEpoch, targetEpoch, prevSource, prevTarget)
+			}
+			if sourceEpoch < prevSource {
+				return fmt.Errorf("%w: source epoch %d", ErrBelowWatermark, sourceEpoch)
+			}
+			// entries are sorted by target, so the first one holds the minimum
+			if first && targetEpoch < prevTarget {
+				return fmt.Errorf("%w: target epoch %d", ErrBelowWatermark, targetEpoch)
+			}
+			first = false
+		}
+		return tx.Put(kv.ValidatorSignedAttestations, key, attestationValue(sourceEpoch, signingRoot))
+	})
+}
+
+// isRepeat reports whether a stored signing root proves that the same message is signed
+// again. An unknown (zero) root never does.
+func isRepeat(stored []byte, signingRoot common.Hash) bool {
+	return signingRoot != (common.Hash{}) && bytes.Equal(stored, signingRoot[:])
+}
+
+// Prune drops the history of the epochs before currentEpoch-pruningEpochs. The latest block
+// and attestation of each validator are always kept, so the low watermarks only move up.
+func (s *SlashingProtection) Prune(ctx context.Context, currentEpoch, pruningEpochs, slotsPerEpoch uint64) error {
+	if currentEpoch <= pruningEpochs {
+		return nil
+	}
+	minEpoch := currentEpoch - pruningEpochs
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		if err := pruneTable(tx, kv.ValidatorSignedBlocks, minEpoch*slotsPerEpoch); err != nil {
+			return err
+		}
+		return pruneTable(tx, kv.ValidatorSignedAttestations, minEpoch)
+	})
+}
+
+func pruneTable(tx kv.RwTx, table string, below uint64) error {
+	c, err := tx.RwCursor(table)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	var toDelete [][]byte
+	var prev []byte
+	for k, _, err := c.First(); ; k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if k == nil {
+			break
+		}
+		// prev is only deleted once we know that it is not the last entry of its validator
+		if prev != nil && bytes.Equal(prev[:48], k[:48]) {
+			toDelete = append(toDelete, prev)
+		}
+		prev = nil
+		if binary.BigEndian.Uint64(k[48:]) < below {
+			prev = common.Copy(k)
+		}
+	}
+	for _, k := range toDelete {
+		if err := tx.Delete(table, k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package slashing_protection
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+)
+
+var (
+	testGenesisValidatorsRoot = common.Hash{0x47}
+	pubkeyA                   = common.Bytes48{0xa}
+	pubkeyB                   = common.Bytes48{0xb}
+)
+
+func newTestSlashingProtection(t *testing.T) *SlashingProtection {
+	t.Helper()
+	db := memdb.NewTestDB(t, kv.CaplinValidatorDB)
+	s, err := New(context.Background(), db, testGenesisValidatorsRoot)
+	require.NoError(t, err)
+	return s
+}
+
+func TestGenesisValidatorsRoot(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := memdb.NewTestDB(t, kv.CaplinValidatorDB)
+	_, err := New(ctx, db, testGenesisValidatorsRoot)
+	require.NoError(t, err)
+	_, err = New(ctx, db, testGenesisValidatorsRoot)
+	require.NoError(t, err)
+	_, err = New(ctx, db, common.Hash{1})
+	require.ErrorIs(t, err, ErrGenesisRootMismatch)
+}
+
+func TestBlockProposals(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newTestSlashingProtection(t)
+
+	require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 10, common.Hash{1}))
+	// repeat of the same block
+	require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 10, common.Hash{1}))
+	require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 12, common.Hash{2}))
+	require.ErrorIs(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 12, common.Hash{3}), ErrDoubleProposal)
+	require.ErrorIs(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 9, common.Hash{4}), ErrBelowWatermark)
+	require.ErrorIs(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 10, common.Hash{4}), ErrBelowWatermark)
+	require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 11, common.Hash{5}))
+
+	// other validators are unaffected
+	require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyB, 1, common.Hash{1}))
+}
+
+func TestAttestations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newTestSlashingProtection(t)
+
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 2, 3, common.Hash{1}))
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 2, 3, common.Hash{1}))
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 2, 3, common.Hash{2}), ErrDoubleVote)
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 5, 10, common.Hash{3}))
+
+	// surrounding and surrounded votes
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 4, 11, common.Hash{4}), ErrSurroundVote)
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 6, 9, common.Hash{4}), ErrSurroundVote)
+
+	// source and target below the low watermarks
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 1, 12, common.Hash{4}), ErrSurroundVote)
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 1, 2, common.Hash{4}), ErrBelowWatermark)
+
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 12, 11, common.Hash{4}), ErrInvalidAttestation)
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 10, 11, common.Hash{5}))
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyB, 1, 2, common.Hash{4}))
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newTestSlashingProtection(t)
+
+	for epoch := uint64(1); epoch <= 10; epoch++ {
+		require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyA, epoch-1, epoch, common.Hash{byte(epoch)}))
+		require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, epoch*4, common.Hash{byte(epoch)}))
+	}
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyB, 0, 1, common.Hash{1}))
+	require.NoError(t, s.Prune(ctx, 12, 4, 4))
+
+	interchange, err := s.Export(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, interchange.Data, 2)
+	require.Len(t, interchange.Data[0].SignedAttestations, 3) // targets 8, 9 and 10
+	require.Len(t, interchange.Data[0].SignedBlocks, 3)       // slots 32, 36 and 40
+	// the only entry of a validator is kept whatever its age
+	require.Len(t, interchange.Data[1].SignedAttestations, 1)
+
+	// pruning keeps the watermarks
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 3, 4, common.Hash{1}), ErrBelowWatermark)
+}
+
+func TestInterchange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newTestSlashingProtection(t)
+	require.NoError(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 100, common.Hash{1}))
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 5, 6, common.Hash{2}))
+
+	input := `{
+		"metadata": {"interchange_format_version": "5", "genesis_validators_root": "0x4700000000000000000000000000000000000000000000000000000000000000"},
+		"data": [{
+			"pubkey": "0x0a0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			"signed_blocks": [{"slot": "100", "signing_root": "0x0300000000000000000000000000000000000000000000000000000000000000"}, {"slot": "101"}],
+			"signed_attestations": [{"source_epoch": "6", "target_epoch": "7"}]
+		}, {
+			"pubkey": "0x0b0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			"signed_blocks": [],
+			"signed_attestations": [{"source_epoch": "1", "target_epoch": "2", "signing_root": "0x0400000000000000000000000000000000000000000000000000000000000000"}]
+		}]
+	}`
+	var interchange Interchange
+	require.NoError(t, json.Unmarshal([]byte(input), &interchange))
+	require.NoError(t, s.Import(ctx, &interchange))
+
+	// imported blocks have an unknown root and cannot be signed again
+	require.ErrorIs(t, s.CheckAndInsertBlockProposal(ctx, pubkeyA, 101, common.Hash{1}), ErrDoubleProposal)
+	require.ErrorIs(t, s.CheckAndInsertAttestation(ctx, pubkeyA, 6, 7, common.Hash{9}), ErrDoubleVote)
+	require.NoError(t, s.CheckAndInsertAttestation(ctx, pubkeyB, 1, 2, common.Hash{4}))
+
+	exported, err := s.Export(ctx, []common.Bytes48{pubkeyA})
+	require.NoError(t, err)
+	require.Len(t, exported.Data, 1)
+	require.Equal(t, []InterchangeBlock{{Slot: 100}, {Slot: 101}}, exported.Data[0].SignedBlocks)
+	require.Len(t, exported.Data[0].SignedAttestations, 2)
+
+	// the export imports cleanly into a fresh database
+	enc, err := json.Marshal(exported)
+	require.NoError(t, err)
+	var decoded Interchange
+	require.NoError(t, json.Unmarshal(enc, &decoded))
+	fresh := newTestSlashingProtection(t)
+	require.NoError(t, fresh.Import(ctx, &decoded))
+	require.ErrorIs(t, fresh.CheckAndInsertBlockProposal(ctx, pubkeyA, 101, common.Hash{1}), ErrDoubleProposal)
+
+	interchange.Metadata.GenesisValidatorsRoot = common.Hash{1}
+	require.ErrorIs(t, s.Import(ctx, &interchange), ErrGenesisRootMismatch)
+}
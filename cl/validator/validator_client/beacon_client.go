@@ -0,0 +1,290 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package validator_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+)
+
+// handlerTransport serves requests straight from the beacon API handler of the node, so
+// the validator client does not depend on the API being exposed on the network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseBuffer) Header() http.Header { return w.header }
+
+func (w *responseBuffer) Write(b []byte) (int, error) { return w.body.Write(b) }
+
+func (w *responseBuffer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := &responseBuffer{header: http.Header{}}
+	t.handler.ServeHTTP(w, req)
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(w.status) + " " + http.StatusText(w.status),
+		StatusCode:    w.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        w.header,
+		Body:          io.NopCloser(&w.body),
+		ContentLength: int64(w.body.Len()),
+		Request:       req,
+	}, nil
+}
+
+// beaconClient is a minimal client of the standard beacon API, covering the endpoints the
+// validator client needs.
+type beaconClient struct {
+	client    *http.Client
+	baseURL   string
+	beaconCfg *clparams.BeaconChainConfig
+}
+
+func newBeaconClient(handler http.Handler, beaconCfg *clparams.BeaconChainConfig) *beaconClient {
+	return &beaconClient{
+		client:    &http.Client{Transport: handlerTransport{handler: handler}},
+		baseURL:   "http://caplin",
+		beaconCfg: beaconCfg,
+	}
+}
+
+func (b *beaconClient) do(ctx context.Context, method, path string, header http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, b.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("beacon api: %s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(msg))
+	}
+	return resp, nil
+}
+
+// call sends in (if not nil) as JSON and decodes the data field of the response into out
+// (if not nil).
+func (b *beaconClient) call(ctx context.Context, method, path string, header http.Header, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	resp, err := b.do(ctx, method, path, header, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("beacon api: %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+type validatorEntry struct {
+	Index     uint64 `json:"index,string"`
+	Status    string `json:"status"`
+	Validator struct {
+		Pubkey common.Bytes48 `json:"pubkey"`
+	} `json:"validator"`
+}
+
+// validatorIndices resolves the indices of the pubkeys known to the head state.
+func (b *beaconClient) validatorIndices(ctx context.Context, pubkeys []common.Bytes48) (map[common.Bytes48]uint64, error) {
+	ids := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		ids[i] = pubkey.Hex()
+	}
+	var entries []validatorEntry
+	if err := b.call(ctx, http.MethodPost, "/eth/v1/beacon/states/head/validators", nil, map[string]any{"ids": ids}, &entries); err != nil {
+		return nil, err
+	}
+	indices := make(map[common.Bytes48]uint64, len(entries))
+	for _, entry := range entries {
+		indices[entry.Validator.Pubkey] = entry.Index
+	}
+	return indices, nil
+}
+
+func indicesBody(indices []uint64) []string {
+	body := make([]string, len(indices))
+	for i, index := range indices {
+		body[i] = strconv.FormatUint(index, 10)
+	}
+	return body
+}
+
+type attesterDuty struct {
+	Pubkey                  common.Bytes48 `json:"pubkey"`
+	ValidatorIndex          uint64         `json:"validator_index,string"`
+	CommitteeIndex          uint64         `json:"committee_index,string"`
+	CommitteeLength         uint64         `json:"committee_length,string"`
+	ValidatorCommitteeIndex uint64         `json:"validator_committee_index,string"`
+	CommitteesAtSlot        uint64         `json:"committees_at_slot,string"`
+	Slot                    uint64         `json:"slot,string"`
+}
+
+func (b *beaconClient) attesterDuties(ctx context.Context, epoch uint64, indices []uint64) ([]attesterDuty, error) {
+	var duties []attesterDuty
+	err := b.call(ctx, http.MethodPost, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), nil, indicesBody(indices), &duties)
+	return duties, err
+}
+
+type proposerDuty struct {
+	Pubkey         common.Bytes48 `json:"pubkey"`
+	ValidatorIndex uint64         `json:"validator_index,string"`
+	Slot           uint64         `json:"slot,string"`
+}
+
+func (b *beaconClient) proposerDuties(ctx context.Context, epoch uint64) ([]proposerDuty, error) {
+	var duties []proposerDuty
+	err := b.call(ctx, http.MethodGet, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), nil, nil, &duties)
+	return duties, err
+}
+
+type syncDuty struct {
+	Pubkey         common.Bytes48 `json:"pubkey"`
+	ValidatorIndex uint64         `json:"validator_index,string"`
+}
+
+func (b *beaconClient) syncDuties(ctx context.Context, epoch uint64, indices []uint64) ([]syncDuty, error) {
+	var duties []syncDuty
+	err := b.call(ctx, http.MethodPost, fmt.Sprintf("/eth/v1/validator/duties/sync/%d", epoch), nil, indicesBody(indices), &duties)
+	return duties, err
+}
+
+type proposerPreparation struct {
+	ValidatorIndex uint64         `json:"validator_index,string"`
+	FeeRecipient   common.Address `json:"fee_recipient"`
+}
+
+func (b *beaconClient) prepareBeaconProposer(ctx context.Context, preparations []proposerPreparation) error {
+	return b.call(ctx, http.MethodPost, "/eth/v1/validator/prepare_beacon_proposer", nil, preparations, nil)
+}
+
+func (b *beaconClient) attestationData(ctx context.Context, slot, committeeIndex uint64) (*solid.AttestationData, error) {
+	data := &solid.AttestationData{}
+	err := b.call(ctx, http.MethodGet, fmt.Sprintf("/eth/v1/validator/attestation_data?slot=%d&committee_index=%d", slot, committeeIndex), nil, nil, data)
+	return data, err
+}
+
+func versionHeader(version clparams.StateVersion) http.Header {
+	return http.Header{"Eth-Consensus-Version": []string{version.String()}}
+}
+
+func (b *beaconClient) submitAttestations(ctx context.Context, version clparams.StateVersion, attestations []*solid.SingleAttestation) error {
+	return b.call(ctx, http.MethodPost, "/eth/v2/beacon/pool/attestations", versionHeader(version), attestations, nil)
+}
+
+func (b *beaconClient) headBlockRoot(ctx context.Context) (common.Hash, error) {
+	var data struct {
+		Root common.Hash `json:"root"`
+	}
+	err := b.call(ctx, http.MethodGet, "/eth/v1/beacon/blocks/head/root", nil, nil, &data)
+	return data.Root, err
+}
+
+func (b *beaconClient) submitSyncCommitteeMessages(ctx context.Context, messages []*cltypes.SyncCommitteeMessage) error {
+	return b.call(ctx, http.MethodPost, "/eth/v1/beacon/pool/sync_committees", nil, messages, nil)
+}
+
+// produceBlock asks the node for an unsigned block with a local execution payload.
+func (b *beaconClient) produceBlock(ctx context.Context, slot uint64, randaoReveal common.Bytes96, graffiti common.Hash) (*cltypes.DenebBeaconBlock, error) {
+	path := fmt.Sprintf("/eth/v3/validator/blocks/%d?randao_reveal=%s&graffiti=%s&builder_boost_factor=0", slot, randaoReveal.Hex(), graffiti.Hex())
+	resp, err := b.do(ctx, http.MethodGet, path, http.Header{"Accept": []string{"application/octet-stream"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Eth-Execution-Payload-Blinded") == "true" {
+		return nil, errors.New("beacon api: produced a blinded block")
+	}
+	version, err := clparams.StringToClVersion(resp.Header.Get("Eth-Consensus-Version"))
+	if err != nil {
+		return nil, fmt.Errorf("beacon api: produced block: %w", err)
+	}
+	if version < clparams.DenebVersion {
+		return nil, fmt.Errorf("beacon api: produced a %s block", version)
+	}
+	encoded, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	block := cltypes.NewDenebBeaconBlock(b.beaconCfg, version, slot)
+	if err := block.DecodeSSZ(encoded, int(version)); err != nil {
+		return nil, fmt.Errorf("beacon api: produced block: %w", err)
+	}
+	return block, nil
+}
+
+func (b *beaconClient) publishBlock(ctx context.Context, block *cltypes.DenebSignedBeaconBlock) error {
+	encoded, err := block.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	header := versionHeader(block.SignedBlock.Version())
+	header.Set("Content-Type", "application/octet-stream")
+	resp, err := b.do(ctx, http.MethodPost, "/eth/v2/beacon/blocks", header, encoded)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
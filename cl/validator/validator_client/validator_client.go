@@ -0,0 +1,374 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package validator_client implements the validator client built into Caplin. It performs
+// the proposer, attester and sync committee duties of the local and remote keys through the
+// beacon API of the node, and checks the slashing protection database before every signature.
+// Aggregation duties are left to the beacon node subnets and are not performed.
+package validator_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+	"github.com/erigontech/erigon/cl/validator/keymanager"
+	"github.com/erigontech/erigon/cl/validator/signer"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+)
+
+type ValidatorClient struct {
+	beaconCfg          *clparams.BeaconChainConfig
+	clock              eth_clock.EthereumClock
+	beacon             *beaconClient
+	keys               *keymanager.KeyManager
+	slashingProtection *slashing_protection.SlashingProtection
+	requests           *signer.RequestBuilder
+	graffiti           common.Hash
+	logger             log.Logger
+
+	// signers and duties of dutiesEpoch, refreshed at every epoch
+	signers          map[common.Bytes48]signer.Signer
+	dutiesEpoch      uint64
+	attesterDuties   map[uint64][]attesterDuty
+	proposerDuties   map[uint64]proposerDuty
+	syncDuties       []syncDuty
+	hasDuties        bool
+	lastPrunedEpoch  uint64
+	warnedPreElectra bool
+}
+
+// NewValidatorClient creates a validator client talking to the beacon API served by
+// apiHandler.
+func NewValidatorClient(
+	beaconCfg *clparams.BeaconChainConfig,
+	clock eth_clock.EthereumClock,
+	apiHandler http.Handler,
+	keys *keymanager.KeyManager,
+	slashingProtection *slashing_protection.SlashingProtection,
+	graffiti string,
+	logger log.Logger,
+) *ValidatorClient {
+	var g common.Hash
+	copy(g[:], graffiti)
+	return &ValidatorClient{
+		beaconCfg:          beaconCfg,
+		clock:              clock,
+		beacon:             newBeaconClient(apiHandler, beaconCfg),
+		keys:               keys,
+		slashingProtection: slashingProtection,
+		requests:           signer.NewRequestBuilder(beaconCfg, clock.GenesisValidatorsRoot()),
+		graffiti:           g,
+		logger:             logger,
+	}
+}
+
+// Run performs the duties of every slot until ctx is cancelled.
+func (v *ValidatorClient) Run(ctx context.Context) error {
+	v.logger.Info("[Validator] Starting validator client", "localKeys", len(v.keys.LocalKeys()))
+	slot := v.clock.GetCurrentSlot() + 1
+	for {
+		if err := sleepUntil(ctx, v.clock.GetSlotTime(slot)); err != nil {
+			return err
+		}
+		// skip the slots we are too late for
+		if current := v.clock.GetCurrentSlot(); current > slot {
+			slot = current
+		}
+		v.processSlot(ctx, slot)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slot++
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (v *ValidatorClient) processSlot(ctx context.Context, slot uint64) {
+	epoch := slot / v.beaconCfg.SlotsPerEpoch
+	if !v.hasDuties || v.dutiesEpoch != epoch {
+		if err := v.updateDuties(ctx, epoch); err != nil {
+			v.logger.Warn("[Validator] Failed to update duties", "epoch", epoch, "err", err)
+			return
+		}
+	}
+	if duty, ok := v.proposerDuties[slot]; ok {
+		if err := v.propose(ctx, slot, duty); err != nil {
+			v.logger.Warn("[Validator] Failed to propose block", "slot", slot, "pubkey", duty.Pubkey, "err", err)
+		}
+	}
+
+	// attestations and sync committee messages are sent a third into the slot
+	if err := sleepUntil(ctx, v.clock.GetSlotTime(slot).Add(time.Duration(v.beaconCfg.SecondsPerSlot)*time.Second/3)); err != nil {
+		return
+	}
+	if err := v.attest(ctx, slot); err != nil {
+		v.logger.Warn("[Validator] Failed to attest", "slot", slot, "err", err)
+	}
+	if err := v.sendSyncCommitteeMessages(ctx, slot); err != nil {
+		v.logger.Warn("[Validator] Failed to send sync committee messages", "slot", slot, "err", err)
+	}
+}
+
+// updateSigners picks the signer of every key: the key manager for local keys, and a
+// Web3Signer client for remote keys. All of them check slashing protection first.
+func (v *ValidatorClient) updateSigners(ctx context.Context) error {
+	remoteKeys, err := v.keys.RemoteKeys(ctx)
+	if err != nil {
+		return err
+	}
+	signers := map[common.Bytes48]signer.Signer{}
+	local := signer.WithSlashingProtection(signer.NewLocal(v.keys), v.slashingProtection)
+	for _, key := range v.keys.LocalKeys() {
+		signers[key.Pubkey] = local
+	}
+	remotes := map[string]signer.Signer{}
+	for _, key := range remoteKeys {
+		remote, ok := remotes[key.URL]
+		if !ok {
+			web3Signer, err := signer.NewWeb3Signer(key.URL)
+			if err != nil {
+				v.logger.Warn("[Validator] Invalid remote signer", "pubkey", key.Pubkey, "url", key.URL, "err", err)
+				continue
+			}
+			remote = signer.WithSlashingProtection(web3Signer, v.slashingProtection)
+			remotes[key.URL] = remote
+		}
+		signers[key.Pubkey] = remote
+	}
+	v.signers = signers
+	return nil
+}
+
+func (v *ValidatorClient) sign(ctx context.Context, pubkey common.Bytes48, req *signer.Request) (common.Bytes96, error) {
+	s, ok := v.signers[pubkey]
+	if !ok {
+		return common.Bytes96{}, fmt.Errorf("%w: %s", keymanager.ErrUnknownKey, pubkey)
+	}
+	return s.Sign(ctx, pubkey, req)
+}
+
+// updateDuties resolves the indices of the keys and fetches their duties for epoch.
+func (v *ValidatorClient) updateDuties(ctx context.Context, epoch uint64) error {
+	v.attesterDuties = map[uint64][]attesterDuty{}
+	v.proposerDuties = map[uint64]proposerDuty{}
+	v.syncDuties = nil
+	if err := v.updateSigners(ctx); err != nil {
+		return err
+	}
+	pubkeys := make([]common.Bytes48, 0, len(v.signers))
+	for pubkey := range v.signers {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	v.dutiesEpoch, v.hasDuties = epoch, true
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	indices, err := v.beacon.validatorIndices(ctx, pubkeys)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	indexList := make([]uint64, 0, len(indices))
+	preparations := make([]proposerPreparation, 0, len(indices))
+	for pubkey, index := range indices {
+		indexList = append(indexList, index)
+		feeRecipient, err := v.keys.FeeRecipient(ctx, pubkey)
+		if err != nil {
+			return err
+		}
+		preparations = append(preparations, proposerPreparation{ValidatorIndex: index, FeeRecipient: feeRecipient})
+	}
+	if err := v.beacon.prepareBeaconProposer(ctx, preparations); err != nil {
+		return err
+	}
+
+	attesterDuties, err := v.beacon.attesterDuties(ctx, epoch, indexList)
+	if err != nil {
+		return err
+	}
+	for _, duty := range attesterDuties {
+		v.attesterDuties[duty.Slot] = append(v.attesterDuties[duty.Slot], duty)
+	}
+	proposerDuties, err := v.beacon.proposerDuties(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	for _, duty := range proposerDuties {
+		if _, ok := indices[duty.Pubkey]; ok {
+			v.proposerDuties[duty.Slot] = duty
+		}
+	}
+	if epoch >= v.beaconCfg.AltairForkEpoch {
+		if v.syncDuties, err = v.beacon.syncDuties(ctx, epoch, indexList); err != nil {
+			return err
+		}
+	}
+
+	if epoch > v.lastPrunedEpoch {
+		if err := v.slashingProtection.Prune(ctx, epoch, v.beaconCfg.SlashingProtectionPruningEpochs, v.beaconCfg.SlotsPerEpoch); err != nil {
+			v.logger.Warn("[Validator] Failed to prune slashing protection", "err", err)
+		}
+		v.lastPrunedEpoch = epoch
+	}
+	v.logger.Debug("[Validator] Updated duties", "epoch", epoch, "validators", len(indices),
+		"attestations", len(attesterDuties), "proposals", len(v.proposerDuties), "sync", len(v.syncDuties))
+	return nil
+}
+
+func (v *ValidatorClient) propose(ctx context.Context, slot uint64, duty proposerDuty) error {
+	epoch := slot / v.beaconCfg.SlotsPerEpoch
+	if v.clock.StateVersionByEpoch(epoch) < clparams.DenebVersion {
+		return errors.New("block proposals are supported from deneb")
+	}
+
+	randaoReq, err := v.requests.RandaoReveal(epoch)
+	if err != nil {
+		return err
+	}
+	randaoReveal, err := v.sign(ctx, duty.Pubkey, randaoReq)
+	if err != nil {
+		return err
+	}
+	block, err := v.beacon.produceBlock(ctx, slot, randaoReveal, v.graffiti)
+	if err != nil {
+		return err
+	}
+	if block.Block.ProposerIndex != duty.ValidatorIndex {
+		return errors.New("produced block has a different proposer")
+	}
+
+	blockReq, err := v.requests.Block(block.Block)
+	if err != nil {
+		return err
+	}
+	signature, err := v.sign(ctx, duty.Pubkey, blockReq)
+	if err != nil {
+		return err
+	}
+
+	signed := cltypes.NewDenebSignedBeaconBlock(v.beaconCfg, block.Version())
+	signed.SignedBlock.Block = block.Block
+	signed.SignedBlock.Signature = signature
+	signed.KZGProofs = block.KZGProofs
+	signed.Blobs = block.Blobs
+	if err := v.beacon.publishBlock(ctx, signed); err != nil {
+		return err
+	}
+	v.logger.Info("[Validator] Proposed block", "slot", slot, "validator", duty.ValidatorIndex)
+	return nil
+}
+
+func (v *ValidatorClient) attest(ctx context.Context, slot uint64) error {
+	duties := v.attesterDuties[slot]
+	if len(duties) == 0 {
+		return nil
+	}
+	epoch := slot / v.beaconCfg.SlotsPerEpoch
+	version := v.clock.StateVersionByEpoch(epoch)
+	if version < clparams.ElectraVersion {
+		if !v.warnedPreElectra {
+			v.logger.Warn("[Validator] Attestations are supported from electra, skipping")
+			v.warnedPreElectra = true
+		}
+		return nil
+	}
+
+	dataByCommittee := map[uint64]*solid.AttestationData{}
+	attestations := make([]*solid.SingleAttestation, 0, len(duties))
+	for _, duty := range duties {
+		data, ok := dataByCommittee[duty.CommitteeIndex]
+		if !ok {
+			var err error
+			if data, err = v.beacon.attestationData(ctx, slot, duty.CommitteeIndex); err != nil {
+				return err
+			}
+			dataByCommittee[duty.CommitteeIndex] = data
+		}
+		req, err := v.requests.Attestation(data)
+		if err != nil {
+			return err
+		}
+		signature, err := v.sign(ctx, duty.Pubkey, req)
+		if err != nil {
+			v.logger.Warn("[Validator] Failed to sign attestation", "slot", slot, "validator", duty.ValidatorIndex, "err", err)
+			continue
+		}
+		attestations = append(attestations, &solid.SingleAttestation{
+			CommitteeIndex: duty.CommitteeIndex,
+			AttesterIndex:  duty.ValidatorIndex,
+			Data:           data,
+			Signature:      signature,
+		})
+	}
+	if len(attestations) == 0 {
+		return nil
+	}
+	return v.beacon.submitAttestations(ctx, version, attestations)
+}
+
+func (v *ValidatorClient) sendSyncCommitteeMessages(ctx context.Context, slot uint64) error {
+	if len(v.syncDuties) == 0 {
+		return nil
+	}
+	blockRoot, err := v.beacon.headBlockRoot(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := v.requests.SyncCommitteeMessage(slot, blockRoot)
+	if err != nil {
+		return err
+	}
+	messages := make([]*cltypes.SyncCommitteeMessage, 0, len(v.syncDuties))
+	for _, duty := range v.syncDuties {
+		signature, err := v.sign(ctx, duty.Pubkey, req)
+		if err != nil {
+			v.logger.Warn("[Validator] Failed to sign sync committee message", "slot", slot, "validator", duty.ValidatorIndex, "err", err)
+			continue
+		}
+		messages = append(messages, &cltypes.SyncCommitteeMessage{
+			Slot:            slot,
+			BeaconBlockRoot: blockRoot,
+			ValidatorIndex:  duty.ValidatorIndex,
+			Signature:       signature,
+		})
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return v.beacon.submitSyncCommitteeMessages(ctx, messages)
+}
@@ -461,6 +461,13 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 			ArchiveApi: apiHandler,
 		}, config.BeaconAPIRouter)
 		log.Info("Beacon API started", "addr", config.BeaconAPIRouter.Address)
+		if config.ValidatorClient {
+			if err := startValidatorClient(ctx, config, dirs, beaconConfig, ethClock, apiHandler, logger); err != nil {
+				return fmt.Errorf("validator client: %w", err)
+			}
+		}
+	} else if config.ValidatorClient {
+		return errors.New("validator client: the beacon api must be enabled")
 	}
 
 	stageCfg := stages.ClStagesCfg(
@@ -0,0 +1,93 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package caplin1
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+	"github.com/erigontech/erigon/cl/validator/keymanager"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+	"github.com/erigontech/erigon/cl/validator/validator_client"
+)
+
+// startValidatorClient opens the validator database and keys, serves the keymanager API
+// and runs the built-in validator client against apiHandler until ctx is done.
+func startValidatorClient(ctx context.Context, config clparams.CaplinConfig, dirs datadir.Dirs,
+	beaconConfig *clparams.BeaconChainConfig, ethClock eth_clock.EthereumClock, apiHandler http.Handler, logger log.Logger) error {
+	db, err := slashing_protection.OpenDB(ctx, filepath.Join(dirs.CaplinValidator, "chaindata"), logger)
+	if err != nil {
+		return err
+	}
+	slashingProtection, err := slashing_protection.New(ctx, db, ethClock.GenesisValidatorsRoot())
+	if err != nil {
+		db.Close()
+		return err
+	}
+	keystoresDir := config.ValidatorKeystoresDir
+	if keystoresDir == "" {
+		keystoresDir = filepath.Join(dirs.CaplinValidator, "keystores")
+	}
+	keys, err := keymanager.New(keystoresDir, db, config.ValidatorFeeRecipient, logger)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	tokenFile := config.KeymanagerTokenFile
+	if tokenFile == "" {
+		tokenFile = filepath.Join(dirs.CaplinValidator, "api-token.txt")
+	}
+	token, err := keymanager.LoadOrCreateToken(tokenFile)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	listener, err := net.Listen("tcp", config.KeymanagerAddr)
+	if err != nil {
+		db.Close()
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           keymanager.NewApi(keys, slashingProtection, token, logger),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("[Validator] Keymanager API stopped", "err", err)
+		}
+	}()
+	logger.Info("[Validator] Keymanager API started", "addr", listener.Addr(), "tokenFile", tokenFile)
+
+	vc := validator_client.NewValidatorClient(beaconConfig, ethClock, apiHandler, keys, slashingProtection, config.ValidatorGraffiti, logger)
+	go func() {
+		defer db.Close()
+		defer server.Close()
+		if err := vc.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Warn("[Validator] Validator client stopped", "err", err)
+		}
+	}()
+	return nil
+}
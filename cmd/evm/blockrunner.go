@@ -0,0 +1,231 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+
+	"github.com/erigontech/erigon/cmd/evm/internal/t8ntool"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/eth/tracers"
+	"github.com/erigontech/erigon/eth/tracers/logger"
+	"github.com/erigontech/erigon/tests"
+)
+
+var blockTestCommand = cli.Command{
+	Action:    blockTestCmd,
+	Name:      "blocktest",
+	Usage:     "executes the given blockchain tests",
+	ArgsUsage: "<file>",
+	Flags: []cli.Flag{
+		&TraceFlag,
+		&t8ntool.OutputBasedir,
+		&RunFlag,
+		&ForkFlag,
+	},
+}
+
+// BlocktestResult contains the execution status after running a blockchain test
+// and any error that might have occurred.
+type BlocktestResult struct {
+	Name   string   `json:"name"`
+	Pass   bool     `json:"pass"`
+	Fork   string   `json:"fork"`
+	Error  string   `json:"error,omitempty"`
+	Traces []string `json:"traces,omitempty"`
+}
+
+func blockTestCmd(ctx *cli.Context) error {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlWarn, log.StderrHandler))
+
+	// Configure the EVM logger of the transactions
+	var traces *txTraces
+	if ctx.Bool(TraceFlag.Name) {
+		baseDir := ctx.String(t8ntool.OutputBasedir.Name)
+		if len(baseDir) > 0 {
+			if err := os.MkdirAll(baseDir, 0755); err != nil {
+				return fmt.Errorf("failed creating output basedir: %w", err)
+			}
+		}
+		traces = &txTraces{
+			dir: baseDir,
+			config: &logger.LogConfig{
+				DisableMemory:     ctx.Bool(DisableMemoryFlag.Name),
+				DisableStack:      ctx.Bool(DisableStackFlag.Name),
+				DisableStorage:    ctx.Bool(DisableStorageFlag.Name),
+				DisableReturnData: ctx.Bool(DisableReturnDataFlag.Name),
+			},
+		}
+	}
+	filter, err := newTestFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(ctx.Args().First()) != 0 {
+		return runBlockTest(ctx.Args().First(), traces, filter)
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fname := scanner.Text()
+		if len(fname) == 0 {
+			return nil
+		}
+		if err := runBlockTest(fname, traces, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runBlockTest loads the blockchain tests given by fname, and executes the ones
+// selected by filter in name order.
+func runBlockTest(fname string, traces *txTraces, filter *testFilter) error {
+	src, err := os.ReadFile(fname)
+	if err != nil {
+		return err
+	}
+	var blockTests map[string]*tests.BlockTest
+	if err = json.Unmarshal(src, &blockTests); err != nil {
+		return err
+	}
+
+	results := make([]BlocktestResult, 0, len(blockTests))
+	for _, name := range slices.Sorted(maps.Keys(blockTests)) {
+		test := blockTests[name]
+		if !filter.matchName(name) || !filter.matchFork(test.Network()) {
+			continue
+		}
+		result := BlocktestResult{Name: name, Fork: test.Network(), Pass: true}
+		var tracer *tracers.Tracer
+		if traces != nil {
+			tracer = traces.Tracer()
+		}
+		err := test.RunWithTracer(nil, true, tracer)
+		if traces != nil {
+			result.Traces = traces.files
+			if err1 := traces.Close(); err == nil {
+				err = err1
+			}
+		}
+		if err != nil {
+			result.Pass, result.Error = false, err.Error()
+		}
+		results = append(results, result)
+	}
+
+	out, _ := json.MarshalIndent(results, "", "  ")
+	fmt.Println(string(out))
+	return nil
+}
+
+// txTraces writes an EIP-3155 trace of every transaction executed by a blockchain test,
+// including the ones of blocks the test expects to be rejected, to a file of its own in
+// dir. Files are named after the block and the transaction, like the ones of evm t8n:
+// trace-<block number>-<block hash>-<tx index>-<tx hash>.jsonl. A block that is executed
+// again overwrites its traces with the same ones.
+type txTraces struct {
+	dir    string
+	config *logger.LogConfig
+
+	block   *types.Block
+	txIndex int
+	file    *os.File
+	hooks   *tracing.Hooks
+	files   []string
+	err     error
+}
+
+// Tracer returns the hooks for the next test. Block execution runs system calls (beacon
+// roots, withdrawal and consolidation requests, ...) with the same hooks but outside of
+// a transaction, they are not part of an EIP-3155 trace.
+func (t *txTraces) Tracer() *tracers.Tracer {
+	t.files, t.err = nil, nil
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnBlockStart: func(event tracing.BlockEvent) {
+				t.block, t.txIndex = event.Block, 0
+			},
+			OnTxStart: func(env *tracing.VMContext, txn types.Transaction, from common.Address) {
+				t.closeFile()
+				if t.err != nil || t.block == nil {
+					return
+				}
+				name := fmt.Sprintf("trace-%d-%v-%d-%v.jsonl", t.block.NumberU64(), t.block.Hash(), t.txIndex, txn.Hash())
+				t.txIndex++
+				file, err := os.Create(filepath.Join(t.dir, name))
+				if err != nil {
+					t.err = fmt.Errorf("failed creating trace-file: %w", err)
+					return
+				}
+				if !slices.Contains(t.files, name) {
+					t.files = append(t.files, name)
+				}
+				t.file, t.hooks = file, logger.NewJSONLogger(t.config, file).Tracer().Hooks
+				t.hooks.OnTxStart(env, txn, from)
+			},
+			OnTxEnd: func(receipt *types.Receipt, err error) {
+				t.closeFile()
+			},
+			OnExit: func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+				if t.hooks != nil {
+					t.hooks.OnExit(depth, output, gasUsed, err, reverted)
+				}
+			},
+			OnOpcode: func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+				if t.hooks != nil {
+					t.hooks.OnOpcode(pc, op, gas, cost, scope, rData, depth, err)
+				}
+			},
+			OnFault: func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+				if t.hooks != nil {
+					t.hooks.OnFault(pc, op, gas, cost, scope, depth, err)
+				}
+			},
+		},
+	}
+}
+
+func (t *txTraces) closeFile() {
+	if t.file == nil {
+		return
+	}
+	if err := t.file.Close(); err != nil && t.err == nil {
+		t.err = err
+	}
+	t.file, t.hooks = nil, nil
+}
+
+// Close closes the trace of a transaction that did not end and returns the first error
+// met while writing the traces of the test.
+func (t *txTraces) Close() error {
+	t.closeFile()
+	t.block = nil
+	return t.err
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/turbo/cmdtest"
+)
+
+// runBlocktest runs evm blocktest with args and returns the results it printed.
+func runBlocktest(t *testing.T, args ...string) []BlocktestResult {
+	t.Helper()
+	tt := cmdtest.NewTestCmd(t, nil)
+	tt.Run("evm-test", append([]string{"blocktest"}, args...)...)
+	out := tt.Output()
+	tt.WaitExit()
+	require.Equal(t, 0, tt.ExitStatus(), tt.StderrText())
+
+	var results []BlocktestResult
+	require.NoError(t, json.Unmarshal(out, &results), string(out))
+	return results
+}
+
+func TestBlocktestSelection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		want []BlocktestResult
+	}{
+		{
+			name: "all",
+			want: []BlocktestResult{
+				{Name: "store_value_Cancun", Pass: true, Fork: "Cancun"},
+				{Name: "store_value_Shanghai", Pass: true, Fork: "Shanghai"},
+			},
+		},
+		{
+			name: "run",
+			args: []string{"--run", "Shang"},
+			want: []BlocktestResult{{Name: "store_value_Shanghai", Pass: true, Fork: "Shanghai"}},
+		},
+		{
+			name: "fork",
+			args: []string{"--fork", "Cancun"},
+			want: []BlocktestResult{{Name: "store_value_Cancun", Pass: true, Fork: "Cancun"}},
+		},
+		{
+			name: "run and fork",
+			args: []string{"--run", "Shanghai", "--fork", "Cancun"},
+			want: []BlocktestResult{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			results := runBlocktest(t, append(tc.args, "./testdata/blocktest.json")...)
+			require.Equal(t, tc.want, results)
+		})
+	}
+}
+
+func TestBlocktestTrace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+	t.Parallel()
+
+	// The traces are named after the transactions of the blocks of the test
+	src, err := os.ReadFile("./testdata/blocktest.json")
+	require.NoError(t, err)
+	var fixture map[string]struct {
+		Blocks []struct {
+			Rlp hexutil.Bytes `json:"rlp"`
+		} `json:"blocks"`
+	}
+	require.NoError(t, json.Unmarshal(src, &fixture))
+	var want []string
+	for _, b := range fixture["store_value_Cancun"].Blocks {
+		var block types.Block
+		require.NoError(t, rlp.DecodeBytes(b.Rlp, &block))
+		for i, txn := range block.Transactions() {
+			want = append(want, fmt.Sprintf("trace-%d-%v-%d-%v.jsonl", block.NumberU64(), block.Hash(), i, txn.Hash()))
+		}
+	}
+	require.Len(t, want, 3)
+
+	dir := t.TempDir()
+	results := runBlocktest(t, "--trace", "--output.basedir", dir, "--run", "Cancun", "./testdata/blocktest.json")
+	require.Len(t, results, 1)
+	require.True(t, results[0].Pass, results[0].Error)
+	require.Equal(t, want, results[0].Traces)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, len(want))
+	for i, name := range want {
+		file, err := os.Open(filepath.Join(dir, name))
+		require.NoError(t, err)
+		var lines []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		require.NoError(t, scanner.Err())
+		require.NoError(t, file.Close())
+
+		// Every transaction calls the contract: CALLVALUE NUMBER ADD NUMBER SSTORE PUSH0 PUSH0 RETURN
+		require.Len(t, lines, 9, name)
+		var ops []string
+		for _, line := range lines[:8] {
+			var op struct {
+				OpName string `json:"opName"`
+				Depth  int    `json:"depth"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &op), line)
+			require.Equal(t, 1, op.Depth)
+			ops = append(ops, op.OpName)
+		}
+		require.Equal(t, "CALLVALUE NUMBER ADD NUMBER SSTORE PUSH0 PUSH0 RETURN", strings.Join(ops, " "), name)
+
+		var summary struct {
+			Output  string          `json:"output"`
+			GasUsed *hexutil.Uint64 `json:"gasUsed"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[8]), &summary))
+		require.NotNil(t, summary.GasUsed, name)
+		require.Empty(t, summary.Output, name)
+		if i == 0 {
+			require.Equal(t, hexutil.Uint64(0x5661), *summary.GasUsed)
+		}
+	}
+
+	// Without --trace nothing is written
+	dir = t.TempDir()
+	results = runBlocktest(t, "--output.basedir", dir, "--run", "Cancun", "./testdata/blocktest.json")
+	require.Len(t, results, 1)
+	require.Empty(t, results[0].Traces)
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -133,6 +133,19 @@ var (
 		Name:  "noreturndata",
 		Usage: "disable return data output",
 	}
+	TraceFlag = cli.BoolFlag{
+		Name:  "trace",
+		Usage: "output EIP-3155 traces of executed transactions (statetest: to stderr, blocktest: a file per transaction in --output.basedir)",
+	}
+	RunFlag = cli.StringFlag{
+		Name:  "run",
+		Value: ".*",
+		Usage: "run only those tests matching the regular expression",
+	}
+	ForkFlag = cli.StringFlag{
+		Name:  "fork",
+		Usage: "run only those tests filled for the given fork",
+	}
 )
 
 var stateTransitionCommand = cli.Command{
@@ -189,6 +202,7 @@ func init() {
 		&disasmCommand,
 		&runCommand,
 		&stateTestCommand,
+		&blockTestCommand,
 		&stateTransitionCommand,
 	}
 }
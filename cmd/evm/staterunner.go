@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/c2h5oh/datasize"
 	mdbx2 "github.com/erigontech/mdbx-go/mdbx"
@@ -52,6 +53,11 @@ var stateTestCommand = cli.Command{
 	Name:      "statetest",
 	Usage:     "executes the given state tests",
 	ArgsUsage: "<file>",
+	Flags: []cli.Flag{
+		&TraceFlag,
+		&RunFlag,
+		&ForkFlag,
+	},
 }
 
 // StatetestResult contains the execution status after running a state test, any
@@ -66,8 +72,31 @@ type StatetestResult struct {
 	Stats *execStats   `json:"benchStats,omitempty"`
 }
 
+// testFilter selects the tests to run by name and fork.
+type testFilter struct {
+	name *regexp.Regexp
+	fork string
+}
+
+func newTestFilter(ctx *cli.Context) (*testFilter, error) {
+	name, err := regexp.Compile(ctx.String(RunFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s regexp: %w", RunFlag.Name, err)
+	}
+	return &testFilter{name: name, fork: ctx.String(ForkFlag.Name)}, nil
+}
+
+func (f *testFilter) matchName(name string) bool {
+	return f.name.MatchString(name)
+}
+
+func (f *testFilter) matchFork(fork string) bool {
+	return f.fork == "" || f.fork == fork
+}
+
 func stateTestCmd(ctx *cli.Context) error {
-	machineFriendlyOutput := ctx.Bool(MachineFlag.Name)
+	// --trace is the same EIP-3155 output as --json, named as in evm blocktest
+	machineFriendlyOutput := ctx.Bool(MachineFlag.Name) || ctx.Bool(TraceFlag.Name)
 	if machineFriendlyOutput {
 		log.Root().SetHandler(log.DiscardHandler())
 	} else {
@@ -87,9 +116,13 @@ func stateTestCmd(ctx *cli.Context) error {
 	} else if ctx.Bool(DebugFlag.Name) {
 		cfg.Tracer = logger.NewStructLogger(config).Tracer().Hooks
 	}
+	filter, err := newTestFilter(ctx)
+	if err != nil {
+		return err
+	}
 
 	if len(ctx.Args().First()) != 0 {
-		return runStateTest(ctx.Args().First(), cfg, ctx.Bool(MachineFlag.Name), ctx.Bool(BenchFlag.Name))
+		return runStateTest(ctx.Args().First(), cfg, filter, machineFriendlyOutput, ctx.Bool(BenchFlag.Name))
 	}
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
@@ -97,7 +130,7 @@ func stateTestCmd(ctx *cli.Context) error {
 		if len(fname) == 0 {
 			return nil
 		}
-		if err := runStateTest(fname, cfg, ctx.Bool(MachineFlag.Name), ctx.Bool(BenchFlag.Name)); err != nil {
+		if err := runStateTest(fname, cfg, filter, machineFriendlyOutput, ctx.Bool(BenchFlag.Name)); err != nil {
 			return err
 		}
 	}
@@ -105,7 +138,7 @@ func stateTestCmd(ctx *cli.Context) error {
 }
 
 // runStateTest loads the state-test given by fname, and executes the test.
-func runStateTest(fname string, cfg vm.Config, jsonOut bool, bench bool) error {
+func runStateTest(fname string, cfg vm.Config, filter *testFilter, jsonOut bool, bench bool) error {
 	// Load the test content from the input file
 	src, err := os.ReadFile(fname)
 	if err != nil {
@@ -117,7 +150,7 @@ func runStateTest(fname string, cfg vm.Config, jsonOut bool, bench bool) error {
 	}
 
 	// Iterate over all the stateTests, run them and aggregate the results
-	results, err := aggregateResultsFromStateTests(stateTests, cfg, jsonOut, bench)
+	results, err := aggregateResultsFromStateTests(stateTests, cfg, filter, jsonOut, bench)
 	if err != nil {
 		return err
 	}
@@ -128,7 +161,7 @@ func runStateTest(fname string, cfg vm.Config, jsonOut bool, bench bool) error {
 }
 
 func aggregateResultsFromStateTests(
-	stateTests map[string]tests.StateTest, cfg vm.Config,
+	stateTests map[string]tests.StateTest, cfg vm.Config, filter *testFilter,
 	jsonOut bool, bench bool) ([]StatetestResult, error) {
 	dirs := datadir.New(filepath.Join(os.TempDir(), "erigon-statetest"))
 	//this DB is shared. means:
@@ -161,7 +194,13 @@ func aggregateResultsFromStateTests(
 	results := make([]StatetestResult, 0, len(stateTests))
 
 	for key, test := range stateTests {
+		if !filter.matchName(key) {
+			continue
+		}
 		for _, st := range test.Subtests() {
+			if !filter.matchFork(st.Fork) {
+				continue
+			}
 			// Run the test and aggregate the result
 			result := &StatetestResult{Name: key, Fork: st.Fork, Pass: true}
 
@@ -0,0 +1,125 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/jsonrpc"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var (
+	statelessRpcUrl string
+	statelessBlocks uint64
+)
+
+func init() {
+	withDataDir2(cmdVerifyStateless)
+	withBlock(cmdVerifyStateless)
+	withHeimdall(cmdVerifyStateless)
+	cmdVerifyStateless.Flags().Uint64Var(&statelessBlocks, "blocks", 1, "number of blocks to verify, starting at --block")
+	cmdVerifyStateless.Flags().StringVar(&statelessRpcUrl, "rpc.url", "http://localhost:8545", "node serving debug_executionWitness")
+	rootCmd.AddCommand(cmdVerifyStateless)
+}
+
+var cmdVerifyStateless = &cobra.Command{
+	Use:     "verify_stateless",
+	Short:   "Fetch the execution witnesses of a range of blocks from a node and execute the blocks statelessly on them",
+	Example: "go run ./cmd/integration verify_stateless --datadir=... --block=1000000 --blocks=100 --rpc.url=http://localhost:8545",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		db, err := openDB(dbCfg(kv.ChainDB, chaindata), false, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return
+		}
+		defer db.Close()
+
+		if err := verifyStateless(cmd.Context(), db, logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+// verifyStateless round-trips the witnesses the node generates: every block of the range is
+// executed on its witness alone, and must reach the state root of its header.
+func verifyStateless(ctx context.Context, db kv.TemporalRwDB, logger log.Logger) error {
+	client, err := rpc.Dial(statelessRpcUrl, logger)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	chainConfig := fromdb.ChainConfig(db)
+	blockReader, _ := blocksIO(db, logger)
+	engine, _ := initConsensusEngine(ctx, chainConfig, datadirCli, db, blockReader, logger)
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	var failed int
+	// the genesis block has no witness
+	for blockNum := max(block, 1); blockNum < block+statelessBlocks; blockNum++ {
+		blockNrOrHash := hexutil.EncodeUint64(blockNum)
+		var blockRLP hexutil.Bytes
+		if err := client.CallContext(ctx, &blockRLP, "debug_getRawBlock", blockNrOrHash); err != nil {
+			return fmt.Errorf("fetching block %d: %w", blockNum, err)
+		}
+		var b types.Block
+		if err := rlp.DecodeBytes(blockRLP, &b); err != nil {
+			return fmt.Errorf("decoding block %d: %w", blockNum, err)
+		}
+		var witness jsonrpc.ExecutionWitness
+		if err := client.CallContext(ctx, &witness, "debug_executionWitness", blockNrOrHash); err != nil {
+			return fmt.Errorf("fetching witness of block %d: %w", blockNum, err)
+		}
+
+		if _, _, err := witness.Execute(chainConfig, engine, &b, logger); err != nil {
+			logger.Error("[verify_stateless] Block failed", "block", blockNum, "err", err)
+			failed++
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[verify_stateless] Progress", "block", blockNum, "failed", failed)
+		default:
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d blocks failed stateless verification", failed, statelessBlocks)
+	}
+	logger.Info("[verify_stateless] All blocks verified", "from", block, "blocks", statelessBlocks)
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon/rpc"
+)
+
+// quotaFile is the content of the --rpc.quota file, e.g.
+//
+//	{
+//	  "budget": 100000,
+//	  "period": "1m",
+//	  "defaultCost": 1,
+//	  "maxBlocks": 10000,
+//	  "methods": {"eth_getLogs": {"cost": 10, "perBlock": 1}, "trace_filter": {"cost": 100, "perBlock": 10}},
+//	  "keys": {"secret-key": 1000000}
+//	}
+type quotaFile struct {
+	Budget      uint64                    `json:"budget"`
+	Period      string                    `json:"period"`
+	DefaultCost uint64                    `json:"defaultCost"`
+	MaxBlocks   uint64                    `json:"maxBlocks"`
+	Methods     map[string]rpc.MethodCost `json:"methods"`
+	Keys        map[string]uint64         `json:"keys"`
+}
+
+func parseQuotaForRPC(path string) (*rpc.CostQuota, error) {
+	path = strings.TrimSpace(path)
+	if path == "" { // no file is provided
+		return nil, nil
+	}
+
+	fileContents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var quotaFileObj quotaFile
+	if err := json.Unmarshal(fileContents, &quotaFileObj); err != nil {
+		return nil, fmt.Errorf("invalid rpc quota file %s: %w", path, err)
+	}
+	period, err := time.ParseDuration(quotaFileObj.Period)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rpc quota period: %w", err)
+	}
+	return rpc.NewCostQuota(rpc.QuotaConfig{
+		Budget:      quotaFileObj.Budget,
+		Period:      period,
+		DefaultCost: quotaFileObj.DefaultCost,
+		MaxBlocks:   quotaFileObj.MaxBlocks,
+		Methods:     quotaFileObj.Methods,
+		Keys:        quotaFileObj.Keys,
+	})
+}
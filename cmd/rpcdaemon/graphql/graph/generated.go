@@ -61,37 +61,41 @@ type ComplexityRoot struct {
 	}
 
 	Block struct {
-		Account           func(childComplexity int, address string) int
-		BaseFeePerGas     func(childComplexity int) int
-		Call              func(childComplexity int, data model.CallData) int
-		Difficulty        func(childComplexity int) int
-		EstimateGas       func(childComplexity int, data model.CallData) int
-		ExtraData         func(childComplexity int) int
-		GasLimit          func(childComplexity int) int
-		GasUsed           func(childComplexity int) int
-		Hash              func(childComplexity int) int
-		Logs              func(childComplexity int, filter model.BlockFilterCriteria) int
-		LogsBloom         func(childComplexity int) int
-		Miner             func(childComplexity int, block *uint64) int
-		MixHash           func(childComplexity int) int
-		NextBaseFeePerGas func(childComplexity int) int
-		Nonce             func(childComplexity int) int
-		Number            func(childComplexity int) int
-		OmmerAt           func(childComplexity int, index int) int
-		OmmerCount        func(childComplexity int) int
-		OmmerHash         func(childComplexity int) int
-		Ommers            func(childComplexity int) int
-		Parent            func(childComplexity int) int
-		Raw               func(childComplexity int) int
-		RawHeader         func(childComplexity int) int
-		ReceiptsRoot      func(childComplexity int) int
-		StateRoot         func(childComplexity int) int
-		Timestamp         func(childComplexity int) int
-		TransactionAt     func(childComplexity int, index int) int
-		TransactionCount  func(childComplexity int) int
-		Transactions      func(childComplexity int) int
-		TransactionsRoot  func(childComplexity int) int
-		Withdrawals       func(childComplexity int) int
+		Account               func(childComplexity int, address string) int
+		BaseFeePerGas         func(childComplexity int) int
+		BlobGasUsed           func(childComplexity int) int
+		Call                  func(childComplexity int, data model.CallData) int
+		Difficulty            func(childComplexity int) int
+		EstimateGas           func(childComplexity int, data model.CallData) int
+		ExcessBlobGas         func(childComplexity int) int
+		ExtraData             func(childComplexity int) int
+		GasLimit              func(childComplexity int) int
+		GasUsed               func(childComplexity int) int
+		Hash                  func(childComplexity int) int
+		Logs                  func(childComplexity int, filter model.BlockFilterCriteria) int
+		LogsBloom             func(childComplexity int) int
+		Miner                 func(childComplexity int, block *uint64) int
+		MixHash               func(childComplexity int) int
+		NextBaseFeePerGas     func(childComplexity int) int
+		Nonce                 func(childComplexity int) int
+		Number                func(childComplexity int) int
+		OmmerAt               func(childComplexity int, index int) int
+		OmmerCount            func(childComplexity int) int
+		OmmerHash             func(childComplexity int) int
+		Ommers                func(childComplexity int) int
+		Parent                func(childComplexity int) int
+		ParentBeaconBlockRoot func(childComplexity int) int
+		Raw                   func(childComplexity int) int
+		RawHeader             func(childComplexity int) int
+		ReceiptsRoot          func(childComplexity int) int
+		StateRoot             func(childComplexity int) int
+		Timestamp             func(childComplexity int) int
+		TransactionAt         func(childComplexity int, index int) int
+		TransactionCount      func(childComplexity int) int
+		Transactions          func(childComplexity int) int
+		TransactionsRoot      func(childComplexity int) int
+		Withdrawals           func(childComplexity int) int
+		WithdrawalsRoot       func(childComplexity int) int
 	}
 
 	CallResult struct {
@@ -132,6 +136,15 @@ type ComplexityRoot struct {
 		Transaction          func(childComplexity int, hash string) int
 	}
 
+	SetCodeAuthorization struct {
+		Address func(childComplexity int) int
+		ChainID func(childComplexity int) int
+		Nonce   func(childComplexity int) int
+		R       func(childComplexity int) int
+		S       func(childComplexity int) int
+		YParity func(childComplexity int) int
+	}
+
 	SyncState struct {
 		CurrentBlock  func(childComplexity int) int
 		HighestBlock  func(childComplexity int) int
@@ -140,6 +153,8 @@ type ComplexityRoot struct {
 
 	Transaction struct {
 		AccessList           func(childComplexity int) int
+		AuthorizationList    func(childComplexity int) int
+		BlobVersionedHashes  func(childComplexity int) int
 		Block                func(childComplexity int) int
 		CreatedContract      func(childComplexity int, block *uint64) int
 		CumulativeGasUsed    func(childComplexity int) int
@@ -153,6 +168,7 @@ type ComplexityRoot struct {
 		Index                func(childComplexity int) int
 		InputData            func(childComplexity int) int
 		Logs                 func(childComplexity int) int
+		MaxFeePerBlobGas     func(childComplexity int) int
 		MaxFeePerGas         func(childComplexity int) int
 		MaxPriorityFeePerGas func(childComplexity int) int
 		Nonce                func(childComplexity int) int
@@ -282,6 +298,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Block.BaseFeePerGas(childComplexity), true
 
+	case "Block.blobGasUsed":
+		if e.complexity.Block.BlobGasUsed == nil {
+			break
+		}
+
+		return e.complexity.Block.BlobGasUsed(childComplexity), true
+
 	case "Block.call":
 		if e.complexity.Block.Call == nil {
 			break
@@ -313,6 +336,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Block.EstimateGas(childComplexity, args["data"].(model.CallData)), true
 
+	case "Block.excessBlobGas":
+		if e.complexity.Block.ExcessBlobGas == nil {
+			break
+		}
+
+		return e.complexity.Block.ExcessBlobGas(childComplexity), true
+
 	case "Block.extraData":
 		if e.complexity.Block.ExtraData == nil {
 			break
@@ -440,6 +470,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Block.Parent(childComplexity), true
 
+	case "Block.parentBeaconBlockRoot":
+		if e.complexity.Block.ParentBeaconBlockRoot == nil {
+			break
+		}
+
+		return e.complexity.Block.ParentBeaconBlockRoot(childComplexity), true
+
 	case "Block.raw":
 		if e.complexity.Block.Raw == nil {
 			break
@@ -515,6 +552,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Block.Withdrawals(childComplexity), true
 
+	case "Block.withdrawalsRoot":
+		if e.complexity.Block.WithdrawalsRoot == nil {
+			break
+		}
+
+		return e.complexity.Block.WithdrawalsRoot(childComplexity), true
+
 	case "CallResult.data":
 		if e.complexity.CallResult.Data == nil {
 			break
@@ -721,6 +765,48 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Transaction(childComplexity, args["hash"].(string)), true
 
+	case "SetCodeAuthorization.address":
+		if e.complexity.SetCodeAuthorization.Address == nil {
+			break
+		}
+
+		return e.complexity.SetCodeAuthorization.Address(childComplexity), true
+
+	case "SetCodeAuthorization.chainId":
+		if e.complexity.SetCodeAuthorization.ChainID == nil {
+			break
+		}
+
+		return e.complexity.SetCodeAuthorization.ChainID(childComplexity), true
+
+	case "SetCodeAuthorization.nonce":
+		if e.complexity.SetCodeAuthorization.Nonce == nil {
+			break
+		}
+
+		return e.complexity.SetCodeAuthorization.Nonce(childComplexity), true
+
+	case "SetCodeAuthorization.r":
+		if e.complexity.SetCodeAuthorization.R == nil {
+			break
+		}
+
+		return e.complexity.SetCodeAuthorization.R(childComplexity), true
+
+	case "SetCodeAuthorization.s":
+		if e.complexity.SetCodeAuthorization.S == nil {
+			break
+		}
+
+		return e.complexity.SetCodeAuthorization.S(childComplexity), true
+
+	case "SetCodeAuthorization.yParity":
+		if e.complexity.SetCodeAuthorization.YParity == nil {
+			break
+		}
+
+		return e.complexity.SetCodeAuthorization.YParity(childComplexity), true
+
 	case "SyncState.currentBlock":
 		if e.complexity.SyncState.CurrentBlock == nil {
 			break
@@ -749,6 +835,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Transaction.AccessList(childComplexity), true
 
+	case "Transaction.authorizationList":
+		if e.complexity.Transaction.AuthorizationList == nil {
+			break
+		}
+
+		return e.complexity.Transaction.AuthorizationList(childComplexity), true
+
+	case "Transaction.blobVersionedHashes":
+		if e.complexity.Transaction.BlobVersionedHashes == nil {
+			break
+		}
+
+		return e.complexity.Transaction.BlobVersionedHashes(childComplexity), true
+
 	case "Transaction.block":
 		if e.complexity.Transaction.Block == nil {
 			break
@@ -850,6 +950,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Transaction.Logs(childComplexity), true
 
+	case "Transaction.maxFeePerBlobGas":
+		if e.complexity.Transaction.MaxFeePerBlobGas == nil {
+			break
+		}
+
+		return e.complexity.Transaction.MaxFeePerBlobGas(childComplexity), true
+
 	case "Transaction.maxFeePerGas":
 		if e.complexity.Transaction.MaxFeePerGas == nil {
 			break
@@ -2353,6 +2460,14 @@ func (ec *executionContext) fieldContext_Block_parent(_ context.Context, field g
 				return ec.fieldContext_Block_raw(ctx, field)
 			case "withdrawals":
 				return ec.fieldContext_Block_withdrawals(ctx, field)
+			case "withdrawalsRoot":
+				return ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+			case "blobGasUsed":
+				return ec.fieldContext_Block_blobGasUsed(ctx, field)
+			case "excessBlobGas":
+				return ec.fieldContext_Block_excessBlobGas(ctx, field)
+			case "parentBeaconBlockRoot":
+				return ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Block", field.Name)
 		},
@@ -3173,6 +3288,14 @@ func (ec *executionContext) fieldContext_Block_ommers(_ context.Context, field g
 				return ec.fieldContext_Block_raw(ctx, field)
 			case "withdrawals":
 				return ec.fieldContext_Block_withdrawals(ctx, field)
+			case "withdrawalsRoot":
+				return ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+			case "blobGasUsed":
+				return ec.fieldContext_Block_blobGasUsed(ctx, field)
+			case "excessBlobGas":
+				return ec.fieldContext_Block_excessBlobGas(ctx, field)
+			case "parentBeaconBlockRoot":
+				return ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Block", field.Name)
 		},
@@ -3278,6 +3401,14 @@ func (ec *executionContext) fieldContext_Block_ommerAt(ctx context.Context, fiel
 				return ec.fieldContext_Block_raw(ctx, field)
 			case "withdrawals":
 				return ec.fieldContext_Block_withdrawals(ctx, field)
+			case "withdrawalsRoot":
+				return ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+			case "blobGasUsed":
+				return ec.fieldContext_Block_blobGasUsed(ctx, field)
+			case "excessBlobGas":
+				return ec.fieldContext_Block_excessBlobGas(ctx, field)
+			case "parentBeaconBlockRoot":
+				return ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Block", field.Name)
 		},
@@ -3428,6 +3559,12 @@ func (ec *executionContext) fieldContext_Block_transactions(_ context.Context, f
 				return ec.fieldContext_Transaction_raw(ctx, field)
 			case "rawReceipt":
 				return ec.fieldContext_Transaction_rawReceipt(ctx, field)
+			case "maxFeePerBlobGas":
+				return ec.fieldContext_Transaction_maxFeePerBlobGas(ctx, field)
+			case "blobVersionedHashes":
+				return ec.fieldContext_Transaction_blobVersionedHashes(ctx, field)
+			case "authorizationList":
+				return ec.fieldContext_Transaction_authorizationList(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Transaction", field.Name)
 		},
@@ -3523,6 +3660,12 @@ func (ec *executionContext) fieldContext_Block_transactionAt(ctx context.Context
 				return ec.fieldContext_Transaction_raw(ctx, field)
 			case "rawReceipt":
 				return ec.fieldContext_Transaction_rawReceipt(ctx, field)
+			case "maxFeePerBlobGas":
+				return ec.fieldContext_Transaction_maxFeePerBlobGas(ctx, field)
+			case "blobVersionedHashes":
+				return ec.fieldContext_Transaction_blobVersionedHashes(ctx, field)
+			case "authorizationList":
+				return ec.fieldContext_Transaction_authorizationList(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Transaction", field.Name)
 		},
@@ -3929,6 +4072,170 @@ func (ec *executionContext) fieldContext_Block_withdrawals(_ context.Context, fi
 	return fc, nil
 }
 
+func (ec *executionContext) _Block_withdrawalsRoot(ctx context.Context, field graphql.CollectedField, obj *model.Block) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.WithdrawalsRoot, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOBytes322ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Block_withdrawalsRoot(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Block",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Bytes32 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Block_blobGasUsed(ctx context.Context, field graphql.CollectedField, obj *model.Block) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Block_blobGasUsed(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BlobGasUsed, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*uint64)
+	fc.Result = res
+	return ec.marshalOLong2ᚖuint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Block_blobGasUsed(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Block",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Long does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Block_excessBlobGas(ctx context.Context, field graphql.CollectedField, obj *model.Block) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Block_excessBlobGas(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ExcessBlobGas, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*uint64)
+	fc.Result = res
+	return ec.marshalOLong2ᚖuint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Block_excessBlobGas(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Block",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Long does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Block_parentBeaconBlockRoot(ctx context.Context, field graphql.CollectedField, obj *model.Block) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ParentBeaconBlockRoot, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOBytes322ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Block_parentBeaconBlockRoot(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Block",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Bytes32 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _CallResult_data(ctx context.Context, field graphql.CollectedField, obj *model.CallResult) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_CallResult_data(ctx, field)
 	if err != nil {
@@ -4351,6 +4658,12 @@ func (ec *executionContext) fieldContext_Log_transaction(_ context.Context, fiel
 				return ec.fieldContext_Transaction_raw(ctx, field)
 			case "rawReceipt":
 				return ec.fieldContext_Transaction_rawReceipt(ctx, field)
+			case "maxFeePerBlobGas":
+				return ec.fieldContext_Transaction_maxFeePerBlobGas(ctx, field)
+			case "blobVersionedHashes":
+				return ec.fieldContext_Transaction_blobVersionedHashes(ctx, field)
+			case "authorizationList":
+				return ec.fieldContext_Transaction_authorizationList(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Transaction", field.Name)
 		},
@@ -4545,6 +4858,12 @@ func (ec *executionContext) fieldContext_Pending_transactions(_ context.Context,
 				return ec.fieldContext_Transaction_raw(ctx, field)
 			case "rawReceipt":
 				return ec.fieldContext_Transaction_rawReceipt(ctx, field)
+			case "maxFeePerBlobGas":
+				return ec.fieldContext_Transaction_maxFeePerBlobGas(ctx, field)
+			case "blobVersionedHashes":
+				return ec.fieldContext_Transaction_blobVersionedHashes(ctx, field)
+			case "authorizationList":
+				return ec.fieldContext_Transaction_authorizationList(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Transaction", field.Name)
 		},
@@ -4832,6 +5151,14 @@ func (ec *executionContext) fieldContext_Query_block(ctx context.Context, field
 				return ec.fieldContext_Block_raw(ctx, field)
 			case "withdrawals":
 				return ec.fieldContext_Block_withdrawals(ctx, field)
+			case "withdrawalsRoot":
+				return ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+			case "blobGasUsed":
+				return ec.fieldContext_Block_blobGasUsed(ctx, field)
+			case "excessBlobGas":
+				return ec.fieldContext_Block_excessBlobGas(ctx, field)
+			case "parentBeaconBlockRoot":
+				return ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Block", field.Name)
 		},
@@ -4951,6 +5278,14 @@ func (ec *executionContext) fieldContext_Query_blocks(ctx context.Context, field
 				return ec.fieldContext_Block_raw(ctx, field)
 			case "withdrawals":
 				return ec.fieldContext_Block_withdrawals(ctx, field)
+			case "withdrawalsRoot":
+				return ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+			case "blobGasUsed":
+				return ec.fieldContext_Block_blobGasUsed(ctx, field)
+			case "excessBlobGas":
+				return ec.fieldContext_Block_excessBlobGas(ctx, field)
+			case "parentBeaconBlockRoot":
+				return ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Block", field.Name)
 		},
@@ -5113,6 +5448,12 @@ func (ec *executionContext) fieldContext_Query_transaction(ctx context.Context,
 				return ec.fieldContext_Transaction_raw(ctx, field)
 			case "rawReceipt":
 				return ec.fieldContext_Transaction_rawReceipt(ctx, field)
+			case "maxFeePerBlobGas":
+				return ec.fieldContext_Transaction_maxFeePerBlobGas(ctx, field)
+			case "blobVersionedHashes":
+				return ec.fieldContext_Transaction_blobVersionedHashes(ctx, field)
+			case "authorizationList":
+				return ec.fieldContext_Transaction_authorizationList(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Transaction", field.Name)
 		},
@@ -5421,6 +5762,8 @@ func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -5433,8 +5776,6 @@ func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -5510,8 +5851,8 @@ func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _SyncState_startingBlock(ctx context.Context, field graphql.CollectedField, obj *model.SyncState) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SyncState_startingBlock(ctx, field)
+func (ec *executionContext) _SetCodeAuthorization_chainId(ctx context.Context, field graphql.CollectedField, obj *model.SetCodeAuthorization) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SetCodeAuthorization_chainId(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5524,7 +5865,7 @@ func (ec *executionContext) _SyncState_startingBlock(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.StartingBlock, nil
+		return obj.ChainID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5536,26 +5877,26 @@ func (ec *executionContext) _SyncState_startingBlock(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(uint64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNLong2uint64(ctx, field.Selections, res)
+	return ec.marshalNBigInt2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SyncState_startingBlock(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SetCodeAuthorization_chainId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SyncState",
+		Object:     "SetCodeAuthorization",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Long does not have child fields")
+			return nil, errors.New("field of type BigInt does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SyncState_currentBlock(ctx context.Context, field graphql.CollectedField, obj *model.SyncState) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SyncState_currentBlock(ctx, field)
+func (ec *executionContext) _SetCodeAuthorization_address(ctx context.Context, field graphql.CollectedField, obj *model.SetCodeAuthorization) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SetCodeAuthorization_address(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5568,7 +5909,7 @@ func (ec *executionContext) _SyncState_currentBlock(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CurrentBlock, nil
+		return obj.Address, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5580,26 +5921,26 @@ func (ec *executionContext) _SyncState_currentBlock(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(uint64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNLong2uint64(ctx, field.Selections, res)
+	return ec.marshalNAddress2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SyncState_currentBlock(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SetCodeAuthorization_address(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SyncState",
+		Object:     "SetCodeAuthorization",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Long does not have child fields")
+			return nil, errors.New("field of type Address does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _SyncState_highestBlock(ctx context.Context, field graphql.CollectedField, obj *model.SyncState) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_SyncState_highestBlock(ctx, field)
+func (ec *executionContext) _SetCodeAuthorization_nonce(ctx context.Context, field graphql.CollectedField, obj *model.SetCodeAuthorization) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SetCodeAuthorization_nonce(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5612,7 +5953,7 @@ func (ec *executionContext) _SyncState_highestBlock(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HighestBlock, nil
+		return obj.Nonce, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5629,9 +5970,9 @@ func (ec *executionContext) _SyncState_highestBlock(ctx context.Context, field g
 	return ec.marshalNLong2uint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_SyncState_highestBlock(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SetCodeAuthorization_nonce(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "SyncState",
+		Object:     "SetCodeAuthorization",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5642,8 +5983,8 @@ func (ec *executionContext) fieldContext_SyncState_highestBlock(_ context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Transaction_hash(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Transaction_hash(ctx, field)
+func (ec *executionContext) _SetCodeAuthorization_yParity(ctx context.Context, field graphql.CollectedField, obj *model.SetCodeAuthorization) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SetCodeAuthorization_yParity(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5656,7 +5997,7 @@ func (ec *executionContext) _Transaction_hash(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Hash, nil
+		return obj.YParity, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5668,26 +6009,26 @@ func (ec *executionContext) _Transaction_hash(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(uint64)
 	fc.Result = res
-	return ec.marshalNBytes322string(ctx, field.Selections, res)
+	return ec.marshalNLong2uint64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Transaction_hash(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SetCodeAuthorization_yParity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Transaction",
+		Object:     "SetCodeAuthorization",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Bytes32 does not have child fields")
+			return nil, errors.New("field of type Long does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Transaction_nonce(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Transaction_nonce(ctx, field)
+func (ec *executionContext) _SetCodeAuthorization_r(ctx context.Context, field graphql.CollectedField, obj *model.SetCodeAuthorization) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SetCodeAuthorization_r(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5700,7 +6041,7 @@ func (ec *executionContext) _Transaction_nonce(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Nonce, nil
+		return obj.R, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5717,9 +6058,9 @@ func (ec *executionContext) _Transaction_nonce(ctx context.Context, field graphq
 	return ec.marshalNBigInt2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Transaction_nonce(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SetCodeAuthorization_r(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Transaction",
+		Object:     "SetCodeAuthorization",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5730,8 +6071,8 @@ func (ec *executionContext) fieldContext_Transaction_nonce(_ context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _Transaction_index(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Transaction_index(ctx, field)
+func (ec *executionContext) _SetCodeAuthorization_s(ctx context.Context, field graphql.CollectedField, obj *model.SetCodeAuthorization) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SetCodeAuthorization_s(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5744,15 +6085,279 @@ func (ec *executionContext) _Transaction_index(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Index, nil
+		return obj.S, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		return graphql.Null
-	}
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNBigInt2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SetCodeAuthorization_s(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SetCodeAuthorization",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type BigInt does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SyncState_startingBlock(ctx context.Context, field graphql.CollectedField, obj *model.SyncState) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SyncState_startingBlock(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.StartingBlock, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(uint64)
+	fc.Result = res
+	return ec.marshalNLong2uint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SyncState_startingBlock(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SyncState",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Long does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SyncState_currentBlock(ctx context.Context, field graphql.CollectedField, obj *model.SyncState) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SyncState_currentBlock(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.CurrentBlock, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(uint64)
+	fc.Result = res
+	return ec.marshalNLong2uint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SyncState_currentBlock(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SyncState",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Long does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _SyncState_highestBlock(ctx context.Context, field graphql.CollectedField, obj *model.SyncState) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_SyncState_highestBlock(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HighestBlock, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(uint64)
+	fc.Result = res
+	return ec.marshalNLong2uint64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_SyncState_highestBlock(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "SyncState",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Long does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Transaction_hash(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Transaction_hash(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Hash, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNBytes322string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Transaction_hash(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Transaction",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Bytes32 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Transaction_nonce(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Transaction_nonce(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Nonce, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNBigInt2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Transaction_nonce(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Transaction",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type BigInt does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Transaction_index(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Transaction_index(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Index, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
 	res := resTmp.(*int)
 	fc.Result = res
 	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
@@ -6299,6 +6904,14 @@ func (ec *executionContext) fieldContext_Transaction_block(_ context.Context, fi
 				return ec.fieldContext_Block_raw(ctx, field)
 			case "withdrawals":
 				return ec.fieldContext_Block_withdrawals(ctx, field)
+			case "withdrawalsRoot":
+				return ec.fieldContext_Block_withdrawalsRoot(ctx, field)
+			case "blobGasUsed":
+				return ec.fieldContext_Block_blobGasUsed(ctx, field)
+			case "excessBlobGas":
+				return ec.fieldContext_Block_excessBlobGas(ctx, field)
+			case "parentBeaconBlockRoot":
+				return ec.fieldContext_Block_parentBeaconBlockRoot(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Block", field.Name)
 		},
@@ -6895,6 +7508,143 @@ func (ec *executionContext) fieldContext_Transaction_rawReceipt(_ context.Contex
 	return fc, nil
 }
 
+func (ec *executionContext) _Transaction_maxFeePerBlobGas(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Transaction_maxFeePerBlobGas(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MaxFeePerBlobGas, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOBigInt2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Transaction_maxFeePerBlobGas(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Transaction",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type BigInt does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Transaction_blobVersionedHashes(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Transaction_blobVersionedHashes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BlobVersionedHashes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalOBytes322ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Transaction_blobVersionedHashes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Transaction",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Bytes32 does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Transaction_authorizationList(ctx context.Context, field graphql.CollectedField, obj *model.Transaction) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Transaction_authorizationList(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AuthorizationList, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*model.SetCodeAuthorization)
+	fc.Result = res
+	return ec.marshalOSetCodeAuthorization2ᚕᚖgithubᚗcomᚋerigontechᚋerigonᚋcmdᚋrpcdaemonᚋgraphqlᚋgraphᚋmodelᚐSetCodeAuthorizationᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Transaction_authorizationList(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Transaction",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "chainId":
+				return ec.fieldContext_SetCodeAuthorization_chainId(ctx, field)
+			case "address":
+				return ec.fieldContext_SetCodeAuthorization_address(ctx, field)
+			case "nonce":
+				return ec.fieldContext_SetCodeAuthorization_nonce(ctx, field)
+			case "yParity":
+				return ec.fieldContext_SetCodeAuthorization_yParity(ctx, field)
+			case "r":
+				return ec.fieldContext_SetCodeAuthorization_r(ctx, field)
+			case "s":
+				return ec.fieldContext_SetCodeAuthorization_s(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SetCodeAuthorization", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Withdrawal_index(ctx context.Context, field graphql.CollectedField, obj *model.Withdrawal) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Withdrawal_index(ctx, field)
 	if err != nil {
@@ -7156,6 +7906,50 @@ func (ec *executionContext) fieldContext___Directive_description(_ context.Conte
 	return fc, nil
 }
 
+func (ec *executionContext) ___Directive_isRepeatable(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext___Directive_isRepeatable(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.IsRepeatable, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext___Directive_isRepeatable(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Directive",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) ___Directive_locations(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext___Directive_locations(ctx, field)
 	if err != nil {
@@ -7269,50 +8063,6 @@ func (ec *executionContext) fieldContext___Directive_args(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) ___Directive_isRepeatable(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext___Directive_isRepeatable(ctx, field)
-	if err != nil {
-		return graphql.Null
-	}
-	ctx = graphql.WithFieldContext(ctx, fc)
-	defer func() {
-		if r := recover(); r != nil {
-			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
-		}
-	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.IsRepeatable, nil
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
-		return graphql.Null
-	}
-	res := resTmp.(bool)
-	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
-}
-
-func (ec *executionContext) fieldContext___Directive_isRepeatable(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "__Directive",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
 func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext___EnumValue_name(ctx, field)
 	if err != nil {
@@ -7682,6 +8432,8 @@ func (ec *executionContext) fieldContext___Field_type(_ context.Context, field g
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -7694,8 +8446,6 @@ func (ec *executionContext) fieldContext___Field_type(_ context.Context, field g
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -7920,6 +8670,8 @@ func (ec *executionContext) fieldContext___InputValue_type(_ context.Context, fi
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -7932,8 +8684,6 @@ func (ec *executionContext) fieldContext___InputValue_type(_ context.Context, fi
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8155,6 +8905,8 @@ func (ec *executionContext) fieldContext___Schema_types(_ context.Context, field
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8167,8 +8919,6 @@ func (ec *executionContext) fieldContext___Schema_types(_ context.Context, field
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8223,6 +8973,8 @@ func (ec *executionContext) fieldContext___Schema_queryType(_ context.Context, f
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8235,8 +8987,6 @@ func (ec *executionContext) fieldContext___Schema_queryType(_ context.Context, f
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8288,6 +9038,8 @@ func (ec *executionContext) fieldContext___Schema_mutationType(_ context.Context
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8300,8 +9052,6 @@ func (ec *executionContext) fieldContext___Schema_mutationType(_ context.Context
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8353,6 +9103,8 @@ func (ec *executionContext) fieldContext___Schema_subscriptionType(_ context.Con
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8365,8 +9117,6 @@ func (ec *executionContext) fieldContext___Schema_subscriptionType(_ context.Con
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8419,12 +9169,12 @@ func (ec *executionContext) fieldContext___Schema_directives(_ context.Context,
 				return ec.fieldContext___Directive_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Directive_description(ctx, field)
+			case "isRepeatable":
+				return ec.fieldContext___Directive_isRepeatable(ctx, field)
 			case "locations":
 				return ec.fieldContext___Directive_locations(ctx, field)
 			case "args":
 				return ec.fieldContext___Directive_args(ctx, field)
-			case "isRepeatable":
-				return ec.fieldContext___Directive_isRepeatable(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type __Directive", field.Name)
 		},
@@ -8558,6 +9308,47 @@ func (ec *executionContext) fieldContext___Type_description(_ context.Context, f
 	return fc, nil
 }
 
+func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext___Type_specifiedByURL(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SpecifiedByURL(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext___Type_specifiedByURL(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext___Type_fields(ctx, field)
 	if err != nil {
@@ -8666,6 +9457,8 @@ func (ec *executionContext) fieldContext___Type_interfaces(_ context.Context, fi
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8678,8 +9471,6 @@ func (ec *executionContext) fieldContext___Type_interfaces(_ context.Context, fi
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8731,6 +9522,8 @@ func (ec *executionContext) fieldContext___Type_possibleTypes(_ context.Context,
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8743,8 +9536,6 @@ func (ec *executionContext) fieldContext___Type_possibleTypes(_ context.Context,
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8913,6 +9704,8 @@ func (ec *executionContext) fieldContext___Type_ofType(_ context.Context, field
 				return ec.fieldContext___Type_name(ctx, field)
 			case "description":
 				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "fields":
 				return ec.fieldContext___Type_fields(ctx, field)
 			case "interfaces":
@@ -8925,8 +9718,6 @@ func (ec *executionContext) fieldContext___Type_ofType(_ context.Context, field
 				return ec.fieldContext___Type_inputFields(ctx, field)
 			case "ofType":
 				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			case "isOneOf":
 				return ec.fieldContext___Type_isOneOf(ctx, field)
 			}
@@ -8936,47 +9727,6 @@ func (ec *executionContext) fieldContext___Type_ofType(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext___Type_specifiedByURL(ctx, field)
-	if err != nil {
-		return graphql.Null
-	}
-	ctx = graphql.WithFieldContext(ctx, fc)
-	defer func() {
-		if r := recover(); r != nil {
-			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
-		}
-	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (any, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.SpecifiedByURL(), nil
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	if resTmp == nil {
-		return graphql.Null
-	}
-	res := resTmp.(*string)
-	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
-}
-
-func (ec *executionContext) fieldContext___Type_specifiedByURL(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "__Type",
-		Field:      field,
-		IsMethod:   true,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
 func (ec *executionContext) ___Type_isOneOf(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext___Type_isOneOf(ctx, field)
 	if err != nil {
@@ -9424,6 +10174,14 @@ func (ec *executionContext) _Block(ctx context.Context, sel ast.SelectionSet, ob
 			}
 		case "withdrawals":
 			out.Values[i] = ec._Block_withdrawals(ctx, field, obj)
+		case "withdrawalsRoot":
+			out.Values[i] = ec._Block_withdrawalsRoot(ctx, field, obj)
+		case "blobGasUsed":
+			out.Values[i] = ec._Block_blobGasUsed(ctx, field, obj)
+		case "excessBlobGas":
+			out.Values[i] = ec._Block_excessBlobGas(ctx, field, obj)
+		case "parentBeaconBlockRoot":
+			out.Values[i] = ec._Block_parentBeaconBlockRoot(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9896,6 +10654,70 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 	return out
 }
 
+var setCodeAuthorizationImplementors = []string{"SetCodeAuthorization"}
+
+func (ec *executionContext) _SetCodeAuthorization(ctx context.Context, sel ast.SelectionSet, obj *model.SetCodeAuthorization) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, setCodeAuthorizationImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SetCodeAuthorization")
+		case "chainId":
+			out.Values[i] = ec._SetCodeAuthorization_chainId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "address":
+			out.Values[i] = ec._SetCodeAuthorization_address(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "nonce":
+			out.Values[i] = ec._SetCodeAuthorization_nonce(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "yParity":
+			out.Values[i] = ec._SetCodeAuthorization_yParity(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "r":
+			out.Values[i] = ec._SetCodeAuthorization_r(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "s":
+			out.Values[i] = ec._SetCodeAuthorization_s(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var syncStateImplementors = []string{"SyncState"}
 
 func (ec *executionContext) _SyncState(ctx context.Context, sel ast.SelectionSet, obj *model.SyncState) graphql.Marshaler {
@@ -10044,6 +10866,12 @@ func (ec *executionContext) _Transaction(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "maxFeePerBlobGas":
+			out.Values[i] = ec._Transaction_maxFeePerBlobGas(ctx, field, obj)
+		case "blobVersionedHashes":
+			out.Values[i] = ec._Transaction_blobVersionedHashes(ctx, field, obj)
+		case "authorizationList":
+			out.Values[i] = ec._Transaction_authorizationList(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -10139,6 +10967,11 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionS
 			}
 		case "description":
 			out.Values[i] = ec.___Directive_description(ctx, field, obj)
+		case "isRepeatable":
+			out.Values[i] = ec.___Directive_isRepeatable(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "locations":
 			out.Values[i] = ec.___Directive_locations(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -10149,11 +10982,6 @@ func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "isRepeatable":
-			out.Values[i] = ec.___Directive_isRepeatable(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
-			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -10413,6 +11241,8 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 			out.Values[i] = ec.___Type_name(ctx, field, obj)
 		case "description":
 			out.Values[i] = ec.___Type_description(ctx, field, obj)
+		case "specifiedByURL":
+			out.Values[i] = ec.___Type_specifiedByURL(ctx, field, obj)
 		case "fields":
 			out.Values[i] = ec.___Type_fields(ctx, field, obj)
 		case "interfaces":
@@ -10425,8 +11255,6 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 			out.Values[i] = ec.___Type_inputFields(ctx, field, obj)
 		case "ofType":
 			out.Values[i] = ec.___Type_ofType(ctx, field, obj)
-		case "specifiedByURL":
-			out.Values[i] = ec.___Type_specifiedByURL(ctx, field, obj)
 		case "isOneOf":
 			out.Values[i] = ec.___Type_isOneOf(ctx, field, obj)
 		default:
@@ -10750,6 +11578,16 @@ func (ec *executionContext) marshalNPending2ᚖgithubᚗcomᚋerigontechᚋerigo
 	return ec._Pending(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNSetCodeAuthorization2ᚖgithubᚗcomᚋerigontechᚋerigonᚋcmdᚋrpcdaemonᚋgraphqlᚋgraphᚋmodelᚐSetCodeAuthorization(ctx context.Context, sel ast.SelectionSet, v *model.SetCodeAuthorization) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._SetCodeAuthorization(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -11268,6 +12106,44 @@ func (ec *executionContext) marshalOBytes2ᚖstring(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) unmarshalOBytes322ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []any
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNBytes322string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOBytes322ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNBytes322string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOBytes322ᚕᚕstringᚄ(ctx context.Context, v any) ([][]string, error) {
 	if v == nil {
 		return nil, nil
@@ -11408,6 +12284,53 @@ func (ec *executionContext) marshalOLong2ᚖuint64(ctx context.Context, sel ast.
 	return res
 }
 
+func (ec *executionContext) marshalOSetCodeAuthorization2ᚕᚖgithubᚗcomᚋerigontechᚋerigonᚋcmdᚋrpcdaemonᚋgraphqlᚋgraphᚋmodelᚐSetCodeAuthorizationᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.SetCodeAuthorization) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNSetCodeAuthorization2ᚖgithubᚗcomᚋerigontechᚋerigonᚋcmdᚋrpcdaemonᚋgraphqlᚋgraphᚋmodelᚐSetCodeAuthorization(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v any) (*string, error) {
 	if v == nil {
 		return nil, nil
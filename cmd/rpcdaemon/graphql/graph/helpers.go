@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/erigontech/erigon-lib/common"
 	hexutil2 "github.com/erigontech/erigon-lib/common/hexutil"
@@ -14,6 +15,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/hexutil"
 
 	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql/graph/model"
 )
 
 func convertDataToStringP(abstractMap map[string]interface{}, field string) *string {
@@ -38,6 +40,11 @@ func convertDataToStringP(abstractMap map[string]interface{}, field string) *str
 			return nil
 		}
 		result = v.String()
+	case *common.Hash:
+		if v == nil {
+			return nil
+		}
+		result = v.String()
 	case common.Address:
 		result = v.String()
 	case common.Hash:
@@ -109,8 +116,20 @@ func convertDataToUint64P(abstractMap map[string]interface{}, field string) *uin
 		} else {
 			result = resultUint
 		}
+	case *hexutil2.Uint64:
+		if v == nil {
+			return nil
+		}
+		result = uint64(*v)
 	case *hexutil2.Big:
 		result = v.ToInt().Uint64()
+	case string:
+		resultUint, err := hexutil2.DecodeUint64(v)
+		if err != nil {
+			result = 0
+		} else {
+			result = resultUint
+		}
 	case int:
 		result = abstractMap[field].(uint64)
 	case uint64:
@@ -135,3 +154,111 @@ func convertStrHexToDec(hexString *string) *string {
 
 	return &result
 }
+
+// convertOptionalStringP is convertDataToStringP for fields that are only present in some blocks.
+func convertOptionalStringP(abstractMap map[string]interface{}, field string) *string {
+	if _, ok := abstractMap[field]; !ok {
+		return nil
+	}
+	return convertDataToStringP(abstractMap, field)
+}
+
+// convertOptionalUint64P is convertDataToUint64P for fields that are only present in some blocks.
+func convertOptionalUint64P(abstractMap map[string]interface{}, field string) *uint64 {
+	if _, ok := abstractMap[field]; !ok {
+		return nil
+	}
+	return convertDataToUint64P(abstractMap, field)
+}
+
+// convertOptionalIntP is convertDataToIntP for fields that are only present in some blocks.
+func convertOptionalIntP(abstractMap map[string]interface{}, field string) *int {
+	if _, ok := abstractMap[field]; !ok {
+		return nil
+	}
+	return convertDataToIntP(abstractMap, field)
+}
+
+func convertLog(rlog *types.Log) *model.Log {
+	tlog := &model.Log{
+		Index:  int(rlog.Index),
+		Data:   "0x" + hex.EncodeToString(rlog.Data),
+		Topics: make([]string, 0, len(rlog.Topics)),
+	}
+	tlog.Account = &model.Account{}
+	tlog.Account.Address = strings.ToLower(rlog.Address.String())
+
+	for _, rtopic := range rlog.Topics {
+		tlog.Topics = append(tlog.Topics, rtopic.String())
+	}
+	return tlog
+}
+
+// convertTransaction converts a transaction, merged with its receipt, as returned by the GraphQL API. The
+// receipt fields are missing for the transactions of the pool.
+func convertTransaction(transReceipt map[string]interface{}) *model.Transaction {
+	trans := &model.Transaction{}
+	trans.CumulativeGasUsed = convertOptionalUint64P(transReceipt, "cumulativeGasUsed")
+	trans.InputData = *convertDataToStringP(transReceipt, "data")
+	trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
+	trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
+	trans.GasUsed = convertOptionalUint64P(transReceipt, "gasUsed")
+	trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
+	trans.Index = convertOptionalIntP(transReceipt, "transactionIndex")
+	transNonce := convertDataToStringP(transReceipt, "nonce")
+	if transNonce != nil {
+		trans.Nonce = *transNonce
+	}
+	trans.Status = convertOptionalUint64P(transReceipt, "status")
+	trans.Type = convertDataToIntP(transReceipt, "type")
+	trans.Value = *convertDataToStringP(transReceipt, "value")
+
+	var logs []*types.Log
+	switch v := transReceipt["logs"].(type) {
+	case types.Logs:
+		logs = v
+	case []*types.Log:
+		logs = v
+	}
+	trans.Logs = make([]*model.Log, 0, len(logs))
+	for _, rlog := range logs {
+		trans.Logs = append(trans.Logs, convertLog(rlog))
+	}
+
+	trans.From = &model.Account{}
+	trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
+
+	trans.To = &model.Account{}
+	address := convertDataToStringP(transReceipt, "to")
+	// To address could be nil in case of contract creation
+	if address != nil {
+		trans.To.Address = strings.ToLower(*convertDataToStringP(transReceipt, "to"))
+	}
+
+	// Cancun and Prague fields
+	if maxFeePerBlobGas, ok := transReceipt["maxFeePerBlobGas"].(*uint256.Int); ok && maxFeePerBlobGas != nil {
+		fee := maxFeePerBlobGas.Hex()
+		trans.MaxFeePerBlobGas = &fee
+	}
+	if blobHashes, ok := transReceipt["blobVersionedHashes"].([]common.Hash); ok && len(blobHashes) > 0 {
+		trans.BlobVersionedHashes = make([]string, 0, len(blobHashes))
+		for _, blobHash := range blobHashes {
+			trans.BlobVersionedHashes = append(trans.BlobVersionedHashes, blobHash.String())
+		}
+	}
+	if authorizations, ok := transReceipt["authorizationList"].([]types.Authorization); ok {
+		trans.AuthorizationList = make([]*model.SetCodeAuthorization, 0, len(authorizations))
+		for _, auth := range authorizations {
+			trans.AuthorizationList = append(trans.AuthorizationList, &model.SetCodeAuthorization{
+				ChainID: auth.ChainID.Hex(),
+				Address: strings.ToLower(auth.Address.String()),
+				Nonce:   auth.Nonce,
+				YParity: uint64(auth.YParity),
+				R:       auth.R.Hex(),
+				S:       auth.S.Hex(),
+			})
+		}
+	}
+
+	return trans
+}
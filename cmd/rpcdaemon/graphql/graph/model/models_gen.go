@@ -16,37 +16,41 @@ type Account struct {
 }
 
 type Block struct {
-	Number            uint64         `json:"number"`
-	Hash              string         `json:"hash"`
-	Parent            *Block         `json:"parent,omitempty"`
-	Nonce             string         `json:"nonce"`
-	TransactionsRoot  string         `json:"transactionsRoot"`
-	TransactionCount  *int           `json:"transactionCount,omitempty"`
-	StateRoot         string         `json:"stateRoot"`
-	ReceiptsRoot      string         `json:"receiptsRoot"`
-	Miner             *Account       `json:"miner"`
-	ExtraData         string         `json:"extraData"`
-	GasLimit          uint64         `json:"gasLimit"`
-	GasUsed           uint64         `json:"gasUsed"`
-	BaseFeePerGas     *string        `json:"baseFeePerGas,omitempty"`
-	NextBaseFeePerGas *string        `json:"nextBaseFeePerGas,omitempty"`
-	Timestamp         string         `json:"timestamp"`
-	LogsBloom         string         `json:"logsBloom"`
-	MixHash           string         `json:"mixHash"`
-	Difficulty        string         `json:"difficulty"`
-	OmmerCount        *int           `json:"ommerCount,omitempty"`
-	Ommers            []*Block       `json:"ommers,omitempty"`
-	OmmerAt           *Block         `json:"ommerAt,omitempty"`
-	OmmerHash         string         `json:"ommerHash"`
-	Transactions      []*Transaction `json:"transactions,omitempty"`
-	TransactionAt     *Transaction   `json:"transactionAt,omitempty"`
-	Logs              []*Log         `json:"logs"`
-	Account           *Account       `json:"account"`
-	Call              *CallResult    `json:"call,omitempty"`
-	EstimateGas       uint64         `json:"estimateGas"`
-	RawHeader         string         `json:"rawHeader"`
-	Raw               string         `json:"raw"`
-	Withdrawals       []*Withdrawal  `json:"withdrawals,omitempty"`
+	Number                uint64         `json:"number"`
+	Hash                  string         `json:"hash"`
+	Parent                *Block         `json:"parent,omitempty"`
+	Nonce                 string         `json:"nonce"`
+	TransactionsRoot      string         `json:"transactionsRoot"`
+	TransactionCount      *int           `json:"transactionCount,omitempty"`
+	StateRoot             string         `json:"stateRoot"`
+	ReceiptsRoot          string         `json:"receiptsRoot"`
+	Miner                 *Account       `json:"miner"`
+	ExtraData             string         `json:"extraData"`
+	GasLimit              uint64         `json:"gasLimit"`
+	GasUsed               uint64         `json:"gasUsed"`
+	BaseFeePerGas         *string        `json:"baseFeePerGas,omitempty"`
+	NextBaseFeePerGas     *string        `json:"nextBaseFeePerGas,omitempty"`
+	Timestamp             string         `json:"timestamp"`
+	LogsBloom             string         `json:"logsBloom"`
+	MixHash               string         `json:"mixHash"`
+	Difficulty            string         `json:"difficulty"`
+	OmmerCount            *int           `json:"ommerCount,omitempty"`
+	Ommers                []*Block       `json:"ommers,omitempty"`
+	OmmerAt               *Block         `json:"ommerAt,omitempty"`
+	OmmerHash             string         `json:"ommerHash"`
+	Transactions          []*Transaction `json:"transactions,omitempty"`
+	TransactionAt         *Transaction   `json:"transactionAt,omitempty"`
+	Logs                  []*Log         `json:"logs"`
+	Account               *Account       `json:"account"`
+	Call                  *CallResult    `json:"call,omitempty"`
+	EstimateGas           uint64         `json:"estimateGas"`
+	RawHeader             string         `json:"rawHeader"`
+	Raw                   string         `json:"raw"`
+	Withdrawals           []*Withdrawal  `json:"withdrawals,omitempty"`
+	WithdrawalsRoot       *string        `json:"withdrawalsRoot,omitempty"`
+	BlobGasUsed           *uint64        `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas         *uint64        `json:"excessBlobGas,omitempty"`
+	ParentBeaconBlockRoot *string        `json:"parentBeaconBlockRoot,omitempty"`
 }
 
 type BlockFilterCriteria struct {
@@ -100,6 +104,15 @@ type Pending struct {
 type Query struct {
 }
 
+type SetCodeAuthorization struct {
+	ChainID string `json:"chainId"`
+	Address string `json:"address"`
+	Nonce   uint64 `json:"nonce"`
+	YParity uint64 `json:"yParity"`
+	R       string `json:"r"`
+	S       string `json:"s"`
+}
+
 type SyncState struct {
 	StartingBlock uint64 `json:"startingBlock"`
 	CurrentBlock  uint64 `json:"currentBlock"`
@@ -107,32 +120,35 @@ type SyncState struct {
 }
 
 type Transaction struct {
-	Hash                 string         `json:"hash"`
-	Nonce                string         `json:"nonce"`
-	Index                *int           `json:"index,omitempty"`
-	From                 *Account       `json:"from"`
-	To                   *Account       `json:"to,omitempty"`
-	Value                string         `json:"value"`
-	GasPrice             string         `json:"gasPrice"`
-	MaxFeePerGas         *string        `json:"maxFeePerGas,omitempty"`
-	MaxPriorityFeePerGas *string        `json:"maxPriorityFeePerGas,omitempty"`
-	EffectiveTip         *string        `json:"effectiveTip,omitempty"`
-	Gas                  uint64         `json:"gas"`
-	InputData            string         `json:"inputData"`
-	Block                *Block         `json:"block,omitempty"`
-	Status               *uint64        `json:"status,omitempty"`
-	GasUsed              *uint64        `json:"gasUsed,omitempty"`
-	CumulativeGasUsed    *uint64        `json:"cumulativeGasUsed,omitempty"`
-	EffectiveGasPrice    *string        `json:"effectiveGasPrice,omitempty"`
-	CreatedContract      *Account       `json:"createdContract,omitempty"`
-	Logs                 []*Log         `json:"logs,omitempty"`
-	R                    string         `json:"r"`
-	S                    string         `json:"s"`
-	V                    string         `json:"v"`
-	Type                 *int           `json:"type,omitempty"`
-	AccessList           []*AccessTuple `json:"accessList,omitempty"`
-	Raw                  string         `json:"raw"`
-	RawReceipt           string         `json:"rawReceipt"`
+	Hash                 string                  `json:"hash"`
+	Nonce                string                  `json:"nonce"`
+	Index                *int                    `json:"index,omitempty"`
+	From                 *Account                `json:"from"`
+	To                   *Account                `json:"to,omitempty"`
+	Value                string                  `json:"value"`
+	GasPrice             string                  `json:"gasPrice"`
+	MaxFeePerGas         *string                 `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *string                 `json:"maxPriorityFeePerGas,omitempty"`
+	EffectiveTip         *string                 `json:"effectiveTip,omitempty"`
+	Gas                  uint64                  `json:"gas"`
+	InputData            string                  `json:"inputData"`
+	Block                *Block                  `json:"block,omitempty"`
+	Status               *uint64                 `json:"status,omitempty"`
+	GasUsed              *uint64                 `json:"gasUsed,omitempty"`
+	CumulativeGasUsed    *uint64                 `json:"cumulativeGasUsed,omitempty"`
+	EffectiveGasPrice    *string                 `json:"effectiveGasPrice,omitempty"`
+	CreatedContract      *Account                `json:"createdContract,omitempty"`
+	Logs                 []*Log                  `json:"logs,omitempty"`
+	R                    string                  `json:"r"`
+	S                    string                  `json:"s"`
+	V                    string                  `json:"v"`
+	Type                 *int                    `json:"type,omitempty"`
+	AccessList           []*AccessTuple          `json:"accessList,omitempty"`
+	Raw                  string                  `json:"raw"`
+	RawReceipt           string                  `json:"rawReceipt"`
+	MaxFeePerBlobGas     *string                 `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes  []string                `json:"blobVersionedHashes,omitempty"`
+	AuthorizationList    []*SetCodeAuthorization `json:"authorizationList,omitempty"`
 }
 
 type Withdrawal struct {
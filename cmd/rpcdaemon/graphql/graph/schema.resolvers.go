@@ -6,21 +6,30 @@ package graph
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
-	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql/graph/model"
+	"github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/rpc"
 )
 
 // SendRawTransaction is the resolver for the sendRawTransaction field.
 func (r *mutationResolver) SendRawTransaction(ctx context.Context, data string) (string, error) {
-	panic("not implemented: SendRawTransaction - sendRawTransaction")
+	encodedTx, err := hexutil.Decode(data)
+	if err != nil {
+		return "", err
+	}
+	hash, err := r.GraphQLAPI.SendRawTransaction(ctx, encodedTx)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
 }
 
 // Block is the resolver for the block field.
@@ -119,49 +128,7 @@ func (r *queryResolver) Block(ctx context.Context, number *string, hash *string)
 		absRcp := res["receipts"]
 		rcp := absRcp.([]map[string]interface{})
 		for _, transReceipt := range rcp {
-			trans := &model.Transaction{}
-			trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
-			trans.InputData = *convertDataToStringP(transReceipt, "data")
-			trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
-			trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
-			trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
-			transNonce := convertDataToStringP(transReceipt, "nonce")
-			if transNonce != nil {
-				trans.Nonce = *transNonce
-			}
-			trans.Status = convertDataToUint64P(transReceipt, "status")
-			trans.Type = convertDataToIntP(transReceipt, "type")
-			trans.Value = *convertDataToStringP(transReceipt, "value")
-
-			trans.Logs = make([]*model.Log, 0)
-			for _, rlog := range transReceipt["logs"].(types.Logs) {
-				tlog := model.Log{
-					Index: int(rlog.Index),
-					Data:  "0x" + hex.EncodeToString(rlog.Data),
-				}
-				tlog.Account = &model.Account{}
-				tlog.Account.Address = strings.ToLower(rlog.Address.String())
-
-				for _, rtopic := range rlog.Topics {
-					tlog.Topics = append(tlog.Topics, rtopic.String())
-				}
-
-				trans.Logs = append(trans.Logs, &tlog)
-			}
-
-			trans.From = &model.Account{}
-			trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
-
-			trans.To = &model.Account{}
-			address := convertDataToStringP(transReceipt, "to")
-			// To address could be nil in case of contract creation
-			if address != nil {
-				trans.To.Address = strings.ToLower(*convertDataToStringP(transReceipt, "to"))
-			}
-
-			block.Transactions = append(block.Transactions, trans)
+			block.Transactions = append(block.Transactions, convertTransaction(transReceipt))
 		}
 
 		// Withdrawals
@@ -177,6 +144,12 @@ func (r *queryResolver) Block(ctx context.Context, number *string, hash *string)
 
 			block.Withdrawals = append(block.Withdrawals, wthd)
 		}
+
+		// Shanghai and Cancun fields
+		block.WithdrawalsRoot = convertOptionalStringP(blk, "withdrawalsRoot")
+		block.BlobGasUsed = convertOptionalUint64P(blk, "blobGasUsed")
+		block.ExcessBlobGas = convertOptionalUint64P(blk, "excessBlobGas")
+		block.ParentBeaconBlockRoot = convertOptionalStringP(blk, "parentBeaconBlockRoot")
 	}
 
 	return block, ctx.Err()
@@ -207,32 +180,138 @@ func (r *queryResolver) Blocks(ctx context.Context, from *uint64, to *uint64) ([
 
 // Pending is the resolver for the pending field.
 func (r *queryResolver) Pending(ctx context.Context) (*model.Pending, error) {
-	panic("not implemented: Pending - pending")
+	res, err := r.GraphQLAPI.GetBlockDetails(ctx, rpc.PendingBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &model.Pending{Transactions: []*model.Transaction{}}
+	if res == nil {
+		// No pending block is available, e.g. the node doesn't mine
+		return pending, ctx.Err()
+	}
+	for _, transReceipt := range res["receipts"].([]map[string]interface{}) {
+		pending.Transactions = append(pending.Transactions, convertTransaction(transReceipt))
+	}
+	pending.TransactionCount = len(pending.Transactions)
+
+	return pending, ctx.Err()
 }
 
 // Transaction is the resolver for the transaction field.
 func (r *queryResolver) Transaction(ctx context.Context, hash string) (*model.Transaction, error) {
-	panic("not implemented: Transaction - transaction")
+	txnHash, err := hexutil.Decode(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(txnHash) != length.Hash {
+		return nil, fmt.Errorf("invalid transaction hash length: %d", len(txnHash))
+	}
+
+	res, err := r.GraphQLAPI.GetTransactionDetails(ctx, common.BytesToHash(txnHash))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ctx.Err()
+	}
+
+	trans := convertTransaction(res)
+	// Pending transactions are not included in a block yet
+	blockNumber, blockHash := convertOptionalUint64P(res, "blockNumber"), convertOptionalStringP(res, "blockHash")
+	if blockNumber != nil && blockHash != nil {
+		trans.Block = &model.Block{Number: *blockNumber, Hash: *blockHash}
+	}
+
+	return trans, ctx.Err()
 }
 
 // Logs is the resolver for the logs field.
 func (r *queryResolver) Logs(ctx context.Context, filter model.FilterCriteria) ([]*model.Log, error) {
-	panic("not implemented: Logs - logs")
+	var crit filters.FilterCriteria
+	if filter.FromBlock != nil {
+		crit.FromBlock = new(big.Int).SetUint64(*filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		crit.ToBlock = new(big.Int).SetUint64(*filter.ToBlock)
+	}
+	for _, address := range filter.Addresses {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid address: %s", address)
+		}
+		crit.Addresses = append(crit.Addresses, common.HexToAddress(address))
+	}
+	for _, topics := range filter.Topics {
+		position := make([]common.Hash, 0, len(topics))
+		for _, topic := range topics {
+			position = append(position, common.HexToHash(topic))
+		}
+		crit.Topics = append(crit.Topics, position)
+	}
+
+	logs, err := r.GraphQLAPI.GetLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Log, 0, len(logs))
+	for _, rlog := range logs {
+		tlog := convertLog(rlog)
+		index := int(rlog.TxIndex)
+		tlog.Transaction = &model.Transaction{
+			Hash:  rlog.TxHash.String(),
+			Index: &index,
+			Block: &model.Block{Number: rlog.BlockNumber, Hash: rlog.BlockHash.String()},
+		}
+		result = append(result, tlog)
+	}
+
+	return result, ctx.Err()
 }
 
 // GasPrice is the resolver for the gasPrice field.
 func (r *queryResolver) GasPrice(ctx context.Context) (string, error) {
-	panic("not implemented: GasPrice - gasPrice")
+	gasPrice, err := r.GraphQLAPI.GasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+	return gasPrice.String(), nil
 }
 
 // MaxPriorityFeePerGas is the resolver for the maxPriorityFeePerGas field.
 func (r *queryResolver) MaxPriorityFeePerGas(ctx context.Context) (string, error) {
-	panic("not implemented: MaxPriorityFeePerGas - maxPriorityFeePerGas")
+	tipCap, err := r.GraphQLAPI.MaxPriorityFeePerGas(ctx)
+	if err != nil {
+		return "", err
+	}
+	return tipCap.String(), nil
 }
 
 // Syncing is the resolver for the syncing field.
 func (r *queryResolver) Syncing(ctx context.Context) (*model.SyncState, error) {
-	panic("not implemented: Syncing - syncing")
+	res, err := r.GraphQLAPI.Syncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The node isn't syncing
+	progress, ok := res.(map[string]interface{})
+	if !ok {
+		return nil, ctx.Err()
+	}
+
+	startingBlock := convertDataToUint64P(progress, "startingBlock")
+	currentBlock := convertDataToUint64P(progress, "currentBlock")
+	highestBlock := convertDataToUint64P(progress, "highestBlock")
+	if startingBlock == nil || currentBlock == nil || highestBlock == nil {
+		return nil, fmt.Errorf("incomplete sync progress: %v", progress)
+	}
+
+	return &model.SyncState{
+		StartingBlock: *startingBlock,
+		CurrentBlock:  *currentBlock,
+		HighestBlock:  *highestBlock,
+	}, ctx.Err()
 }
 
 // ChainID is the resolver for the chainID field.
@@ -17,11 +17,35 @@
 package graphql
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/eth/filters"
+	"github.com/erigontech/erigon/execution/stages/mock"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+	"github.com/erigontech/erigon/rpc/jsonrpc"
+	"github.com/erigontech/erigon/rpc/rpccfg"
+	"github.com/erigontech/erigon/rpc/rpchelper"
 )
 
 func TestGraphQLQueryBlock(t *testing.T) {
@@ -159,3 +183,261 @@ func TestGraphQLQueryBlock(t *testing.T) {
 		}
 	}
 }
+
+var (
+	testTxnHash   = common.HexToHash("0x2c4d0ed2c6e1e8a4bd2b2a85d2b9a4d4a4e52d1ec2b8c8c2e3e77e5fda5a6b1c")
+	testBlockHash = common.HexToHash("0x5a81cbe2ff1b1b4b0b6f0b2ea4c0c1d5a2b1c0f5c1d2e3f4a5b6c7d8e9f0a1b2")
+	testBlobHash  = common.HexToHash("0x01a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
+	testFrom      = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+	testTo        = common.HexToAddress("0x1000000000000000000000000000000000000001")
+)
+
+// testGraphQLAPI serves canned responses in the format of jsonrpc.GraphQLAPIImpl.
+type testGraphQLAPI struct {
+	syncing interface{}
+	sent    hexutil.Bytes
+	crit    filters.FilterCriteria
+}
+
+func (api *testGraphQLAPI) GetBlockDetails(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error) {
+	if number == rpc.PendingBlockNumber {
+		return nil, nil
+	}
+	blobGasUsed, excessBlobGas := uint64(131072), uint64(262144)
+	withdrawalsHash, beaconRoot := common.HexToHash("0x01"), common.HexToHash("0x02")
+	header := &types.Header{
+		Number:                big.NewInt(int64(number)),
+		Difficulty:            big.NewInt(0),
+		GasLimit:              30_000_000,
+		BaseFee:               big.NewInt(7),
+		WithdrawalsHash:       &withdrawalsHash,
+		BlobGasUsed:           &blobGasUsed,
+		ExcessBlobGas:         &excessBlobGas,
+		ParentBeaconBlockRoot: &beaconRoot,
+	}
+	block, err := ethapi.RPCMarshalBlock(types.NewBlockWithHeader(header), false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	block["transactionCount"] = 0
+	return map[string]interface{}{
+		"block":       block,
+		"receipts":    []map[string]interface{}{},
+		"withdrawals": []map[string]interface{}{},
+	}, nil
+}
+
+func (api *testGraphQLAPI) GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	if hash != testTxnHash {
+		return nil, nil
+	}
+	return map[string]interface{}{
+		"blockHash":           testBlockHash,
+		"blockNumber":         hexutil.Uint64(42),
+		"transactionHash":     testTxnHash,
+		"transactionIndex":    hexutil.Uint64(1),
+		"from":                testFrom,
+		"to":                  &testTo,
+		"type":                hexutil.Uint(types.BlobTxType),
+		"gasUsed":             hexutil.Uint64(21000),
+		"cumulativeGasUsed":   hexutil.Uint64(42000),
+		"effectiveGasPrice":   (*hexutil.Big)(big.NewInt(10)),
+		"status":              hexutil.Uint64(1),
+		"logs":                types.Logs{},
+		"nonce":               uint64(5),
+		"value":               uint256.NewInt(1),
+		"data":                []byte{},
+		"blobVersionedHashes": []common.Hash{testBlobHash},
+		"maxFeePerBlobGas":    uint256.NewInt(3),
+		"authorizationList": []types.Authorization{{
+			ChainID: *uint256.NewInt(1),
+			Address: testTo,
+			Nonce:   9,
+			YParity: 1,
+			R:       *uint256.NewInt(2),
+			S:       *uint256.NewInt(3),
+		}},
+	}, nil
+}
+
+func (api *testGraphQLAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error) {
+	api.crit = crit
+	return types.Logs{{
+		Address:     testTo,
+		Topics:      []common.Hash{testBlobHash},
+		Data:        []byte{0x01},
+		BlockNumber: 42,
+		BlockHash:   testBlockHash,
+		TxHash:      testTxnHash,
+		TxIndex:     1,
+		Index:       3,
+	}}, nil
+}
+
+func (api *testGraphQLAPI) GetChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (api *testGraphQLAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	return (*hexutil.Big)(big.NewInt(1_000_000_000)), nil
+}
+
+func (api *testGraphQLAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	return (*hexutil.Big)(big.NewInt(2)), nil
+}
+
+func (api *testGraphQLAPI) Syncing(ctx context.Context) (interface{}, error) {
+	return api.syncing, nil
+}
+
+func (api *testGraphQLAPI) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	if len(encodedTx) == 0 {
+		return common.Hash{}, errors.New("empty transaction")
+	}
+	api.sent = encodedTx
+	return testTxnHash, nil
+}
+
+func queryGraphQL(t *testing.T, server *httptest.Server, body string) string {
+	t.Helper()
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	bodyBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(bodyBytes)
+}
+
+func TestGraphQLResolvers(t *testing.T) {
+	api := &testGraphQLAPI{syncing: false}
+	server := httptest.NewServer(CreateHandler([]rpc.API{{Namespace: "graphql", Service: api}}))
+	defer server.Close()
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: `{"query": "{gasPrice,maxPriorityFeePerGas}"}`,
+			want: `{"data":{"gasPrice":"0x3b9aca00","maxPriorityFeePerGas":"0x2"}}`,
+		},
+		{ // Not syncing
+			body: `{"query": "{syncing{currentBlock}}"}`,
+			want: `{"data":{"syncing":null}}`,
+		},
+		{ // No pending block
+			body: `{"query": "{pending{transactionCount,transactions{hash}}}"}`,
+			want: `{"data":{"pending":{"transactionCount":0,"transactions":[]}}}`,
+		},
+		{
+			body: `{"query": "{transaction(hash:\"` + testTxnHash.Hex() + `\"){hash,index,nonce,blobVersionedHashes,maxFeePerBlobGas,authorizationList{chainId,address,nonce,yParity,r,s},block{number}}}"}`,
+			want: `{"data":{"transaction":{"hash":"` + testTxnHash.Hex() + `","index":1,"nonce":"0x5","blobVersionedHashes":["` + testBlobHash.Hex() + `"],"maxFeePerBlobGas":"0x3","authorizationList":[{"chainId":"0x1","address":"0x1000000000000000000000000000000000000001","nonce":9,"yParity":1,"r":"0x2","s":"0x3"}],"block":{"number":42}}}}`,
+		},
+		{ // Unknown transaction
+			body: `{"query": "{transaction(hash:\"` + testBlockHash.Hex() + `\"){hash}}"}`,
+			want: `{"data":{"transaction":null}}`,
+		},
+		{
+			body: `{"query": "{logs(filter:{fromBlock:40,toBlock:42,addresses:[\"` + testTo.Hex() + `\"]}){index,data,topics,account{address},transaction{hash}}}"}`,
+			want: `{"data":{"logs":[{"index":3,"data":"0x01","topics":["` + testBlobHash.Hex() + `"],"account":{"address":"0x1000000000000000000000000000000000000001"},"transaction":{"hash":"` + testTxnHash.Hex() + `"}}]}}`,
+		},
+		{
+			body: `{"query": "{block(number:\"17\"){number,withdrawalsRoot,blobGasUsed,excessBlobGas,parentBeaconBlockRoot}}"}`,
+			want: `{"data":{"block":{"number":17,"withdrawalsRoot":"0x0000000000000000000000000000000000000000000000000000000000000001","blobGasUsed":131072,"excessBlobGas":262144,"parentBeaconBlockRoot":"0x0000000000000000000000000000000000000000000000000000000000000002"}}}`,
+		},
+		{
+			body: `{"query": "mutation {sendRawTransaction(data:\"0x02f8\")}"}`,
+			want: `{"data":{"sendRawTransaction":"` + testTxnHash.Hex() + `"}}`,
+		},
+	} {
+		if have := queryGraphQL(t, server, tt.body); have != tt.want {
+			t.Errorf("testcase %d %s,\nhave:\n%v\nwant:\n%v", i, tt.body, have, tt.want)
+		}
+	}
+
+	require.Equal(t, hexutil.Bytes{0x02, 0xf8}, api.sent)
+	require.Equal(t, big.NewInt(40), api.crit.FromBlock)
+	require.Equal(t, big.NewInt(42), api.crit.ToBlock)
+	require.Equal(t, []common.Address{testTo}, api.crit.Addresses)
+}
+
+func TestGraphQLSyncing(t *testing.T) {
+	api := &testGraphQLAPI{syncing: map[string]interface{}{
+		"startingBlock": "0x0",
+		"currentBlock":  hexutil.Uint64(100),
+		"highestBlock":  hexutil.Uint64(200),
+	}}
+	server := httptest.NewServer(CreateHandler([]rpc.API{{Namespace: "graphql", Service: api}}))
+	defer server.Close()
+
+	have := queryGraphQL(t, server, `{"query": "{syncing{startingBlock,currentBlock,highestBlock}}"}`)
+	require.Equal(t, `{"data":{"syncing":{"startingBlock":0,"currentBlock":100,"highestBlock":200}}}`, have)
+}
+
+func TestGraphQLSyncingIncomplete(t *testing.T) {
+	api := &testGraphQLAPI{syncing: map[string]interface{}{
+		"startingBlock": "0x0",
+		"currentBlock":  hexutil.Uint64(100),
+		"highestBlock":  (*hexutil.Uint64)(nil),
+	}}
+	server := httptest.NewServer(CreateHandler([]rpc.API{{Namespace: "graphql", Service: api}}))
+	defer server.Close()
+
+	have := queryGraphQL(t, server, `{"query": "{syncing{currentBlock}}"}`)
+	require.Contains(t, have, "incomplete sync progress")
+}
+
+func TestGraphQLTransaction(t *testing.T) {
+	m := mock.MockWithTxPool(t)
+	newTxn := func(nonce uint64) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, testTo, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+		require.NoError(t, err)
+		return txn
+	}
+	mined := newTxn(0)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+		b.AddTx(mined)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	pending := newTxn(1)
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, pending.MarshalBinary(buf))
+	reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+	require.NoError(t, err)
+	require.Equal(t, []txpool.ImportResult{txpool.ImportResult_SUCCESS}, reply.Imported, reply.Errors)
+
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+	base := jsonrpc.NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil)
+	api := jsonrpc.NewGraphQLAPI(base, m.DB, nil, txPool)
+	server := httptest.NewServer(CreateHandler([]rpc.API{{Namespace: "graphql", Service: jsonrpc.GraphQLAPI(api)}}))
+	defer server.Close()
+
+	from, to := strings.ToLower(m.Address.Hex()), strings.ToLower(testTo.Hex())
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: `{"query": "{transaction(hash:\"` + mined.Hash().Hex() + `\"){hash,nonce,value,from{address},to{address},status,block{number}}}"}`,
+			want: `{"data":{"transaction":{"hash":"` + mined.Hash().Hex() + `","nonce":"0x0","value":"0x4d2","from":{"address":"` + from + `"},"to":{"address":"` + to + `"},"status":1,"block":{"number":1}}}}`,
+		},
+		{ // in the pool, without block and receipt
+			body: `{"query": "{transaction(hash:\"` + pending.Hash().Hex() + `\"){hash,nonce,value,from{address},to{address},status,logs{index},block{number}}}"}`,
+			want: `{"data":{"transaction":{"hash":"` + pending.Hash().Hex() + `","nonce":"0x1","value":"0x4d2","from":{"address":"` + from + `"},"to":{"address":"` + to + `"},"status":null,"logs":[],"block":null}}}`,
+		},
+		{ // Unknown transaction
+			body: `{"query": "{transaction(hash:\"` + testBlockHash.Hex() + `\"){hash}}"}`,
+			want: `{"data":{"transaction":null}}`,
+		},
+	} {
+		if have := queryGraphQL(t, server, tt.body); have != tt.want {
+			t.Errorf("testcase %d %s,\nhave:\n%v\nwant:\n%v", i, tt.body, have, tt.want)
+		}
+	}
+}
@@ -465,7 +465,7 @@ func NewTorrentSession(cli *TorrentClient, chain string) *torrentSession {
 }
 
 func DownloadManifest(ctx context.Context, session DownloadSession) ([]fs.DirEntry, error) {
-	if session, ok := session.(*downloader.RCloneSession); ok {
+	if session, ok := session.(downloader.RemoteSession); ok {
 		reader, err := session.Cat(ctx, "manifest.txt")
 
 		if err != nil {
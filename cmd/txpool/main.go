@@ -44,6 +44,7 @@ import (
 	"github.com/erigontech/erigon/cmd/utils"
 	"github.com/erigontech/erigon/turbo/debug"
 	"github.com/erigontech/erigon/turbo/logging"
+	"github.com/erigontech/erigon/turbo/telemetry"
 	"github.com/erigontech/erigon/txnprovider/txpool"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
@@ -75,6 +76,9 @@ var (
 	mdbxWriteMap bool
 
 	commitEvery time.Duration
+
+	localsLifetime          time.Duration
+	localsRebroadcastBlocks uint64
 )
 
 func init() {
@@ -100,6 +104,8 @@ func init() {
 	rootCmd.PersistentFlags().Uint64Var(&priceBump, "txpool.pricebump", txpoolcfg.DefaultConfig.PriceBump, "Price bump percentage to replace an already existing transaction")
 	rootCmd.PersistentFlags().Uint64Var(&blobPriceBump, "txpool.blobpricebump", txpoolcfg.DefaultConfig.BlobPriceBump, "Price bump percentage to replace an existing blob (type-3) transaction")
 	rootCmd.PersistentFlags().DurationVar(&commitEvery, utils.TxPoolCommitEveryFlag.Name, utils.TxPoolCommitEveryFlag.Value, utils.TxPoolCommitEveryFlag.Usage)
+	rootCmd.PersistentFlags().DurationVar(&localsLifetime, utils.TxPoolLocalsLifetimeFlag.Name, utils.TxPoolLocalsLifetimeFlag.Value, utils.TxPoolLocalsLifetimeFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&localsRebroadcastBlocks, utils.TxPoolLocalsRebroadcastFlag.Name, utils.TxPoolLocalsRebroadcastFlag.Value, utils.TxPoolLocalsRebroadcastFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&mdbxWriteMap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
@@ -127,7 +133,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	if err != nil {
 		return fmt.Errorf("could not connect to remoteKv: %w", err)
 	}
-	coreConn, err := grpcutil.Connect(creds, privateApiAddr)
+	coreConn, err := grpcutil.Connect(creds, privateApiAddr, telemetry.DialOption())
 	if err != nil {
 		return fmt.Errorf("could not connect to remoteKv: %w", err)
 	}
@@ -147,7 +153,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 		if err != nil {
 			return fmt.Errorf("could not connect to sentry: %w", err)
 		}
-		sentryConn, err := grpcutil.Connect(creds, sentryAddr[i])
+		sentryConn, err := grpcutil.Connect(creds, sentryAddr[i], telemetry.DialOption())
 		if err != nil {
 			return fmt.Errorf("could not connect to sentry: %w", err)
 		}
@@ -172,6 +178,8 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	cfg.BlobPriceBump = blobPriceBump
 	cfg.NoGossip = noTxGossip
 	cfg.MdbxWriteMap = mdbxWriteMap
+	cfg.LocalsLifetime = localsLifetime
+	cfg.LocalsRebroadcastBlocks = localsRebroadcastBlocks
 
 	cacheConfig := kvcache.DefaultCoherentConfig
 	cacheConfig.MetricsLabel = "txpool"
@@ -69,6 +69,7 @@ import (
 	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 	"github.com/erigontech/erigon/turbo/logging"
+	"github.com/erigontech/erigon/txnprovider/bundle/bundlecfg"
 	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 
@@ -215,6 +216,16 @@ var (
 		Usage: "How often transactions should be committed to the storage",
 		Value: txpoolcfg.DefaultConfig.CommitEvery,
 	}
+	TxPoolLocalsLifetimeFlag = cli.DurationFlag{
+		Name:  "txpool.locals.lifetime",
+		Usage: "How long local transactions are journaled, to be kept across restarts and evictions, while not mined (0 disables the journal)",
+		Value: txpoolcfg.DefaultConfig.LocalsLifetime,
+	}
+	TxPoolLocalsRebroadcastFlag = cli.Uint64Flag{
+		Name:  "txpool.locals.rebroadcast",
+		Usage: "Number of blocks after which a journaled local transaction not mined yet is re-broadcast to peers (0 disables it)",
+		Value: txpoolcfg.DefaultConfig.LocalsRebroadcastBlocks,
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -388,6 +399,11 @@ var (
 		Usage: "Maximum number of bytes returned from eth_call or similar invocations",
 		Value: 100_000,
 	}
+	RpcMaxGetProofRewindBlockCount = cli.IntFlag{
+		Name:  "rpc.maxgetproofrewindblockcount.limit",
+		Usage: "Max number of blocks behind the latest one eth_getProof rebuilds the state trie for, when the commitment history doesn't reach them",
+		Value: 1_000,
+	}
 	HTTPTraceFlag = cli.BoolFlag{
 		Name:  "http.trace",
 		Usage: "Print all HTTP requests to logs with INFO level",
@@ -405,6 +421,10 @@ var (
 		Name:  "rpc.accessList",
 		Usage: "Specify granular (method-by-method) API allowlist",
 	}
+	RpcQuotaFlag = cli.StringFlag{
+		Name:  "rpc.quota",
+		Usage: "Specify JSON file with the per-client budget and per-method costs of RPC calls, clients exceeding it get 'limit exceeded' errors",
+	}
 
 	RpcGasCapFlag = cli.UintFlag{
 		Name:  "rpc.gascap",
@@ -529,6 +549,10 @@ var (
 		Usage: "Allowed ports to pick for different eth p2p protocol versions as follows <porta>,<portb>,..,<porti>",
 		Value: cli.NewUintSlice(uint(ListenPortFlag.Value), 30304, 30305, 30306, 30307),
 	}
+	P2pSnapServerFlag = cli.BoolFlag{
+		Name:  "p2p.snap",
+		Usage: "Serve the snap/1 protocol to the peers, so they can snap sync from the recent states of this node",
+	}
 	SentryAddrFlag = cli.StringFlag{
 		Name:  "sentry.api.addr",
 		Usage: "Comma separated sentry addresses '<host>:<port>,<host>:<port>'",
@@ -783,6 +807,17 @@ var (
 		Value: "http://localhost:1317",
 	}
 
+	HeimdallGrpcFlag = cli.StringFlag{
+		Name:  "bor.heimdall.grpc",
+		Usage: "Comma-separated addresses (host:port) of Heimdall v2 gRPC endpoints. When set, they are queried before --bor.heimdall, failing over between all of them",
+		Value: "",
+	}
+
+	HeimdallRaceFlag = cli.BoolFlag{
+		Name:  "bor.heimdall.race",
+		Usage: "Send every Heimdall request to all healthy endpoints at once and use the first answer, instead of failing over in order (needs --bor.heimdall.grpc)",
+	}
+
 	// WithoutHeimdallFlag no heimdall (for testing purpose)
 	WithoutHeimdallFlag = cli.BoolFlag{
 		Name:  "bor.withoutheimdall",
@@ -866,6 +901,36 @@ var (
 		Usage: "Enable caplin validator monitoring metrics",
 		Value: false,
 	}
+	CaplinValidatorClientFlag = cli.BoolFlag{
+		Name:  "caplin.validator",
+		Usage: "Enable the built-in validator client (requires --beacon.api=beacon,validator)",
+		Value: false,
+	}
+	CaplinValidatorKeystoresDirFlag = cli.StringFlag{
+		Name:  "caplin.validator.keystores-dir",
+		Usage: "Directory of the EIP-2335 keystores of the validator client, each <name>.json unlocked by <name>.txt. Defaults to <datadir>/caplin/validator/keystores",
+		Value: "",
+	}
+	CaplinValidatorFeeRecipientFlag = cli.StringFlag{
+		Name:  "caplin.validator.fee-recipient",
+		Usage: "Default fee recipient of the validator client keys",
+		Value: "",
+	}
+	CaplinValidatorGraffitiFlag = cli.StringFlag{
+		Name:  "caplin.validator.graffiti",
+		Usage: "Graffiti of the blocks proposed by the validator client",
+		Value: "",
+	}
+	CaplinKeymanagerAddrFlag = cli.StringFlag{
+		Name:  "caplin.keymanager.addr",
+		Usage: "Listening address of the keymanager API of the validator client",
+		Value: "localhost:5062",
+	}
+	CaplinKeymanagerTokenFileFlag = cli.StringFlag{
+		Name:  "caplin.keymanager.token-file",
+		Usage: "Bearer token file of the keymanager API, generated if missing. Defaults to <datadir>/caplin/validator/api-token.txt",
+		Value: "",
+	}
 	CaplinMaxPeerCount = cli.Uint64Flag{
 		Name:  "caplin.max-peer-count",
 		Usage: "Max number of peers to connect",
@@ -1108,6 +1173,15 @@ var (
 		Name:  "shutter.p2p.listen.port",
 		Usage: "Use to override the default p2p listen port (defaults to 23102)",
 	}
+	BundlesEnabledFlag = cli.BoolFlag{
+		Name:  "bundles",
+		Usage: "Enable eth_sendBundle and eth_cancelBundle, and include the submitted bundles in the blocks built by this node (defaults to false)",
+	}
+	BundlesMaxFlag = cli.IntFlag{
+		Name:  "bundles.max",
+		Usage: "Maximum number of bundles waiting for their target block",
+		Value: bundlecfg.DefaultConfig.MaxBundles,
+	}
 	PolygonPosSingleSlotFinalityFlag = cli.BoolFlag{
 		Name:  "polygon.pos.ssf",
 		Usage: "Enabling Polygon PoS Single Slot Finality",
@@ -1259,6 +1333,8 @@ func NewP2PConfig(
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth67")
 	case direct.ETH68:
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth68")
+	case direct.ETH69:
+		enodeDBPath = filepath.Join(dirs.Nodes, "eth69")
 	default:
 		return nil, fmt.Errorf("unknown protocol: %v", protocol)
 	}
@@ -1588,6 +1664,12 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolGossipDisableFlag.Name) {
 		cfg.NoGossip = ctx.Bool(TxPoolGossipDisableFlag.Name)
 	}
+	if ctx.IsSet(TxPoolLocalsLifetimeFlag.Name) {
+		cfg.LocalsLifetime = ctx.Duration(TxPoolLocalsLifetimeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolLocalsRebroadcastFlag.Name) {
+		cfg.LocalsRebroadcastBlocks = ctx.Uint64(TxPoolLocalsRebroadcastFlag.Name)
+	}
 	cfg.AllowAA = ctx.Bool(AAFlag.Name)
 	cfg.LogEvery = 3 * time.Minute
 	cfg.CommitEvery = common.RandomizeDuration(ctx.Duration(TxPoolCommitEveryFlag.Name))
@@ -1613,6 +1695,17 @@ func setShutter(ctx *cli.Context, chainName string, nodeConfig *nodecfg.Config,
 	ethConfig.Shutter = config
 }
 
+func setBundles(ctx *cli.Context, ethConfig *ethconfig.Config) {
+	if enabled := ctx.Bool(BundlesEnabledFlag.Name); !enabled {
+		return
+	}
+
+	config := bundlecfg.DefaultConfig
+	config.Enabled = true
+	config.MaxBundles = ctx.Int(BundlesMaxFlag.Name)
+	ethConfig.Bundles = config
+}
+
 func setEthash(ctx *cli.Context, datadir string, cfg *ethconfig.Config) {
 	if ctx.IsSet(EthashDatasetDirFlag.Name) {
 		cfg.Ethash.DatasetDir = ctx.String(EthashDatasetDirFlag.Name)
@@ -1700,6 +1793,8 @@ func setClique(ctx *cli.Context, cfg *chainspec.ConsensusSnapshotConfig, datadir
 
 func setBorConfig(ctx *cli.Context, cfg *ethconfig.Config, nodeConfig *nodecfg.Config, logger log.Logger) {
 	cfg.HeimdallURL = ctx.String(HeimdallURLFlag.Name)
+	cfg.HeimdallGrpcAddrs = common.CliString2Array(ctx.String(HeimdallGrpcFlag.Name))
+	cfg.HeimdallRace = ctx.Bool(HeimdallRaceFlag.Name)
 	cfg.WithoutHeimdall = ctx.Bool(WithoutHeimdallFlag.Name)
 
 	heimdall.RecordWayPoints(true)
@@ -1832,6 +1927,17 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	// bunch of extra stuff
 	cfg.CaplinConfig.MevRelayUrl = ctx.String(CaplinMevRelayUrl.Name)
 	cfg.CaplinConfig.EnableValidatorMonitor = ctx.Bool(CaplinValidatorMonitorFlag.Name)
+	cfg.CaplinConfig.ValidatorClient = ctx.Bool(CaplinValidatorClientFlag.Name)
+	cfg.CaplinConfig.ValidatorKeystoresDir = ctx.String(CaplinValidatorKeystoresDirFlag.Name)
+	if feeRecipient := ctx.String(CaplinValidatorFeeRecipientFlag.Name); feeRecipient != "" {
+		if !common.IsHexAddress(feeRecipient) {
+			Fatalf("Invalid --%s: %s", CaplinValidatorFeeRecipientFlag.Name, feeRecipient)
+		}
+		cfg.CaplinConfig.ValidatorFeeRecipient = common.HexToAddress(feeRecipient)
+	}
+	cfg.CaplinConfig.ValidatorGraffiti = ctx.String(CaplinValidatorGraffitiFlag.Name)
+	cfg.CaplinConfig.KeymanagerAddr = ctx.String(CaplinKeymanagerAddrFlag.Name)
+	cfg.CaplinConfig.KeymanagerTokenFile = ctx.String(CaplinKeymanagerTokenFileFlag.Name)
 	if checkpointUrls := ctx.StringSlice(CaplinCheckpointSyncUrlFlag.Name); len(checkpointUrls) > 0 {
 		clparams.ConfigurableCheckpointsURLs = checkpointUrls
 	}
@@ -1960,6 +2066,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 
 	setTxPool(ctx, nodeConfig.Dirs.TxPool, cfg)
 	setShutter(ctx, chain, nodeConfig, cfg)
+	setBundles(ctx, cfg)
 
 	setEthash(ctx, nodeConfig.Dirs.DataDir, cfg)
 	setClique(ctx, &cfg.Clique, nodeConfig.Dirs.DataDir)
@@ -1991,6 +2098,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	cfg.SnapServer = ctx.Bool(P2pSnapServerFlag.Name)
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs = []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -2171,6 +2279,8 @@ func CobraFlags(cmd *cobra.Command, urfaveCliFlagsLists ...[]cli.Flag) {
 				flags.String(f.Name, f.Value, f.Usage)
 			case *cli.BoolFlag:
 				flags.Bool(f.Name, false, f.Usage)
+			case *cli.Float64Flag:
+				flags.Float64(f.Name, f.Value, f.Usage)
 			default:
 				panic(fmt.Errorf("unexpected type: %T", flag))
 			}
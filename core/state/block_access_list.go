@@ -0,0 +1,212 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// BlockAccessListBuilder collects the EIP-7928 access list of a block. An IntraBlockState
+// records into it (see IntraBlockState.SetBlockAccessList) and the executor merges the
+// per-transaction builders in block order before calling Build.
+type BlockAccessListBuilder struct {
+	accounts map[common.Address]*accountAccesses
+}
+
+type accountAccesses struct {
+	storage map[common.Hash][]types.StorageChange
+	reads   map[common.Hash]struct{}
+	balance []types.BalanceChange
+	nonce   []types.NonceChange
+	code    []types.CodeChange
+}
+
+func NewBlockAccessListBuilder() *BlockAccessListBuilder {
+	return &BlockAccessListBuilder{accounts: map[common.Address]*accountAccesses{}}
+}
+
+func (b *BlockAccessListBuilder) account(addr common.Address) *accountAccesses {
+	acc, ok := b.accounts[addr]
+	if !ok {
+		acc = &accountAccesses{storage: map[common.Hash][]types.StorageChange{}, reads: map[common.Hash]struct{}{}}
+		b.accounts[addr] = acc
+	}
+	return acc
+}
+
+// AccountRead records that addr was accessed, even if none of its fields changes.
+func (b *BlockAccessListBuilder) AccountRead(addr common.Address) {
+	b.account(addr)
+}
+
+func (b *BlockAccessListBuilder) StorageRead(addr common.Address, key common.Hash) {
+	b.account(addr).reads[key] = struct{}{}
+}
+
+// StorageWrite records the value of a slot after the access at index. prev is the value
+// before the access and is only consulted if the slot has no earlier change in this block.
+func (b *BlockAccessListBuilder) StorageWrite(index uint16, addr common.Address, key common.Hash, prev, value uint256.Int) {
+	acc := b.account(addr)
+	changes := acc.storage[key]
+	v := common.Hash(value.Bytes32())
+	if n := len(changes); n > 0 {
+		if changes[n-1].Value == v {
+			return
+		}
+		if changes[n-1].Index == index {
+			changes[n-1].Value = v
+			return
+		}
+	} else if prev.Eq(&value) {
+		// a write of the current value is a read as far as the access list is concerned
+		acc.reads[key] = struct{}{}
+		return
+	}
+	acc.storage[key] = append(changes, types.StorageChange{Index: index, Value: v})
+}
+
+func (b *BlockAccessListBuilder) BalanceChange(index uint16, addr common.Address, prev, value uint256.Int) {
+	acc := b.account(addr)
+	if n := len(acc.balance); n > 0 {
+		if acc.balance[n-1].Balance.Eq(&value) {
+			return
+		}
+		if acc.balance[n-1].Index == index {
+			acc.balance[n-1].Balance = value
+			return
+		}
+	} else if prev.Eq(&value) {
+		return
+	}
+	acc.balance = append(acc.balance, types.BalanceChange{Index: index, Balance: value})
+}
+
+func (b *BlockAccessListBuilder) NonceChange(index uint16, addr common.Address, prev, value uint64) {
+	acc := b.account(addr)
+	if n := len(acc.nonce); n > 0 {
+		if acc.nonce[n-1].Nonce == value {
+			return
+		}
+		if acc.nonce[n-1].Index == index {
+			acc.nonce[n-1].Nonce = value
+			return
+		}
+	} else if prev == value {
+		return
+	}
+	acc.nonce = append(acc.nonce, types.NonceChange{Index: index, Nonce: value})
+}
+
+// CodeChange records the code of addr after the access at index. prevHash is the code hash
+// before the access (zero for an account that did not exist) and is only consulted if the
+// account has no earlier code change.
+func (b *BlockAccessListBuilder) CodeChange(index uint16, addr common.Address, prevHash common.Hash, code []byte) {
+	acc := b.account(addr)
+	if n := len(acc.code); n > 0 {
+		if bytes.Equal(acc.code[n-1].Code, code) {
+			return
+		}
+		if acc.code[n-1].Index == index {
+			acc.code[n-1].Code = common.Copy(code)
+			return
+		}
+	} else if prevHash == codeHash(code) || (prevHash == common.Hash{} && len(code) == 0) {
+		return
+	}
+	acc.code = append(acc.code, types.CodeChange{Index: index, Code: common.Copy(code)})
+}
+
+// Merge appends the accesses recorded by other, which must only hold indices after the ones
+// already in b.
+func (b *BlockAccessListBuilder) Merge(other *BlockAccessListBuilder) {
+	for addr, o := range other.accounts {
+		acc := b.account(addr)
+		for key := range o.reads {
+			acc.reads[key] = struct{}{}
+		}
+		for key, changes := range o.storage {
+			for _, c := range changes {
+				if n := len(acc.storage[key]); n == 0 || acc.storage[key][n-1].Value != c.Value {
+					acc.storage[key] = append(acc.storage[key], c)
+				}
+			}
+		}
+		for _, c := range o.balance {
+			if n := len(acc.balance); n == 0 || !acc.balance[n-1].Balance.Eq(&c.Balance) {
+				acc.balance = append(acc.balance, c)
+			}
+		}
+		for _, c := range o.nonce {
+			if n := len(acc.nonce); n == 0 || acc.nonce[n-1].Nonce != c.Nonce {
+				acc.nonce = append(acc.nonce, c)
+			}
+		}
+		for _, c := range o.code {
+			if n := len(acc.code); n == 0 || !bytes.Equal(acc.code[n-1].Code, c.Code) {
+				acc.code = append(acc.code, c)
+			}
+		}
+	}
+}
+
+// Build returns the access list in canonical order. Slots that were written are dropped
+// from the reads, and the system address is only kept if its state changed.
+func (b *BlockAccessListBuilder) Build() types.BlockAccessList {
+	bal := make(types.BlockAccessList, 0, len(b.accounts))
+	for addr, acc := range b.accounts {
+		changes := types.AccountChanges{
+			Address:        addr,
+			BalanceChanges: acc.balance,
+			NonceChanges:   acc.nonce,
+			CodeChanges:    acc.code,
+		}
+		for key, slotChanges := range acc.storage {
+			if len(slotChanges) > 0 {
+				changes.StorageChanges = append(changes.StorageChanges, types.SlotChanges{Slot: key, Changes: slotChanges})
+			}
+		}
+		for key := range acc.reads {
+			if len(acc.storage[key]) == 0 {
+				changes.StorageReads = append(changes.StorageReads, key)
+			}
+		}
+		if addr == SystemAddress && len(changes.StorageChanges) == 0 && len(changes.BalanceChanges) == 0 &&
+			len(changes.NonceChanges) == 0 && len(changes.CodeChanges) == 0 {
+			continue
+		}
+		slices.SortFunc(changes.StorageChanges, func(a, b types.SlotChanges) int { return bytes.Compare(a.Slot[:], b.Slot[:]) })
+		slices.SortFunc(changes.StorageReads, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+		bal = append(bal, changes)
+	}
+	slices.SortFunc(bal, func(a, b types.AccountChanges) int { return bytes.Compare(a.Address[:], b.Address[:]) })
+	return bal
+}
+
+func codeHash(code []byte) common.Hash {
+	if len(code) == 0 {
+		return empty.CodeHash
+	}
+	return crypto.Keccak256Hash(code)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	stateLib "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+func newBlockAccessListTestState(t *testing.T) *IntraBlockState {
+	t.Helper()
+	_, tx, _ := NewTestTemporalDb(t)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	t.Cleanup(domains.Close)
+	require.NoError(t, rawdbv3.TxNums.Append(tx, 1, 1))
+	return New(NewReaderV3(domains.AsGetter(tx)))
+}
+
+func TestBlockAccessListRecording(t *testing.T) {
+	t.Parallel()
+	a, b, c := common.Address{0xa}, common.Address{0xb}, common.Address{0xc}
+	slot1, slot2 := common.Hash{1}, common.Hash{2}
+	rules := &chain.Rules{}
+
+	ibs := newBlockAccessListTestState(t)
+	builder := NewBlockAccessListBuilder()
+
+	ibs.SetBlockAccessList(builder, 1)
+	require.NoError(t, ibs.AddBalance(a, *uint256.NewInt(100), tracing.BalanceChangeUnspecified))
+	require.NoError(t, ibs.SetNonce(a, 1))
+	require.NoError(t, ibs.SetState(b, slot1, *uint256.NewInt(5)))
+	var value uint256.Int
+	require.NoError(t, ibs.GetState(c, slot2, &value))
+	require.NoError(t, ibs.FinalizeTx(rules, NewNoopWriter()))
+
+	ibs.SetBlockAccessList(builder, 2)
+	require.NoError(t, ibs.SetState(b, slot1, *uint256.NewInt(5))) // unchanged
+	require.NoError(t, ibs.SubBalance(a, *uint256.NewInt(40), tracing.BalanceChangeUnspecified))
+	require.NoError(t, ibs.FinalizeTx(rules, NewNoopWriter()))
+
+	bal := builder.Build()
+	require.NoError(t, bal.Validate())
+	require.Len(t, bal, 3)
+
+	require.Equal(t, a, bal[0].Address)
+	require.Equal(t, []types.BalanceChange{{Index: 1, Balance: *uint256.NewInt(100)}, {Index: 2, Balance: *uint256.NewInt(60)}}, bal[0].BalanceChanges)
+	require.Equal(t, []types.NonceChange{{Index: 1, Nonce: 1}}, bal[0].NonceChanges)
+
+	require.Equal(t, b, bal[1].Address)
+	require.Equal(t, []types.SlotChanges{{Slot: slot1, Changes: []types.StorageChange{{Index: 1, Value: common.Hash(uint256.NewInt(5).Bytes32())}}}}, bal[1].StorageChanges)
+	require.Empty(t, bal[1].StorageReads)
+
+	require.Equal(t, c, bal[2].Address)
+	require.Equal(t, []common.Hash{slot2}, bal[2].StorageReads)
+	require.Empty(t, bal[2].BalanceChanges)
+}
+
+func TestBlockAccessListMerge(t *testing.T) {
+	t.Parallel()
+	a := common.Address{0xa}
+	slot := common.Hash{1}
+
+	tx1 := NewBlockAccessListBuilder()
+	tx1.StorageWrite(1, a, slot, *uint256.NewInt(0), *uint256.NewInt(7))
+	tx1.BalanceChange(1, a, *uint256.NewInt(0), *uint256.NewInt(10))
+	tx2 := NewBlockAccessListBuilder()
+	tx2.StorageWrite(2, a, slot, *uint256.NewInt(7), *uint256.NewInt(7)) // rewrite of the same value
+	tx2.BalanceChange(2, a, *uint256.NewInt(10), *uint256.NewInt(3))
+	tx2.CodeChange(2, a, common.Hash{}, nil) // account did not exist and still has no code
+
+	block := NewBlockAccessListBuilder()
+	block.Merge(tx1)
+	block.Merge(tx2)
+	bal := block.Build()
+	require.NoError(t, bal.Validate())
+	require.Len(t, bal, 1)
+	require.Len(t, bal[0].StorageChanges, 1)
+	require.Len(t, bal[0].StorageChanges[0].Changes, 1)
+	require.Empty(t, bal[0].StorageReads)
+	require.Len(t, bal[0].BalanceChanges, 2)
+	require.Empty(t, bal[0].CodeChanges)
+
+	// the system address is dropped unless its state changed
+	sys := NewBlockAccessListBuilder()
+	sys.AccountRead(SystemAddress)
+	require.Empty(t, sys.Build())
+}
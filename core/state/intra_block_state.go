@@ -98,6 +98,10 @@ type IntraBlockState struct {
 	tracingHooks   *tracing.Hooks
 	balanceInc     map[common.Address]*BalanceIncrease // Map of balance increases (without first reading the account)
 
+	// EIP-7928 block access list the accesses are recorded into, if any
+	bal      *BlockAccessListBuilder
+	balIndex uint16
+
 	// Versioned storage used for parallel tx processing, versions
 	// are maintaned across transactions until they are reset
 	// at the block level
@@ -195,6 +199,13 @@ func (sdb *IntraBlockState) IsVersioned() bool {
 	return sdb.versionMap != nil
 }
 
+// SetBlockAccessList makes the state record every account and slot it touches, and the
+// values it writes at the given block access index, into bal. A nil bal stops recording.
+func (sdb *IntraBlockState) SetBlockAccessList(bal *BlockAccessListBuilder, index uint16) {
+	sdb.bal = bal
+	sdb.balIndex = index
+}
+
 func (sdb *IntraBlockState) SetHooks(hooks *tracing.Hooks) {
 	sdb.tracingHooks = hooks
 }
@@ -561,6 +572,9 @@ func (sdb *IntraBlockState) GetDelegatedDesignation(addr common.Address) (common
 // GetState retrieves a value from the given account's storage trie.
 // DESCRIBED: docs/programmers_guide/guide.md#address---identifier-of-an-account
 func (sdb *IntraBlockState) GetState(addr common.Address, key common.Hash, value *uint256.Int) error {
+	if sdb.bal != nil {
+		sdb.bal.StorageRead(addr, key)
+	}
 	versionedValue, source, err := versionedRead(sdb, addr, StatePath, key, false, *u256.N0,
 		func(v uint256.Int) uint256.Int {
 			return v
@@ -585,6 +599,9 @@ func (sdb *IntraBlockState) GetState(addr common.Address, key common.Hash, value
 // GetCommittedState retrieves a value from the given account's committed storage trie.
 // DESCRIBED: docs/programmers_guide/guide.md#address---identifier-of-an-account
 func (sdb *IntraBlockState) GetCommittedState(addr common.Address, key common.Hash, value *uint256.Int) error {
+	if sdb.bal != nil {
+		sdb.bal.StorageRead(addr, key)
+	}
 	versionedValue, source, err := versionedRead(sdb, addr, StatePath, key, true, *u256.N0,
 		func(v uint256.Int) uint256.Int {
 			return v
@@ -990,6 +1007,9 @@ func (sdb *IntraBlockState) GetTransientState(addr common.Address, key common.Ha
 }
 
 func (sdb *IntraBlockState) getStateObject(addr common.Address) (*stateObject, error) {
+	if sdb.bal != nil {
+		sdb.bal.AccountRead(addr)
+	}
 	if so, ok := sdb.stateObjects[addr]; ok {
 		return so, nil
 	}
@@ -1256,6 +1276,12 @@ func updateAccount(EIP161Enabled bool, isAura bool, stateWriter StateWriter, add
 		if err := stateWriter.DeleteAccount(addr, &stateObject.original); err != nil {
 			return err
 		}
+		if bal := stateObject.db.bal; bal != nil {
+			index := stateObject.db.balIndex
+			bal.BalanceChange(index, addr, stateObject.original.Balance, uint256.Int{})
+			bal.NonceChange(index, addr, stateObject.original.Nonce, 0)
+			bal.CodeChange(index, addr, stateObject.original.CodeHash, nil)
+		}
 		stateObject.deleted = true
 	}
 	if isDirty && (stateObject.createdContract || !stateObject.selfdestructed) && !emptyRemoval {
@@ -1265,6 +1291,9 @@ func updateAccount(EIP161Enabled bool, isAura bool, stateWriter StateWriter, add
 			if err := stateWriter.UpdateAccountCode(addr, stateObject.data.Incarnation, stateObject.data.CodeHash, stateObject.code); err != nil {
 				return err
 			}
+			if bal := stateObject.db.bal; bal != nil {
+				bal.CodeChange(stateObject.db.balIndex, addr, stateObject.original.CodeHash, stateObject.code)
+			}
 		}
 		if stateObject.createdContract {
 			if err := stateWriter.CreateContract(addr); err != nil {
@@ -1280,6 +1309,11 @@ func updateAccount(EIP161Enabled bool, isAura bool, stateWriter StateWriter, add
 		if err := stateWriter.UpdateAccountData(addr, &stateObject.original, &stateObject.data); err != nil {
 			return err
 		}
+		if bal := stateObject.db.bal; bal != nil {
+			index := stateObject.db.balIndex
+			bal.BalanceChange(index, addr, stateObject.original.Balance, stateObject.data.Balance)
+			bal.NonceChange(index, addr, stateObject.original.Nonce, stateObject.data.Nonce)
+		}
 	}
 	return nil
 }
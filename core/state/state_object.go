@@ -289,6 +289,9 @@ func (so *stateObject) updateStotage(stateWriter StateWriter) error {
 		if err := stateWriter.WriteAccountStorage(so.address, so.data.GetIncarnation(), key, so.blockOriginStorage[key], value); err != nil {
 			return err
 		}
+		if so.db.bal != nil {
+			so.db.bal.StorageWrite(so.db.balIndex, so.address, key, so.originStorage[key], value)
+		}
 		so.originStorage[key] = value
 	}
 	return nil
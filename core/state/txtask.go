@@ -82,6 +82,9 @@ type TxTask struct {
 
 	GasUsed uint64
 
+	// BlockAccessList holds the EIP-7928 accesses recorded while executing this task.
+	BlockAccessList *BlockAccessListBuilder
+
 	// BlockReceipts is used only by Gnosis:
 	//  - it does store `proof, err := rlp.EncodeToBytes(ValidatorSetProof{Header: header, Receipts: r})`
 	//  - and later read it by filter: len(l.Topics) == 2 && l.Address == s.contractAddress && l.Topics[0] == EVENT_NAME_HASH && l.Topics[1] == header.ParentHash
@@ -203,6 +206,7 @@ func (t *TxTask) Reset() *TxTask {
 	t.Logs = nil
 	t.TraceFroms = nil
 	t.TraceTos = nil
+	t.BlockAccessList = nil
 	t.Error = nil
 	t.Failed = false
 	return t
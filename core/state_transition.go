@@ -386,7 +386,7 @@ func (st *StateTransition) ApplyFrame() (*evmtypes.ExecutionResult, error) {
 	// Execute the preparatory steps for state transition which includes:
 	// - prepare accessList(post-berlin; eip-7702)
 	// - reset transient storage(eip 1153)
-	st.state.Prepare(rules, msg.From(), coinbase, msg.To(), vm.ActivePrecompiles(rules), accessTuples, verifiedAuthorities)
+	st.state.Prepare(rules, msg.From(), coinbase, msg.To(), st.evm.ActivePrecompiles(), accessTuples, verifiedAuthorities)
 
 	var (
 		ret   []byte
@@ -538,7 +538,7 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (result *
 	// Execute the preparatory steps for state transition which includes:
 	// - prepare accessList(post-berlin; eip-7702)
 	// - reset transient storage(eip 1153)
-	if err = st.state.Prepare(rules, msg.From(), coinbase, msg.To(), vm.ActivePrecompiles(rules), accessTuples, verifiedAuthorities); err != nil {
+	if err = st.state.Prepare(rules, msg.From(), coinbase, msg.To(), st.evm.ActivePrecompiles(), accessTuples, verifiedAuthorities); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrStateTransitionFailed, err)
 	}
 	var (
@@ -141,6 +141,10 @@ type (
 	// beacon block root.
 	OnSystemCallEndHook = func()
 
+	// UnwindHook is called after execution has been unwound to `unwindPoint`. Blocks above
+	// `unwindPoint` are no longer canonical and will be re-executed (possibly different ones).
+	UnwindHook = func(unwindPoint uint64)
+
 	/*
 		- State events -
 	*/
@@ -177,6 +181,7 @@ type Hooks struct {
 	OnGenesisBlock    GenesisBlockHook
 	OnSystemCallStart OnSystemCallStartHook
 	OnSystemCallEnd   OnSystemCallEndHook
+	OnUnwind          UnwindHook
 	// State events
 	OnBalanceChange BalanceChangeHook
 	OnNonceChange   NonceChangeHook
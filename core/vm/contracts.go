@@ -23,6 +23,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
+	"maps"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -55,6 +56,9 @@ type PrecompiledContract interface {
 	Run(input []byte) ([]byte, error) // Run runs the precompiled contract
 }
 
+// PrecompiledContracts maps addresses to the precompiled contracts living there.
+type PrecompiledContracts map[common.Address]PrecompiledContract
+
 // NOTE: THE STRUCT NAMES ARE USED IN jsonrpc/eth_system.go
 
 // PrecompiledContractsHomestead contains the default set of pre-compiled Ethereum
@@ -260,6 +264,35 @@ func ActivePrecompiles(rules *chain.Rules) []common.Address {
 	}
 }
 
+// ActivePrecompiledContracts returns a copy of the precompiled contracts enabled with the current configuration.
+// The copy can be freely modified, e.g. to move precompiles to other addresses.
+func ActivePrecompiledContracts(rules *chain.Rules) PrecompiledContracts {
+	return maps.Clone(activePrecompiledContracts(rules))
+}
+
+func activePrecompiledContracts(rules *chain.Rules) PrecompiledContracts {
+	switch {
+	case rules.IsOsaka:
+		return PrecompiledContractsOsaka
+	case rules.IsBhilai:
+		return PrecompiledContractsBhilai
+	case rules.IsPrague:
+		return PrecompiledContractsPrague
+	case rules.IsNapoli:
+		return PrecompiledContractsNapoli
+	case rules.IsCancun:
+		return PrecompiledContractsCancun
+	case rules.IsBerlin:
+		return PrecompiledContractsBerlin
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 // It returns
 // - the returned bytes,
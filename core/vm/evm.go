@@ -22,6 +22,7 @@ package vm
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sync/atomic"
 
 	"github.com/holiman/uint256"
@@ -41,26 +42,9 @@ import (
 var emptyHash = common.Hash{}
 
 func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
-	var precompiles map[common.Address]PrecompiledContract
-	switch {
-	case evm.chainRules.IsOsaka:
-		precompiles = PrecompiledContractsOsaka
-	case evm.chainRules.IsBhilai:
-		precompiles = PrecompiledContractsBhilai
-	case evm.chainRules.IsPrague:
-		precompiles = PrecompiledContractsPrague
-	case evm.chainRules.IsNapoli:
-		precompiles = PrecompiledContractsNapoli
-	case evm.chainRules.IsCancun:
-		precompiles = PrecompiledContractsCancun
-	case evm.chainRules.IsBerlin:
-		precompiles = PrecompiledContractsBerlin
-	case evm.chainRules.IsIstanbul:
-		precompiles = PrecompiledContractsIstanbul
-	case evm.chainRules.IsByzantium:
-		precompiles = PrecompiledContractsByzantium
-	default:
-		precompiles = PrecompiledContractsHomestead
+	precompiles := evm.precompiles
+	if precompiles == nil {
+		precompiles = activePrecompiledContracts(evm.chainRules)
 	}
 	p, ok := precompiles[addr]
 	return p, ok
@@ -98,6 +82,9 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// precompiles overrides the set of precompiled contracts selected by the chain rules (used by call simulations)
+	precompiles         PrecompiledContracts
+	precompileAddresses []common.Address // sorted addresses of precompiles, set together with precompiles
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -156,6 +143,29 @@ func (evm *EVM) ResetBetweenBlocks(blockCtx evmtypes.BlockContext, txCtx evmtype
 	evm.abort.Store(false)
 }
 
+// SetPrecompiles replaces the precompiled contracts used by the EVM, regardless of the chain rules.
+// It is meant for call simulations which move or override precompiles.
+func (evm *EVM) SetPrecompiles(precompiles PrecompiledContracts) {
+	evm.precompiles = precompiles
+	evm.precompileAddresses = nil
+	if precompiles == nil {
+		return
+	}
+	evm.precompileAddresses = make([]common.Address, 0, len(precompiles))
+	for addr := range precompiles {
+		evm.precompileAddresses = append(evm.precompileAddresses, addr)
+	}
+	slices.SortFunc(evm.precompileAddresses, func(a, b common.Address) int { return a.Cmp(b) })
+}
+
+// ActivePrecompiles returns the addresses of the precompiles used by the EVM.
+func (evm *EVM) ActivePrecompiles() []common.Address {
+	if evm.precompiles == nil {
+		return ActivePrecompiles(evm.chainRules)
+	}
+	return evm.precompileAddresses
+}
+
 // Cancel cancels any running EVM operation. This may be called concurrently and
 // it's safe to be called multiple times.
 func (evm *EVM) Cancel() { evm.abort.Store(true) }
@@ -0,0 +1,427 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package objectstore is a native client for S3-compatible object stores (AWS S3, MinIO, R2, ...)
+// used to publish and mirror snapshot files without an external rclone process.
+package objectstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Scheme = "s3://"
+
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	metaInfoHash     = "X-Amz-Meta-Btih"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// Error is an error response of the object store.
+type Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("objectstore: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+type Config struct {
+	// Endpoint of the store, e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+// ConfigFromURL parses a s3://bucket/prefix location. The endpoint, region and credentials are
+// taken from the standard AWS_ENDPOINT_URL, AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
+// environment variables; without credentials requests are anonymous.
+func ConfigFromURL(location string) (Config, error) {
+	if !IsURL(location) {
+		return Config{}, fmt.Errorf("objectstore: %s is not a %s location", location, Scheme)
+	}
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(location, Scheme), "/")
+	if bucket == "" {
+		return Config{}, fmt.Errorf("objectstore: no bucket in %s", location)
+	}
+	cfg := Config{
+		Endpoint:  os.Getenv("AWS_ENDPOINT_URL"),
+		Region:    os.Getenv("AWS_REGION"),
+		Bucket:    bucket,
+		Prefix:    strings.Trim(prefix, "/"),
+		AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	return cfg, nil
+}
+
+func IsURL(location string) bool {
+	return strings.HasPrefix(location, Scheme)
+}
+
+// Client talks to a single bucket using path-style addressing, which every S3-compatible store
+// supports. Object keys passed to its methods are relative to the configured prefix.
+type Client struct {
+	cfg        Config
+	endpoint   *url.URL
+	httpClient *http.Client
+}
+
+func NewClient(cfg Config, httpClient *http.Client) (*Client, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("objectstore: invalid endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("objectstore: invalid endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("objectstore: bucket is not set")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{cfg: cfg, endpoint: endpoint, httpClient: httpClient}, nil
+}
+
+// BaseURL is the url of the prefix, usable as a webseed when the bucket is public.
+func (c *Client) BaseURL() *url.URL {
+	return c.endpoint.JoinPath(c.cfg.Bucket, c.cfg.Prefix)
+}
+
+func (c *Client) String() string {
+	return Scheme + strings.TrimSuffix(c.cfg.Bucket+"/"+c.cfg.Prefix, "/")
+}
+
+func (c *Client) objectKey(key string) string {
+	if c.cfg.Prefix == "" {
+		return key
+	}
+	return c.cfg.Prefix + "/" + key
+}
+
+// Object describes a stored object.
+type Object struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	// InfoHash is the torrent info hash the object was uploaded with, when known
+	InfoHash string
+}
+
+type request struct {
+	method      string
+	key         string
+	query       url.Values
+	header      http.Header
+	body        io.Reader
+	size        int64
+	payloadHash string
+}
+
+func (c *Client) do(ctx context.Context, r request) (*http.Response, error) {
+	u := *c.endpoint
+	u.Path = "/" + c.cfg.Bucket
+	if r.key != "" {
+		u.Path += "/" + c.objectKey(r.key)
+	}
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(r.query)
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), r.body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	if r.body != nil {
+		req.ContentLength = r.size
+		if r.size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	if r.payloadHash == "" {
+		r.payloadHash = emptyPayloadHash
+	}
+	c.sign(req, r.payloadHash, time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp, nil
+}
+
+func responseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	e := &Error{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := xml.Unmarshal(body, e); err != nil || e.Code == "" {
+		e.Code, e.Message = resp.Status, strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+// sign adds AWS Signature Version 4 headers to req, unless the client is anonymous.
+func (c *Client) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.cfg.AccessKey == "" {
+		return
+	}
+
+	signed := []string{"host"}
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if strings.HasPrefix(k, "x-amz-") || k == "content-md5" || k == "content-type" || k == "range" {
+			signed = append(signed, k)
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	sort.Strings(signed)
+	var canonicalHeaders strings.Builder
+	for _, k := range signed {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	date := amzDate[:8]
+	scope := date + "/" + c.cfg.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+c.cfg.SecretKey), date)
+	key = hmacSHA256(key, c.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// uriEncode encodes s the way SigV4 expects: everything but the unreserved characters, and '/'
+// unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~':
+			b.WriteByte(ch)
+		case ch == '/' && !encodeSlash:
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func decodeXML(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	return xml.NewDecoder(resp.Body).Decode(v)
+}
+
+func objectFromHeader(key string, h http.Header) Object {
+	size, _ := strconv.ParseInt(h.Get("Content-Length"), 10, 64)
+	if _, total, ok := strings.Cut(h.Get("Content-Range"), "/"); ok {
+		size, _ = strconv.ParseInt(total, 10, 64)
+	}
+	modified, _ := http.ParseTime(h.Get("Last-Modified"))
+	return Object{
+		Key:          key,
+		Size:         size,
+		ETag:         strings.Trim(h.Get("ETag"), `"`),
+		LastModified: modified,
+		InfoHash:     h.Get(metaInfoHash),
+	}
+}
+
+// Head returns the description of the object at key, or ErrNotFound.
+func (c *Client) Head(ctx context.Context, key string) (Object, error) {
+	resp, err := c.do(ctx, request{method: http.MethodHead, key: key})
+	if err != nil {
+		return Object{}, err
+	}
+	resp.Body.Close()
+	return objectFromHeader(key, resp.Header), nil
+}
+
+// Get returns the content of the object at key from offset on. A non-empty etag makes the
+// request fail if the object has been replaced in the meantime.
+func (c *Client) Get(ctx context.Context, key string, offset int64, etag string) (io.ReadCloser, Object, error) {
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	if etag != "" {
+		header.Set("If-Match", `"`+etag+`"`)
+	}
+	resp, err := c.do(ctx, request{method: http.MethodGet, key: key, header: header})
+	if err != nil {
+		return nil, Object{}, err
+	}
+	if offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		return nil, Object{}, fmt.Errorf("objectstore: range request for %s answered with %s", key, resp.Status)
+	}
+	return resp.Body, objectFromHeader(key, resp.Header), nil
+}
+
+// Put stores size bytes read from body at key in a single request. The sha256 and md5 of the
+// content are sent along, so the store refuses anything that got corrupted on the way.
+func (c *Client) Put(ctx context.Context, key string, body io.ReadSeeker, size int64, infoHash string) (string, error) {
+	sum, err := hashPayload(body, size)
+	if err != nil {
+		return "", err
+	}
+	header := sum.header()
+	if infoHash != "" {
+		header.Set(metaInfoHash, infoHash)
+	}
+	resp, err := c.do(ctx, request{method: http.MethodPut, key: key, header: header, body: body, size: size, payloadHash: sum.sha256})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return sum.checkETag(key, resp.Header.Get("ETag"))
+}
+
+// Delete removes the object at key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, request{method: http.MethodDelete, key: key})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		ETag         string    `xml:"ETag"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns the objects under the prefix, with keys relative to it. Objects in
+// subdirectories are included.
+func (c *Client) List(ctx context.Context) ([]Object, error) {
+	prefix := c.objectKey("")
+	var objects []Object
+	var token string
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := c.do(ctx, request{method: http.MethodGet, query: query})
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		if err := decodeXML(resp, &result); err != nil {
+			return nil, fmt.Errorf("objectstore: can't decode list: %w", err)
+		}
+		for _, o := range result.Contents {
+			key := strings.TrimPrefix(o.Key, prefix)
+			if key == "" || strings.HasSuffix(key, "/") {
+				continue
+			}
+			objects = append(objects, Object{Key: key, Size: o.Size, ETag: strings.Trim(o.ETag, `"`), LastModified: o.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package objectstore
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// PieceLength of the torrents the downloader builds, see downloadercfg.DefaultPieceSize
+const PieceLength = 2 * 1024 * 1024
+
+// InfoHash returns the hex v1 info hash of the single-file torrent named name built from the
+// file at path: the hash chain.toml, and preverified.toml in the datadir, pin snapshot files with.
+// It is the one the downloader gets for the file from metainfo.Info.BuildFromFilePath.
+func InfoHash(path, name string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var pieces bytes.Buffer
+	var length int64
+	buf := make([]byte, PieceLength)
+	for {
+		n, err := io.ReadFull(f, buf)
+		if n > 0 {
+			sum := sha1.Sum(buf[:n]) //nolint:gosec
+			pieces.Write(sum[:])
+			length += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("infohash: %s: %w", path, err)
+		}
+	}
+
+	// the bencoded info dictionary, keys sorted, empty values omitted
+	var info bytes.Buffer
+	info.WriteString("d")
+	if length > 0 {
+		info.WriteString("6:lengthi" + strconv.FormatInt(length, 10) + "e")
+	}
+	info.WriteString("4:name" + strconv.Itoa(len(name)) + ":" + name)
+	info.WriteString("12:piece lengthi" + strconv.Itoa(PieceLength) + "e")
+	if pieces.Len() > 0 {
+		info.WriteString("6:pieces" + strconv.Itoa(pieces.Len()) + ":")
+		info.Write(pieces.Bytes())
+	}
+	info.WriteString("e")
+	sum := sha1.Sum(info.Bytes()) //nolint:gosec
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,328 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const (
+	// DefaultPartSize of multipart uploads, grown for files which would need more than maxParts
+	DefaultPartSize = 64 * 1024 * 1024
+	minPartSize     = 5 * 1024 * 1024
+	maxParts        = 10_000
+)
+
+type payloadSum struct {
+	sha256 string
+	md5    []byte
+}
+
+// hashPayload hashes the size bytes of body and rewinds it.
+func hashPayload(body io.ReadSeeker, size int64) (payloadSum, error) {
+	sha, md := sha256.New(), md5.New() //nolint:gosec
+	if _, err := io.CopyN(io.MultiWriter(sha, md), body, size); err != nil {
+		return payloadSum{}, err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return payloadSum{}, err
+	}
+	return payloadSum{sha256: hex.EncodeToString(sha.Sum(nil)), md5: md.Sum(nil)}, nil
+}
+
+func (s payloadSum) header() http.Header {
+	return http.Header{"Content-Md5": {base64.StdEncoding.EncodeToString(s.md5)}}
+}
+
+// checkETag compares the etag of a single-request upload, the md5 of the content on every
+// S3-compatible store unless it encrypts with its own keys, with what has been sent.
+func (s payloadSum) checkETag(key, etag string) (string, error) {
+	etag = strings.Trim(etag, `"`)
+	if len(etag) == 2*md5.Size && etag != hex.EncodeToString(s.md5) {
+		return "", fmt.Errorf("objectstore: %s: stored etag %s does not match md5 %x", key, etag, s.md5)
+	}
+	return etag, nil
+}
+
+type initiateMultipartUploadResult struct {
+	UploadId string `xml:"UploadId"`
+}
+
+type listMultipartUploadsResult struct {
+	Uploads []struct {
+		Key      string `xml:"Key"`
+		UploadId string `xml:"UploadId"`
+	} `xml:"Upload"`
+}
+
+type part struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+	Size       int64  `xml:"Size,omitempty"`
+}
+
+type listPartsResult struct {
+	Parts                []part `xml:"Part"`
+	IsTruncated          bool   `xml:"IsTruncated"`
+	NextPartNumberMarker int    `xml:"NextPartNumberMarker"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []part   `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	ETag  string `xml:"ETag"`
+	Code  string `xml:"Code"`
+	Error string `xml:"Message"`
+}
+
+func (c *Client) createMultipartUpload(ctx context.Context, key, infoHash string) (string, error) {
+	header := http.Header{}
+	if infoHash != "" {
+		header.Set(metaInfoHash, infoHash)
+	}
+	resp, err := c.do(ctx, request{method: http.MethodPost, key: key, query: url.Values{"uploads": {""}}, header: header})
+	if err != nil {
+		return "", err
+	}
+	var result initiateMultipartUploadResult
+	if err := decodeXML(resp, &result); err != nil {
+		return "", fmt.Errorf("objectstore: can't decode multipart upload: %w", err)
+	}
+	return result.UploadId, nil
+}
+
+// pendingUploads returns the ids of the unfinished multipart uploads of key.
+func (c *Client) pendingUploads(ctx context.Context, key string) ([]string, error) {
+	resp, err := c.do(ctx, request{method: http.MethodGet, query: url.Values{"uploads": {""}, "prefix": {c.objectKey(key)}}})
+	if err != nil {
+		return nil, err
+	}
+	var result listMultipartUploadsResult
+	if err := decodeXML(resp, &result); err != nil {
+		return nil, fmt.Errorf("objectstore: can't decode multipart uploads: %w", err)
+	}
+	var ids []string
+	for _, u := range result.Uploads {
+		if u.Key == c.objectKey(key) {
+			ids = append(ids, u.UploadId)
+		}
+	}
+	return ids, nil
+}
+
+func (c *Client) listParts(ctx context.Context, key, uploadId string) ([]part, error) {
+	var parts []part
+	marker := 0
+	for {
+		query := url.Values{"uploadId": {uploadId}}
+		if marker > 0 {
+			query.Set("part-number-marker", strconv.Itoa(marker))
+		}
+		resp, err := c.do(ctx, request{method: http.MethodGet, key: key, query: query})
+		if err != nil {
+			return nil, err
+		}
+		var result listPartsResult
+		if err := decodeXML(resp, &result); err != nil {
+			return nil, fmt.Errorf("objectstore: can't decode parts: %w", err)
+		}
+		parts = append(parts, result.Parts...)
+		if !result.IsTruncated || result.NextPartNumberMarker <= marker {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+func (c *Client) uploadPart(ctx context.Context, key, uploadId string, number int, body io.ReadSeeker, size int64, sum payloadSum) (string, error) {
+	query := url.Values{"uploadId": {uploadId}, "partNumber": {strconv.Itoa(number)}}
+	resp, err := c.do(ctx, request{method: http.MethodPut, key: key, query: query, header: sum.header(), body: body, size: size, payloadHash: sum.sha256})
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return sum.checkETag(fmt.Sprintf("%s part %d", key, number), resp.Header.Get("ETag"))
+}
+
+func (c *Client) completeMultipartUpload(ctx context.Context, key, uploadId string, parts []part) error {
+	body, err := xml.Marshal(completeMultipartUpload{Parts: parts})
+	if err != nil {
+		return err
+	}
+	sha := sha256.Sum256(body)
+	resp, err := c.do(ctx, request{method: http.MethodPost, key: key, query: url.Values{"uploadId": {uploadId}},
+		body: bytes.NewReader(body), size: int64(len(body)), payloadHash: hex.EncodeToString(sha[:])})
+	if err != nil {
+		return err
+	}
+	// the store may fail after having answered 200, the error is then in the body
+	var result completeMultipartUploadResult
+	if err := decodeXML(resp, &result); err != nil {
+		return fmt.Errorf("objectstore: can't decode multipart completion: %w", err)
+	}
+	if result.Code != "" {
+		return &Error{StatusCode: resp.StatusCode, Code: result.Code, Message: result.Error}
+	}
+	return nil
+}
+
+func (c *Client) abortMultipartUpload(ctx context.Context, key, uploadId string) error {
+	resp, err := c.do(ctx, request{method: http.MethodDelete, key: key, query: url.Values{"uploadId": {uploadId}}})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func partSize(size int64, preferred int64) int64 {
+	partSize := max(preferred, minPartSize)
+	for size > partSize*maxParts {
+		partSize *= 2
+	}
+	return partSize
+}
+
+// UploadFile stores the file at path under key, tagged with infoHash. Files bigger than the part
+// size, preferredPartSize unless the file would need too many parts, go up in parts; the parts of
+// an interrupted upload which match the file are kept and only the missing ones are sent.
+func (c *Client) UploadFile(ctx context.Context, key, path, infoHash string, preferredPartSize int64, logger log.Logger) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := stat.Size()
+	partSize := partSize(size, preferredPartSize)
+	if size <= partSize {
+		_, err := c.Put(ctx, key, io.NewSectionReader(f, 0, size), size, infoHash)
+		return err
+	}
+
+	uploadId, done, err := c.resumableUpload(ctx, key, partSize, logger)
+	if err != nil {
+		return err
+	}
+	if uploadId == "" {
+		if uploadId, err = c.createMultipartUpload(ctx, key, infoHash); err != nil {
+			return err
+		}
+	}
+
+	var parts []part
+	for number, offset := 1, int64(0); offset < size; number, offset = number+1, offset+partSize {
+		body := io.NewSectionReader(f, offset, min(partSize, size-offset))
+		sum, err := hashPayload(body, body.Size())
+		if err != nil {
+			return err
+		}
+		if etag, ok := done[number]; ok {
+			if etag == hex.EncodeToString(sum.md5) {
+				parts = append(parts, part{PartNumber: number, ETag: etag})
+				continue
+			}
+			// the unfinished upload was of other content, and was tagged with its info hash
+			logger.Debug("[objectstore] Restarting upload of changed file", "key", key)
+			if err := c.abortMultipartUpload(ctx, key, uploadId); err != nil {
+				return err
+			}
+			if uploadId, err = c.createMultipartUpload(ctx, key, infoHash); err != nil {
+				return err
+			}
+			done, parts, number, offset = nil, nil, 0, -partSize
+			continue
+		}
+		etag, err := c.uploadPart(ctx, key, uploadId, number, body, body.Size(), sum)
+		if err != nil {
+			return fmt.Errorf("objectstore: upload of %s part %d: %w", key, number, err)
+		}
+		parts = append(parts, part{PartNumber: number, ETag: etag})
+	}
+	if err := c.completeMultipartUpload(ctx, key, uploadId, parts); err != nil {
+		return err
+	}
+	if len(done) > 0 {
+		logger.Debug("[objectstore] Resumed upload", "key", key, "parts", len(parts), "reused", len(done))
+	}
+	return nil
+}
+
+// resumableUpload finds an unfinished upload of key made with the same part size and returns its
+// id with the etags of its parts. Other unfinished uploads of key are aborted.
+func (c *Client) resumableUpload(ctx context.Context, key string, partSize int64, logger log.Logger) (string, map[int]string, error) {
+	ids, err := c.pendingUploads(ctx, key)
+	if err != nil {
+		return "", nil, err
+	}
+	var resumed string
+	done := map[int]string{}
+	for _, id := range ids {
+		if resumed == "" {
+			parts, err := c.listParts(ctx, key, id)
+			if err != nil {
+				return "", nil, err
+			}
+			if sameSize(parts, partSize) {
+				resumed = id
+				for _, p := range parts {
+					done[p.PartNumber] = strings.Trim(p.ETag, `"`)
+				}
+				continue
+			}
+		}
+		if err := c.abortMultipartUpload(ctx, key, id); err != nil {
+			logger.Debug("[objectstore] Can't abort stale upload", "key", key, "err", err)
+		}
+	}
+	return resumed, done, nil
+}
+
+// sameSize reports whether parts could have been cut with partSize: all but the last one, which
+// may be short, have that size.
+func sameSize(parts []part, partSize int64) bool {
+	if len(parts) == 0 {
+		return false
+	}
+	for i, p := range parts {
+		if p.Size != partSize && (i != len(parts)-1 || p.Size > partSize) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const (
+	// ManifestFile lists the published files, one name per line, the way webseeds expect it
+	ManifestFile = "manifest.txt"
+	nodeFile     = "node.txt"
+	partialExt   = ".tmp"
+)
+
+var ErrHashMismatch = errors.New("info hash mismatch")
+
+// Store publishes the files of a local snapshot directory to an object store and mirrors them
+// back. Snapshot files are content addressed: each object is tagged with the torrent info hash
+// of its content, which is checked against the preverified hashes on the way up and down.
+type Store struct {
+	client      *Client
+	partSize    int64
+	preverified func(name string) (hash string, ok bool)
+	logger      log.Logger
+}
+
+// NewStore returns a store over client. preverified looks up the expected info hash of a file,
+// it may be nil.
+func NewStore(client *Client, preverified func(name string) (string, bool), logger log.Logger) *Store {
+	if preverified == nil {
+		preverified = func(string) (string, bool) { return "", false }
+	}
+	return &Store{client: client, partSize: DefaultPartSize, preverified: preverified, logger: logger}
+}
+
+func (s *Store) Client() *Client {
+	return s.client
+}
+
+// SetPartSize sets the preferred size of the parts of multipart uploads.
+func (s *Store) SetPartSize(partSize int64) {
+	s.partSize = partSize
+}
+
+// hashed reports whether name is a file pinned by an info hash, unlike .torrent files and the
+// manifest.
+func hashed(name string) bool {
+	return !strings.HasSuffix(name, ".torrent") && name != ManifestFile && name != nodeFile
+}
+
+// verify checks the info hash of the file at path against the preverified one, or against
+// stored when name is not preverified. It returns the info hash.
+func (s *Store) verify(path, name, stored string) (string, error) {
+	hash, err := InfoHash(path, name)
+	if err != nil {
+		return "", err
+	}
+	expected, ok := s.preverified(name)
+	if !ok {
+		expected = stored
+	}
+	if expected != "" && !strings.EqualFold(expected, hash) {
+		return "", fmt.Errorf("%w: %s: %s, expected %s", ErrHashMismatch, name, hash, expected)
+	}
+	return hash, nil
+}
+
+// Upload publishes the file name of localDir, unless the store already holds the same content.
+func (s *Store) Upload(ctx context.Context, localDir, name string) error {
+	path := filepath.Join(localDir, filepath.FromSlash(name))
+	var hash string
+	if hashed(name) {
+		var err error
+		if hash, err = s.verify(path, name, ""); err != nil {
+			return err
+		}
+		if remote, err := s.client.Head(ctx, name); err == nil && strings.EqualFold(remote.InfoHash, hash) {
+			s.logger.Debug("[objectstore] Already uploaded", "file", name, "hash", hash)
+			return nil
+		}
+	}
+	if err := s.client.UploadFile(ctx, name, path, hash, s.partSize, s.logger); err != nil {
+		return fmt.Errorf("upload %s: %w", name, err)
+	}
+	s.logger.Debug("[objectstore] Uploaded", "file", name, "to", s.client)
+	return nil
+}
+
+// Download mirrors the object name into localDir. An interrupted download is resumed, and the
+// file only takes its final name once its info hash has been verified.
+func (s *Store) Download(ctx context.Context, localDir, name string) error {
+	remote, err := s.client.Head(ctx, name)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", name, err)
+	}
+	path := filepath.Join(localDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	partial := path + partialExt
+
+	var offset int64
+	if info, err := os.Stat(partial); err == nil && info.Size() < remote.Size {
+		offset = info.Size()
+	}
+	f, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return err
+	}
+	if offset < remote.Size {
+		body, _, err := s.client.Get(ctx, name, offset, remote.ETag)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("download %s: %w", name, err)
+		}
+		_, err = f.Seek(offset, io.SeekStart)
+		if err == nil {
+			_, err = io.Copy(f, body)
+		}
+		body.Close()
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("download %s: %w", name, err)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if hashed(name) {
+		if _, err := s.verify(partial, name, remote.InfoHash); err != nil {
+			os.Remove(partial)
+			return err
+		}
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return err
+	}
+	if offset > 0 {
+		s.logger.Debug("[objectstore] Resumed download", "file", name, "from", offset)
+	}
+	return nil
+}
+
+// Cat returns the content of the object name.
+func (s *Store) Cat(ctx context.Context, name string) (io.ReadCloser, error) {
+	body, _, err := s.client.Get(ctx, name, 0, "")
+	return body, err
+}
+
+func (s *Store) List(ctx context.Context) ([]Object, error) {
+	return s.client.List(ctx)
+}
+
+// Manifest returns the manifest of objects: their sorted names, without the manifest itself.
+func Manifest(objects []Object) []byte {
+	names := make([]string, 0, len(objects))
+	for _, o := range objects {
+		if o.Key == ManifestFile || o.Key == nodeFile || strings.HasSuffix(o.Key, partialExt) {
+			continue
+		}
+		names = append(names, o.Key)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	for _, name := range names {
+		b.WriteString(name + "\n")
+	}
+	return b.Bytes()
+}
+
+// PublishManifest writes the manifest of what the store holds, which makes its public url usable
+// as a webseed. It returns the number of listed files.
+func (s *Store) PublishManifest(ctx context.Context) (int, error) {
+	objects, err := s.client.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	manifest := Manifest(objects)
+	if _, err := s.client.Put(ctx, ManifestFile, bytes.NewReader(manifest), int64(len(manifest)), ""); err != nil {
+		return 0, fmt.Errorf("upload %s: %w", ManifestFile, err)
+	}
+	return bytes.Count(manifest, []byte("\n")), nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package objectstore
+
+import (
+	"context"
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const testBucket = "snapshots"
+
+type testObject struct {
+	data     []byte
+	etag     string
+	infoHash string
+}
+
+type testUpload struct {
+	key      string
+	infoHash string
+	parts    map[int][]byte
+}
+
+// testS3 is a MinIO-like stand-in: it checks signatures and payload checksums like the real
+// thing, and keeps objects and multipart uploads in memory.
+type testS3 struct {
+	t       *testing.T
+	mu      sync.Mutex
+	cfg     Config
+	objects map[string]*testObject
+	uploads map[string]*testUpload
+	// failPart makes the upload of that part number fail once
+	failPart  int
+	requests  []string
+	uploadIds int
+	server    *httptest.Server
+}
+
+func newTestS3(t *testing.T) *testS3 {
+	t.Helper()
+	s := &testS3{t: t, objects: map[string]*testObject{}, uploads: map[string]*testUpload{}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.server.Close)
+	s.cfg = Config{Endpoint: s.server.URL, Region: "us-east-1", Bucket: testBucket, Prefix: "mainnet/v1", AccessKey: "minio", SecretKey: "minio123"}
+	return s
+}
+
+func (s *testS3) client(t *testing.T) *Client {
+	t.Helper()
+	c, err := NewClient(s.cfg, nil)
+	require.NoError(t, err)
+	return c
+}
+
+func (s *testS3) count(prefix string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int
+	for _, r := range s.requests {
+		if strings.HasPrefix(r, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *testS3) fail(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func (s *testS3) authorized(r *http.Request, body []byte) bool {
+	date, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		return false
+	}
+	signed := r.Clone(context.Background())
+	signed.URL.Host = r.Host
+	signed.Header.Del("Authorization")
+	(&Client{cfg: s.cfg}).sign(signed, r.Header.Get("X-Amz-Content-Sha256"), date)
+	return signed.Header.Get("Authorization") == r.Header.Get("Authorization")
+}
+
+func (s *testS3) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(s.t, err)
+	if !s.authorized(r, body) {
+		s.fail(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+	if md := r.Header.Get("Content-Md5"); md != "" {
+		sum := md5.Sum(body) //nolint:gosec
+		if md != base64.StdEncoding.EncodeToString(sum[:]) {
+			s.fail(w, http.StatusBadRequest, "BadDigest")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != testBucket {
+		s.fail(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	query := r.URL.Query()
+	s.requests = append(s.requests, r.Method+" "+strings.TrimPrefix(key, s.cfg.Prefix+"/")+" "+r.URL.RawQuery)
+
+	switch {
+	case r.Method == http.MethodGet && key == "" && query.Has("uploads"):
+		var result listMultipartUploadsResult
+		for id, u := range s.uploads {
+			if strings.HasPrefix(u.key, query.Get("prefix")) {
+				result.Uploads = append(result.Uploads, struct {
+					Key      string `xml:"Key"`
+					UploadId string `xml:"UploadId"`
+				}{u.key, id})
+			}
+		}
+		s.writeXML(w, result)
+	case r.Method == http.MethodGet && key == "":
+		var result listBucketResult
+		keys := make([]string, 0, len(s.objects))
+		for k := range s.objects {
+			if strings.HasPrefix(k, query.Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				ETag         string    `xml:"ETag"`
+				LastModified time.Time `xml:"LastModified"`
+			}{Key: k, Size: int64(len(s.objects[k].data)), ETag: `"` + s.objects[k].etag + `"`})
+		}
+		s.writeXML(w, result)
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		s.uploadIds++
+		id := strconv.Itoa(s.uploadIds)
+		s.uploads[id] = &testUpload{key: key, infoHash: r.Header.Get(metaInfoHash), parts: map[int][]byte{}}
+		s.writeXML(w, initiateMultipartUploadResult{UploadId: id})
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		u, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			s.fail(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		if number == s.failPart {
+			s.failPart = 0
+			s.fail(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		u.parts[number] = body
+		sum := md5.Sum(body) //nolint:gosec
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	case r.Method == http.MethodGet && query.Has("uploadId"):
+		u, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			s.fail(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		var result listPartsResult
+		for number, data := range u.parts {
+			sum := md5.Sum(data) //nolint:gosec
+			result.Parts = append(result.Parts, part{PartNumber: number, ETag: `"` + hex.EncodeToString(sum[:]) + `"`, Size: int64(len(data))})
+		}
+		sort.Slice(result.Parts, func(i, j int) bool { return result.Parts[i].PartNumber < result.Parts[j].PartNumber })
+		s.writeXML(w, result)
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		u, ok := s.uploads[query.Get("uploadId")]
+		if !ok {
+			s.fail(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		var complete completeMultipartUpload
+		require.NoError(s.t, xml.Unmarshal(body, &complete))
+		var data []byte
+		etags := md5.New() //nolint:gosec
+		for i, p := range complete.Parts {
+			partData, ok := u.parts[p.PartNumber]
+			sum := md5.Sum(partData) //nolint:gosec
+			if !ok || p.PartNumber != i+1 || strings.Trim(p.ETag, `"`) != hex.EncodeToString(sum[:]) {
+				s.fail(w, http.StatusBadRequest, "InvalidPart")
+				return
+			}
+			data = append(data, partData...)
+			etags.Write(sum[:])
+		}
+		etag := fmt.Sprintf("%x-%d", etags.Sum(nil), len(complete.Parts))
+		s.objects[u.key] = &testObject{data: data, etag: etag, infoHash: u.infoHash}
+		delete(s.uploads, query.Get("uploadId"))
+		s.writeXML(w, completeMultipartUploadResult{ETag: etag})
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(s.uploads, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut:
+		sum := md5.Sum(body) //nolint:gosec
+		s.objects[key] = &testObject{data: body, etag: hex.EncodeToString(sum[:]), infoHash: r.Header.Get(metaInfoHash)}
+		w.Header().Set("ETag", `"`+s.objects[key].etag+`"`)
+	case r.Method == http.MethodHead || r.Method == http.MethodGet:
+		o, ok := s.objects[key]
+		if !ok {
+			s.fail(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		if match := r.Header.Get("If-Match"); match != "" && match != `"`+o.etag+`"` {
+			s.fail(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		w.Header().Set("ETag", `"`+o.etag+`"`)
+		if o.infoHash != "" {
+			w.Header().Set(metaInfoHash, o.infoHash)
+		}
+		data, status := o.data, http.StatusOK
+		if rng := r.Header.Get("Range"); rng != "" {
+			from, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", from, len(data)-1, len(data)))
+			data, status = data[from:], http.StatusPartialContent
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.fail(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (s *testS3) writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	require.NoError(s.t, xml.NewEncoder(w).Encode(v))
+}
+
+func writeTestFile(t *testing.T, dir, name string, size int) []byte {
+	t.Helper()
+	data := make([]byte, size)
+	rand.New(rand.NewSource(int64(size))).Read(data)
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return data
+}
+
+func TestInfoHash(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	data := []byte("hello")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.seg"), data, 0o644))
+
+	piece := sha1.Sum(data) //nolint:gosec
+	info := "d6:lengthi5e4:name5:a.seg12:piece lengthi2097152e6:pieces20:" + string(piece[:]) + "e"
+	expected := sha1.Sum([]byte(info)) //nolint:gosec
+
+	hash, err := InfoHash(filepath.Join(dir, "a.seg"), "a.seg")
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(expected[:]), hash)
+}
+
+func TestUploadDownloadAndManifest(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	s3 := newTestS3(t)
+	local := t.TempDir()
+	small := writeTestFile(t, local, "v1.0-000000-000500-headers.seg", 1000)
+	big := writeTestFile(t, local, "domain/v1.0-accounts.0-32.kv", 12*1024*1024)
+	writeTestFile(t, local, "v1.0-000000-000500-headers.seg.torrent", 100)
+	smallHash, err := InfoHash(filepath.Join(local, "v1.0-000000-000500-headers.seg"), "v1.0-000000-000500-headers.seg")
+	require.NoError(t, err)
+
+	store := NewStore(s3.client(t), func(name string) (string, bool) {
+		return smallHash, name == "v1.0-000000-000500-headers.seg"
+	}, logger)
+	store.SetPartSize(minPartSize)
+	for _, name := range []string{"v1.0-000000-000500-headers.seg", "v1.0-000000-000500-headers.seg.torrent", "domain/v1.0-accounts.0-32.kv"} {
+		require.NoError(t, store.Upload(ctx, local, name))
+	}
+	require.Equal(t, 3, s3.count("PUT domain/v1.0-accounts.0-32.kv partNumber"))
+	require.Equal(t, smallHash, s3.objects["mainnet/v1/v1.0-000000-000500-headers.seg"].infoHash)
+
+	// same content is not uploaded again
+	require.NoError(t, store.Upload(ctx, local, "domain/v1.0-accounts.0-32.kv"))
+	require.Equal(t, 3, s3.count("PUT domain/v1.0-accounts.0-32.kv partNumber"))
+
+	n, err := store.PublishManifest(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	manifest, err := store.Cat(ctx, ManifestFile)
+	require.NoError(t, err)
+	content, err := io.ReadAll(manifest)
+	require.NoError(t, err)
+	manifest.Close()
+	require.Equal(t, "domain/v1.0-accounts.0-32.kv\nv1.0-000000-000500-headers.seg\nv1.0-000000-000500-headers.seg.torrent\n", string(content))
+
+	// the public url of the prefix serves as a webseed
+	require.Equal(t, s3.server.URL+"/snapshots/mainnet/v1", store.Client().BaseURL().String())
+
+	mirror := t.TempDir()
+	require.NoError(t, store.Download(ctx, mirror, "v1.0-000000-000500-headers.seg"))
+	require.NoError(t, store.Download(ctx, mirror, "domain/v1.0-accounts.0-32.kv"))
+	data, err := os.ReadFile(filepath.Join(mirror, "v1.0-000000-000500-headers.seg"))
+	require.NoError(t, err)
+	require.Equal(t, small, data)
+	data, err = os.ReadFile(filepath.Join(mirror, "domain/v1.0-accounts.0-32.kv"))
+	require.NoError(t, err)
+	require.Equal(t, big, data)
+}
+
+func TestResumeUpload(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	s3 := newTestS3(t)
+	local := t.TempDir()
+	big := writeTestFile(t, local, "v1.0-accounts.0-32.kv", 12*1024*1024)
+	store := NewStore(s3.client(t), nil, logger)
+	store.SetPartSize(minPartSize)
+
+	s3.failPart = 3
+	require.Error(t, store.Upload(ctx, local, "v1.0-accounts.0-32.kv"))
+	require.Len(t, s3.uploads, 1)
+
+	require.NoError(t, store.Upload(ctx, local, "v1.0-accounts.0-32.kv"))
+	require.Equal(t, 1, s3.count("PUT v1.0-accounts.0-32.kv partNumber=1"))
+	require.Equal(t, 1, s3.count("PUT v1.0-accounts.0-32.kv partNumber=2"))
+	require.Equal(t, 2, s3.count("PUT v1.0-accounts.0-32.kv partNumber=3"))
+	require.Equal(t, big, s3.objects["mainnet/v1/v1.0-accounts.0-32.kv"].data)
+	require.Empty(t, s3.uploads)
+}
+
+func TestResumeDownload(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	s3 := newTestS3(t)
+	local := t.TempDir()
+	data := writeTestFile(t, local, "v1.0-000000-000500-headers.seg", 3*PieceLength+17)
+	store := NewStore(s3.client(t), nil, logger)
+	require.NoError(t, store.Upload(ctx, local, "v1.0-000000-000500-headers.seg"))
+
+	mirror := t.TempDir()
+	partial := filepath.Join(mirror, "v1.0-000000-000500-headers.seg"+partialExt)
+	require.NoError(t, os.WriteFile(partial, data[:PieceLength], 0o644))
+	require.NoError(t, store.Download(ctx, mirror, "v1.0-000000-000500-headers.seg"))
+	require.Equal(t, 1, s3.count("GET v1.0-000000-000500-headers.seg"))
+	downloaded, err := os.ReadFile(filepath.Join(mirror, "v1.0-000000-000500-headers.seg"))
+	require.NoError(t, err)
+	require.Equal(t, data, downloaded)
+	_, err = os.Stat(partial)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestHashMismatch(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	s3 := newTestS3(t)
+	local := t.TempDir()
+	writeTestFile(t, local, "v1.0-000000-000500-headers.seg", 1000)
+
+	// a file which doesn't match chain.toml is not published
+	wrong := NewStore(s3.client(t), func(string) (string, bool) { return strings.Repeat("00", 20), true }, logger)
+	require.ErrorIs(t, wrong.Upload(ctx, local, "v1.0-000000-000500-headers.seg"), ErrHashMismatch)
+	require.Empty(t, s3.objects)
+
+	// nor is a corrupted object mirrored
+	store := NewStore(s3.client(t), nil, logger)
+	require.NoError(t, store.Upload(ctx, local, "v1.0-000000-000500-headers.seg"))
+	s3.objects["mainnet/v1/v1.0-000000-000500-headers.seg"].data[10] ^= 0xff
+	mirror := t.TempDir()
+	require.ErrorIs(t, store.Download(ctx, mirror, "v1.0-000000-000500-headers.seg"), ErrHashMismatch)
+	entries, err := os.ReadDir(mirror)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = store.Cat(ctx, "missing.seg")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSignatureRequired(t *testing.T) {
+	t.Parallel()
+	s3 := newTestS3(t)
+	cfg := s3.cfg
+	cfg.SecretKey = "wrong"
+	c, err := NewClient(cfg, nil)
+	require.NoError(t, err)
+	_, err = c.List(context.Background())
+	var storeErr *Error
+	require.ErrorAs(t, err, &storeErr)
+	require.Equal(t, "SignatureDoesNotMatch", storeErr.Code)
+	require.Equal(t, http.StatusForbidden, storeErr.StatusCode)
+}
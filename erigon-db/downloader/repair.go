@@ -0,0 +1,461 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/recsplit"
+	"github.com/erigontech/erigon-lib/seg"
+)
+
+// QuarantineDir holds the snapshot files which failed verification and can't be repaired in place.
+const QuarantineDir = "quarantine"
+
+// Damage is a snapshot file which failed verification.
+type Damage struct {
+	// Name of the file, relative to the snapshots dir
+	Name string
+	// Accessor is set for derived files (indices, filters), rebuilt locally from their segment
+	Accessor bool
+	// Pieces lists the torrent pieces which don't match the .torrent of the file, nil when the
+	// file has no .torrent or when they all match
+	Pieces []int
+	Err    error
+}
+
+func (d Damage) String() string {
+	if len(d.Pieces) > 0 {
+		return fmt.Sprintf("%s: %d bad pieces: %v", d.Name, len(d.Pieces), d.Err)
+	}
+	return fmt.Sprintf("%s: %v", d.Name, d.Err)
+}
+
+var (
+	versionPrefix = regexp.MustCompile(`^v\d+(\.\d+)?-`)
+	// data file extension of each accessor extension
+	accessorData = map[string]string{
+		".idx":  ".seg",
+		".kvi":  ".kv",
+		".kvei": ".kv",
+		".bt":   ".kv",
+		".vi":   ".v",
+		".efi":  ".ef",
+	}
+	dataExts = []string{".seg", ".kv", ".v", ".ef"}
+	// block indices which have one key per word of their segment
+	wordIndexed = []string{"headers", "bodies", "transactions", "transactions-to-block"}
+)
+
+// dataKey identifies the data file an accessor is derived from, the versions of both may differ.
+func dataKey(dir, name, dataExt string) string {
+	base := versionPrefix.ReplaceAllString(strings.TrimSuffix(name, filepath.Ext(name)), "")
+	if dataExt == ".seg" {
+		base = strings.TrimSuffix(base, "-to-block")
+	}
+	if dataExt != ".seg" {
+		// state accessors live in the accessor dir, their data in the domain/history/idx dirs
+		dir = ""
+	}
+	return filepath.Join(dir, base) + dataExt
+}
+
+type snapshotFile struct {
+	name string // relative to the snapshots dir
+	path string
+	ext  string
+}
+
+func listSnapshotFiles(dirs datadir.Dirs) (data, accessors []snapshotFile, err error) {
+	err = filepath.WalkDir(dirs.Snap, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(dirs.Snap, path)
+		if err != nil {
+			return err
+		}
+		f := snapshotFile{name: filepath.ToSlash(name), path: path, ext: filepath.Ext(path)}
+		switch {
+		case slices.Contains(dataExts, f.ext):
+			data = append(data, f)
+		case accessorData[f.ext] != "":
+			accessors = append(accessors, f)
+		}
+		return nil
+	})
+	return data, accessors, err
+}
+
+// VerifySnapshots verifies all the snapshot files of dirs with workers goroutines: the pieces of
+// the files with a .torrent against it, that segments decompress to their word count, and that
+// accessors are consistent with their segment. It returns the damaged files sorted by name.
+func VerifySnapshots(ctx context.Context, dirs datadir.Dirs, workers int, logger log.Logger) ([]Damage, error) {
+	data, accessors, err := listSnapshotFiles(dirs)
+	if err != nil {
+		return nil, err
+	}
+	segments := map[string]snapshotFile{}
+	for _, f := range data {
+		segments[dataKey(filepath.Dir(f.name), filepath.Base(f.name), f.ext)] = f
+	}
+
+	var (
+		mu       sync.Mutex
+		damages  []Damage
+		verified atomic.Int64
+		total    = len(data) + len(accessors)
+	)
+	report := func(d Damage) {
+		mu.Lock()
+		defer mu.Unlock()
+		damages = append(damages, d)
+		logger.Warn("[repair] Damaged file", "file", d.Name, "pieces", len(d.Pieces), "err", d.Err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		logEvery := time.NewTicker(20 * time.Second)
+		defer logEvery.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-logEvery.C:
+				logger.Info("[repair] Verifying", "files", fmt.Sprintf("%d/%d", verified.Load(), total))
+			}
+		}
+	}()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	for _, f := range data {
+		g.Go(func() error {
+			defer verified.Add(1)
+			pieces, err := VerifyPieces(gctx, dirs.Snap, f.name)
+			if err != nil && !errors.Is(err, errPiecesMismatch) {
+				return err
+			}
+			if err == nil {
+				err = verifySegment(f.path)
+			}
+			if err != nil {
+				report(Damage{Name: f.name, Pieces: pieces, Err: err})
+			}
+			return gctx.Err()
+		})
+	}
+	for _, f := range accessors {
+		g.Go(func() error {
+			defer verified.Add(1)
+			key := dataKey(filepath.Dir(f.name), filepath.Base(f.name), accessorData[f.ext])
+			segment, ok := segments[key]
+			if !ok {
+				// an accessor of a file which isn't there has nothing to be consistent with
+				return nil
+			}
+			if err := verifyAccessor(f, segment); err != nil {
+				report(Damage{Name: f.name, Accessor: true, Err: err})
+			}
+			return gctx.Err()
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// accessors of damaged files are rebuilt from the repaired ones
+	damaged := map[string]string{}
+	for _, d := range damages {
+		if !d.Accessor {
+			ext := filepath.Ext(d.Name)
+			damaged[dataKey(filepath.Dir(d.Name), filepath.Base(d.Name), ext)] = d.Name
+		}
+	}
+	for _, f := range accessors {
+		key := dataKey(filepath.Dir(f.name), filepath.Base(f.name), accessorData[f.ext])
+		name, ok := damaged[key]
+		if !ok || slices.ContainsFunc(damages, func(d Damage) bool { return d.Name == f.name }) {
+			continue
+		}
+		damages = append(damages, Damage{Name: f.name, Accessor: true, Err: fmt.Errorf("derived from damaged %s", name)})
+	}
+	sort.Slice(damages, func(i, j int) bool { return damages[i].Name < damages[j].Name })
+	return damages, nil
+}
+
+var errPiecesMismatch = errors.New("pieces don't match the .torrent")
+
+func loadTorrentInfo(snapDir, name string) (*metainfo.Info, error) {
+	mi, err := metainfo.LoadFromFile(filepath.Join(snapDir, filepath.FromSlash(name)+".torrent"))
+	if err != nil {
+		return nil, err
+	}
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+	if info.PieceLength <= 0 || len(info.Pieces)%sha1.Size != 0 {
+		return nil, fmt.Errorf("%s.torrent: invalid v1 info", name)
+	}
+	return &info, nil
+}
+
+// VerifyPieces hashes the file name of snapDir piece by piece and returns the pieces which don't
+// match its .torrent, with errPiecesMismatch. Files without a .torrent are not checked.
+func VerifyPieces(ctx context.Context, snapDir, name string) ([]int, error) {
+	info, err := loadTorrentInfo(snapDir, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(snapDir, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var bad []int
+	buf := make([]byte, info.PieceLength)
+	numPieces := len(info.Pieces) / sha1.Size
+	for i := 0; i < numPieces; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n, err := io.ReadFull(f, buf[:pieceSize(info, i)])
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+		sum := sha1.Sum(buf[:n]) //nolint:gosec
+		if n != pieceSize(info, i) || !bytes.Equal(sum[:], info.Pieces[i*sha1.Size:(i+1)*sha1.Size]) {
+			bad = append(bad, i)
+		}
+	}
+	// trailing garbage spoils the last piece for the torrent client
+	if stat, err := f.Stat(); err == nil && stat.Size() > info.TotalLength() && numPieces > 0 && !slices.Contains(bad, numPieces-1) {
+		bad = append(bad, numPieces-1)
+	}
+	if len(bad) > 0 {
+		return bad, fmt.Errorf("%w: %d of %d", errPiecesMismatch, len(bad), numPieces)
+	}
+	return nil, nil
+}
+
+func pieceSize(info *metainfo.Info, i int) int {
+	return int(min(info.PieceLength, info.TotalLength()-int64(i)*info.PieceLength))
+}
+
+// verifySegment reads every word of the segment at path and checks their count.
+func verifySegment(path string) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("decompressor: %v", rec)
+		}
+	}()
+	d, err := seg.NewDecompressor(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	words, err := countWords(d)
+	if err != nil {
+		return err
+	}
+	if words != d.Count() {
+		return fmt.Errorf("decompressor: %d words, header says %d", words, d.Count())
+	}
+	return nil
+}
+
+func countWords(d *seg.Decompressor) (int, error) {
+	r := seg.NewReader(d.MakeGetter(), seg.DetectCompressType(d.MakeGetter()))
+	var words int
+	for r.HasNext() {
+		if offset, _ := r.Skip(); offset > uint64(d.Size()) {
+			return 0, fmt.Errorf("decompressor: word %d ends past the end of the file", words)
+		}
+		words++
+	}
+	return words, nil
+}
+
+// verifyAccessor checks the recsplit accessor f against its segment: block indices have a key per
+// word, and the offsets of enumerated indices point into the segment.
+func verifyAccessor(f, segment snapshotFile) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("accessor: %v", rec)
+		}
+	}()
+	switch f.ext {
+	case ".idx", ".kvi", ".vi", ".efi":
+	default:
+		// btree and existence filters are checked when opened by the node
+		return nil
+	}
+	idx, err := recsplit.OpenIndex(f.path)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+	d, err := seg.NewDecompressor(segment.path)
+	if err != nil {
+		// the segment is reported on its own
+		return nil
+	}
+	defer d.Close()
+
+	base := versionPrefix.ReplaceAllString(strings.TrimSuffix(filepath.Base(f.name), f.ext), "")
+	if f.ext == ".idx" && slices.ContainsFunc(wordIndexed, func(t string) bool { return strings.HasSuffix(base, "-"+t) }) {
+		if idx.KeyCount() != uint64(d.Count()) {
+			return fmt.Errorf("accessor: %d keys for the %d words of %s", idx.KeyCount(), d.Count(), segment.name)
+		}
+	}
+	if idx.Enums() {
+		for i := uint64(0); i < idx.KeyCount(); i++ {
+			if offset := idx.OrdinalLookup(i); offset >= uint64(d.Size()) {
+				return fmt.Errorf("accessor: ordinal %d points at %d, past the end of %s", i, offset, segment.name)
+			}
+		}
+	}
+	return nil
+}
+
+// Quarantine moves the snapshot file name out of the snapshots dir, into the quarantine dir of
+// the datadir, and returns its new path.
+func Quarantine(dirs datadir.Dirs, name string) (string, error) {
+	dst := filepath.Join(dirs.DataDir, QuarantineDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(filepath.Join(dirs.Snap, filepath.FromSlash(name)), dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// RepairPieces re-requests the bad pieces of the file name. The file is first renamed to its .part
+// file, which nothing but the downloader opens, and the pieces are fetched from the webseeds. The
+// file gets its name back once they all match its .torrent; otherwise the remaining pieces are
+// returned, and the downloader's torrent client, which rehashes .part files when it starts,
+// requests exactly those.
+func RepairPieces(ctx context.Context, client *http.Client, webseeds []*url.URL, snapDir, name string, pieces []int, logger log.Logger) ([]int, error) {
+	info, err := loadTorrentInfo(snapDir, name)
+	if err != nil {
+		return pieces, err
+	}
+	path := filepath.Join(snapDir, filepath.FromSlash(name))
+	partPath := path + ".part"
+	if err := os.Rename(path, partPath); err != nil {
+		return pieces, err
+	}
+	f, err := os.OpenFile(partPath, os.O_RDWR, 0o644)
+	if err != nil {
+		return pieces, err
+	}
+	defer f.Close()
+	if err := f.Truncate(info.TotalLength()); err != nil {
+		return pieces, err
+	}
+
+	var remaining []int
+	for _, piece := range pieces {
+		if err := ctx.Err(); err != nil {
+			return append(remaining, pieces[len(remaining):]...), err
+		}
+		if !fetchPiece(ctx, client, webseeds, name, info, piece, f, logger) {
+			remaining = append(remaining, piece)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return pieces, err
+	}
+	if len(remaining) > 0 {
+		return remaining, nil
+	}
+	if err := f.Close(); err != nil {
+		return pieces, err
+	}
+	return nil, os.Rename(partPath, path)
+}
+
+// fetchPiece downloads the piece of file name from the first webseed which serves it right, and
+// writes it in place.
+func fetchPiece(ctx context.Context, client *http.Client, webseeds []*url.URL, name string, info *metainfo.Info, piece int, f *os.File, logger log.Logger) bool {
+	offset, size := int64(piece)*info.PieceLength, pieceSize(info, piece)
+	expected := info.Pieces[piece*sha1.Size : (piece+1)*sha1.Size]
+	buf := make([]byte, size)
+	for _, webseed := range webseeds {
+		u := webseed.JoinPath(name).String()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			continue
+		}
+		insertCloudflareHeaders(req)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+int64(size)-1))
+		resp, err := client.Do(req)
+		if err != nil {
+			logger.Debug("[repair] Webseed request failed", "url", u, "err", err)
+			continue
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			logger.Debug("[repair] Webseed can't serve piece", "url", u, "piece", piece, "status", resp.Status)
+			continue
+		}
+		_, err = io.ReadFull(resp.Body, buf)
+		resp.Body.Close()
+		if sum := sha1.Sum(buf); err != nil || !bytes.Equal(sum[:], expected) { //nolint:gosec
+			logger.Debug("[repair] Webseed served a bad piece", "url", u, "piece", piece, "err", err)
+			continue
+		}
+		if _, err := f.WriteAt(buf, offset); err != nil {
+			logger.Warn("[repair] Can't write piece", "file", name, "piece", piece, "err", err)
+			return false
+		}
+		return true
+	}
+	return false
+}
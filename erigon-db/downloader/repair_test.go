@@ -0,0 +1,128 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/seg"
+)
+
+func createTestSegment(t *testing.T, dirs datadir.Dirs, name string) []byte {
+	t.Helper()
+	path := filepath.Join(dirs.Snap, name)
+	c, err := seg.NewCompressor(context.Background(), "test", path, dirs.Tmp, seg.DefaultCfg, log.LvlDebug, log.New())
+	require.NoError(t, err)
+	defer c.Close()
+	rnd := rand.New(rand.NewSource(1))
+	word := make([]byte, 4096)
+	for i := 0; i < 1500; i++ { // a few 2MiB pieces of incompressible words
+		rnd.Read(word)
+		require.NoError(t, c.AddWord(word))
+	}
+	require.NoError(t, c.Compress())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = BuildTorrentIfNeed(context.Background(), name, dirs.Snap, NewAtomicTorrentFS(dirs.Snap))
+	require.NoError(t, err)
+	return content
+}
+
+func TestVerifyAndRepairPieces(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	ctx, logger := context.Background(), log.New()
+	const name = "v1.0-000000-000500-headers.seg"
+	content := createTestSegment(t, dirs, name)
+
+	damages, err := VerifySnapshots(ctx, dirs, 2, logger)
+	require.NoError(err)
+	require.Empty(damages)
+
+	// flip a byte in the second piece
+	path := filepath.Join(dirs.Snap, name)
+	f, err := os.OpenFile(path, os.O_RDWR, 0o644)
+	require.NoError(err)
+	_, err = f.WriteAt([]byte{content[3<<20] ^ 0xff}, 3<<20)
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	damages, err = VerifySnapshots(ctx, dirs, 2, logger)
+	require.NoError(err)
+	require.Len(damages, 1)
+	require.Equal(name, damages[0].Name)
+	require.Equal([]int{1}, damages[0].Pieces)
+
+	webseed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+	}))
+	defer webseed.Close()
+	u, err := url.Parse(webseed.URL)
+	require.NoError(err)
+
+	remaining, err := RepairPieces(ctx, webseed.Client(), []*url.URL{u}, dirs.Snap, name, damages[0].Pieces, logger)
+	require.NoError(err)
+	require.Empty(remaining)
+	repaired, err := os.ReadFile(path)
+	require.NoError(err)
+	require.Equal(content, repaired)
+}
+
+func TestRepairPiecesLeavesPartFile(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	ctx, logger := context.Background(), log.New()
+	const name = "v1.0-000000-000500-bodies.seg"
+	createTestSegment(t, dirs, name)
+
+	// no webseed serves the piece: the torrent client gets it from the .part file
+	remaining, err := RepairPieces(ctx, http.DefaultClient, nil, dirs.Snap, name, []int{0}, logger)
+	require.NoError(err)
+	require.Equal([]int{0}, remaining)
+	require.NoFileExists(filepath.Join(dirs.Snap, name))
+	require.FileExists(filepath.Join(dirs.Snap, name+".part"))
+}
+
+func TestQuarantine(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	const name = "v1.0-000000-000500-headers.idx"
+	require.NoError(os.WriteFile(filepath.Join(dirs.Snap, name), []byte("corrupt"), 0o644))
+
+	damages, err := VerifySnapshots(context.Background(), dirs, 1, log.New())
+	require.NoError(err)
+	require.Empty(damages) // an accessor without its segment isn't checked
+
+	dst, err := Quarantine(dirs, name)
+	require.NoError(err)
+	require.Equal(filepath.Join(dirs.DataDir, QuarantineDir, name), dst)
+	require.NoFileExists(filepath.Join(dirs.Snap, name))
+	require.FileExists(dst)
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/erigontech/erigon-db/downloader/objectstore"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/snaptype"
+)
+
+// RemoteSession keeps a local snapshot directory in sync with a remote one, through rclone
+// (RCloneSession) or natively with an S3-compatible object store (S3Session).
+type RemoteSession interface {
+	Upload(ctx context.Context, files ...string) error
+	Download(ctx context.Context, files ...string) error
+	Cat(ctx context.Context, file string) (io.Reader, error)
+	ReadRemoteDir(ctx context.Context, refresh bool) ([]fs.DirEntry, error)
+	ReadLocalDir(ctx context.Context) ([]fs.DirEntry, error)
+	LocalFsRoot() string
+	RemoteFsRoot() string
+	Label() string
+	Stop()
+}
+
+var (
+	_ RemoteSession = (*RCloneSession)(nil)
+	_ RemoteSession = (*S3Session)(nil)
+)
+
+// S3Session publishes snapshot files to an s3://bucket/prefix location and mirrors them back
+// without an rclone process. Snapshot files are checked against the preverified hashes both ways.
+type S3Session struct {
+	sync.Mutex
+	store   *objectstore.Store
+	localFs string
+	files   map[string]*rcloneInfo
+}
+
+// NewS3Session opens a session between localFs and the remoteFs s3:// location, see
+// objectstore.ConfigFromURL for its endpoint and credentials.
+func NewS3Session(localFs string, remoteFs string, preverified snapcfg.PreverifiedItems, logger log.Logger) (*S3Session, error) {
+	cfg, err := objectstore.ConfigFromURL(remoteFs)
+	if err != nil {
+		return nil, err
+	}
+	client, err := objectstore.NewClient(cfg, &http.Client{})
+	if err != nil {
+		return nil, err
+	}
+	store := objectstore.NewStore(client, func(name string) (string, bool) {
+		item, ok := preverified.Get(name)
+		return item.Hash, ok
+	}, logger)
+
+	return &S3Session{
+		store:   store,
+		localFs: localFs,
+		files:   map[string]*rcloneInfo{},
+	}, nil
+}
+
+func (s *S3Session) Upload(ctx context.Context, files ...string) error {
+	for _, file := range files {
+		if !dir.FileNonZero(filepath.Join(s.localFs, file)) {
+			return fmt.Errorf("can't upload: %s: %s", file, "file is not uploadable")
+		}
+		if err := s.store.Upload(ctx, s.localFs, file); err != nil {
+			return fmt.Errorf("can't upload: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *S3Session) Download(ctx context.Context, files ...string) error {
+	for _, file := range files {
+		if err := s.store.Download(ctx, s.localFs, file); err != nil {
+			return fmt.Errorf("can't download: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *S3Session) Cat(ctx context.Context, file string) (io.Reader, error) {
+	body, err := s.store.Cat(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(content), nil
+}
+
+func (s *S3Session) ReadRemoteDir(ctx context.Context, refresh bool) ([]fs.DirEntry, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.files) == 0 || refresh {
+		objects, err := s.store.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't get remote list: %w", err)
+		}
+		files := make(map[string]*rcloneInfo, len(objects))
+		for _, o := range objects {
+			localInfo, _ := os.Stat(filepath.Join(s.localFs, o.Key))
+			info := &rcloneInfo{
+				file:       o.Key,
+				localInfo:  localInfo,
+				remoteInfo: remoteInfo{Name: o.Key, Size: uint64(o.Size), ModTime: o.LastModified},
+			}
+			if snapInfo, isStateFile, ok := snaptype.ParseFileName(s.localFs, o.Key); ok && !isStateFile {
+				info.snapInfo = &snapInfo
+			}
+			files[o.Key] = info
+		}
+		s.files = files
+	}
+
+	entries := make([]fs.DirEntry, 0, len(s.files))
+	for _, info := range s.files {
+		if info.remoteInfo.Size > 0 {
+			entries = append(entries, &dirEntry{&fileInfo{info}})
+		}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
+
+func (s *S3Session) ReadLocalDir(ctx context.Context) ([]fs.DirEntry, error) {
+	return dir.ReadDir(s.localFs)
+}
+
+func (s *S3Session) LocalFsRoot() string {
+	return s.localFs
+}
+
+func (s *S3Session) RemoteFsRoot() string {
+	return s.store.Client().String()
+}
+
+func (s *S3Session) Label() string {
+	return s.RemoteFsRoot()
+}
+
+func (s *S3Session) Stop() {}
@@ -679,6 +679,33 @@ func WriteSenders(db kv.Putter, hash common.Hash, number uint64, senders []commo
 	return nil
 }
 
+// ReadBlockAccessList returns the EIP-7928 block access list stored for the block, or nil if there is none.
+func ReadBlockAccessList(db kv.Getter, hash common.Hash, number uint64) (types.BlockAccessList, error) {
+	data, err := db.GetOne(kv.BlockAccessLists, dbutils.BlockBodyKey(number, hash))
+	if err != nil {
+		return nil, fmt.Errorf("readBlockAccessList failed: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	bal := types.BlockAccessList{}
+	if err := rlp.DecodeBytes(data, &bal); err != nil {
+		return nil, fmt.Errorf("invalid block access list RLP: %w", err)
+	}
+	return bal, nil
+}
+
+func WriteBlockAccessList(db kv.Putter, hash common.Hash, number uint64, bal types.BlockAccessList) error {
+	data, err := rlp.EncodeToBytes(bal)
+	if err != nil {
+		return err
+	}
+	if err := db.Put(kv.BlockAccessLists, dbutils.BlockBodyKey(number, hash), data); err != nil {
+		return fmt.Errorf("failed to store block access list: %w", err)
+	}
+	return nil
+}
+
 // DeleteBody removes all block body data associated with a hash.
 func DeleteBody(db kv.Putter, hash common.Hash, number uint64) {
 	if err := db.Delete(kv.BlockBody, dbutils.BlockBodyKey(number, hash)); err != nil {
@@ -877,6 +904,9 @@ func PruneBlocks(tx kv.RwTx, blockTo uint64, blocksDeleteLimit int) (deleted int
 		if err = tx.Delete(kv.Senders, kCopy); err != nil {
 			return deleted, err
 		}
+		if err = tx.Delete(kv.BlockAccessLists, kCopy); err != nil {
+			return deleted, err
+		}
 		if err = tx.Delete(kv.BlockBody, kCopy); err != nil {
 			return deleted, err
 		}
@@ -925,6 +955,9 @@ func TruncateBlocks(ctx context.Context, tx kv.RwTx, blockFrom uint64) error {
 		if err := tx.Delete(kv.Senders, kCopy); err != nil {
 			return err
 		}
+		if err := tx.Delete(kv.BlockAccessLists, kCopy); err != nil {
+			return err
+		}
 		if err := tx.Delete(kv.BlockBody, kCopy); err != nil {
 			return err
 		}
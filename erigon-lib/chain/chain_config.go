@@ -70,10 +70,11 @@ type Config struct {
 	MergeHeight                   *big.Int `json:"mergeBlock,omitempty"`                    // The Merge block number
 
 	// Mainnet fork scheduling switched from block numbers to timestamps after The Merge
-	ShanghaiTime *big.Int `json:"shanghaiTime,omitempty"`
-	CancunTime   *big.Int `json:"cancunTime,omitempty"`
-	PragueTime   *big.Int `json:"pragueTime,omitempty"`
-	OsakaTime    *big.Int `json:"osakaTime,omitempty"`
+	ShanghaiTime  *big.Int `json:"shanghaiTime,omitempty"`
+	CancunTime    *big.Int `json:"cancunTime,omitempty"`
+	PragueTime    *big.Int `json:"pragueTime,omitempty"`
+	OsakaTime     *big.Int `json:"osakaTime,omitempty"`
+	AmsterdamTime *big.Int `json:"amsterdamTime,omitempty"`
 
 	// Optional EIP-4844 parameters (see also EIP-7691, EIP-7840, EIP-7892)
 	MinBlobGasPrice       *uint64                       `json:"minBlobGasPrice,omitempty"`
@@ -341,6 +342,11 @@ func (c *Config) IsOsaka(time uint64) bool {
 	return isForked(c.OsakaTime, time)
 }
 
+// IsAmsterdam returns whether time is either equal to the Amsterdam fork time or greater.
+func (c *Config) IsAmsterdam(time uint64) bool {
+	return isForked(c.AmsterdamTime, time)
+}
+
 func (c *Config) GetBurntContract(num uint64) *common.Address {
 	if len(c.BurntContract) == 0 {
 		return nil
@@ -666,7 +672,7 @@ type Rules struct {
 	IsByzantium, IsConstantinople, IsPetersburg       bool
 	IsIstanbul, IsBerlin, IsLondon, IsShanghai        bool
 	IsCancun, IsNapoli, IsBhilai                      bool
-	IsPrague, IsOsaka                                 bool
+	IsPrague, IsOsaka, IsAmsterdam                    bool
 	IsAura                                            bool
 }
 
@@ -694,6 +700,7 @@ func (c *Config) Rules(num uint64, time uint64) *Rules {
 		IsBhilai:           c.IsBhilai(num),
 		IsPrague:           c.IsPrague(time) || c.IsBhilai(num),
 		IsOsaka:            c.IsOsaka(time),
+		IsAmsterdam:        c.IsAmsterdam(time),
 		IsAura:             c.Aura != nil,
 	}
 }
@@ -0,0 +1,223 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+// ErrNoBranch is returned by WalkLeaves when there is no branch node stored at the starting prefix.
+// It happens when the (sub)trie is empty or consists of a single leaf or extension folded into the upper cell.
+var ErrNoBranch = errors.New("no branch node at prefix")
+
+// BranchReader loads the branch node stored under the compacted nibble prefix, see PatriciaContext.Branch.
+// Returned branches must have plain keys dereferenced.
+type BranchReader func(compactPrefix []byte) ([]byte, error)
+
+// WalkLeaves iterates the leaves of the trie below the prefix (in nibbles) in the order of their hashed keys,
+// reading only branch nodes. It calls fn with the plain key of every leaf whose hashed key (in nibbles, see
+// KeyToHexNibbleHash) is not less than from, until fn returns false.
+// Prefixes shorter than 64 nibbles walk accounts, longer ones walk the storage of the account the prefix belongs to.
+func WalkLeaves(readBranch BranchReader, prefix []byte, from []byte, fn func(plainKey, hashedKey []byte) (bool, error)) error {
+	w := branchWalker{readBranch: readBranch, storage: len(prefix) >= 64}
+	cells, err := w.cells(prefix)
+	if err != nil {
+		return err
+	}
+	if cells == nil {
+		return ErrNoBranch
+	}
+	_, err = w.walk(prefix, cells, from, true, fn)
+	return err
+}
+
+// LastLeafBefore returns the plain key of the leaf below the prefix with the greatest hashed key less than key,
+// or nil if there is no such leaf. The prefix (in nibbles) must be a prefix of key, otherwise it follows WalkLeaves.
+func LastLeafBefore(readBranch BranchReader, prefix []byte, key []byte) ([]byte, error) {
+	w := branchWalker{readBranch: readBranch, storage: len(prefix) >= 64}
+	cells, err := w.cells(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if cells == nil {
+		return nil, ErrNoBranch
+	}
+	return w.before(prefix, cells, key)
+}
+
+type branchWalker struct {
+	readBranch BranchReader
+	storage    bool
+	cell       cell
+}
+
+// branchCell is a decoded cell of a branch node: either a leaf or a reference to the child branch.
+type branchCell struct {
+	nibble   int
+	plainKey []byte // set for leaves
+	child    []byte // prefix of the child branch otherwise
+}
+
+// cells decodes the cells of the branch at prefix, it returns nil if there is no branch or it is empty.
+func (w *branchWalker) cells(prefix []byte) ([]branchCell, error) {
+	branchData, err := w.readBranch(hexNibblesToCompactBytes(prefix))
+	if err != nil {
+		return nil, err
+	}
+	if len(branchData) < 4 {
+		return nil, nil
+	}
+	// skip touch map, cells are present for the bits of after map
+	bitmap := binary.BigEndian.Uint16(branchData[2:])
+	if bitmap == 0 {
+		return nil, nil
+	}
+	cells := make([]branchCell, 0, bits.OnesCount16(bitmap))
+	pos := 4
+	for bitset := bitmap; bitset != 0; {
+		bit := bitset & -bitset
+		bitset ^= bit
+		nibble := bits.TrailingZeros16(bit)
+		if pos >= len(branchData) {
+			return nil, fmt.Errorf("branch %x: no cell for nibble %x", prefix, nibble)
+		}
+		c := &w.cell
+		c.reset()
+		fieldBits := cellFields(branchData[pos])
+		pos++
+		if pos, err = c.fillFromFields(branchData, pos, fieldBits); err != nil {
+			return nil, fmt.Errorf("branch %x nibble %x: %w", prefix, nibble, err)
+		}
+
+		bc := branchCell{nibble: nibble}
+		switch {
+		case !w.storage && c.accountAddrLen > 0:
+			bc.plainKey = common.Copy(c.accountAddr[:c.accountAddrLen])
+		case w.storage && c.storageAddrLen > 0:
+			bc.plainKey = common.Copy(c.storageAddr[:c.storageAddrLen])
+		default:
+			bc.child = make([]byte, 0, len(prefix)+1+c.hashedExtLen)
+			bc.child = append(append(append(bc.child, prefix...), byte(nibble)), c.hashedExtension[:c.hashedExtLen]...)
+		}
+		cells = append(cells, bc)
+	}
+	return cells, nil
+}
+
+// childCells decodes the cells of the branch referenced by the cell of the parent branch, which must exist.
+func (w *branchWalker) childCells(parent []byte, child []byte) ([]branchCell, error) {
+	cells, err := w.cells(child)
+	if err != nil {
+		return nil, err
+	}
+	if cells == nil {
+		return nil, fmt.Errorf("branch %x: missing child branch %x", parent, child)
+	}
+	return cells, nil
+}
+
+// walk visits the cells of the branch at prefix. bounded means that prefix is a prefix of from, so smaller children are skipped.
+func (w *branchWalker) walk(prefix []byte, cells []branchCell, from []byte, bounded bool, fn func(plainKey, hashedKey []byte) (bool, error)) (next bool, err error) {
+	for _, c := range cells {
+		if c.plainKey != nil {
+			hashedKey := KeyToHexNibbleHash(c.plainKey)
+			if bytes.Compare(hashedKey, from) < 0 {
+				continue
+			}
+			if next, err = fn(c.plainKey, hashedKey); err != nil || !next {
+				return false, err
+			}
+			continue
+		}
+
+		childBounded := false
+		if bounded {
+			n := min(len(c.child), len(from))
+			cmp := bytes.Compare(c.child[:n], from[:n])
+			if cmp < 0 {
+				continue
+			}
+			childBounded = cmp == 0
+		}
+		childCells, err := w.childCells(prefix, c.child)
+		if err != nil {
+			return false, err
+		}
+		if next, err = w.walk(c.child, childCells, from, childBounded, fn); err != nil || !next {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// before looks for the last leaf less than key in the branch at prefix, which is a prefix of key.
+func (w *branchWalker) before(prefix []byte, cells []branchCell, key []byte) ([]byte, error) {
+	if len(prefix) >= len(key) {
+		return nil, fmt.Errorf("prefix %x is not shorter than key %x", prefix, key)
+	}
+	nibble := int(key[len(prefix)])
+	for i := len(cells) - 1; i >= 0; i-- {
+		c := cells[i]
+		if c.nibble > nibble {
+			continue
+		}
+		if c.plainKey != nil {
+			if c.nibble < nibble || bytes.Compare(KeyToHexNibbleHash(c.plainKey), key) < 0 {
+				return c.plainKey, nil
+			}
+			continue
+		}
+		n := min(len(c.child), len(key))
+		cmp := bytes.Compare(c.child[:n], key[:n])
+		if cmp > 0 || n == len(key) {
+			continue
+		}
+		childCells, err := w.childCells(prefix, c.child)
+		if err != nil {
+			return nil, err
+		}
+		if cmp < 0 {
+			return w.last(c.child, childCells)
+		}
+		plainKey, err := w.before(c.child, childCells, key)
+		if err != nil || plainKey != nil {
+			return plainKey, err
+		}
+	}
+	return nil, nil
+}
+
+// last returns the leaf with the greatest hashed key in the branch at prefix.
+func (w *branchWalker) last(prefix []byte, cells []branchCell) ([]byte, error) {
+	for {
+		c := cells[len(cells)-1]
+		if c.plainKey != nil {
+			return c.plainKey, nil
+		}
+		childCells, err := w.childCells(prefix, c.child)
+		if err != nil {
+			return nil, err
+		}
+		prefix, cells = c.child, childCells
+	}
+}
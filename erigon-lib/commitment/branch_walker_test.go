@@ -0,0 +1,106 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+)
+
+func TestWalkLeaves(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ms := NewMockState(t)
+	hph := NewHexPatriciaHashed(length.Addr, ms)
+
+	builder := NewUpdateBuilder()
+	for i := 0; i < 100; i++ {
+		addr := make([]byte, length.Addr)
+		addr[0], addr[length.Addr-1] = byte(i), byte(i*7)
+		builder.Balance(hex.EncodeToString(addr), uint64(i+1))
+	}
+	plainKeys, updates := builder.Build()
+	require.NoError(t, ms.applyPlainUpdates(plainKeys, updates))
+	upds := WrapKeyUpdates(t, ModeDirect, KeyToHexNibbleHash, plainKeys, updates)
+	defer upds.Close()
+	_, err := hph.Process(ctx, upds, "")
+	require.NoError(t, err)
+
+	hashedKeys := make([][]byte, len(plainKeys))
+	for i, pk := range plainKeys {
+		hashedKeys[i] = KeyToHexNibbleHash(pk)
+	}
+	slices.SortFunc(hashedKeys, bytes.Compare)
+
+	readBranch := func(prefix []byte) ([]byte, error) {
+		branch, _, err := ms.Branch(prefix)
+		return branch, err
+	}
+	walk := func(from []byte, limit int) (walked [][]byte) {
+		err := WalkLeaves(readBranch, nil, from, func(plainKey, hashedKey []byte) (bool, error) {
+			require.Equal(t, KeyToHexNibbleHash(plainKey), hashedKey)
+			walked = append(walked, hashedKey)
+			return len(walked) < limit, nil
+		})
+		require.NoError(t, err)
+		return walked
+	}
+
+	t.Run("all", func(t *testing.T) {
+		require.Equal(t, hashedKeys, walk(make([]byte, 64), len(hashedKeys)+1))
+	})
+	t.Run("from existing key", func(t *testing.T) {
+		require.Equal(t, hashedKeys[40:50], walk(hashedKeys[40], 10))
+	})
+	t.Run("from missing key", func(t *testing.T) {
+		from := common.Copy(hashedKeys[40])
+		from[63]++ // hashed keys differ well before the last nibble
+		require.Equal(t, hashedKeys[41:], walk(from, len(hashedKeys)))
+	})
+	t.Run("last leaf before", func(t *testing.T) {
+		before := func(key []byte) []byte {
+			plainKey, err := LastLeafBefore(readBranch, nil, key)
+			require.NoError(t, err)
+			if plainKey == nil {
+				return nil
+			}
+			return KeyToHexNibbleHash(plainKey)
+		}
+		require.Nil(t, before(hashedKeys[0]))
+		require.Equal(t, hashedKeys[39], before(hashedKeys[40]))
+		from := common.Copy(hashedKeys[40])
+		from[63]++
+		require.Equal(t, hashedKeys[40], before(from))
+		last := bytes.Repeat([]byte{0xf}, 64)
+		require.Equal(t, hashedKeys[len(hashedKeys)-1], before(last))
+	})
+	t.Run("no branch", func(t *testing.T) {
+		err := WalkLeaves(readBranch, hashedKeys[0], make([]byte, 128), func(plainKey, hashedKey []byte) (bool, error) {
+			return true, nil
+		})
+		require.ErrorIs(t, err, ErrNoBranch)
+	})
+}
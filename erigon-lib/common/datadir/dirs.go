@@ -54,6 +54,8 @@ type Dirs struct {
 	CaplinIndexing   string
 	CaplinLatest     string
 	CaplinGenesis    string
+	CaplinValidator  string
+	Supply           string
 }
 
 func New(datadir string) Dirs {
@@ -111,6 +113,8 @@ func Open(datadir string) Dirs {
 		CaplinIndexing:   filepath.Join(datadir, "caplin", "indexing"),
 		CaplinLatest:     filepath.Join(datadir, "caplin", "latest"),
 		CaplinGenesis:    filepath.Join(datadir, "caplin", "genesis-state"),
+		CaplinValidator:  filepath.Join(datadir, "caplin", "validator"),
+		Supply:           filepath.Join(datadir, "supply"),
 	}
 	return dirs
 }
@@ -123,3 +123,55 @@ func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest,
 func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
 	return s.server.GetBlobs(ctx, in)
 }
+
+// -- start Events
+
+func (s *TxPoolClient) Events(ctx context.Context, in *txpool_proto.EventsRequest, opts ...grpc.CallOption) (txpool_proto.Txpool_EventsClient, error) {
+	ch := make(chan *eventsReply, 16384)
+	streamServer := &TxPoolEventsS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.Events(in, streamServer))
+	}()
+	return &TxPoolEventsC{ch: ch, ctx: ctx}, nil
+}
+
+type eventsReply struct {
+	r   *txpool_proto.Event
+	err error
+}
+
+type TxPoolEventsS struct {
+	ch  chan *eventsReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolEventsS) Send(m *txpool_proto.Event) error {
+	s.ch <- &eventsReply{r: m}
+	return nil
+}
+func (s *TxPoolEventsS) Context() context.Context { return s.ctx }
+func (s *TxPoolEventsS) Err(err error) {
+	if err == nil {
+		return
+	}
+	s.ch <- &eventsReply{err: err}
+}
+
+type TxPoolEventsC struct {
+	ch  chan *eventsReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolEventsC) Recv() (*txpool_proto.Event, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *TxPoolEventsC) Context() context.Context { return c.ctx }
+
+// -- end Events
@@ -68,7 +68,8 @@ func TLS(tlsCACert, tlsCertFile, tlsKeyFile string) (credentials.TransportCreden
 	}), nil
 }
 
-func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.Server {
+// NewServer creates the gRPC server, extraOpts are appended to the defaults (e.g. tracing stats handlers).
+func NewServer(rateLimit uint32, creds credentials.TransportCredentials, extraOpts ...grpc.ServerOption) *grpc.Server {
 	var (
 		streamInterceptors []grpc.StreamServerInterceptor
 		unaryInterceptors  []grpc.UnaryServerInterceptor
@@ -95,6 +96,7 @@ func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.S
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 		grpc.Creds(creds),
 	}
+	opts = append(opts, extraOpts...)
 	grpcServer := grpc.NewServer(opts...)
 	reflection.Register(grpcServer)
 
@@ -105,7 +107,8 @@ func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.S
 	return grpcServer
 }
 
-func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.ClientConn, error) {
+// Connect dials the gRPC server, extraOpts are appended to the defaults (e.g. tracing stats handlers).
+func Connect(creds credentials.TransportCredentials, dialAddress string, extraOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	var dialOpts []grpc.DialOption
 
 	backoffCfg := backoff.DefaultConfig
@@ -121,6 +124,7 @@ func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.
 	} else {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
 	}
+	dialOpts = append(dialOpts, extraOpts...)
 
 	//if opts.inMemConn != nil {
 	//	dialOpts = append(dialOpts, grpc.WithContextDialer(func(ctx context.Context, url string) (net.Conn, error) {
@@ -130,6 +130,64 @@ func (AllReply_TxnType) EnumDescriptor() ([]byte, []int) {
 	return file_txpool_txpool_proto_rawDescGZIP(), []int{8, 0}
 }
 
+type Event_Kind int32
+
+const (
+	Event_ADDED    Event_Kind = 0 // Accepted into the pool, always lands in the queued sub-pool first
+	Event_PROMOTED Event_Kind = 1 // Moved to a better sub-pool
+	Event_DEMOTED  Event_Kind = 2 // Moved to a worse sub-pool
+	Event_REPLACED Event_Kind = 3 // Replaced by a transaction with the same sender and nonce, see replaced_by
+	Event_EVICTED  Event_Kind = 4 // Dropped from the pool, see reason
+	Event_MINED    Event_Kind = 5 // Included into a block and removed from the pool
+)
+
+// Enum value maps for Event_Kind.
+var (
+	Event_Kind_name = map[int32]string{
+		0: "ADDED",
+		1: "PROMOTED",
+		2: "DEMOTED",
+		3: "REPLACED",
+		4: "EVICTED",
+		5: "MINED",
+	}
+	Event_Kind_value = map[string]int32{
+		"ADDED":    0,
+		"PROMOTED": 1,
+		"DEMOTED":  2,
+		"REPLACED": 3,
+		"EVICTED":  4,
+		"MINED":    5,
+	}
+)
+
+func (x Event_Kind) Enum() *Event_Kind {
+	p := new(Event_Kind)
+	*p = x
+	return p
+}
+
+func (x Event_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_txpool_txpool_proto_enumTypes[2].Descriptor()
+}
+
+func (Event_Kind) Type() protoreflect.EnumType {
+	return &file_txpool_txpool_proto_enumTypes[2]
+}
+
+func (x Event_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event_Kind.Descriptor instead.
+func (Event_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17, 0}
+}
+
 type TxHashes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Hashes        []*typesproto.H256     `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
@@ -440,6 +498,10 @@ func (x *OnAddReply) GetRplTxs() [][]byte {
 
 type AllRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        *typesproto.H160       `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`                                                          // Only transactions of this sender, all senders if unset
+	TxnTypes      []AllReply_TxnType     `protobuf:"varint,2,rep,packed,name=txn_types,json=txnTypes,proto3,enum=txpool.AllReply_TxnType" json:"txn_types,omitempty"` // Only transactions of these sub-pools, all sub-pools if empty
+	Offset        uint64                 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                                                         // Matching transactions to skip, they are ordered by sender and nonce
+	Limit         uint64                 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                                                           // Maximum number of transactions to return, unlimited if zero
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -474,6 +536,34 @@ func (*AllRequest) Descriptor() ([]byte, []int) {
 	return file_txpool_txpool_proto_rawDescGZIP(), []int{7}
 }
 
+func (x *AllRequest) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *AllRequest) GetTxnTypes() []AllReply_TxnType {
+	if x != nil {
+		return x.TxnTypes
+	}
+	return nil
+}
+
+func (x *AllRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AllRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type AllReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Txs           []*AllReply_Tx         `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
@@ -850,18 +940,163 @@ func (x *GetBlobsReply) GetProofs() [][]byte {
 	return nil
 }
 
+type EventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Senders       []*typesproto.H160     `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"` // Only events of these senders, all senders if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventsRequest) Reset() {
+	*x = EventsRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventsRequest) ProtoMessage() {}
+
+func (x *EventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventsRequest.ProtoReflect.Descriptor instead.
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EventsRequest) GetSenders() []*typesproto.H160 {
+	if x != nil {
+		return x.Senders
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          Event_Kind             `protobuf:"varint,1,opt,name=kind,proto3,enum=txpool.Event_Kind" json:"kind,omitempty"`
+	Hash          *typesproto.H256       `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Sender        *typesproto.H160       `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce         uint64                 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	SubPool       AllReply_TxnType       `protobuf:"varint,5,opt,name=sub_pool,json=subPool,proto3,enum=txpool.AllReply_TxnType" json:"sub_pool,omitempty"` // Sub-pool after the event, only set for ADDED, PROMOTED and DEMOTED
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`                                                // Discard reason for REPLACED, EVICTED and MINED
+	ReplacedBy    *typesproto.H256       `protobuf:"bytes,7,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`                      // Hash of the replacing transaction for REPLACED
+	BlockNum      uint64                 `protobuf:"varint,8,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`                           // Block the pool was at, or was applying, when the event happened
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Event) GetKind() Event_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Event_ADDED
+}
+
+func (x *Event) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Event) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *Event) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Event) GetSubPool() AllReply_TxnType {
+	if x != nil {
+		return x.SubPool
+	}
+	return AllReply_PENDING
+}
+
+func (x *Event) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Event) GetReplacedBy() *typesproto.H256 {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return nil
+}
+
+func (x *Event) GetBlockNum() uint64 {
+	if x != nil {
+		return x.BlockNum
+	}
+	return 0
+}
+
 type AllReply_Tx struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TxnType       AllReply_TxnType       `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
 	Sender        *typesproto.H160       `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
 	RlpTx         []byte                 `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	SubPool       uint32                 `protobuf:"varint,4,opt,name=sub_pool,json=subPool,proto3" json:"sub_pool,omitempty"` // SubPoolMarker bitset of the transaction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AllReply_Tx) Reset() {
 	*x = AllReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[16]
+	mi := &file_txpool_txpool_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -873,7 +1108,7 @@ func (x *AllReply_Tx) String() string {
 func (*AllReply_Tx) ProtoMessage() {}
 
 func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[16]
+	mi := &file_txpool_txpool_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,6 +1145,13 @@ func (x *AllReply_Tx) GetRlpTx() []byte {
 	return nil
 }
 
+func (x *AllReply_Tx) GetSubPool() uint32 {
+	if x != nil {
+		return x.SubPool
+	}
+	return 0
+}
+
 type PendingReply_Tx struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Sender        *typesproto.H160       `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
@@ -921,7 +1163,7 @@ type PendingReply_Tx struct {
 
 func (x *PendingReply_Tx) Reset() {
 	*x = PendingReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+	mi := &file_txpool_txpool_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -933,7 +1175,7 @@ func (x *PendingReply_Tx) String() string {
 func (*PendingReply_Tx) ProtoMessage() {}
 
 func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+	mi := &file_txpool_txpool_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -990,15 +1232,20 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\fOnAddRequest\"%\n" +
 	"\n" +
 	"OnAddReply\x12\x17\n" +
-	"\arpl_txs\x18\x01 \x03(\fR\x06rplTxs\"\f\n" +
+	"\arpl_txs\x18\x01 \x03(\fR\x06rplTxs\"\x96\x01\n" +
 	"\n" +
-	"AllRequest\"\xda\x01\n" +
+	"AllRequest\x12#\n" +
+	"\x06sender\x18\x01 \x01(\v2\v.types.H160R\x06sender\x125\n" +
+	"\ttxn_types\x18\x02 \x03(\x0e2\x18.txpool.AllReply.TxnTypeR\btxnTypes\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x04R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x04R\x05limit\"\xf6\x01\n" +
 	"\bAllReply\x12%\n" +
-	"\x03txs\x18\x01 \x03(\v2\x13.txpool.AllReply.TxR\x03txs\x1au\n" +
+	"\x03txs\x18\x01 \x03(\v2\x13.txpool.AllReply.TxR\x03txs\x1a\x90\x01\n" +
 	"\x02Tx\x123\n" +
 	"\btxn_type\x18\x01 \x01(\x0e2\x18.txpool.AllReply.TxnTypeR\atxnType\x12#\n" +
 	"\x06sender\x18\x02 \x01(\v2\v.types.H160R\x06sender\x12\x15\n" +
-	"\x06rlp_tx\x18\x03 \x01(\fR\x05rlpTx\"0\n" +
+	"\x06rlp_tx\x18\x03 \x01(\fR\x05rlpTx\x12\x19\n" +
+	"\bsub_pool\x18\x04 \x01(\rR\asubPool\"0\n" +
 	"\aTxnType\x12\v\n" +
 	"\aPENDING\x10\x00\x12\n" +
 	"\n" +
@@ -1026,14 +1273,33 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"blobHashes\"=\n" +
 	"\rGetBlobsReply\x12\x14\n" +
 	"\x05blobs\x18\x01 \x03(\fR\x05blobs\x12\x16\n" +
-	"\x06proofs\x18\x02 \x03(\fR\x06proofs*l\n" +
+	"\x06proofs\x18\x02 \x03(\fR\x06proofs\"6\n" +
+	"\rEventsRequest\x12%\n" +
+	"\asenders\x18\x01 \x03(\v2\v.types.H160R\asenders\"\xf7\x02\n" +
+	"\x05Event\x12&\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x12.txpool.Event.KindR\x04kind\x12\x1f\n" +
+	"\x04hash\x18\x02 \x01(\v2\v.types.H256R\x04hash\x12#\n" +
+	"\x06sender\x18\x03 \x01(\v2\v.types.H160R\x06sender\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\x04R\x05nonce\x123\n" +
+	"\bsub_pool\x18\x05 \x01(\x0e2\x18.txpool.AllReply.TxnTypeR\asubPool\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12,\n" +
+	"\vreplaced_by\x18\a \x01(\v2\v.types.H256R\n" +
+	"replacedBy\x12\x1b\n" +
+	"\tblock_num\x18\b \x01(\x04R\bblockNum\"R\n" +
+	"\x04Kind\x12\t\n" +
+	"\x05ADDED\x10\x00\x12\f\n" +
+	"\bPROMOTED\x10\x01\x12\v\n" +
+	"\aDEMOTED\x10\x02\x12\f\n" +
+	"\bREPLACED\x10\x03\x12\v\n" +
+	"\aEVICTED\x10\x04\x12\t\n" +
+	"\x05MINED\x10\x05*l\n" +
 	"\fImportResult\x12\v\n" +
 	"\aSUCCESS\x10\x00\x12\x12\n" +
 	"\x0eALREADY_EXISTS\x10\x01\x12\x0f\n" +
 	"\vFEE_TOO_LOW\x10\x02\x12\t\n" +
 	"\x05STALE\x10\x03\x12\v\n" +
 	"\aINVALID\x10\x04\x12\x12\n" +
-	"\x0eINTERNAL_ERROR\x10\x052\xa8\x04\n" +
+	"\x0eINTERNAL_ERROR\x10\x052\xda\x04\n" +
 	"\x06Txpool\x126\n" +
 	"\aVersion\x12\x16.google.protobuf.Empty\x1a\x13.types.VersionReply\x121\n" +
 	"\vFindUnknown\x12\x10.txpool.TxHashes\x1a\x10.txpool.TxHashes\x12+\n" +
@@ -1044,7 +1310,8 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\x05OnAdd\x12\x14.txpool.OnAddRequest\x1a\x12.txpool.OnAddReply0\x01\x124\n" +
 	"\x06Status\x12\x15.txpool.StatusRequest\x1a\x13.txpool.StatusReply\x121\n" +
 	"\x05Nonce\x12\x14.txpool.NonceRequest\x1a\x12.txpool.NonceReply\x12:\n" +
-	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
+	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReply\x120\n" +
+	"\x06Events\x12\x15.txpool.EventsRequest\x1a\r.txpool.Event0\x01B\x16Z\x14./txpool;txpoolprotob\x06proto3"
 
 var (
 	file_txpool_txpool_proto_rawDescOnce sync.Once
@@ -1058,70 +1325,83 @@ func file_txpool_txpool_proto_rawDescGZIP() []byte {
 	return file_txpool_txpool_proto_rawDescData
 }
 
-var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_txpool_txpool_proto_goTypes = []any{
 	(ImportResult)(0),               // 0: txpool.ImportResult
 	(AllReply_TxnType)(0),           // 1: txpool.AllReply.TxnType
-	(*TxHashes)(nil),                // 2: txpool.TxHashes
-	(*AddRequest)(nil),              // 3: txpool.AddRequest
-	(*AddReply)(nil),                // 4: txpool.AddReply
-	(*TransactionsRequest)(nil),     // 5: txpool.TransactionsRequest
-	(*TransactionsReply)(nil),       // 6: txpool.TransactionsReply
-	(*OnAddRequest)(nil),            // 7: txpool.OnAddRequest
-	(*OnAddReply)(nil),              // 8: txpool.OnAddReply
-	(*AllRequest)(nil),              // 9: txpool.AllRequest
-	(*AllReply)(nil),                // 10: txpool.AllReply
-	(*PendingReply)(nil),            // 11: txpool.PendingReply
-	(*StatusRequest)(nil),           // 12: txpool.StatusRequest
-	(*StatusReply)(nil),             // 13: txpool.StatusReply
-	(*NonceRequest)(nil),            // 14: txpool.NonceRequest
-	(*NonceReply)(nil),              // 15: txpool.NonceReply
-	(*GetBlobsRequest)(nil),         // 16: txpool.GetBlobsRequest
-	(*GetBlobsReply)(nil),           // 17: txpool.GetBlobsReply
-	(*AllReply_Tx)(nil),             // 18: txpool.AllReply.Tx
-	(*PendingReply_Tx)(nil),         // 19: txpool.PendingReply.Tx
-	(*typesproto.H256)(nil),         // 20: types.H256
-	(*typesproto.H160)(nil),         // 21: types.H160
-	(*emptypb.Empty)(nil),           // 22: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 23: types.VersionReply
+	(Event_Kind)(0),                 // 2: txpool.Event.Kind
+	(*TxHashes)(nil),                // 3: txpool.TxHashes
+	(*AddRequest)(nil),              // 4: txpool.AddRequest
+	(*AddReply)(nil),                // 5: txpool.AddReply
+	(*TransactionsRequest)(nil),     // 6: txpool.TransactionsRequest
+	(*TransactionsReply)(nil),       // 7: txpool.TransactionsReply
+	(*OnAddRequest)(nil),            // 8: txpool.OnAddRequest
+	(*OnAddReply)(nil),              // 9: txpool.OnAddReply
+	(*AllRequest)(nil),              // 10: txpool.AllRequest
+	(*AllReply)(nil),                // 11: txpool.AllReply
+	(*PendingReply)(nil),            // 12: txpool.PendingReply
+	(*StatusRequest)(nil),           // 13: txpool.StatusRequest
+	(*StatusReply)(nil),             // 14: txpool.StatusReply
+	(*NonceRequest)(nil),            // 15: txpool.NonceRequest
+	(*NonceReply)(nil),              // 16: txpool.NonceReply
+	(*GetBlobsRequest)(nil),         // 17: txpool.GetBlobsRequest
+	(*GetBlobsReply)(nil),           // 18: txpool.GetBlobsReply
+	(*EventsRequest)(nil),           // 19: txpool.EventsRequest
+	(*Event)(nil),                   // 20: txpool.Event
+	(*AllReply_Tx)(nil),             // 21: txpool.AllReply.Tx
+	(*PendingReply_Tx)(nil),         // 22: txpool.PendingReply.Tx
+	(*typesproto.H256)(nil),         // 23: types.H256
+	(*typesproto.H160)(nil),         // 24: types.H160
+	(*emptypb.Empty)(nil),           // 25: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil), // 26: types.VersionReply
 }
 var file_txpool_txpool_proto_depIdxs = []int32{
-	20, // 0: txpool.TxHashes.hashes:type_name -> types.H256
+	23, // 0: txpool.TxHashes.hashes:type_name -> types.H256
 	0,  // 1: txpool.AddReply.imported:type_name -> txpool.ImportResult
-	20, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
-	18, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
-	19, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
-	21, // 5: txpool.NonceRequest.address:type_name -> types.H160
-	20, // 6: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
-	1,  // 7: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
-	21, // 8: txpool.AllReply.Tx.sender:type_name -> types.H160
-	21, // 9: txpool.PendingReply.Tx.sender:type_name -> types.H160
-	22, // 10: txpool.Txpool.Version:input_type -> google.protobuf.Empty
-	2,  // 11: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
-	3,  // 12: txpool.Txpool.Add:input_type -> txpool.AddRequest
-	5,  // 13: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
-	9,  // 14: txpool.Txpool.All:input_type -> txpool.AllRequest
-	22, // 15: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
-	7,  // 16: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
-	12, // 17: txpool.Txpool.Status:input_type -> txpool.StatusRequest
-	14, // 18: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
-	16, // 19: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
-	23, // 20: txpool.Txpool.Version:output_type -> types.VersionReply
-	2,  // 21: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
-	4,  // 22: txpool.Txpool.Add:output_type -> txpool.AddReply
-	6,  // 23: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
-	10, // 24: txpool.Txpool.All:output_type -> txpool.AllReply
-	11, // 25: txpool.Txpool.Pending:output_type -> txpool.PendingReply
-	8,  // 26: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
-	13, // 27: txpool.Txpool.Status:output_type -> txpool.StatusReply
-	15, // 28: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
-	17, // 29: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
-	20, // [20:30] is the sub-list for method output_type
-	10, // [10:20] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	23, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
+	24, // 3: txpool.AllRequest.sender:type_name -> types.H160
+	1,  // 4: txpool.AllRequest.txn_types:type_name -> txpool.AllReply.TxnType
+	21, // 5: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
+	22, // 6: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
+	24, // 7: txpool.NonceRequest.address:type_name -> types.H160
+	23, // 8: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
+	24, // 9: txpool.EventsRequest.senders:type_name -> types.H160
+	2,  // 10: txpool.Event.kind:type_name -> txpool.Event.Kind
+	23, // 11: txpool.Event.hash:type_name -> types.H256
+	24, // 12: txpool.Event.sender:type_name -> types.H160
+	1,  // 13: txpool.Event.sub_pool:type_name -> txpool.AllReply.TxnType
+	23, // 14: txpool.Event.replaced_by:type_name -> types.H256
+	1,  // 15: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
+	24, // 16: txpool.AllReply.Tx.sender:type_name -> types.H160
+	24, // 17: txpool.PendingReply.Tx.sender:type_name -> types.H160
+	25, // 18: txpool.Txpool.Version:input_type -> google.protobuf.Empty
+	3,  // 19: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
+	4,  // 20: txpool.Txpool.Add:input_type -> txpool.AddRequest
+	6,  // 21: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
+	10, // 22: txpool.Txpool.All:input_type -> txpool.AllRequest
+	25, // 23: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
+	8,  // 24: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
+	13, // 25: txpool.Txpool.Status:input_type -> txpool.StatusRequest
+	15, // 26: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
+	17, // 27: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
+	19, // 28: txpool.Txpool.Events:input_type -> txpool.EventsRequest
+	26, // 29: txpool.Txpool.Version:output_type -> types.VersionReply
+	3,  // 30: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
+	5,  // 31: txpool.Txpool.Add:output_type -> txpool.AddReply
+	7,  // 32: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
+	11, // 33: txpool.Txpool.All:output_type -> txpool.AllReply
+	12, // 34: txpool.Txpool.Pending:output_type -> txpool.PendingReply
+	9,  // 35: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
+	14, // 36: txpool.Txpool.Status:output_type -> txpool.StatusReply
+	16, // 37: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
+	18, // 38: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
+	20, // 39: txpool.Txpool.Events:output_type -> txpool.Event
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_txpool_txpool_proto_init() }
@@ -1134,8 +1414,8 @@ func file_txpool_txpool_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_txpool_txpool_proto_rawDesc), len(file_txpool_txpool_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   18,
+			NumEnums:      3,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,440 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	mdbx2 "github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const (
+	ManifestFileName = "manifest.json"
+	manifestVersion  = 1
+
+	partialSuffix    = ".partial"
+	journalFileName  = "journal.jsonl"
+	backupNameLayout = "20060102-150405"
+
+	chaindataEntry = "chaindata/mdbx.dat"
+)
+
+// ManifestFile describes one file of a backup, by its slash separated path relative to the
+// datadir.
+type ManifestFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest lists the files of a complete backup. Previous names the backup the unchanged
+// immutable files were hard-linked from, if any.
+type Manifest struct {
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	Previous  string         `json:"previous,omitempty"`
+	Files     []ManifestFile `json:"files"`
+}
+
+func ReadManifest(backupDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(backupDir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFileName, err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("%s: unsupported version %d", ManifestFileName, m.Version)
+	}
+	return &m, nil
+}
+
+// latestBackup returns the name of the most recent complete backup in root, or "".
+func latestBackup(root string) (string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	latest := ""
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasSuffix(e.Name(), partialSuffix) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(root, e.Name(), ManifestFileName)); err != nil {
+			continue
+		}
+		// names are timestamps, so they sort chronologically
+		if e.Name() > latest {
+			latest = e.Name()
+		}
+	}
+	return latest, nil
+}
+
+// newBackupName names a backup after the current time, made unique within root.
+func newBackupName(root string) (string, error) {
+	base := time.Now().UTC().Format(backupNameLayout)
+	name := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(root, name)); os.IsNotExist(err) {
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// partialBackup returns the name of an interrupted backup in root, or "".
+func partialBackup(root string) (string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	for _, e := range entries {
+		if e.IsDir() && strings.HasSuffix(e.Name(), partialSuffix) {
+			return strings.TrimSuffix(e.Name(), partialSuffix), nil
+		}
+	}
+	return "", nil
+}
+
+// isImmutableFile reports whether a file of the snapshots directory is part of a backup:
+// everything but the files still being written.
+func isImmutableFile(name string) bool {
+	return !strings.HasSuffix(name, ".tmp") && !strings.Contains(name, ".tmp.") && !strings.HasSuffix(name, ".lock")
+}
+
+// listImmutableFiles returns the files of the snapshots directory, relative to the datadir.
+func listImmutableFiles(dirs datadir.Dirs) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dirs.Snap, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || !isImmutableFile(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(dirs.DataDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+type journal struct {
+	f    *os.File
+	done map[string]ManifestFile
+}
+
+func openJournal(path string) (*journal, error) {
+	j := &journal{done: map[string]ManifestFile{}}
+	if data, err := os.ReadFile(path); err == nil {
+		scanner := bufio.NewScanner(strings.NewReader(string(data)))
+		for scanner.Scan() {
+			var entry ManifestFile
+			// a torn last line is the entry being written when the backup was interrupted
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				break
+			}
+			j.done[entry.Path] = entry
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	j.f = f
+	return j, nil
+}
+
+func (j *journal) add(entry ManifestFile) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	j.done[entry.Path] = entry
+	return nil
+}
+
+// Hot makes a consistent backup of a datadir, even while the node runs, into a new
+// subdirectory of root and returns its path.
+//
+// The chaindata is copied under a single read transaction, and the immutable files of the
+// snapshots directory are captured while that transaction is open, so they match the state
+// of the database. Immutable files already present in the latest backup of root are
+// hard-linked from it, new ones are hard-linked from the datadir when it is on the same file
+// system, or copied. An interrupted backup is resumed by the next call.
+func Hot(ctx context.Context, dirs datadir.Dirs, root string, logger log.Logger) (string, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return "", err
+	}
+	previous, err := latestBackup(root)
+	if err != nil {
+		return "", err
+	}
+	var previousFiles map[string]ManifestFile
+	if previous != "" {
+		m, err := ReadManifest(filepath.Join(root, previous))
+		if err != nil {
+			return "", fmt.Errorf("previous backup %s: %w", previous, err)
+		}
+		previousFiles = make(map[string]ManifestFile, len(m.Files))
+		for _, f := range m.Files {
+			previousFiles[f.Path] = f
+		}
+	}
+
+	name, err := partialBackup(root)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		if name, err = newBackupName(root); err != nil {
+			return "", err
+		}
+	} else {
+		logger.Info("[backup] Resuming interrupted backup", "name", name)
+	}
+	partial := filepath.Join(root, name+partialSuffix)
+	if err := os.MkdirAll(partial, 0o755); err != nil {
+		return "", err
+	}
+	j, err := openJournal(filepath.Join(partial, journalFileName))
+	if err != nil {
+		return "", err
+	}
+	defer j.f.Close()
+
+	src, err := mdbx2.New(kv.ChainDB, logger).Path(dirs.Chaindata).
+		RoTxsLimiter(semaphore.NewWeighted(ReadAheadThreads)).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(kv.ChainDB) }).
+		Accede(true).
+		Open(ctx)
+	if err != nil {
+		return "", fmt.Errorf("open chaindata: %w", err)
+	}
+	defer src.Close()
+	// the read transaction pins the state of the database, the files are captured before it ends
+	srcTx, err := src.BeginRo(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer srcTx.Rollback()
+
+	if err := captureImmutableFiles(ctx, dirs, root, previous, previousFiles, partial, j, logger); err != nil {
+		return "", err
+	}
+	if _, ok := j.done[chaindataEntry]; !ok {
+		if err := copyChaindata(ctx, src, srcTx, filepath.Join(partial, "chaindata"), logger); err != nil {
+			return "", fmt.Errorf("copy chaindata: %w", err)
+		}
+		srcTx.Rollback()
+		entry, err := hashEntry(filepath.Join(partial, filepath.FromSlash(chaindataEntry)), chaindataEntry)
+		if err != nil {
+			return "", err
+		}
+		if err := j.add(entry); err != nil {
+			return "", err
+		}
+	}
+
+	m := Manifest{Version: manifestVersion, CreatedAt: time.Now().UTC(), Previous: previous}
+	for _, entry := range j.done {
+		m.Files = append(m.Files, entry)
+	}
+	sort.Slice(m.Files, func(a, b int) bool { return m.Files[a].Path < m.Files[b].Path })
+	if err := writeManifest(partial, &m); err != nil {
+		return "", err
+	}
+	j.f.Close()
+	if err := os.Remove(filepath.Join(partial, journalFileName)); err != nil {
+		return "", err
+	}
+	dst := filepath.Join(root, name)
+	if err := os.Rename(partial, dst); err != nil {
+		return "", err
+	}
+	logger.Info("[backup] Done", "path", dst, "files", len(m.Files), "previous", previous)
+	return dst, nil
+}
+
+// captureImmutableFiles links or copies the immutable files into the backup. Files merged
+// away between listing and linking are replaced by the merged file, so the directory is
+// listed again until a pass captures everything it listed.
+func captureImmutableFiles(ctx context.Context, dirs datadir.Dirs, root, previous string, previousFiles map[string]ManifestFile,
+	partial string, j *journal, logger log.Logger) error {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for {
+		files, err := listImmutableFiles(dirs)
+		if err != nil {
+			return err
+		}
+		missing := 0
+		for i, rel := range files {
+			if _, ok := j.done[rel]; ok {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-logEvery.C:
+				logger.Info("[backup] Capturing files", "progress", fmt.Sprintf("%d/%d", i, len(files)))
+			default:
+			}
+			entry, err := captureFile(dirs, root, previous, previousFiles, partial, rel)
+			if errors.Is(err, fs.ErrNotExist) {
+				logger.Debug("[backup] File removed while capturing", "file", rel)
+				missing++
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", rel, err)
+			}
+			if err := j.add(entry); err != nil {
+				return err
+			}
+		}
+		if missing == 0 {
+			return nil
+		}
+	}
+}
+
+func captureFile(dirs datadir.Dirs, root, previous string, previousFiles map[string]ManifestFile, partial, rel string) (ManifestFile, error) {
+	srcPath := filepath.Join(dirs.DataDir, filepath.FromSlash(rel))
+	dstPath := filepath.Join(partial, filepath.FromSlash(rel))
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o755); err != nil {
+		return ManifestFile{}, err
+	}
+	// a leftover of an interrupted attempt
+	if err := os.Remove(dstPath); err != nil && !os.IsNotExist(err) {
+		return ManifestFile{}, err
+	}
+
+	// immutable file names are unique per content, the size guards against a file rebuilt
+	// with the same name
+	if prev, ok := previousFiles[rel]; ok && prev.Size == info.Size() {
+		if err := os.Link(filepath.Join(root, previous, filepath.FromSlash(rel)), dstPath); err == nil {
+			return prev, nil
+		}
+	}
+	if err := os.Link(srcPath, dstPath); err != nil {
+		if _, err := copyFile(srcPath, dstPath); err != nil {
+			return ManifestFile{}, err
+		}
+	}
+	return hashEntry(dstPath, rel)
+}
+
+func copyChaindata(ctx context.Context, src kv.RoDB, srcTx kv.Tx, to string, logger log.Logger) error {
+	if err := os.RemoveAll(to); err != nil {
+		return err
+	}
+	info, err := src.(*mdbx2.MdbxKV).Env().Info(nil)
+	if err != nil {
+		return err
+	}
+	dst, err := mdbx2.New(kv.ChainDB, logger).Path(to).
+		PageSize(src.PageSize()).
+		MapSize(datasize.ByteSize(info.Geo.Upper)).
+		GrowthStep(4 * datasize.GB).
+		WriteMap(true).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(kv.ChainDB) }).
+		Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for name, b := range src.AllTables() {
+		if b.IsDeprecated {
+			continue
+		}
+		if err := backupTable(ctx, srcTx, dst, name, logEvery, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, ManifestFileName+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, ManifestFileName))
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func newTestDatadir(t *testing.T) datadir.Dirs {
+	t.Helper()
+	dirs := datadir.New(t.TempDir())
+	db := mdbx.New(kv.ChainDB, log.New()).Path(dirs.Chaindata).MustOpen()
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		return tx.Put(kv.HeaderNumber, []byte("hash"), []byte("number"))
+	}))
+	db.Close()
+	writeFile(t, filepath.Join(dirs.Snap, "v1.0-000000-000500-headers.seg"), "headers")
+	writeFile(t, filepath.Join(dirs.SnapDomain, "v1.0-accounts.0-32.kv"), "accounts")
+	writeFile(t, filepath.Join(dirs.SnapDomain, "v1.0-accounts.32-48.kv.tmp"), "being written")
+	return dirs
+}
+
+func manifestPaths(m *Manifest) []string {
+	paths := make([]string, len(m.Files))
+	for i, f := range m.Files {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+func sameFile(t *testing.T, a, b string) bool {
+	t.Helper()
+	ai, err := os.Stat(a)
+	require.NoError(t, err)
+	bi, err := os.Stat(b)
+	require.NoError(t, err)
+	return os.SameFile(ai, bi)
+}
+
+func TestHotBackupAndRestore(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	dirs := newTestDatadir(t)
+	root := t.TempDir()
+
+	first, err := Hot(ctx, dirs, root, logger)
+	require.NoError(t, err)
+	m, err := ReadManifest(first)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"chaindata/mdbx.dat",
+		"snapshots/domain/v1.0-accounts.0-32.kv",
+		"snapshots/v1.0-000000-000500-headers.seg",
+	}, manifestPaths(m))
+	require.Empty(t, m.Previous)
+
+	// the second backup only brings the new file, the others are linked from the first one
+	writeFile(t, filepath.Join(dirs.SnapDomain, "v1.0-accounts.32-48.kv"), "more accounts")
+	second, err := Hot(ctx, dirs, root, logger)
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+	m, err = ReadManifest(second)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Base(first), m.Previous)
+	require.Len(t, m.Files, 4)
+	require.True(t, sameFile(t, filepath.Join(first, "snapshots/domain/v1.0-accounts.0-32.kv"), filepath.Join(second, "snapshots/domain/v1.0-accounts.0-32.kv")))
+	_, err = Verify(ctx, second, logger)
+	require.NoError(t, err)
+
+	restored := datadir.Open(t.TempDir())
+	require.NoError(t, Restore(ctx, second, restored, false, logger))
+	data, err := os.ReadFile(filepath.Join(restored.SnapDomain, "v1.0-accounts.32-48.kv"))
+	require.NoError(t, err)
+	require.Equal(t, "more accounts", string(data))
+	db := mdbx.New(kv.ChainDB, logger).Path(restored.Chaindata).MustOpen()
+	defer db.Close()
+	require.NoError(t, db.View(ctx, func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.HeaderNumber, []byte("hash"))
+		require.Equal(t, []byte("number"), v)
+		return err
+	}))
+
+	// restore needs a fresh datadir
+	require.Error(t, Restore(ctx, second, restored, false, logger))
+}
+
+func TestHotBackupResume(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	dirs := newTestDatadir(t)
+	root := t.TempDir()
+
+	// an interrupted backup: a leftover copy that never made it to the journal
+	partial := filepath.Join(root, "20250101-000000"+partialSuffix)
+	writeFile(t, filepath.Join(partial, "snapshots/v1.0-000000-000500-headers.seg"), "head")
+
+	path, err := Hot(ctx, dirs, root, logger)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "20250101-000000"), path)
+	_, err = os.Stat(partial)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(path, journalFileName))
+	require.True(t, os.IsNotExist(err))
+	_, err = Verify(ctx, path, logger)
+	require.NoError(t, err)
+}
+
+func TestVerifyDetectsCorruption(t *testing.T) {
+	t.Parallel()
+	ctx, logger := context.Background(), log.New()
+	dirs := newTestDatadir(t)
+	path, err := Hot(ctx, dirs, t.TempDir(), logger)
+	require.NoError(t, err)
+
+	// break the link with the datadir before corrupting the backup
+	corrupted := filepath.Join(path, "snapshots/v1.0-000000-000500-headers.seg")
+	require.NoError(t, os.Remove(corrupted))
+	writeFile(t, corrupted, "HEADERS")
+	_, err = Verify(ctx, path, logger)
+	require.ErrorIs(t, err, ErrCorruptedBackup)
+
+	restored := datadir.Open(t.TempDir())
+	require.ErrorIs(t, Restore(ctx, path, restored, true, logger), ErrCorruptedBackup)
+	fresh, err := isFresh(restored)
+	require.NoError(t, err)
+	require.True(t, fresh)
+}
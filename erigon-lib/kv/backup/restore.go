@@ -0,0 +1,153 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+var ErrCorruptedBackup = errors.New("backup does not match its manifest")
+
+func hashEntry(path, rel string) (ManifestFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	return ManifestFile{Path: rel, Size: size, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// copyFile copies src to dst, synced to disk, and returns the sha256 of the copied bytes.
+func copyFile(src, dst string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, h), in); err != nil {
+		out.Close()
+		return "", err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), out.Close()
+}
+
+// Verify checks every file of the backup in backupDir against its manifest.
+func Verify(ctx context.Context, backupDir string, logger log.Logger) (*Manifest, error) {
+	m, err := ReadManifest(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range m.Files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		entry, err := hashEntry(filepath.Join(backupDir, filepath.FromSlash(f.Path)), f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrCorruptedBackup, f.Path, err)
+		}
+		if entry != f {
+			return nil, fmt.Errorf("%w: %s: size %d sha256 %s, expected size %d sha256 %s", ErrCorruptedBackup, f.Path, entry.Size, entry.SHA256, f.Size, f.SHA256)
+		}
+		logger.Debug("[backup] Verified", "file", f.Path, "progress", fmt.Sprintf("%d/%d", i+1, len(m.Files)))
+	}
+	return m, nil
+}
+
+// isFresh reports whether dirs holds neither chaindata nor snapshots.
+func isFresh(dirs datadir.Dirs) (bool, error) {
+	for _, dir := range []string{dirs.Chaindata, dirs.Snap} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, err
+		}
+		if len(entries) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Restore verifies the backup in backupDir and restores it into the fresh datadir dirs. The
+// files are hard-linked from the backup when link is set, copied and checked again
+// otherwise.
+func Restore(ctx context.Context, backupDir string, dirs datadir.Dirs, link bool, logger log.Logger) error {
+	fresh, err := isFresh(dirs)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return fmt.Errorf("datadir %s is not empty, restore needs a fresh datadir", dirs.DataDir)
+	}
+	logger.Info("[backup] Verifying backup", "path", backupDir)
+	m, err := Verify(ctx, backupDir, logger)
+	if err != nil {
+		return err
+	}
+	logger.Info("[backup] Restoring", "path", backupDir, "datadir", dirs.DataDir, "files", len(m.Files))
+	for _, f := range m.Files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		src := filepath.Join(backupDir, filepath.FromSlash(f.Path))
+		dst := filepath.Join(dirs.DataDir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if link {
+			if err := os.Link(src, dst); err != nil {
+				return err
+			}
+			continue
+		}
+		sum, err := copyFile(src, dst)
+		if err != nil {
+			return err
+		}
+		if sum != f.SHA256 {
+			return fmt.Errorf("%w: %s changed while restoring", ErrCorruptedBackup, f.Path)
+		}
+	}
+	logger.Info("[backup] Restored", "datadir", dirs.DataDir)
+	return nil
+}
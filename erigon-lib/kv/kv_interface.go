@@ -217,16 +217,18 @@ type DBVerbosityLvl int8
 type Label string
 
 const (
-	ChainDB         = "chaindata"
-	TxPoolDB        = "txpool"
-	SentryDB        = "sentry"
-	ConsensusDB     = "consensus"
-	DownloaderDB    = "downloader"
-	HeimdallDB      = "heimdall"
-	DiagnosticsDB   = "diagnostics"
-	PolygonBridgeDB = "polygon-bridge"
-	CaplinDB        = "caplin"
-	TemporaryDB     = "temporary"
+	ChainDB           = "chaindata"
+	TxPoolDB          = "txpool"
+	SentryDB          = "sentry"
+	ConsensusDB       = "consensus"
+	DownloaderDB      = "downloader"
+	HeimdallDB        = "heimdall"
+	DiagnosticsDB     = "diagnostics"
+	PolygonBridgeDB   = "polygon-bridge"
+	SupplyDB          = "supply"
+	CaplinDB          = "caplin"
+	CaplinValidatorDB = "caplin-validator"
+	TemporaryDB       = "temporary"
 )
 
 type GetPut interface {
@@ -27,6 +27,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -52,6 +55,10 @@ import (
 // Erigon has much Historical data - which is immutable: reading of historical data for hours still gives you consistant data.
 const MaxTxTTL = 60 * time.Second
 
+// tracer continues the traces of the clients in the temporal lookups, so the time spent in the
+// gRPC hop can be told apart from the time spent in the DB
+var tracer = otel.Tracer("github.com/erigontech/erigon-lib/kv/remotedbserver")
+
 // KvServiceAPIVersion - use it to track changes in API
 // 1.1.0 - added pending transactions, add methods eth_getRawTransactionByHash, eth_retRawTransactionByBlockHashAndIndex, eth_retRawTransactionByBlockNumberAndIndex| Yes     |                                            |
 // 1.2.0 - Added separated services for mining and txpool methods
@@ -532,7 +539,7 @@ func (s *StateChangePubSub) remove(id uint) {
 // Temporal methods
 //
 
-func (s *KvServer) GetLatest(_ context.Context, req *remote.GetLatestReq) (reply *remote.GetLatestReply, err error) {
+func (s *KvServer) GetLatest(ctx context.Context, req *remote.GetLatestReq) (reply *remote.GetLatestReply, err error) {
 	domainName, err := kv.String2Domain(req.Table)
 	if err != nil {
 		return nil, err
@@ -544,11 +551,15 @@ func (s *KvServer) GetLatest(_ context.Context, req *remote.GetLatestReq) (reply
 			return errors.New("server DB doesn't implement kv.Temporal interface")
 		}
 		if req.Latest {
+			_, span := tracer.Start(ctx, "TemporalTx.GetLatest", trace.WithAttributes(attribute.String("kv.domain", req.Table)))
+			defer span.End()
 			reply.V, _, err = ttx.GetLatest(domainName, req.K)
 			if err != nil {
 				return err
 			}
 		} else {
+			_, span := tracer.Start(ctx, "TemporalTx.GetAsOf", trace.WithAttributes(attribute.String("kv.domain", req.Table), attribute.Int64("kv.ts", int64(req.Ts))))
+			defer span.End()
 			reply.V, reply.Ok, err = ttx.GetAsOf(domainName, req.K, req.Ts)
 			if err != nil {
 				return err
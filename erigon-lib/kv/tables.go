@@ -113,6 +113,9 @@ const (
 	// Transaction senders - stored separately from the block bodies
 	Senders = "TxSender" // block_num_u64 + blockHash -> sendersList (no serialization format, every 20 bytes is new sender)
 
+	// EIP-7928 block access lists produced or validated during execution
+	BlockAccessLists = "BlockAccessList" // block_num_u64 + blockHash -> rlp(block access list)
+
 	// headBlockKey tracks the latest know full block's hash.
 	HeadBlockKey = "LastBlock"
 
@@ -332,6 +335,7 @@ var ChaindataTables = []string{
 	PlainContractCode,
 	ChangeSets3,
 	Senders,
+	BlockAccessLists,
 	HeadBlockKey,
 	HeadHeaderKey,
 	LastForkchoice,
@@ -454,12 +458,38 @@ const (
 	RecentLocalTransaction = "RecentLocalTransaction" // sequence_u64 -> tx_hash
 	PoolTransaction        = "PoolTransaction"        // txHash -> sender+tx_rlp
 	PoolInfo               = "PoolInfo"               // option_key -> option_value
+	PoolLocalTransaction   = "PoolLocalTransaction"   // tx_hash -> added_at_u64 + sender + tx_rlp
+)
+
+const (
+	SupplyDelta = "SupplyDelta" // block_num_u64 -> supply delta of the block (json)
+)
+
+var SupplyTables = []string{
+	SupplyDelta,
+}
+
+const (
+	ValidatorSignedBlocks       = "ValidatorSignedBlocks"       // pubkey + slot_u64 -> signing_root
+	ValidatorSignedAttestations = "ValidatorSignedAttestations" // pubkey + target_epoch_u64 -> source_epoch_u64 + signing_root
+	ValidatorRemoteKeys         = "ValidatorRemoteKeys"         // pubkey -> remote signer url
+	ValidatorFeeRecipients      = "ValidatorFeeRecipients"      // pubkey -> fee recipient address
+	ValidatorInfo               = "ValidatorInfo"               // option_key -> option_value
 )
 
+var CaplinValidatorTables = []string{
+	ValidatorSignedBlocks,
+	ValidatorSignedAttestations,
+	ValidatorRemoteKeys,
+	ValidatorFeeRecipients,
+	ValidatorInfo,
+}
+
 var TxPoolTables = []string{
 	RecentLocalTransaction,
 	PoolTransaction,
 	PoolInfo,
+	PoolLocalTransaction,
 }
 var SentryTables = []string{
 	Inodes,
@@ -607,6 +637,8 @@ var DownloaderTablesCfg = TableCfg{}
 var DiagnosticsTablesCfg = TableCfg{}
 var HeimdallTablesCfg = TableCfg{}
 var PolygonBridgeTablesCfg = TableCfg{}
+var SupplyTablesCfg = TableCfg{}
+var CaplinValidatorTablesCfg = TableCfg{}
 var ReconTablesCfg = TableCfg{
 	PlainStateD:    {Flags: DupSort},
 	CodeD:          {Flags: DupSort},
@@ -629,8 +661,12 @@ func TablesCfgByLabel(label Label) TableCfg {
 		return HeimdallTablesCfg
 	case PolygonBridgeDB:
 		return PolygonBridgeTablesCfg
+	case SupplyDB:
+		return SupplyTablesCfg
 	case ConsensusDB:
 		return ConsensusTablesCfg
+	case CaplinValidatorDB:
+		return CaplinValidatorTablesCfg
 	default:
 		panic(fmt.Sprintf("unexpected label: %s", label))
 	}
@@ -719,6 +755,20 @@ func reinit() {
 			PolygonBridgeTablesCfg[name] = TableCfgItem{}
 		}
 	}
+
+	for _, name := range SupplyTables {
+		_, ok := SupplyTablesCfg[name]
+		if !ok {
+			SupplyTablesCfg[name] = TableCfgItem{}
+		}
+	}
+
+	for _, name := range CaplinValidatorTables {
+		_, ok := CaplinValidatorTablesCfg[name]
+		if !ok {
+			CaplinValidatorTablesCfg[name] = TableCfgItem{}
+		}
+	}
 }
 
 // Temporal
@@ -156,9 +156,10 @@ func (m *sentryMultiplexer) SendMessageByMinBlock(ctx context.Context, in *sentr
 	// a refactor of the entry code
 	for _, client := range m.clients {
 		cin := &sentryproto.SendMessageByMinBlockRequest{
-			Data:     in.Data,
-			MinBlock: in.MinBlock,
-			MaxPeers: in.MaxPeers - uint64(len(allSentPeers)),
+			Data:            in.Data,
+			MinBlock:        in.MinBlock,
+			MinHistoryBlock: in.MinHistoryBlock,
+			MaxPeers:        in.MaxPeers - uint64(len(allSentPeers)),
 		}
 
 		sentPeers, err := client.SendMessageByMinBlock(ctx, cin, opts...)
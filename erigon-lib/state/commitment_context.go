@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"sync/atomic"
 	"time"
@@ -79,6 +80,13 @@ func (sdc *SharedDomainsCommitmentContext) Trie() commitment.Trie {
 	return sdc.patriciaTrie
 }
 
+// Branch reads the branch node stored under compacted nibble prefix, as of SetLimitReadAsOfTxNum if set.
+// It satisfies commitment.BranchReader.
+func (sdc *SharedDomainsCommitmentContext) Branch(prefix []byte) ([]byte, error) {
+	branch, _, err := sdc.mainTtx.Branch(prefix)
+	return branch, err
+}
+
 // TouchKey marks plainKey as updated and applies different fn for different key types
 // (different behaviour for Code, Account and Storage key modifications).
 func (sdc *SharedDomainsCommitmentContext) TouchKey(d kv.Domain, key string, val []byte) {
@@ -385,6 +393,71 @@ func (sdc *SharedDomainsCommitmentContext) rebuildCommitment(ctx context.Context
 	return sdc.ComputeCommitment(ctx, true, blockNum, txNum, "rebuild commit")
 }
 
+// RewoundTrie is the trie as of a past txNum rebuilt by RewindTo, on top of the latest branches
+// at BaseTxNum. It is read-only and can be shared: see UseRewoundTrie.
+type RewoundTrie struct {
+	TxNum     uint64
+	BaseTxNum uint64
+	RootHash  []byte
+
+	branches map[string][]byte
+	state    []byte
+}
+
+// RewindTo rebuilds the trie as of txNum without commitment history: the keys updated since
+// txNum are read as of txNum from the accounts and storage history, and folded into the latest
+// branches. The rebuilt branches are kept in memory, nothing is written to the shared domains.
+// Reads are left as of txNum, so that the trie can prove keys against the returned root.
+func (sdc *SharedDomainsCommitmentContext) RewindTo(ctx context.Context, roTx kv.TemporalTx, blockNum, txNum uint64) (*RewoundTrie, error) {
+	baseTxNum := sdc.sharedDomains.TxNum()
+	sdc.mainTtx.SetLimitReadAsOfTxNum(txNum, false)
+	sdc.mainTtx.rewound = map[string][]byte{}
+
+	for _, domain := range []kv.Domain{kv.AccountsDomain, kv.StorageDomain} {
+		it, err := roTx.HistoryRange(domain, int(txNum), math.MaxInt64, order.Asc, -1)
+		if err != nil {
+			return nil, err
+		}
+		for it.HasNext() {
+			k, _, err := it.Next()
+			if err != nil {
+				it.Close()
+				return nil, err
+			}
+			sdc.TouchKey(domain, string(k), nil)
+		}
+		it.Close()
+	}
+
+	rootHash, err := sdc.ComputeCommitment(ctx, false, blockNum, txNum, "rewind commitment")
+	if err != nil {
+		return nil, err
+	}
+	state, err := sdc.encodeCommitmentState(blockNum, txNum)
+	if err != nil {
+		return nil, err
+	}
+	return &RewoundTrie{
+		TxNum:     txNum,
+		BaseTxNum: baseTxNum,
+		RootHash:  common.Copy(rootHash),
+		branches:  maps.Clone(sdc.mainTtx.rewound),
+		state:     state,
+	}, nil
+}
+
+// UseRewoundTrie restores a trie rebuilt by RewindTo, which must have been rebuilt on top of the
+// same latest branches.
+func (sdc *SharedDomainsCommitmentContext) UseRewoundTrie(rt *RewoundTrie) error {
+	if base := sdc.sharedDomains.TxNum(); base != rt.BaseTxNum {
+		return fmt.Errorf("rewound trie is based on txNum %d, latest commitment is at %d", rt.BaseTxNum, base)
+	}
+	sdc.mainTtx.SetLimitReadAsOfTxNum(rt.TxNum, false)
+	sdc.mainTtx.rewound = maps.Clone(rt.branches)
+	_, _, err := sdc.restorePatriciaState(rt.state)
+	return err
+}
+
 type TrieContext struct {
 	roTtx  kv.TemporalTx
 	getter kv.TemporalGetter
@@ -395,6 +468,10 @@ type TrieContext struct {
 	stepSize           uint64
 	domainsOnly        bool // if true, do not use history reader and limit to domain files only
 	trace              bool
+
+	// branches rebuilt by RewindTo: they take precedence over the latest ones, which are read
+	// instead of the commitment history
+	rewound map[string][]byte
 }
 
 func (sdc *TrieContext) Branch(pref []byte) ([]byte, uint64, error) {
@@ -402,9 +479,14 @@ func (sdc *TrieContext) Branch(pref []byte) ([]byte, uint64, error) {
 	//	sdc.mu.Lock()
 	//	defer sdc.mu.Unlock()
 	//}
+	if sdc.rewound != nil {
+		if branch, ok := sdc.rewound[string(pref)]; ok {
+			return branch, sdc.limitReadAsOfTxNum / sdc.stepSize, nil
+		}
+	}
 	// Trie reads prefix during unfold and after everything is ready reads it again to Merge update.
 	// Keep dereferenced version inside sd commitmentDomain map ready to read again
-	if !sdc.domainsOnly && sdc.limitReadAsOfTxNum > 0 {
+	if !sdc.domainsOnly && sdc.limitReadAsOfTxNum > 0 && sdc.rewound == nil {
 		branch, _, err := sdc.roTtx.GetAsOf(kv.CommitmentDomain, pref, sdc.limitReadAsOfTxNum)
 		if sdc.trace {
 			fmt.Printf("[SDC] Branch @%d: %x: %x\n%s\n", sdc.limitReadAsOfTxNum, pref, branch, commitment.BranchData(branch).String())
@@ -431,6 +513,10 @@ func (sdc *TrieContext) Branch(pref []byte) ([]byte, uint64, error) {
 }
 
 func (sdc *TrieContext) PutBranch(prefix []byte, data []byte, prevData []byte, prevStep uint64) error {
+	if sdc.rewound != nil {
+		sdc.rewound[string(prefix)] = common.Copy(data)
+		return nil
+	}
 	if sdc.limitReadAsOfTxNum > 0 && !sdc.domainsOnly { // do not store branches if explicitly operate on history
 		return nil
 	}
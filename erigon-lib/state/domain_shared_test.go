@@ -774,3 +774,82 @@ func TestSharedDomain_HasPrefix_StorageDomain(t *testing.T) {
 		roTtx4.Rollback()
 	}
 }
+
+func TestSharedDomain_RewindTo(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+
+	stepSize := uint64(16)
+	_db, agg := testDbAndAggregatorv3(t, stepSize)
+	db := wrapDbWithCtx(_db, agg)
+
+	ctx := context.Background()
+	rwTx, err := db.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+
+	domains, err := NewSharedDomains(rwTx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	maxTx := 4 * stepSize
+	hashes := make([][]byte, maxTx)
+	k0 := make([]byte, length.Addr)
+	commitStep := 3
+	for i := 0; i < int(maxTx); i++ {
+		txNum := uint64(i)
+		domains.SetTxNum(txNum)
+		for accs := 0; accs < 32; accs++ {
+			k0[0] = byte(accs)
+			if accs%(i%5+2) == 1 {
+				// some accounts are updated now and then, some deleted and recreated
+				if i%7 == 3 {
+					require.NoError(t, domains.DomainDel(kv.AccountsDomain, rwTx, k0, txNum, nil, 0))
+				}
+				continue
+			}
+			acc := accounts3.Account{
+				Nonce:   txNum,
+				Balance: *uint256.NewInt(uint64(i*10e6) + uint64(accs*10e2)),
+			}
+			require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, k0, accounts3.SerialiseV3(&acc), txNum, nil, 0))
+			loc := make([]byte, length.Hash)
+			loc[0] = byte(i % 4)
+			require.NoError(t, domains.DomainPut(kv.StorageDomain, rwTx, composite(k0, loc), []byte{byte(i + 1)}, txNum, nil, 0))
+		}
+		if i%commitStep == 0 {
+			rh, err := domains.ComputeCommitment(ctx, true, 0, txNum, "")
+			require.NoError(t, err)
+			hashes[i] = rh
+		}
+	}
+	require.NoError(t, domains.Flush(ctx, rwTx))
+	domains.Close()
+	require.NoError(t, rwTx.Commit())
+
+	roTx, err := db.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer roTx.Rollback()
+
+	for i := 0; i < int(maxTx)-commitStep; i += commitStep {
+		domains, err := NewSharedDomains(roTx, log.New())
+		require.NoError(t, err)
+
+		// as of the next txNum: the state right after txNum i
+		rt, err := domains.GetCommitmentContext().RewindTo(ctx, roTx, uint64(i), uint64(i+1))
+		require.NoError(t, err)
+		require.Equal(t, hashes[i], rt.RootHash, "txNum %d", i)
+		domains.Close()
+
+		domains, err = NewSharedDomains(roTx, log.New())
+		require.NoError(t, err)
+		require.NoError(t, domains.GetCommitmentContext().UseRewoundTrie(rt))
+		rh, err := domains.GetCommitmentContext().Trie().RootHash()
+		require.NoError(t, err)
+		require.Equal(t, hashes[i], rh)
+		domains.Close()
+	}
+}
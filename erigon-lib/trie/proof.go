@@ -120,6 +120,51 @@ func (t *Trie) Prove(key []byte, fromLevel int, storage bool) ([][]byte, error)
 	return proof, nil
 }
 
+// EncodedNodes returns the RLP encodings of all nodes resolved in the trie, including the
+// storage tries of resolved accounts. Nodes which are embedded into their parent (encoding
+// shorter than a hash) are not returned separately, except for the root. Hash nodes are
+// not expanded, so for a witness trie the result is the set of nodes the witness consists of.
+func (t *Trie) EncodedNodes() ([][]byte, error) {
+	var nodes [][]byte
+	hasher := newHasher(t.valueNodesRLPEncoded)
+	defer returnHasherToPool(hasher)
+	var collect func(n Node, root bool) error
+	collect = func(n Node, root bool) error {
+		switch n := n.(type) {
+		case *ShortNode, *DuoNode, *FullNode:
+			rlp, err := hasher.hashChildren(n, 0)
+			if err != nil {
+				return err
+			}
+			if root || len(rlp) >= length.Hash {
+				nodes = append(nodes, common.CopyBytes(rlp))
+			}
+		}
+		switch n := n.(type) {
+		case *ShortNode:
+			return collect(n.Val, false)
+		case *DuoNode:
+			if err := collect(n.child1, false); err != nil {
+				return err
+			}
+			return collect(n.child2, false)
+		case *FullNode:
+			for _, child := range n.Children {
+				if err := collect(child, false); err != nil {
+					return err
+				}
+			}
+		case *AccountNode:
+			return collect(n.Storage, true)
+		}
+		return nil
+	}
+	if err := collect(t.RootNode, true); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
 func decodeRef(buf []byte) (Node, []byte, error) {
 	kind, val, rest, err := rlp.Split(buf)
 	if err != nil {
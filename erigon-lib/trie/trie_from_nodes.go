@@ -0,0 +1,134 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// BuildTrieFromNodes builds the state trie with the given root out of RLP encoded trie nodes, as
+// returned by EncodedNodes: the nodes of the account trie and of the storage tries of its accounts.
+// The codes are attached to the accounts they belong to. Nodes which are not given are kept as
+// hash nodes, so the trie only holds the part of the state the nodes cover.
+func BuildTrieFromNodes(root common.Hash, nodes [][]byte, codes [][]byte) (*Trie, error) {
+	r := nodesResolver{
+		nodes: make(map[common.Hash][]byte, len(nodes)),
+		codes: make(map[common.Hash][]byte, len(codes)),
+	}
+	for _, n := range nodes {
+		r.nodes[crypto.Keccak256Hash(n)] = n
+	}
+	for _, c := range codes {
+		r.codes[crypto.Keccak256Hash(c)] = c
+	}
+
+	if _, ok := r.nodes[root]; !ok && root != EmptyRoot {
+		return nil, fmt.Errorf("root node %x is missing", root)
+	}
+	rootNode, err := r.resolve(root, false)
+	if err != nil {
+		return nil, err
+	}
+	t := NewInMemoryTrie(rootNode)
+	if h := t.Hash(); h != root {
+		return nil, fmt.Errorf("root of the trie built from nodes %x, expected %x", h, root)
+	}
+	return t, nil
+}
+
+type nodesResolver struct {
+	nodes map[common.Hash][]byte
+	codes map[common.Hash][]byte
+}
+
+func (r *nodesResolver) resolve(hash common.Hash, storage bool) (Node, error) {
+	if hash == EmptyRoot {
+		return nil, nil
+	}
+	enc, ok := r.nodes[hash]
+	if !ok {
+		return &HashNode{hash: common.CopyBytes(hash[:])}, nil
+	}
+	n, err := decodeNode(enc)
+	if err != nil {
+		return nil, fmt.Errorf("decode node %x: %w", hash, err)
+	}
+	return r.expand(n, storage)
+}
+
+// expand replaces the hash references of a decoded node by the nodes they point to, and its
+// leaves by the values the trie keeps: accounts in the account trie, raw values in storage tries.
+func (r *nodesResolver) expand(n Node, storage bool) (Node, error) {
+	switch n := n.(type) {
+	case nil:
+		return nil, nil
+	case HashNode:
+		return r.resolve(common.BytesToHash(n.hash), storage)
+	case *ShortNode:
+		var err error
+		if v, ok := n.Val.(ValueNode); ok {
+			n.Val, err = r.leaf(v, storage)
+		} else {
+			n.Val, err = r.expand(n.Val, storage)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return n, nil
+	case *FullNode:
+		for i := range n.Children[:16] {
+			child, err := r.expand(n.Children[i], storage)
+			if err != nil {
+				return nil, err
+			}
+			n.Children[i] = child
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("unexpected node type %T", n)
+	}
+}
+
+func (r *nodesResolver) leaf(v ValueNode, storage bool) (Node, error) {
+	if storage {
+		val, _, err := rlp.SplitString(v)
+		if err != nil {
+			return nil, fmt.Errorf("decode storage value %x: %w", []byte(v), err)
+		}
+		return ValueNode(common.CopyBytes(val)), nil
+	}
+
+	var acc accounts.Account
+	if err := acc.DecodeForHashing(v); err != nil {
+		return nil, err
+	}
+	storageRoot, err := r.resolve(acc.Root, true)
+	if err != nil {
+		return nil, err
+	}
+	accNode := &AccountNode{Account: acc, Storage: storageRoot, RootCorrect: true, CodeSize: codeSizeUncached}
+	if code, ok := r.codes[acc.CodeHash]; ok {
+		accNode.Code = code
+		accNode.CodeSize = len(code)
+	}
+	return accNode, nil
+}
@@ -704,3 +704,89 @@ func TestShortNode(t *testing.T) {
 //		t.Fatal(err)
 //	}
 //}
+
+func TestEncodedNodes(t *testing.T) {
+	trie := newEmpty()
+	for i := 0; i < 64; i++ {
+		trie.Update(crypto.Keccak256([]byte{byte(i)}), bytes.Repeat([]byte{byte(i)}, 32))
+	}
+	root := trie.Hash()
+
+	nodes, err := trie.EncodedNodes()
+	require.NoError(t, err)
+	require.Greater(t, len(nodes), 64)
+	require.Equal(t, root, crypto.Keccak256Hash(nodes[0]))
+	// every node but the root is referenced by hash from another node
+	for _, node := range nodes[1:] {
+		require.GreaterOrEqual(t, len(node), 32)
+		hash := crypto.Keccak256(node)
+		referenced := false
+		for _, parent := range nodes {
+			if bytes.Contains(parent, hash) {
+				referenced = true
+				break
+			}
+		}
+		require.True(t, referenced, "node %x is not referenced", hash)
+	}
+}
+
+func TestBuildTrieFromNodes(t *testing.T) {
+	trie := newEmpty()
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	var addrHashes []common.Hash
+	for i := 0; i < 16; i++ {
+		addrHash := crypto.Keccak256Hash([]byte{byte(i)})
+		addrHashes = append(addrHashes, addrHash)
+		acc := accounts.NewAccount()
+		acc.Nonce = uint64(i)
+		acc.Balance.SetUint64(uint64(i) * 1000)
+		if i%2 == 0 {
+			acc.CodeHash = crypto.Keccak256Hash(code)
+		}
+		trie.UpdateAccount(addrHash[:], &acc)
+		for j := 0; j < i; j++ {
+			keyHash := crypto.Keccak256Hash([]byte{byte(i), byte(j)})
+			trie.Update(append(common.CopyBytes(addrHash[:]), keyHash[:]...), []byte{byte(j + 1)})
+		}
+		_, storageRoot := trie.DeepHash(addrHash[:])
+		acc.Root = storageRoot
+		trie.UpdateAccount(addrHash[:], &acc)
+	}
+	root := trie.Hash()
+
+	nodes, err := trie.EncodedNodes()
+	require.NoError(t, err)
+	rebuilt, err := BuildTrieFromNodes(root, nodes, [][]byte{code})
+	require.NoError(t, err)
+	require.Equal(t, root, rebuilt.Hash())
+
+	for i, addrHash := range addrHashes {
+		acc, ok := rebuilt.GetAccount(addrHash[:])
+		require.True(t, ok)
+		require.Equal(t, uint64(i), acc.Nonce)
+		gotCode, ok := rebuilt.GetAccountCode(addrHash[:])
+		require.True(t, ok)
+		if i%2 == 0 {
+			require.Equal(t, code, gotCode)
+		} else {
+			require.Nil(t, gotCode)
+		}
+		for j := 0; j < i; j++ {
+			keyHash := crypto.Keccak256Hash([]byte{byte(i), byte(j)})
+			val, ok := rebuilt.Get(append(common.CopyBytes(addrHash[:]), keyHash[:]...))
+			require.True(t, ok)
+			require.Equal(t, []byte{byte(j + 1)}, val)
+		}
+	}
+
+	// without the nodes below the root the rest of the trie stays behind hash nodes
+	partial, err := BuildTrieFromNodes(root, nodes[:1], nil)
+	require.NoError(t, err)
+	require.Equal(t, root, partial.Hash())
+	_, ok := partial.GetAccount(addrHashes[0][:])
+	require.False(t, ok)
+
+	_, err = BuildTrieFromNodes(common.Hash{1}, nodes, nil)
+	require.Error(t, err)
+}
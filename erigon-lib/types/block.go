@@ -106,6 +106,8 @@ type Header struct {
 
 	RequestsHash *common.Hash `json:"requestsHash"` // EIP-7685
 
+	BlockAccessListHash *common.Hash `json:"blockAccessListHash"` // EIP-7928
+
 	// by default all headers are immutable
 	// but assembling/mining may use `NewEmptyHeaderForAssembling` to create temporary mutable Header object
 	// then pass it to `block.WithSeal(header)` - to produce new block with immutable `Header`
@@ -174,6 +176,10 @@ func (h *Header) EncodingSize() int {
 		encodingSize += 33
 	}
 
+	if h.BlockAccessListHash != nil {
+		encodingSize += 33
+	}
+
 	return encodingSize
 }
 
@@ -324,6 +330,16 @@ func (h *Header) EncodeRLP(w io.Writer) error {
 		}
 	}
 
+	if h.BlockAccessListHash != nil {
+		b[0] = 128 + 32
+		if _, err := w.Write(b[:1]); err != nil {
+			return err
+		}
+		if _, err := w.Write(h.BlockAccessListHash[:]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -486,6 +502,23 @@ func (h *Header) DecodeRLP(s *rlp.Stream) error {
 	h.RequestsHash = new(common.Hash)
 	h.RequestsHash.SetBytes(b)
 
+	// BlockAccessListHash
+	if b, err = s.Bytes(); err != nil {
+		if errors.Is(err, rlp.EOL) {
+			h.BlockAccessListHash = nil
+			if err := s.ListEnd(); err != nil {
+				return fmt.Errorf("close header struct (no BlockAccessListHash): %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("read BlockAccessListHash: %w", err)
+	}
+	if len(b) != 32 {
+		return fmt.Errorf("wrong size for BlockAccessListHash: %d", len(b))
+	}
+	h.BlockAccessListHash = new(common.Hash)
+	h.BlockAccessListHash.SetBytes(b)
+
 	if err := s.ListEnd(); err != nil {
 		return fmt.Errorf("close header struct: %w", err)
 	}
@@ -558,6 +591,9 @@ func (h *Header) Size() common.StorageSize {
 	if h.RequestsHash != nil {
 		s += common.StorageSize(32)
 	}
+	if h.BlockAccessListHash != nil {
+		s += common.StorageSize(32)
+	}
 	return s
 }
 
@@ -1141,6 +1177,10 @@ func CopyHeader(h *Header) *Header {
 		cpy.RequestsHash = new(common.Hash)
 		cpy.RequestsHash.SetBytes(h.RequestsHash.Bytes())
 	}
+	if h.BlockAccessListHash != nil {
+		cpy.BlockAccessListHash = new(common.Hash)
+		cpy.BlockAccessListHash.SetBytes(h.BlockAccessListHash.Bytes())
+	}
 	cpy.mutable = h.mutable
 	return &cpy
 }
@@ -1276,6 +1316,7 @@ func (b *Block) WithdrawalsHash() *common.Hash       { return b.header.Withdrawa
 func (b *Block) Withdrawals() Withdrawals            { return b.withdrawals }
 func (b *Block) ParentBeaconBlockRoot() *common.Hash { return b.header.ParentBeaconBlockRoot }
 func (b *Block) RequestsHash() *common.Hash          { return b.header.RequestsHash }
+func (b *Block) BlockAccessListHash() *common.Hash   { return b.header.BlockAccessListHash }
 
 // Header returns a deep-copy of the entire block header using CopyHeader()
 func (b *Block) Header() *Header       { return CopyHeader(b.header) }
@@ -0,0 +1,270 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+// Block access lists (EIP-7928) record every account and storage slot touched by a block,
+// together with the post-values written at each block access index. Index 0 is the block
+// pre-execution (system calls), index i+1 is transaction i and index len(txs)+1 is the
+// post-execution (withdrawals, requests, rewards).
+
+// StorageChange is the value a storage slot holds after the access at Index.
+type StorageChange struct {
+	Index uint16
+	Value common.Hash
+}
+
+// SlotChanges lists the writes to a single storage slot, ordered by index.
+type SlotChanges struct {
+	Slot    common.Hash
+	Changes []StorageChange
+}
+
+// BalanceChange is the balance an account holds after the access at Index.
+type BalanceChange struct {
+	Index   uint16
+	Balance uint256.Int
+}
+
+// NonceChange is the nonce an account holds after the access at Index.
+type NonceChange struct {
+	Index uint16
+	Nonce uint64
+}
+
+// CodeChange is the code an account holds after the access at Index.
+type CodeChange struct {
+	Index uint16
+	Code  []byte
+}
+
+// AccountChanges is the access list entry of a single account.
+type AccountChanges struct {
+	Address        common.Address
+	StorageChanges []SlotChanges
+	StorageReads   []common.Hash
+	BalanceChanges []BalanceChange
+	NonceChanges   []NonceChange
+	CodeChanges    []CodeChange
+}
+
+// BlockAccessList is ordered by address; see Validate for the full set of ordering rules.
+type BlockAccessList []AccountChanges
+
+// Hash returns the keccak256 of the RLP encoding, as committed to by Header.BlockAccessListHash.
+func (bal BlockAccessList) Hash() common.Hash {
+	if bal == nil {
+		bal = BlockAccessList{}
+	}
+	return rlpHash(bal)
+}
+
+// Validate checks that the list is in canonical form: accounts, slots and reads sorted and
+// unique, changes ordered by strictly increasing index and no slot both read and written.
+func (bal BlockAccessList) Validate() error {
+	for i := range bal {
+		acc := &bal[i]
+		if i > 0 && bytes.Compare(bal[i-1].Address[:], acc.Address[:]) >= 0 {
+			return fmt.Errorf("block access list: account %x out of order", acc.Address)
+		}
+		written := make(map[common.Hash]struct{}, len(acc.StorageChanges))
+		for j := range acc.StorageChanges {
+			slot := &acc.StorageChanges[j]
+			if j > 0 && bytes.Compare(acc.StorageChanges[j-1].Slot[:], slot.Slot[:]) >= 0 {
+				return fmt.Errorf("block access list: account %x: slot %x out of order", acc.Address, slot.Slot)
+			}
+			if len(slot.Changes) == 0 {
+				return fmt.Errorf("block access list: account %x: slot %x has no changes", acc.Address, slot.Slot)
+			}
+			for k := 1; k < len(slot.Changes); k++ {
+				if slot.Changes[k-1].Index >= slot.Changes[k].Index {
+					return fmt.Errorf("block access list: account %x: slot %x: index %d out of order", acc.Address, slot.Slot, slot.Changes[k].Index)
+				}
+			}
+			written[slot.Slot] = struct{}{}
+		}
+		for j, slot := range acc.StorageReads {
+			if j > 0 && bytes.Compare(acc.StorageReads[j-1][:], slot[:]) >= 0 {
+				return fmt.Errorf("block access list: account %x: read %x out of order", acc.Address, slot)
+			}
+			if _, ok := written[slot]; ok {
+				return fmt.Errorf("block access list: account %x: slot %x is both read and written", acc.Address, slot)
+			}
+		}
+		for k := 1; k < len(acc.BalanceChanges); k++ {
+			if acc.BalanceChanges[k-1].Index >= acc.BalanceChanges[k].Index {
+				return fmt.Errorf("block access list: account %x: balance index %d out of order", acc.Address, acc.BalanceChanges[k].Index)
+			}
+		}
+		for k := 1; k < len(acc.NonceChanges); k++ {
+			if acc.NonceChanges[k-1].Index >= acc.NonceChanges[k].Index {
+				return fmt.Errorf("block access list: account %x: nonce index %d out of order", acc.Address, acc.NonceChanges[k].Index)
+			}
+		}
+		for k := 1; k < len(acc.CodeChanges); k++ {
+			if acc.CodeChanges[k-1].Index >= acc.CodeChanges[k].Index {
+				return fmt.Errorf("block access list: account %x: code index %d out of order", acc.Address, acc.CodeChanges[k].Index)
+			}
+		}
+	}
+	return nil
+}
+
+type storageChangeJSON struct {
+	Index hexutil.Uint64 `json:"blockAccessIndex"`
+	Value common.Hash    `json:"postValue"`
+}
+
+func (c StorageChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(storageChangeJSON{Index: hexutil.Uint64(c.Index), Value: c.Value})
+}
+
+func (c *StorageChange) UnmarshalJSON(input []byte) error {
+	var dec storageChangeJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	c.Index, c.Value = uint16(dec.Index), dec.Value
+	return nil
+}
+
+type slotChangesJSON struct {
+	Slot    common.Hash     `json:"slot"`
+	Changes []StorageChange `json:"slotChanges"`
+}
+
+func (c SlotChanges) MarshalJSON() ([]byte, error) {
+	return json.Marshal(slotChangesJSON(c))
+}
+
+func (c *SlotChanges) UnmarshalJSON(input []byte) error {
+	var dec slotChangesJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*c = SlotChanges(dec)
+	return nil
+}
+
+type balanceChangeJSON struct {
+	Index   hexutil.Uint64 `json:"blockAccessIndex"`
+	Balance *hexutil.Big   `json:"postBalance"`
+}
+
+func (c BalanceChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(balanceChangeJSON{Index: hexutil.Uint64(c.Index), Balance: (*hexutil.Big)(c.Balance.ToBig())})
+}
+
+func (c *BalanceChange) UnmarshalJSON(input []byte) error {
+	var dec balanceChangeJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Balance == nil {
+		return fmt.Errorf("missing required field 'postBalance' for BalanceChange")
+	}
+	if overflow := c.Balance.SetFromBig(dec.Balance.ToInt()); overflow {
+		return fmt.Errorf("postBalance overflows uint256")
+	}
+	c.Index = uint16(dec.Index)
+	return nil
+}
+
+type nonceChangeJSON struct {
+	Index hexutil.Uint64 `json:"blockAccessIndex"`
+	Nonce hexutil.Uint64 `json:"postNonce"`
+}
+
+func (c NonceChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nonceChangeJSON{Index: hexutil.Uint64(c.Index), Nonce: hexutil.Uint64(c.Nonce)})
+}
+
+func (c *NonceChange) UnmarshalJSON(input []byte) error {
+	var dec nonceChangeJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	c.Index, c.Nonce = uint16(dec.Index), uint64(dec.Nonce)
+	return nil
+}
+
+type codeChangeJSON struct {
+	Index hexutil.Uint64 `json:"blockAccessIndex"`
+	Code  hexutil.Bytes  `json:"newCode"`
+}
+
+func (c CodeChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(codeChangeJSON{Index: hexutil.Uint64(c.Index), Code: c.Code})
+}
+
+func (c *CodeChange) UnmarshalJSON(input []byte) error {
+	var dec codeChangeJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	c.Index, c.Code = uint16(dec.Index), dec.Code
+	return nil
+}
+
+type accountChangesJSON struct {
+	Address        common.Address  `json:"address"`
+	StorageChanges []SlotChanges   `json:"storageChanges"`
+	StorageReads   []common.Hash   `json:"storageReads"`
+	BalanceChanges []BalanceChange `json:"balanceChanges"`
+	NonceChanges   []NonceChange   `json:"nonceChanges"`
+	CodeChanges    []CodeChange    `json:"codeChanges"`
+}
+
+func (c AccountChanges) MarshalJSON() ([]byte, error) {
+	enc := accountChangesJSON(c)
+	// empty lists are rendered as [] rather than null
+	if enc.StorageChanges == nil {
+		enc.StorageChanges = []SlotChanges{}
+	}
+	if enc.StorageReads == nil {
+		enc.StorageReads = []common.Hash{}
+	}
+	if enc.BalanceChanges == nil {
+		enc.BalanceChanges = []BalanceChange{}
+	}
+	if enc.NonceChanges == nil {
+		enc.NonceChanges = []NonceChange{}
+	}
+	if enc.CodeChanges == nil {
+		enc.CodeChanges = []CodeChange{}
+	}
+	return json.Marshal(enc)
+}
+
+func (c *AccountChanges) UnmarshalJSON(input []byte) error {
+	var dec accountChangesJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*c = AccountChanges(dec)
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+func testBlockAccessList() BlockAccessList {
+	return BlockAccessList{
+		{
+			Address: common.Address{1},
+			StorageChanges: []SlotChanges{
+				{Slot: common.Hash{1}, Changes: []StorageChange{{Index: 1, Value: common.Hash{0xaa}}, {Index: 3, Value: common.Hash{0xbb}}}},
+			},
+			StorageReads:   []common.Hash{{2}, {3}},
+			BalanceChanges: []BalanceChange{{Index: 1, Balance: *uint256.NewInt(1000)}},
+			NonceChanges:   []NonceChange{{Index: 1, Nonce: 7}},
+		},
+		{
+			Address:     common.Address{2},
+			CodeChanges: []CodeChange{{Index: 2, Code: []byte{0x60, 0x00}}},
+		},
+		{
+			Address: common.Address{3},
+		},
+	}
+}
+
+func TestBlockAccessListRLP(t *testing.T) {
+	t.Parallel()
+	bal := testBlockAccessList()
+	enc, err := rlp.EncodeToBytes(bal)
+	require.NoError(t, err)
+
+	var dec BlockAccessList
+	require.NoError(t, rlp.DecodeBytes(enc, &dec))
+	require.Equal(t, bal.Hash(), dec.Hash())
+	require.NoError(t, dec.Validate())
+	require.Equal(t, uint64(7), dec[0].NonceChanges[0].Nonce)
+	require.Equal(t, []byte{0x60, 0x00}, dec[1].CodeChanges[0].Code)
+
+	// keccak256(rlp([]))
+	require.Equal(t, common.HexToHash("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"), BlockAccessList(nil).Hash())
+}
+
+func TestBlockAccessListValidate(t *testing.T) {
+	t.Parallel()
+	bal := testBlockAccessList()
+	bal[0], bal[1] = bal[1], bal[0]
+	require.ErrorContains(t, bal.Validate(), "out of order")
+
+	bal = testBlockAccessList()
+	bal[0].StorageReads = append(bal[0].StorageReads, common.Hash{1})
+	require.ErrorContains(t, bal.Validate(), "out of order")
+
+	bal = testBlockAccessList()
+	bal[0].StorageReads = []common.Hash{{1}}
+	require.ErrorContains(t, bal.Validate(), "both read and written")
+
+	bal = testBlockAccessList()
+	bal[0].StorageChanges[0].Changes[1].Index = 1
+	require.ErrorContains(t, bal.Validate(), "index 1 out of order")
+
+	bal = testBlockAccessList()
+	bal[0].StorageChanges[0].Changes = nil
+	require.ErrorContains(t, bal.Validate(), "has no changes")
+}
+
+func TestBlockAccessListJSON(t *testing.T) {
+	t.Parallel()
+	bal := testBlockAccessList()
+	enc, err := json.Marshal(bal)
+	require.NoError(t, err)
+	require.Contains(t, string(enc), `"blockAccessIndex":"0x1","postBalance":"0x3e8"`)
+	require.Contains(t, string(enc), `"address":"0x0300000000000000000000000000000000000000","storageChanges":[],"storageReads":[]`)
+
+	var dec BlockAccessList
+	require.NoError(t, json.Unmarshal(enc, &dec))
+	require.Equal(t, bal.Hash(), dec.Hash())
+}
+
+func TestHeaderBlockAccessListHash(t *testing.T) {
+	t.Parallel()
+	balHash := testBlockAccessList().Hash()
+	h := &Header{
+		Number:                big.NewInt(1),
+		Difficulty:            big.NewInt(0),
+		BaseFee:               big.NewInt(7),
+		WithdrawalsHash:       &empty.RootHash,
+		BlobGasUsed:           new(uint64),
+		ExcessBlobGas:         new(uint64),
+		ParentBeaconBlockRoot: &common.Hash{},
+		RequestsHash:          &empty.RequestsHash,
+		BlockAccessListHash:   &balHash,
+	}
+	enc, err := rlp.EncodeToBytes(h)
+	require.NoError(t, err)
+	require.Equal(t, h.EncodingSize(), len(enc)-rlp.ListPrefixLen(h.EncodingSize()))
+
+	var dec Header
+	require.NoError(t, rlp.DecodeBytes(enc, &dec))
+	require.Equal(t, balHash, *dec.BlockAccessListHash)
+	require.Equal(t, h.Hash(), dec.Hash())
+	require.Equal(t, balHash, *CopyHeader(h).BlockAccessListHash)
+
+	h.BlockAccessListHash = nil
+	enc, err = rlp.EncodeToBytes(h)
+	require.NoError(t, err)
+	dec = Header{}
+	require.NoError(t, rlp.DecodeBytes(enc, &dec))
+	require.Nil(t, dec.BlockAccessListHash)
+}
@@ -96,6 +96,15 @@ type receiptRLP struct {
 	Logs              []*Log
 }
 
+// receiptRLP69 is the eth/69 network encoding of a receipt (EIP-7642). The type is a plain
+// list element instead of an envelope, and the bloom is dropped: receivers derive it from the logs.
+type receiptRLP69 struct {
+	Type              uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*Log
+}
+
 // storedReceiptRLP is the storage encoding of a receipt.
 type storedReceiptRLP struct {
 	Type              uint8
@@ -307,6 +316,27 @@ func (r *Receipt) DecodeRLP(s *rlp.Stream) error {
 	return nil
 }
 
+// EncodeRLP69 writes the eth/69 network encoding of the receipt, it has no bloom.
+func (r *Receipt) EncodeRLP69(w io.Writer) error {
+	return rlp.Encode(w, &receiptRLP69{r.Type, r.statusEncoding(), r.CumulativeGasUsed, r.Logs})
+}
+
+// DecodeRLP69 loads a receipt from its eth/69 network encoding, the bloom is recomputed from the logs.
+func (r *Receipt) DecodeRLP69(s *rlp.Stream) error {
+	var data receiptRLP69
+	if err := s.Decode(&data); err != nil {
+		return err
+	}
+	switch data.Type {
+	case LegacyTxType, AccessListTxType, DynamicFeeTxType, BlobTxType, SetCodeTxType:
+	default:
+		return ErrTxTypeNotSupported
+	}
+	r.Type, r.CumulativeGasUsed, r.Logs = data.Type, data.CumulativeGasUsed, data.Logs
+	r.Bloom = LogsBloom(data.Logs)
+	return r.setStatus(data.PostStateOrStatus)
+}
+
 func (r *Receipt) setStatus(postStateOrStatus []byte) error {
 	switch {
 	case bytes.Equal(postStateOrStatus, receiptStatusSuccessfulRLP):
@@ -613,3 +613,27 @@ func TestReceiptEncode(t *testing.T) {
 		require.Equal(t, len(r1.Logs[0].Topics), len(r2.Logs[0].Topics))
 	})
 }
+
+func TestReceiptEncodeRLP69(t *testing.T) {
+	logs := []*Log{{Address: common.HexToAddress("0x11"), Topics: []common.Hash{common.HexToHash("dead"), common.HexToHash("beef")}, Data: []byte{0x01, 0x00, 0xff}}}
+	for _, r1 := range []*Receipt{
+		{Type: LegacyTxType, Status: ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: logs},
+		{Type: DynamicFeeTxType, Status: ReceiptStatusFailed, CumulativeGasUsed: 1},
+		{Type: LegacyTxType, PostState: common.HexToHash("0x01").Bytes(), CumulativeGasUsed: 7, Logs: logs},
+	} {
+		r1.Bloom = CreateBloom(Receipts{r1})
+		var buf bytes.Buffer
+		require.NoError(t, r1.EncodeRLP69(&buf))
+		// no bloom on the wire: it alone is bigger than the whole encoding
+		require.Less(t, buf.Len(), BloomByteLength)
+
+		r2 := &Receipt{}
+		require.NoError(t, r2.DecodeRLP69(rlp.NewStream(bytes.NewReader(buf.Bytes()), 0)))
+		require.Equal(t, r1.Type, r2.Type)
+		require.Equal(t, r1.Status, r2.Status)
+		require.Equal(t, r1.PostState, r2.PostState)
+		require.Equal(t, r1.CumulativeGasUsed, r2.CumulativeGasUsed)
+		require.Equal(t, r1.Bloom, r2.Bloom)
+		require.Len(t, r2.Logs, len(r1.Logs))
+	}
+}
@@ -425,6 +425,9 @@ func (m *Message) SetBlobVersionedHashes(blobHashes []common.Hash) {
 func (m *Message) SetAuthorizations(authorizations []Authorization) {
 	m.authorizations = authorizations
 }
+func (m *Message) SetNonce(nonce uint64) {
+	m.nonce = nonce
+}
 func (m *Message) CheckNonce() bool { return m.checkNonce }
 func (m *Message) SetCheckNonce(checkNonce bool) {
 	m.checkNonce = checkNonce
@@ -115,6 +115,7 @@ import (
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/p2p/protocols/snap"
 	"github.com/erigontech/erigon/p2p/sentry"
 	"github.com/erigontech/erigon/p2p/sentry/sentry_multi_client"
 	"github.com/erigontech/erigon/polygon/bor"
@@ -134,6 +135,7 @@ import (
 	"github.com/erigontech/erigon/turbo/silkworm"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 	"github.com/erigontech/erigon/txnprovider"
+	"github.com/erigontech/erigon/txnprovider/bundle"
 	"github.com/erigontech/erigon/txnprovider/shutter"
 	"github.com/erigontech/erigon/txnprovider/txpool"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
@@ -206,6 +208,7 @@ type Ethereum struct {
 	txPoolGrpcServer          txpoolproto.TxpoolServer
 	txPoolRpcClient           txpoolproto.TxpoolClient
 	shutterPool               *shutter.Pool
+	bundlePool                *bundle.Pool
 	blockBuilderNotifyNewTxns chan struct{}
 	forkValidator             *engine_helpers.ForkValidator
 	downloader                *downloader.Downloader
@@ -506,6 +509,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			return nil, err
 		}
 
+		var snapHandler *snap.Handler
+		if config.SnapServer {
+			if snapHandler, err = snap.NewHandler(backend.chainDB, blockReader, logger); err != nil {
+				return nil, err
+			}
+		}
+
 		var pi int // points to next port to be picked from refCfg.AllowedPorts
 		for _, protocol := range p2pConfig.ProtocolVersion {
 			cfg := p2pConfig
@@ -538,6 +548,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 			cfg.ListenAddr = fmt.Sprintf("%s:%d", listenHost, listenPort)
 			server := sentry.NewGrpcServer(backend.sentryCtx, nil, readNodeInfo, &cfg, protocol, logger)
+			if snapHandler != nil {
+				server.AddSnapProtocol(snapHandler)
+			}
 			backend.sentryServers = append(backend.sentryServers, server)
 			sentries = append(sentries, direct.NewSentryClientDirect(protocol, server))
 		}
@@ -613,7 +626,27 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	var heimdallRPC *heimdall.BackendServer
 
 	if chainConfig.Bor != nil {
-		if !config.WithoutHeimdall {
+		if !config.WithoutHeimdall && len(config.HeimdallGrpcAddrs) > 0 {
+			// the endpoints fail fast, the failover client retries across all of them
+			endpoints := make([]heimdall.FailoverEndpoint, 0, len(config.HeimdallGrpcAddrs)+1)
+			for _, addr := range config.HeimdallGrpcAddrs {
+				grpcClient, err := heimdall.NewGrpcClient(addr, logger, heimdall.WithGrpcMaxRetries(1))
+				if err != nil {
+					return nil, err
+				}
+				endpoints = append(endpoints, heimdall.FailoverEndpoint{Name: addr, Client: grpcClient})
+			}
+			endpoints = append(endpoints, heimdall.FailoverEndpoint{
+				Name:   config.HeimdallURL,
+				Client: heimdall.NewHttpClient(config.HeimdallURL, logger, heimdall.WithApiVersioner(ctx), heimdall.WithHttpMaxRetries(1)),
+			})
+
+			failoverOpts := []heimdall.FailoverClientOption{heimdall.WithFailoverMaxRetries(heimdall.MaxRetriesUnlimited)}
+			if config.HeimdallRace {
+				failoverOpts = append(failoverOpts, heimdall.WithFailoverRace())
+			}
+			heimdallClient = heimdall.NewFailoverClient(ctx, endpoints, logger, failoverOpts...)
+		} else if !config.WithoutHeimdall {
 			heimdallClient = heimdall.NewHttpClient(
 				config.HeimdallURL,
 				logger,
@@ -690,6 +723,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		chainConfig,
 		genesis,
 		backend.config.NetworkID,
+		backend.config.Prune,
 		logger,
 	)
 
@@ -798,6 +832,16 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	backend.rpcDaemonStateCache = rpcDaemonStateCache
 	backend.rpcFilters = rpcFilters
 
+	currentBlockNumReader := func(ctx context.Context) (*uint64, error) {
+		tx, err := backend.chainDB.BeginRo(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		defer tx.Rollback()
+		return chain.CurrentBlockNumber(tx)
+	}
+
 	if config.Shutter.Enabled {
 		if config.TxPool.Disable {
 			panic("can't enable shutter pool when devp2p txpool is disabled")
@@ -829,15 +873,6 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		)
 		contractBackend := contracts.NewDirectBackend(ethApi)
 		baseTxnProvider := backend.txPool
-		currentBlockNumReader := func(ctx context.Context) (*uint64, error) {
-			tx, err := backend.chainDB.BeginRo(ctx)
-			if err != nil {
-				return nil, err
-			}
-
-			defer tx.Rollback()
-			return chain.CurrentBlockNumber(tx)
-		}
 		backend.shutterPool = shutter.NewPool(
 			logger,
 			config.Shutter,
@@ -849,6 +884,16 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		txnProvider = backend.shutterPool
 	}
 
+	if config.Bundles.Enabled {
+		if config.TxPool.Disable {
+			panic("can't enable bundles when devp2p txpool is disabled")
+		}
+
+		simulator := bundle.NewStateSimulator(backend.chainDB, chainConfig, blockReader)
+		backend.bundlePool = bundle.NewPool(logger, config.Bundles, txnProvider, simulator, currentBlockNumReader)
+		txnProvider = backend.bundlePool
+	}
+
 	miner := stagedsync.NewMiningState(&config.Miner)
 	backend.pendingBlocks = miner.PendingResultCh
 
@@ -1180,6 +1225,22 @@ func (s *Ethereum) Init(stack *node.Node, config *ethconfig.Config, chainConfig
 	}
 
 	s.apiList = jsonrpc.APIList(chainKv, s.ethRpcClient, s.txPoolRpcClient, s.miningRpcClient, s.rpcFilters, s.rpcDaemonStateCache, blockReader, &httpRpcCfg, s.engine, s.logger, s.polygonBridge, s.heimdallService)
+	if s.bundlePool != nil {
+		s.apiList = append(s.apiList, rpc.API{
+			Namespace: "eth",
+			Public:    true,
+			Service:   bundle.NewAPI(s.bundlePool, chainConfig),
+			Version:   "1.0",
+		})
+	}
+	if s.txPool != nil {
+		s.apiList = append(s.apiList, rpc.API{
+			Namespace: "txpool",
+			Public:    true,
+			Service:   txpool.NewLocalsAPI(s.txPool),
+			Version:   "1.0",
+		})
+	}
 
 	if config.SilkwormRpcDaemon && httpRpcCfg.Enabled {
 		interface_log_settings := silkworm.RpcInterfaceLogSettings{
@@ -1761,6 +1822,7 @@ func (s *Ethereum) Stop() error {
 	for _, sentryServer := range s.sentryServers {
 		sentryServer.Close()
 	}
+	jsonrpc.CloseAPIList(s.apiList)
 	s.chainDB.Close()
 
 	if s.silkwormRPCDaemonService != nil {
@@ -44,6 +44,7 @@ import (
 	"github.com/erigontech/erigon/execution/consensus/ethash/ethashcfg"
 	"github.com/erigontech/erigon/params"
 	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/txnprovider/bundle/bundlecfg"
 	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
@@ -107,6 +108,7 @@ var Defaults = Config{
 		Recommit: 3 * time.Second,
 	},
 	TxPool:      txpoolcfg.DefaultConfig,
+	Bundles:     bundlecfg.DefaultConfig,
 	RPCGasCap:   50000000,
 	GPO:         FullNodeGPO,
 	RPCTxFeeCap: 1, // 1 ether
@@ -191,6 +193,9 @@ type Config struct {
 	// for nodes to connect to.
 	EthDiscoveryURLs []string
 
+	// SnapServer enables serving the snap protocol to the peers, see p2p/protocols/snap.
+	SnapServer bool
+
 	Prune     prune.Mode
 	BatchSize datasize.ByteSize // Batch size for execution stage
 
@@ -223,6 +228,7 @@ type Config struct {
 	// Transaction pool options
 	TxPool  txpoolcfg.Config
 	Shutter shuttercfg.Config
+	Bundles bundlecfg.Config
 
 	// Gas Price Oracle options
 	GPO gaspricecfg.Config
@@ -238,6 +244,10 @@ type Config struct {
 
 	// URL to connect to Heimdall node
 	HeimdallURL string
+	// Heimdall v2 gRPC endpoints (host:port), queried before HeimdallURL with failover between all of them
+	HeimdallGrpcAddrs []string
+	// Send Heimdall requests to all healthy endpoints at once, needs HeimdallGrpcAddrs
+	HeimdallRace bool
 	// No heimdall service
 	WithoutHeimdall bool
 
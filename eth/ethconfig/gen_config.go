@@ -18,6 +18,7 @@ import (
 	"github.com/erigontech/erigon/execution/chainspec"
 	"github.com/erigontech/erigon/execution/consensus/ethash/ethashcfg"
 	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/txnprovider/bundle/bundlecfg"
 	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
@@ -28,6 +29,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                             *types.Genesis `toml:",omitempty"`
 		NetworkID                           uint64
 		EthDiscoveryURLs                    []string
+		SnapServer                          bool
 		Prune                               prune.Mode
 		BatchSize                           datasize.ByteSize
 		ImportMode                          bool
@@ -44,11 +46,14 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Aura                                chain.AuRaConfig
 		TxPool                              txpoolcfg.Config
 		Shutter                             shuttercfg.Config
+		Bundles                             bundlecfg.Config
 		GPO                                 gaspricecfg.Config
 		RPCGasCap                           uint64  `toml:",omitempty"`
 		RPCTxFeeCap                         float64 `toml:",omitempty"`
 		StateStream                         bool
 		HeimdallURL                         string
+		HeimdallGrpcAddrs                   []string
+		HeimdallRace                        bool
 		WithoutHeimdall                     bool
 		Ethstats                            string
 		InternalCL                          bool
@@ -72,6 +77,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Genesis = c.Genesis
 	enc.NetworkID = c.NetworkID
 	enc.EthDiscoveryURLs = c.EthDiscoveryURLs
+	enc.SnapServer = c.SnapServer
 	enc.Prune = c.Prune
 	enc.BatchSize = c.BatchSize
 	enc.ImportMode = c.ImportMode
@@ -88,11 +94,14 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Aura = c.Aura
 	enc.TxPool = c.TxPool
 	enc.Shutter = c.Shutter
+	enc.Bundles = c.Bundles
 	enc.GPO = c.GPO
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
 	enc.StateStream = c.StateStream
 	enc.HeimdallURL = c.HeimdallURL
+	enc.HeimdallGrpcAddrs = c.HeimdallGrpcAddrs
+	enc.HeimdallRace = c.HeimdallRace
 	enc.WithoutHeimdall = c.WithoutHeimdall
 	enc.Ethstats = c.Ethstats
 	enc.InternalCL = c.InternalCL
@@ -120,6 +129,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                             *types.Genesis `toml:",omitempty"`
 		NetworkID                           *uint64
 		EthDiscoveryURLs                    []string
+		SnapServer                          *bool
 		Prune                               *prune.Mode
 		BatchSize                           *datasize.ByteSize
 		ImportMode                          *bool
@@ -136,11 +146,14 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Aura                                *chain.AuRaConfig
 		TxPool                              *txpoolcfg.Config
 		Shutter                             *shuttercfg.Config
+		Bundles                             *bundlecfg.Config
 		GPO                                 *gaspricecfg.Config
 		RPCGasCap                           *uint64  `toml:",omitempty"`
 		RPCTxFeeCap                         *float64 `toml:",omitempty"`
 		StateStream                         *bool
 		HeimdallURL                         *string
+		HeimdallGrpcAddrs                   []string
+		HeimdallRace                        *bool
 		WithoutHeimdall                     *bool
 		WithHeimdallWaypointRecording       *bool
 		Ethstats                            *string
@@ -174,6 +187,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.EthDiscoveryURLs != nil {
 		c.EthDiscoveryURLs = dec.EthDiscoveryURLs
 	}
+	if dec.SnapServer != nil {
+		c.SnapServer = *dec.SnapServer
+	}
 	if dec.Prune != nil {
 		c.Prune = *dec.Prune
 	}
@@ -222,6 +238,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Shutter != nil {
 		c.Shutter = *dec.Shutter
 	}
+	if dec.Bundles != nil {
+		c.Bundles = *dec.Bundles
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -237,6 +256,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.HeimdallURL != nil {
 		c.HeimdallURL = *dec.HeimdallURL
 	}
+	if dec.HeimdallGrpcAddrs != nil {
+		c.HeimdallGrpcAddrs = dec.HeimdallGrpcAddrs
+	}
+	if dec.HeimdallRace != nil {
+		c.HeimdallRace = *dec.HeimdallRace
+	}
 	if dec.WithoutHeimdall != nil {
 		c.WithoutHeimdall = *dec.WithoutHeimdall
 	}
@@ -0,0 +1,539 @@
+package live
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func init() {
+	register("fileTracer", newFileTracer)
+}
+
+const (
+	fileTracerDefaultBlocksPerFile = 100_000
+	fileTracerDefaultMaxFileSize   = 512 * 1024 * 1024
+
+	fileTracerSegmentPrefix = "traces-"
+	fileTracerSegmentExt    = ".jsonl"
+	fileTracerPartExt       = ".jsonl.part"
+)
+
+type fileTracerConfig struct {
+	Path          string `json:"path"`          // directory the segments are written to
+	BlocksPerFile uint64 `json:"blocksPerFile"` // segments are aligned to multiples of blocksPerFile
+	MaxFileSize   int64  `json:"maxFileSize"`   // segment is sealed early once it grows beyond maxFileSize bytes
+}
+
+// fileTracer writes call frames, logs and balance changes of executed blocks into
+// block-range segmented JSONL files, so they can be loaded offline.
+//
+// Every line is a JSON object with a "type" ("tx" or "block") and a "block" number.
+// The segment being written is named traces-<from>.jsonl.part, and is renamed to
+// traces-<from>-<to>.jsonl (inclusive range) once it is sealed. A block is written only
+// after it was executed successfully, so segments never contain partial blocks.
+// On unwind, or when a block at or below the last written one is executed again,
+// the files are rewound: records of the discarded blocks are removed.
+//
+// The tracer expects blocks to be executed serially, in order.
+type fileTracer struct {
+	cfg fileTracerConfig
+
+	file     *os.File
+	w        *bufio.Writer
+	size     int64
+	segFrom  uint64
+	hasSeg   bool
+	lastBlk  uint64
+	hasLast  bool
+	disabled bool // set after an I/O error, nothing is written anymore
+
+	// current block
+	block         *types.Block
+	blockBuf      bytes.Buffer
+	blockBalances []*balanceChangeRecord
+	txIndex       int
+	inSystemCall  bool
+	tx            *txRecord
+	callStack     []*callFrameRecord
+}
+
+type callFrameRecord struct {
+	Type       string             `json:"type"`
+	From       common.Address     `json:"from"`
+	To         common.Address     `json:"to"`
+	Value      *hexutil.Big       `json:"value,omitempty"`
+	Gas        hexutil.Uint64     `json:"gas"`
+	GasUsed    hexutil.Uint64     `json:"gasUsed"`
+	Input      hexutil.Bytes      `json:"input,omitempty"`
+	Output     hexutil.Bytes      `json:"output,omitempty"`
+	Precompile bool               `json:"precompile,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	Reverted   bool               `json:"reverted,omitempty"`
+	Calls      []*callFrameRecord `json:"calls,omitempty"`
+}
+
+type logRecord struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+	Index   hexutil.Uint   `json:"logIndex"`
+}
+
+type balanceChangeRecord struct {
+	Address common.Address `json:"address"`
+	Prev    *hexutil.Big   `json:"prev"`
+	New     *hexutil.Big   `json:"new"`
+	Reason  string         `json:"reason"`
+}
+
+type txRecord struct {
+	Type           string                 `json:"type"`
+	Block          uint64                 `json:"block"`
+	BlockHash      common.Hash            `json:"blockHash"`
+	TxIndex        int                    `json:"txIndex"`
+	TxHash         common.Hash            `json:"txHash"`
+	From           common.Address         `json:"from"`
+	Status         hexutil.Uint64         `json:"status"`
+	GasUsed        hexutil.Uint64         `json:"gasUsed"`
+	Error          string                 `json:"error,omitempty"`
+	Call           *callFrameRecord       `json:"call,omitempty"`
+	Logs           []*logRecord           `json:"logs"`
+	BalanceChanges []*balanceChangeRecord `json:"balanceChanges"`
+}
+
+type blockRecord struct {
+	Type           string                 `json:"type"`
+	Block          uint64                 `json:"block"`
+	BlockHash      common.Hash            `json:"blockHash"`
+	ParentHash     common.Hash            `json:"parentHash"`
+	Timestamp      hexutil.Uint64         `json:"timestamp"`
+	TxCount        int                    `json:"txCount"`
+	BalanceChanges []*balanceChangeRecord `json:"balanceChanges"`
+}
+
+func newFileTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	var config fileTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+	if config.Path == "" {
+		return nil, errors.New("fileTracer: path is required")
+	}
+	if config.BlocksPerFile == 0 {
+		config.BlocksPerFile = fileTracerDefaultBlocksPerFile
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = fileTracerDefaultMaxFileSize
+	}
+	if err := os.MkdirAll(config.Path, 0o755); err != nil {
+		return nil, fmt.Errorf("fileTracer: %w", err)
+	}
+
+	t := &fileTracer{cfg: config}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnBlockStart:      t.OnBlockStart,
+			OnBlockEnd:        t.OnBlockEnd,
+			OnSystemCallStart: t.OnSystemCallStart,
+			OnSystemCallEnd:   t.OnSystemCallEnd,
+			OnUnwind:          t.OnUnwind,
+			OnTxStart:         t.OnTxStart,
+			OnTxEnd:           t.OnTxEnd,
+			OnEnter:           t.OnEnter,
+			OnExit:            t.OnExit,
+			OnBalanceChange:   t.OnBalanceChange,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *fileTracer) OnBlockStart(event tracing.BlockEvent) {
+	t.block = event.Block
+	t.blockBuf.Reset()
+	t.blockBalances = []*balanceChangeRecord{}
+	t.txIndex = 0
+	t.tx = nil
+	t.callStack = t.callStack[:0]
+	if t.disabled {
+		return
+	}
+	// First block since start, or a block which was already written: drop everything from it onwards
+	if num := event.Block.NumberU64(); !t.hasLast || num <= t.lastBlk {
+		var unwindPoint uint64
+		if num > 0 {
+			unwindPoint = num - 1
+		}
+		t.fail(t.rewind(unwindPoint, num == 0))
+	}
+}
+
+func (t *fileTracer) OnBlockEnd(err error) {
+	defer func() { t.block = nil }()
+	if t.block == nil || t.disabled {
+		return
+	}
+	if err != nil {
+		// Block is invalid, none of its records are kept
+		return
+	}
+	t.appendRecord(&blockRecord{
+		Type:           "block",
+		Block:          t.block.NumberU64(),
+		BlockHash:      t.block.Hash(),
+		ParentHash:     t.block.ParentHash(),
+		Timestamp:      hexutil.Uint64(t.block.Time()),
+		TxCount:        t.txIndex,
+		BalanceChanges: t.blockBalances,
+	})
+	t.fail(t.writeBlock(t.block.NumberU64()))
+}
+
+func (t *fileTracer) OnSystemCallStart() {
+	t.inSystemCall = true
+}
+
+func (t *fileTracer) OnSystemCallEnd() {
+	t.inSystemCall = false
+}
+
+// OnUnwind removes records of blocks above unwindPoint.
+func (t *fileTracer) OnUnwind(unwindPoint uint64) {
+	if t.disabled || !t.hasLast || unwindPoint >= t.lastBlk {
+		return
+	}
+	t.fail(t.rewind(unwindPoint, false))
+}
+
+func (t *fileTracer) OnTxStart(env *tracing.VMContext, txn types.Transaction, from common.Address) {
+	if t.block == nil || txn == nil {
+		return
+	}
+	t.tx = &txRecord{
+		Type:           "tx",
+		Block:          t.block.NumberU64(),
+		BlockHash:      t.block.Hash(),
+		TxIndex:        t.txIndex,
+		TxHash:         txn.Hash(),
+		From:           from,
+		Logs:           []*logRecord{},
+		BalanceChanges: []*balanceChangeRecord{},
+	}
+	t.callStack = t.callStack[:0]
+}
+
+func (t *fileTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if t.tx == nil {
+		return
+	}
+	if err != nil {
+		t.tx.Error = err.Error()
+	}
+	if receipt != nil {
+		t.tx.Status = hexutil.Uint64(receipt.Status)
+		t.tx.GasUsed = hexutil.Uint64(receipt.GasUsed)
+		for _, l := range receipt.Logs {
+			t.tx.Logs = append(t.tx.Logs, &logRecord{
+				Address: l.Address,
+				Topics:  l.Topics,
+				Data:    l.Data,
+				Index:   hexutil.Uint(l.Index),
+			})
+		}
+	}
+	t.appendRecord(t.tx)
+	t.tx = nil
+	t.callStack = t.callStack[:0]
+	t.txIndex++
+}
+
+func (t *fileTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if t.tx == nil || t.inSystemCall {
+		return
+	}
+	frame := &callFrameRecord{
+		Type:       vm.OpCode(typ).String(),
+		From:       from,
+		To:         to,
+		Gas:        hexutil.Uint64(gas),
+		Input:      common.Copy(input),
+		Precompile: precompile,
+	}
+	if value != nil {
+		frame.Value = (*hexutil.Big)(value.ToBig())
+	}
+	if n := len(t.callStack); n > 0 {
+		parent := t.callStack[n-1]
+		parent.Calls = append(parent.Calls, frame)
+	} else {
+		t.tx.Call = frame
+	}
+	t.callStack = append(t.callStack, frame)
+}
+
+func (t *fileTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.tx == nil || t.inSystemCall || len(t.callStack) == 0 {
+		return
+	}
+	frame := t.callStack[len(t.callStack)-1]
+	t.callStack = t.callStack[:len(t.callStack)-1]
+	frame.GasUsed = hexutil.Uint64(gasUsed)
+	frame.Output = common.Copy(output)
+	frame.Reverted = reverted
+	if err != nil {
+		frame.Error = err.Error()
+	}
+}
+
+func (t *fileTracer) OnBalanceChange(a common.Address, prev, new uint256.Int, reason tracing.BalanceChangeReason) {
+	if t.block == nil {
+		return
+	}
+	change := &balanceChangeRecord{
+		Address: a,
+		Prev:    (*hexutil.Big)(prev.ToBig()),
+		New:     (*hexutil.Big)(new.ToBig()),
+		Reason:  reason.String(),
+	}
+	if t.tx != nil && !t.inSystemCall {
+		t.tx.BalanceChanges = append(t.tx.BalanceChanges, change)
+		return
+	}
+	t.blockBalances = append(t.blockBalances, change)
+}
+
+func (t *fileTracer) GetResult() (json.RawMessage, error) {
+	return json.RawMessage{}, nil
+}
+
+// Stop flushes and closes the segment being written. It stays unsealed and is
+// resumed on the next start.
+func (t *fileTracer) Stop(err error) {
+	t.fail(t.closeSegment())
+}
+
+func (t *fileTracer) fail(err error) {
+	if err == nil {
+		return
+	}
+	log.Warn("[fileTracer] writing traces disabled", "err", err)
+	t.disabled = true
+	if t.file != nil {
+		t.file.Close()
+		t.file, t.w = nil, nil
+	}
+}
+
+func (t *fileTracer) appendRecord(rec any) {
+	if t.disabled {
+		return
+	}
+	enc, err := json.Marshal(rec)
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	t.blockBuf.Write(enc)
+	t.blockBuf.WriteByte('\n')
+}
+
+// writeBlock appends the buffered records of block blockNum to the current segment,
+// sealing the segment when it reaches its block range end or its size limit.
+func (t *fileTracer) writeBlock(blockNum uint64) error {
+	if !t.hasSeg {
+		if err := t.openSegment(blockNum, 0); err != nil {
+			return err
+		}
+	}
+	n, err := t.w.Write(t.blockBuf.Bytes())
+	if err != nil {
+		return err
+	}
+	t.blockBuf.Reset()
+	t.size += int64(n)
+	t.lastBlk, t.hasLast = blockNum, true
+	if (blockNum+1)%t.cfg.BlocksPerFile == 0 || t.size >= t.cfg.MaxFileSize {
+		return t.sealSegment()
+	}
+	return t.w.Flush()
+}
+
+func (t *fileTracer) partPath(from uint64) string {
+	return filepath.Join(t.cfg.Path, fmt.Sprintf("%s%012d%s", fileTracerSegmentPrefix, from, fileTracerPartExt))
+}
+
+func (t *fileTracer) sealedPath(from, to uint64) string {
+	return filepath.Join(t.cfg.Path, fmt.Sprintf("%s%012d-%012d%s", fileTracerSegmentPrefix, from, to, fileTracerSegmentExt))
+}
+
+// openSegment opens (or creates) the unsealed segment starting at block from,
+// truncated to size bytes.
+func (t *fileTracer) openSegment(from uint64, size int64) error {
+	f, err := os.OpenFile(t.partPath(from), os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+	t.file, t.w = f, bufio.NewWriterSize(f, 1024*1024)
+	t.size, t.segFrom, t.hasSeg = size, from, true
+	return nil
+}
+
+func (t *fileTracer) closeSegment() error {
+	if !t.hasSeg || t.file == nil {
+		return nil
+	}
+	err := t.w.Flush()
+	if closeErr := t.file.Close(); err == nil {
+		err = closeErr
+	}
+	t.file, t.w, t.hasSeg = nil, nil, false
+	return err
+}
+
+func (t *fileTracer) sealSegment() error {
+	from := t.segFrom
+	if err := t.closeSegment(); err != nil {
+		return err
+	}
+	return os.Rename(t.partPath(from), t.sealedPath(from, t.lastBlk))
+}
+
+type fileTracerSegment struct {
+	from, to uint64
+	sealed   bool
+	path     string
+}
+
+// segments lists the segments in the output directory, ordered by their first block.
+func (t *fileTracer) segments() ([]fileTracerSegment, error) {
+	entries, err := os.ReadDir(t.cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	var segs []fileTracerSegment
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, fileTracerSegmentPrefix) {
+			continue
+		}
+		seg := fileTracerSegment{path: filepath.Join(t.cfg.Path, name)}
+		switch {
+		case strings.HasSuffix(name, fileTracerPartExt):
+			if _, err := fmt.Sscanf(strings.TrimSuffix(name, fileTracerPartExt), fileTracerSegmentPrefix+"%d", &seg.from); err != nil {
+				continue
+			}
+		case strings.HasSuffix(name, fileTracerSegmentExt):
+			if _, err := fmt.Sscanf(strings.TrimSuffix(name, fileTracerSegmentExt), fileTracerSegmentPrefix+"%d-%d", &seg.from, &seg.to); err != nil {
+				continue
+			}
+			seg.sealed = true
+		default:
+			continue
+		}
+		segs = append(segs, seg)
+	}
+	sort.Slice(segs, func(i, j int) bool { return segs[i].from < segs[j].from })
+	return segs, nil
+}
+
+// rewind removes all records of blocks above unwindPoint (all records if dropAll is set)
+// and reopens the segment containing unwindPoint for appending.
+func (t *fileTracer) rewind(unwindPoint uint64, dropAll bool) error {
+	if err := t.closeSegment(); err != nil {
+		return err
+	}
+	t.hasLast = false
+	segs, err := t.segments()
+	if err != nil {
+		return err
+	}
+	for _, seg := range segs {
+		if dropAll || seg.from > unwindPoint {
+			if err := os.Remove(seg.path); err != nil {
+				return err
+			}
+			continue
+		}
+		if seg.sealed && seg.to <= unwindPoint {
+			t.lastBlk, t.hasLast = seg.to, true
+			continue
+		}
+		// Segment contains unwindPoint: keep the records up to it and resume writing
+		size, last, ok, err := truncatePoint(seg.path, unwindPoint)
+		if err != nil {
+			return err
+		}
+		if seg.sealed {
+			if err := os.Rename(seg.path, t.partPath(seg.from)); err != nil {
+				return err
+			}
+		}
+		if err := t.openSegment(seg.from, size); err != nil {
+			return err
+		}
+		if ok {
+			t.lastBlk, t.hasLast = last, true
+		}
+	}
+	return nil
+}
+
+// truncatePoint returns the size of the file prefix holding the records of blocks
+// up to unwindPoint and the last block within that prefix.
+func truncatePoint(path string, unwindPoint uint64) (size int64, last uint64, ok bool, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	var rec struct {
+		Block uint64 `json:"block"`
+	}
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Drop a partially written trailing line
+			return size, last, ok, nil
+		}
+		if err != nil {
+			return 0, 0, false, err
+		}
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return 0, 0, false, fmt.Errorf("%s at offset %d: %w", path, size, err)
+		}
+		if rec.Block > unwindPoint {
+			return size, last, ok, nil
+		}
+		size += int64(len(line))
+		last, ok = rec.Block, true
+	}
+}
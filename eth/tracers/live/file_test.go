@@ -0,0 +1,163 @@
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func newTestFileTracer(t *testing.T, dir string) *tracers.Tracer {
+	t.Helper()
+	tracer, err := newFileTracer(nil, json.RawMessage(`{"path":"`+dir+`","blocksPerFile":4}`))
+	require.NoError(t, err)
+	return tracer
+}
+
+func executeTestBlock(hooks *tracing.Hooks, num uint64, extra byte) common.Hash {
+	block := types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(num), Extra: []byte{extra}})
+	hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+	txn := types.NewTransaction(num, common.Address{2}, uint256.NewInt(1), 21000, uint256.NewInt(1), nil)
+	hooks.OnTxStart(&tracing.VMContext{BlockNumber: num}, txn, common.Address{1})
+	hooks.OnEnter(0, byte(vm.CALL), common.Address{1}, common.Address{2}, false, nil, 21000, uint256.NewInt(1), nil)
+	hooks.OnBalanceChange(common.Address{2}, *uint256.NewInt(0), *uint256.NewInt(1), tracing.BalanceChangeTransfer)
+	hooks.OnExit(0, nil, 0, nil, false)
+	hooks.OnTxEnd(&types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 21000}, nil)
+	hooks.OnBalanceChange(common.Address{3}, *uint256.NewInt(0), *uint256.NewInt(2), tracing.BalanceIncreaseRewardMineBlock)
+	hooks.OnBlockEnd(nil)
+	return block.Hash()
+}
+
+type testTraceLine struct {
+	Type      string      `json:"type"`
+	Block     uint64      `json:"block"`
+	BlockHash common.Hash `json:"blockHash"`
+}
+
+func readTraceLines(t *testing.T, path string) []testTraceLine {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var lines []testTraceLine
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line testTraceLine
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func TestFileTracerRotation(t *testing.T) {
+	dir := t.TempDir()
+	tracer := newTestFileTracer(t, dir)
+	defer tracer.Stop(nil)
+	hooks := tracer.Hooks
+	for num := uint64(1); num <= 5; num++ {
+		executeTestBlock(hooks, num, 0)
+	}
+
+	lines := readTraceLines(t, filepath.Join(dir, "traces-000000000001-000000000003.jsonl"))
+	require.Len(t, lines, 6)
+	require.Equal(t, "tx", lines[0].Type)
+	require.Equal(t, "block", lines[1].Type)
+	require.Equal(t, uint64(3), lines[5].Block)
+
+	lines = readTraceLines(t, filepath.Join(dir, "traces-000000000004.jsonl.part"))
+	require.Len(t, lines, 4)
+	require.Equal(t, uint64(5), lines[3].Block)
+
+	// failed block leaves no records behind
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(6)})
+	hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+	hooks.OnBlockEnd(vm.ErrOutOfGas)
+	require.Len(t, readTraceLines(t, filepath.Join(dir, "traces-000000000004.jsonl.part")), 4)
+}
+
+func TestFileTracerUnwind(t *testing.T) {
+	dir := t.TempDir()
+	tracer := newTestFileTracer(t, dir)
+	defer func() { tracer.Stop(nil) }()
+	hooks := tracer.Hooks
+	for num := uint64(1); num <= 5; num++ {
+		executeTestBlock(hooks, num, 0)
+	}
+
+	// unwind into the sealed segment reopens it
+	hooks.OnUnwind(2)
+	_, err := os.Stat(filepath.Join(dir, "traces-000000000004.jsonl.part"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "traces-000000000001-000000000003.jsonl"))
+	require.True(t, os.IsNotExist(err))
+
+	newHash := executeTestBlock(hooks, 3, 1)
+	executeTestBlock(hooks, 4, 1)
+	lines := readTraceLines(t, filepath.Join(dir, "traces-000000000001-000000000003.jsonl"))
+	require.Len(t, lines, 6)
+	require.Equal(t, newHash, lines[5].BlockHash)
+
+	// re-execution of an already written block without an unwind (e.g. after restart)
+	tracer.Stop(nil)
+	tracer = newTestFileTracer(t, dir)
+	executeTestBlock(tracer.Hooks, 4, 2)
+	lines = readTraceLines(t, filepath.Join(dir, "traces-000000000004.jsonl.part"))
+	require.Len(t, lines, 2)
+	require.Equal(t, uint64(4), lines[1].Block)
+}
+
+func TestFileTracerMaxFileSize(t *testing.T) {
+	// size of the records of a single block
+	single := t.TempDir()
+	tracer, err := newFileTracer(nil, json.RawMessage(`{"path":"`+single+`","blocksPerFile":1}`))
+	require.NoError(t, err)
+	executeTestBlock(tracer.Hooks, 1, 0)
+	tracer.Stop(nil)
+	info, err := os.Stat(filepath.Join(single, "traces-000000000001-000000000001.jsonl"))
+	require.NoError(t, err)
+
+	// segments are sealed as soon as they cross the limit, before the end of their block range
+	dir := t.TempDir()
+	tracer, err = newFileTracer(nil, json.RawMessage(`{"path":"`+dir+`","blocksPerFile":100,"maxFileSize":`+strconv.FormatInt(info.Size()+1, 10)+`}`))
+	require.NoError(t, err)
+	defer tracer.Stop(nil)
+	for num := uint64(1); num <= 5; num++ {
+		executeTestBlock(tracer.Hooks, num, 0)
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.Equal(t, []string{
+		"traces-000000000001-000000000002.jsonl",
+		"traces-000000000003-000000000004.jsonl",
+		"traces-000000000005.jsonl.part",
+	}, names)
+
+	for i, name := range names[:2] {
+		lines := readTraceLines(t, filepath.Join(dir, name))
+		require.Len(t, lines, 4)
+		require.Equal(t, uint64(2*i+1), lines[0].Block)
+		require.Equal(t, uint64(2*i+2), lines[3].Block)
+		require.Equal(t, "block", lines[3].Type)
+	}
+	lines := readTraceLines(t, filepath.Join(dir, names[2]))
+	require.Len(t, lines, 2)
+	require.Equal(t, uint64(5), lines[1].Block)
+}
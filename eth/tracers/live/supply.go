@@ -0,0 +1,331 @@
+package live
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/erigontech/mdbx-go/mdbx"
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon-lib/kv"
+	mdbx2 "github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/eth/tracers"
+	"github.com/erigontech/erigon/execution/consensus/misc"
+)
+
+func init() {
+	register("supply", newSupplyTracer)
+}
+
+// SupplyIssuance is the ether created within a block.
+type SupplyIssuance struct {
+	GenesisAlloc *hexutil.Big `json:"genesisAlloc,omitempty"`
+	Reward       *hexutil.Big `json:"reward,omitempty"`
+	Withdrawals  *hexutil.Big `json:"withdrawals,omitempty"`
+}
+
+// SupplyBurn is the ether destroyed within a block.
+type SupplyBurn struct {
+	EIP1559      *hexutil.Big `json:"1559,omitempty"`
+	Blob         *hexutil.Big `json:"blob,omitempty"`
+	Selfdestruct *hexutil.Big `json:"selfdestruct,omitempty"`
+}
+
+// SupplyDelta is the change of the ether supply caused by a single block.
+type SupplyDelta struct {
+	Issuance   *SupplyIssuance  `json:"issuance,omitempty"`
+	Burn       *SupplyBurn      `json:"burn,omitempty"`
+	Delta      *math.Decimal256 `json:"delta"` // issuance minus burn, decimal as it is negative when burn exceeds issuance
+	Number     hexutil.Uint64   `json:"blockNumber"`
+	Hash       common.Hash      `json:"hash"`
+	ParentHash common.Hash      `json:"parentHash"`
+}
+
+const (
+	supplyFlushBlocks   = 1024            // deltas are written in batches of at most supplyFlushBlocks blocks...
+	supplyFlushInterval = 5 * time.Second // ...or at least every supplyFlushInterval, so the RPC sees recent blocks
+)
+
+// supplyTracer accounts ether issuance and burn of every executed block, and persists
+// one SupplyDelta per block into the supply database (kv.SupplyDB) in <datadir>/supply,
+// where erigon_getSupplyDelta reads it from. Records are keyed by block number, so
+// re-executed blocks overwrite the previous record, and unwound blocks are deleted.
+type supplyTracer struct {
+	db          kv.RwDB
+	chainConfig *chain.Config
+
+	pending   []supplyRecord // encoded deltas not written to db yet
+	lastFlush time.Time
+
+	block        *types.Block
+	genesisAlloc big.Int
+	reward       big.Int
+	withdrawals  big.Int
+	eip1559Burn  big.Int
+	blobBurn     big.Int
+	selfdestruct big.Int
+
+	disabled bool // set after a database error, nothing is persisted anymore
+}
+
+type supplyRecord struct {
+	block uint64
+	delta []byte
+}
+
+func newSupplyTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	if ctx == nil || ctx.Dirs.Supply == "" {
+		return nil, errors.New("supply: datadir is required")
+	}
+	db, err := OpenSupplyDB(context.Background(), ctx.Dirs.Supply, false, log.Root())
+	if err != nil {
+		return nil, fmt.Errorf("supply: %w", err)
+	}
+
+	t := &supplyTracer{db: db, lastFlush: time.Now()}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnBlockchainInit: t.OnBlockchainInit,
+			OnGenesisBlock:   t.OnGenesisBlock,
+			OnBlockStart:     t.OnBlockStart,
+			OnBlockEnd:       t.OnBlockEnd,
+			OnUnwind:         t.OnUnwind,
+			OnBalanceChange:  t.OnBalanceChange,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OpenSupplyDB opens the database the supply tracer persists per-block deltas to.
+// Deltas are committed frequently, so the database works in SafeNoSync mode.
+// Readers (rpcdaemon) must open it with accede set: they must not create it.
+func OpenSupplyDB(ctx context.Context, path string, accede bool, logger log.Logger) (kv.RwDB, error) {
+	return mdbx2.New(kv.SupplyDB, logger).
+		WithTableCfg(func(defaultBuckets kv.TableCfg) kv.TableCfg { return kv.SupplyTablesCfg }).
+		GrowthStep(16 * datasize.MB).
+		MapSize(64 * datasize.GB).
+		AddFlags(mdbx.SafeNoSync).
+		SyncPeriod(2 * time.Second).
+		Accede(accede).
+		Path(path).
+		Open(ctx)
+}
+
+// ReadSupplyDeltas returns the persisted deltas of blocks from..to (inclusive).
+// Blocks which were not traced are skipped.
+func ReadSupplyDeltas(tx kv.Tx, from, to uint64) ([]*SupplyDelta, error) {
+	deltas := make([]*SupplyDelta, 0, 16)
+	err := tx.ForEach(kv.SupplyDelta, hexutil.EncodeTs(from), func(k, v []byte) error {
+		if len(k) != 8 {
+			return fmt.Errorf("supply: unexpected key length %d", len(k))
+		}
+		if binary.BigEndian.Uint64(k) > to {
+			return errBreak
+		}
+		var delta SupplyDelta
+		if err := json.Unmarshal(v, &delta); err != nil {
+			return err
+		}
+		deltas = append(deltas, &delta)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBreak) {
+		return nil, err
+	}
+	return deltas, nil
+}
+
+var errBreak = errors.New("break")
+
+func (t *supplyTracer) OnBlockchainInit(chainConfig *chain.Config) {
+	t.chainConfig = chainConfig
+}
+
+func (t *supplyTracer) OnGenesisBlock(b *types.Block, alloc types.GenesisAlloc) {
+	t.reset(b)
+	for _, account := range alloc {
+		if account.Balance != nil {
+			t.genesisAlloc.Add(&t.genesisAlloc, account.Balance)
+		}
+	}
+	t.OnBlockEnd(nil)
+}
+
+func (t *supplyTracer) OnBlockStart(event tracing.BlockEvent) {
+	t.reset(event.Block)
+	header := event.Block.HeaderNoCopy()
+
+	// Base fee is credited to the burnt contract on chains which have one
+	if header.BaseFee != nil && (t.chainConfig == nil || t.chainConfig.GetBurntContract(header.Number.Uint64()) == nil) {
+		t.eip1559Burn.Mul(header.BaseFee, new(big.Int).SetUint64(header.GasUsed))
+	}
+	if header.BlobGasUsed != nil && header.ExcessBlobGas != nil && t.chainConfig != nil {
+		blobGasPrice, err := misc.GetBlobGasPrice(t.chainConfig, *header.ExcessBlobGas, header.Time)
+		if err != nil {
+			log.Warn("[supply] blob gas price", "block", header.Number, "err", err)
+		} else {
+			t.blobBurn.Mul(blobGasPrice.ToBig(), new(big.Int).SetUint64(*header.BlobGasUsed))
+		}
+	}
+}
+
+func (t *supplyTracer) OnBlockEnd(err error) {
+	defer func() { t.block = nil }()
+	if t.block == nil || t.disabled || err != nil {
+		return
+	}
+
+	delta := &SupplyDelta{
+		Number:     hexutil.Uint64(t.block.NumberU64()),
+		Hash:       t.block.Hash(),
+		ParentHash: t.block.ParentHash(),
+	}
+	var total big.Int
+	if t.genesisAlloc.Sign() != 0 || t.reward.Sign() != 0 || t.withdrawals.Sign() != 0 {
+		delta.Issuance = &SupplyIssuance{
+			GenesisAlloc: supplyAmount(&t.genesisAlloc),
+			Reward:       supplyAmount(&t.reward),
+			Withdrawals:  supplyAmount(&t.withdrawals),
+		}
+		total.Add(&t.genesisAlloc, &t.reward)
+		total.Add(&total, &t.withdrawals)
+	}
+	if t.eip1559Burn.Sign() != 0 || t.blobBurn.Sign() != 0 || t.selfdestruct.Sign() != 0 {
+		delta.Burn = &SupplyBurn{
+			EIP1559:      supplyAmount(&t.eip1559Burn),
+			Blob:         supplyAmount(&t.blobBurn),
+			Selfdestruct: supplyAmount(&t.selfdestruct),
+		}
+		total.Sub(&total, &t.eip1559Burn)
+		total.Sub(&total, &t.blobBurn)
+		total.Sub(&total, &t.selfdestruct)
+	}
+	delta.Delta = (*math.Decimal256)(&total)
+
+	v, err := json.Marshal(delta)
+	if err != nil {
+		t.fail(err)
+		return
+	}
+	t.pending = append(t.pending, supplyRecord{block: t.block.NumberU64(), delta: v})
+	if len(t.pending) >= supplyFlushBlocks || time.Since(t.lastFlush) >= supplyFlushInterval {
+		t.fail(t.flush(nil))
+	}
+}
+
+// OnUnwind deletes deltas of blocks above unwindPoint.
+func (t *supplyTracer) OnUnwind(unwindPoint uint64) {
+	if t.disabled {
+		return
+	}
+	t.pending = slices.DeleteFunc(t.pending, func(r supplyRecord) bool { return r.block > unwindPoint })
+	t.fail(t.flush(func(tx kv.RwTx) error {
+		c, err := tx.RwCursor(kv.SupplyDelta)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		for k, _, err := c.Seek(hexutil.EncodeTs(unwindPoint + 1)); k != nil; k, _, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			if err := c.DeleteCurrent(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+func (t *supplyTracer) OnBalanceChange(a common.Address, prev, new uint256.Int, reason tracing.BalanceChangeReason) {
+	if t.block == nil {
+		return
+	}
+	var diff uint256.Int
+	switch reason {
+	case tracing.BalanceIncreaseRewardMineBlock, tracing.BalanceIncreaseRewardMineUncle:
+		diff.Sub(&new, &prev)
+		t.reward.Add(&t.reward, diff.ToBig())
+	case tracing.BalanceIncreaseWithdrawal:
+		diff.Sub(&new, &prev)
+		t.withdrawals.Add(&t.withdrawals, diff.ToBig())
+	case tracing.BalanceIncreaseGenesisBalance:
+		diff.Sub(&new, &prev)
+		t.genesisAlloc.Add(&t.genesisAlloc, diff.ToBig())
+	case tracing.BalanceDecreaseSelfdestructBurn:
+		diff.Sub(&prev, &new)
+		t.selfdestruct.Add(&t.selfdestruct, diff.ToBig())
+	}
+}
+
+func (t *supplyTracer) GetResult() (json.RawMessage, error) {
+	return json.RawMessage{}, nil
+}
+
+func (t *supplyTracer) Stop(err error) {
+	if !t.disabled {
+		t.fail(t.flush(nil))
+	}
+	t.db.Close()
+}
+
+// flush writes the pending deltas, and runs then (if set) in the same transaction.
+func (t *supplyTracer) flush(then func(tx kv.RwTx) error) error {
+	if len(t.pending) == 0 && then == nil {
+		return nil
+	}
+	err := t.db.Update(context.Background(), func(tx kv.RwTx) error {
+		for _, r := range t.pending {
+			if err := tx.Put(kv.SupplyDelta, hexutil.EncodeTs(r.block), r.delta); err != nil {
+				return err
+			}
+		}
+		if then != nil {
+			return then(tx)
+		}
+		return nil
+	})
+	t.pending = t.pending[:0]
+	t.lastFlush = time.Now()
+	return err
+}
+
+func (t *supplyTracer) reset(b *types.Block) {
+	t.block = b
+	t.genesisAlloc.SetUint64(0)
+	t.reward.SetUint64(0)
+	t.withdrawals.SetUint64(0)
+	t.eip1559Burn.SetUint64(0)
+	t.blobBurn.SetUint64(0)
+	t.selfdestruct.SetUint64(0)
+}
+
+func (t *supplyTracer) fail(err error) {
+	if err == nil {
+		return
+	}
+	log.Warn("[supply] persisting supply deltas disabled", "err", err)
+	t.disabled = true
+}
+
+func supplyAmount(v *big.Int) *hexutil.Big {
+	if v.Sign() == 0 {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Set(v))
+}
@@ -0,0 +1,99 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func TestSupplyTracer(t *testing.T) {
+	ctx := context.Background()
+	db, err := OpenSupplyDB(ctx, t.TempDir(), false, log.New())
+	require.NoError(t, err)
+	tracer := &supplyTracer{db: db}
+	t.Cleanup(func() { tracer.Stop(nil) })
+
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+	tracer.OnGenesisBlock(genesis, types.GenesisAlloc{common.Address{1}: {Balance: big.NewInt(1000)}})
+
+	for num := int64(1); num <= 3; num++ {
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(num), BaseFee: big.NewInt(7), GasUsed: 21000})
+		tracer.OnBlockStart(tracing.BlockEvent{Block: block})
+		tracer.OnBalanceChange(common.Address{2}, *uint256.NewInt(0), *uint256.NewInt(100), tracing.BalanceIncreaseWithdrawal)
+		tracer.OnBalanceChange(common.Address{3}, *uint256.NewInt(50), *uint256.NewInt(0), tracing.BalanceDecreaseSelfdestructBurn)
+		tracer.OnBalanceChange(common.Address{4}, *uint256.NewInt(0), *uint256.NewInt(5), tracing.BalanceChangeTransfer)
+		tracer.OnBlockEnd(nil)
+	}
+	tracer.OnUnwind(2)
+
+	var deltas []*SupplyDelta
+	require.NoError(t, db.View(ctx, func(tx kv.Tx) (err error) {
+		deltas, err = ReadSupplyDeltas(tx, 0, 10)
+		return err
+	}))
+	require.Len(t, deltas, 3)
+	require.Equal(t, int64(1000), deltas[0].Issuance.GenesisAlloc.ToInt().Int64())
+	require.Equal(t, int64(1000), (*big.Int)(deltas[0].Delta).Int64())
+	require.Nil(t, deltas[0].Burn)
+
+	require.Equal(t, int64(100), deltas[1].Issuance.Withdrawals.ToInt().Int64())
+	require.Nil(t, deltas[1].Issuance.Reward)
+	require.Equal(t, int64(7*21000), deltas[1].Burn.EIP1559.ToInt().Int64())
+	require.Equal(t, int64(50), deltas[1].Burn.Selfdestruct.ToInt().Int64())
+	// burn exceeds issuance
+	require.Equal(t, int64(100-7*21000-50), (*big.Int)(deltas[1].Delta).Int64())
+	require.Equal(t, uint64(2), uint64(deltas[2].Number))
+}
+
+func TestSupplyDeltaJSON(t *testing.T) {
+	for _, delta := range []int64{0, 1000, -147050} {
+		enc, err := json.Marshal(&SupplyDelta{Delta: (*math.Decimal256)(big.NewInt(delta)), Number: 1})
+		require.NoError(t, err)
+		require.Contains(t, string(enc), fmt.Sprintf(`"delta":"%d"`, delta))
+
+		var dec SupplyDelta
+		require.NoError(t, json.Unmarshal(enc, &dec))
+		require.Equal(t, delta, (*big.Int)(dec.Delta).Int64())
+	}
+}
+
+func TestSupplyTracerDatadir(t *testing.T) {
+	_, err := newSupplyTracer(&tracers.Context{}, nil)
+	require.Error(t, err)
+
+	dirs := datadir.New(t.TempDir())
+	tracer, err := newSupplyTracer(&tracers.Context{Dirs: dirs}, nil)
+	require.NoError(t, err)
+	for num := int64(1); num <= 3; num++ {
+		tracer.OnBlockStart(tracing.BlockEvent{Block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(num)})})
+		tracer.OnBalanceChange(common.Address{2}, *uint256.NewInt(0), *uint256.NewInt(100), tracing.BalanceIncreaseWithdrawal)
+		tracer.OnBlockEnd(nil)
+	}
+	// pending deltas are written on stop
+	tracer.Stop(nil)
+
+	ctx := context.Background()
+	db, err := OpenSupplyDB(ctx, dirs.Supply, true, log.New())
+	require.NoError(t, err)
+	defer db.Close()
+	var deltas []*SupplyDelta
+	require.NoError(t, db.View(ctx, func(tx kv.Tx) (err error) {
+		deltas, err = ReadSupplyDeltas(tx, 0, 10)
+		return err
+	}))
+	require.Len(t, deltas, 3)
+}
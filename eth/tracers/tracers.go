@@ -25,6 +25,7 @@ import (
 	"errors"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon/core/tracing"
 )
 
@@ -34,6 +35,8 @@ type Context struct {
 	BlockHash common.Hash // Hash of the block the txn is contained within (zero if dangling txn or call)
 	TxIndex   int         // Index of the transaction within a block (zero if dangling txn or call)
 	TxHash    common.Hash // Hash of the transaction being traced (zero if dangling call)
+
+	Dirs datadir.Dirs // Data directories of the node, set only for live tracers (--vmtrace)
 }
 
 // Tracer interface extends vm.EVMLogger and additionally
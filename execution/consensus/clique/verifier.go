@@ -101,6 +101,10 @@ func (c *Clique) verifyHeader(chain consensus.ChainHeaderReader, header *types.H
 		return consensus.ErrUnexpectedRequests
 	}
 
+	if header.BlockAccessListHash != nil {
+		return consensus.ErrUnexpectedBlockAccessList
+	}
+
 	// All basic checks passed, verify cascading fields
 	return c.verifyCascadingFields(chain, header, parents)
 }
@@ -52,4 +52,7 @@ var (
 
 	// ErrUnexpectedRequests is returned if a pre-Prague block has EIP-7685 requests.
 	ErrUnexpectedRequests = errors.New("unexpected requests")
+
+	// ErrUnexpectedBlockAccessList is returned if a pre-Amsterdam block commits to an EIP-7928 block access list.
+	ErrUnexpectedBlockAccessList = errors.New("unexpected block access list")
 )
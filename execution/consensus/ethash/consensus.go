@@ -261,6 +261,10 @@ func VerifyHeaderBasics(chain consensus.ChainHeaderReader, header, parent *types
 		return consensus.ErrUnexpectedRequests
 	}
 
+	if header.BlockAccessListHash != nil {
+		return consensus.ErrUnexpectedBlockAccessList
+	}
+
 	// If all checks passed, validate any special fields for hard forks
 	if err := misc.VerifyDAOHeaderExtraData(chain.Config(), header); err != nil {
 		return err
@@ -334,6 +334,15 @@ func (s *Merge) verifyHeader(chain consensus.ChainHeaderReader, header, parent *
 		return consensus.ErrUnexpectedRequests
 	}
 
+	// Verify existence / non-existence of blockAccessListHash
+	amsterdam := chain.Config().IsAmsterdam(header.Time)
+	if amsterdam && header.BlockAccessListHash == nil {
+		return errors.New("missing blockAccessListHash")
+	}
+	if !amsterdam && header.BlockAccessListHash != nil {
+		return consensus.ErrUnexpectedBlockAccessList
+	}
+
 	return nil
 }
 
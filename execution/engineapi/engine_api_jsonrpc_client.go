@@ -19,6 +19,7 @@ package engineapi
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -45,10 +46,15 @@ func WithJsonRpcClientRetryBackOff(retryBackOff time.Duration) JsonRpcClientOpti
 	}
 }
 
+var _ EngineAPI = (*JsonRpcClient)(nil)
+
 type JsonRpcClient struct {
 	rpcClient    *rpc.Client
 	maxRetries   uint64
 	retryBackOff time.Duration
+
+	capabilitiesMu sync.RWMutex
+	capabilities   map[string]struct{} // engine methods supported by the server
 }
 
 func DialJsonRpcClient(url string, jwtSecret []byte, logger log.Logger, opts ...JsonRpcClientOption) (*JsonRpcClient, error) {
@@ -231,6 +237,17 @@ func (c *JsonRpcClient) GetPayloadV4(ctx context.Context, payloadID hexutil.Byte
 	}, c.backOff(ctx))
 }
 
+func (c *JsonRpcClient) GetPayloadV5(ctx context.Context, payloadID hexutil.Bytes) (*enginetypes.GetPayloadResponse, error) {
+	return backoff.RetryWithData(func() (*enginetypes.GetPayloadResponse, error) {
+		var result enginetypes.GetPayloadResponse
+		err := c.rpcClient.CallContext(ctx, &result, "engine_getPayloadV5", payloadID)
+		if err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}, c.backOff(ctx))
+}
+
 func (c *JsonRpcClient) GetPayloadBodiesByHashV1(ctx context.Context, hashes []common.Hash) ([]*enginetypes.ExecutionPayloadBody, error) {
 	return backoff.RetryWithData(func() ([]*enginetypes.ExecutionPayloadBody, error) {
 		var result []*enginetypes.ExecutionPayloadBody
@@ -264,6 +281,62 @@ func (c *JsonRpcClient) GetClientVersionV1(ctx context.Context, callerVersion *e
 	}, c.backOff(ctx))
 }
 
+func (c *JsonRpcClient) GetBlobsV1(ctx context.Context, blobHashes []common.Hash) ([]*enginetypes.BlobAndProofV1, error) {
+	return backoff.RetryWithData(func() ([]*enginetypes.BlobAndProofV1, error) {
+		var result []*enginetypes.BlobAndProofV1
+		err := c.rpcClient.CallContext(ctx, &result, "engine_getBlobsV1", blobHashes)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, c.backOff(ctx))
+}
+
+func (c *JsonRpcClient) GetBlobsV2(ctx context.Context, blobHashes []common.Hash) ([]*enginetypes.BlobAndProofV2, error) {
+	return backoff.RetryWithData(func() ([]*enginetypes.BlobAndProofV2, error) {
+		var result []*enginetypes.BlobAndProofV2
+		err := c.rpcClient.CallContext(ctx, &result, "engine_getBlobsV2", blobHashes)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, c.backOff(ctx))
+}
+
+// ExchangeCapabilities sends the engine methods supported by the caller and returns the ones supported by
+// the server. They are remembered for HasCapability.
+func (c *JsonRpcClient) ExchangeCapabilities(ctx context.Context, capabilities []string) ([]string, error) {
+	result, err := backoff.RetryWithData(func() ([]string, error) {
+		var result []string
+		err := c.rpcClient.CallContext(ctx, &result, "engine_exchangeCapabilities", capabilities)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, c.backOff(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	serverCapabilities := make(map[string]struct{}, len(result))
+	for _, method := range result {
+		serverCapabilities[method] = struct{}{}
+	}
+	c.capabilitiesMu.Lock()
+	defer c.capabilitiesMu.Unlock()
+	c.capabilities = serverCapabilities
+	return result, nil
+}
+
+// HasCapability reports whether the server supports the engine method (e.g. "engine_getBlobsV2"),
+// as of the last ExchangeCapabilities. It is false before the capabilities are exchanged.
+func (c *JsonRpcClient) HasCapability(method string) bool {
+	c.capabilitiesMu.RLock()
+	defer c.capabilitiesMu.RUnlock()
+	_, ok := c.capabilities[method]
+	return ok
+}
+
 func (c *JsonRpcClient) backOff(ctx context.Context) backoff.BackOff {
 	var backOff backoff.BackOff
 	backOff = backoff.NewConstantBackOff(c.retryBackOff)
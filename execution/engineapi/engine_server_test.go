@@ -18,17 +18,25 @@ package engineapi
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"math/big"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/direct"
+	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
 	sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
@@ -39,9 +47,11 @@ import (
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcservices"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
 	"github.com/erigontech/erigon/execution/stages"
 	"github.com/erigontech/erigon/execution/stages/mock"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/jsonrpc"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 	"github.com/erigontech/erigon/rpc/rpchelper"
@@ -177,3 +187,213 @@ func TestGetBlobsV2(t *testing.T) {
 		require.Equal(blobsResp[1].CellProofs[i], hexutil.Bytes(wrappedTxn.Proofs[i+128][:]))
 	}
 }
+
+// testExecutionClient answers the readiness checks, the forkchoice updates and the block assembly right away, and delegates the
+// other calls to the execution module of the mock, for the round trips not to depend on the block building.
+type testExecutionClient struct {
+	execution.ExecutionClient
+}
+
+func (c testExecutionClient) Ready(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*execution.ReadyResponse, error) {
+	return &execution.ReadyResponse{Ready: true}, nil
+}
+
+func (c testExecutionClient) UpdateForkChoice(ctx context.Context, in *execution.ForkChoice, opts ...grpc.CallOption) (*execution.ForkChoiceReceipt, error) {
+	return &execution.ForkChoiceReceipt{Status: execution.ExecutionStatus_Success, LatestValidHash: in.HeadBlockHash}, nil
+}
+
+func (c testExecutionClient) AssembleBlock(ctx context.Context, in *execution.AssembleBlockRequest, opts ...grpc.CallOption) (*execution.AssembleBlockResponse, error) {
+	return &execution.AssembleBlockResponse{Id: 1}, nil
+}
+
+// GetAssembledBlock has no payload for any id
+func (c testExecutionClient) GetAssembledBlock(ctx context.Context, in *execution.GetAssembledBlockRequest, opts ...grpc.CallOption) (*execution.GetAssembledBlockResponse, error) {
+	return &execution.GetAssembledBlockResponse{}, nil
+}
+
+func newJsonRpcClientForTest(t *testing.T, engineServer *EngineServer, logger log.Logger) *JsonRpcClient {
+	srv := rpc.NewServer(1, false, false, true, logger, 0)
+	require.NoError(t, srv.RegisterName("engine", EngineAPI(engineServer)))
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+
+	// the test server doesn't check the token, no retries to get the errors as they are
+	client, err := DialJsonRpcClient(httpSrv.URL, make([]byte, 32), logger, WithJsonRpcClientMaxRetries(0))
+	require.NoError(t, err)
+	return client
+}
+
+// requireRoundTrip checks that the engine method replies the same over the JSON-RPC client as when called directly.
+func requireRoundTrip[T any](t *testing.T, method string, direct func() (T, error), viaClient func() (T, error)) {
+	t.Helper()
+	want, wantErr := direct()
+	got, gotErr := viaClient()
+	if wantErr != nil {
+		require.Error(t, gotErr, method)
+		require.Equal(t, wantErr.Error(), gotErr.Error(), method)
+		return
+	}
+	require.NoError(t, gotErr, method)
+	wantJson, err := json.Marshal(want)
+	require.NoError(t, err, method)
+	gotJson, err := json.Marshal(got)
+	require.NoError(t, err, method)
+	require.JSONEq(t, string(wantJson), string(gotJson), method)
+}
+
+func TestJsonRpcClientRoundTrip(t *testing.T) {
+	logger := log.New()
+	buf := bytes.NewBuffer(nil)
+	mockSentry, require := mock.MockWithTxPoolOsaka(t), require.New(t)
+	oneBlockStep(mockSentry, require, t)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := direct.NewTxPoolClient(mockSentry.TxPoolGrpcServer)
+
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, mockSentry.Log)
+	api := jsonrpc.NewEthAPI(newBaseApiForTest(mockSentry), mockSentry.DB, nil, txPool, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, logger)
+
+	executionRpc := testExecutionClient{direct.NewExecutionClientDirect(mockSentry.Eth1ExecutionService)}
+	eth := rpcservices.NewRemoteBackend(nil, mockSentry.DB, mockSentry.BlockReader)
+	engineServer := NewEngineServer(mockSentry.Log, mockSentry.ChainConfig, executionRpc, mockSentry.HeaderDownload(), nil, false, true, false, true)
+	engineServer.Start(ctx, &httpcfg.HttpCfg{}, mockSentry.DB, mockSentry.BlockReader, ff, nil, mockSentry.Engine, eth, txPool, nil)
+	client := newJsonRpcClientForTest(t, engineServer, logger)
+
+	capabilities, err := client.ExchangeCapabilities(ctx, ourCapabilities)
+	require.NoError(err)
+	require.Equal(ourCapabilities, capabilities)
+	for _, method := range ourCapabilities {
+		require.True(client.HasCapability(method), method)
+	}
+	require.False(client.HasCapability("engine_unknownV1"))
+
+	// a blob transaction in the pool for engine_getBlobs
+	wrappedTxn := types.MakeV1WrappedBlobTxn(uint256.MustFromBig(mockSentry.ChainConfig.ChainID))
+	txn, err := types.SignTx(wrappedTxn, *types.LatestSignerForChainID(mockSentry.ChainConfig.ChainID), mockSentry.Key)
+	require.NoError(err)
+	dt := &wrappedTxn.Tx.DynamicFeeTransaction
+	v, r, s := txn.RawSignatureValues()
+	dt.V.Set(v)
+	dt.R.Set(r)
+	dt.S.Set(s)
+	require.NoError(wrappedTxn.MarshalBinaryWrapped(buf))
+	_, err = api.SendRawTransaction(ctx, buf.Bytes())
+	require.NoError(err)
+
+	var head *types.Header
+	require.NoError(mockSentry.DB.View(ctx, func(tx kv.Tx) error {
+		head = rawdb.ReadCurrentHeader(tx)
+		return nil
+	}))
+	require.NotNil(head)
+
+	forkChoiceState := &engine_types.ForkChoiceState{HeadHash: head.Hash(), SafeBlockHash: head.Hash(), FinalizedBlockHash: head.Hash()}
+	beaconRoot := common.Hash{1}
+	attributes := &engine_types.PayloadAttributes{
+		Timestamp:             hexutil.Uint64(head.Time + 1),
+		SuggestedFeeRecipient: common.Address{1},
+		Withdrawals:           []*types.Withdrawal{},
+		ParentBeaconBlockRoot: &beaconRoot,
+	}
+	// the payload has invalid bloom, so the server rejects it after decoding all the params
+	payload := &engine_types.ExecutionPayload{ParentHash: head.Hash(), BlockNumber: hexutil.Uint64(head.Number.Uint64() + 1)}
+	blobHashes := []common.Hash{}
+	requests := []hexutil.Bytes{}
+	payloadId := hexutil.Bytes{0, 0, 0, 0, 0, 0, 0, 1}
+
+	type fcu func(context.Context, *engine_types.ForkChoiceState, *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error)
+	fcus := map[string][2]fcu{
+		"engine_forkchoiceUpdatedV1": {engineServer.ForkchoiceUpdatedV1, client.ForkchoiceUpdatedV1},
+		"engine_forkchoiceUpdatedV2": {engineServer.ForkchoiceUpdatedV2, client.ForkchoiceUpdatedV2},
+		"engine_forkchoiceUpdatedV3": {engineServer.ForkchoiceUpdatedV3, client.ForkchoiceUpdatedV3},
+	}
+	for method, f := range fcus {
+		requireRoundTrip(t, method, func() (*engine_types.ForkChoiceUpdatedResponse, error) {
+			return f[0](ctx, forkChoiceState, nil)
+		}, func() (*engine_types.ForkChoiceUpdatedResponse, error) {
+			return f[1](ctx, forkChoiceState, nil)
+		})
+		requireRoundTrip(t, method, func() (*engine_types.ForkChoiceUpdatedResponse, error) {
+			return f[0](ctx, forkChoiceState, attributes)
+		}, func() (*engine_types.ForkChoiceUpdatedResponse, error) {
+			return f[1](ctx, forkChoiceState, attributes)
+		})
+	}
+
+	requireRoundTrip(t, "engine_newPayloadV1", func() (*engine_types.PayloadStatus, error) {
+		return engineServer.NewPayloadV1(ctx, payload)
+	}, func() (*engine_types.PayloadStatus, error) {
+		return client.NewPayloadV1(ctx, payload)
+	})
+	requireRoundTrip(t, "engine_newPayloadV2", func() (*engine_types.PayloadStatus, error) {
+		return engineServer.NewPayloadV2(ctx, payload)
+	}, func() (*engine_types.PayloadStatus, error) {
+		return client.NewPayloadV2(ctx, payload)
+	})
+	requireRoundTrip(t, "engine_newPayloadV3", func() (*engine_types.PayloadStatus, error) {
+		return engineServer.NewPayloadV3(ctx, payload, blobHashes, &beaconRoot)
+	}, func() (*engine_types.PayloadStatus, error) {
+		return client.NewPayloadV3(ctx, payload, blobHashes, &beaconRoot)
+	})
+	requireRoundTrip(t, "engine_newPayloadV4", func() (*engine_types.PayloadStatus, error) {
+		return engineServer.NewPayloadV4(ctx, payload, blobHashes, &beaconRoot, requests)
+	}, func() (*engine_types.PayloadStatus, error) {
+		return client.NewPayloadV4(ctx, payload, blobHashes, &beaconRoot, requests)
+	})
+
+	requireRoundTrip(t, "engine_getPayloadV1", func() (*engine_types.ExecutionPayload, error) {
+		return engineServer.GetPayloadV1(ctx, payloadId)
+	}, func() (*engine_types.ExecutionPayload, error) {
+		return client.GetPayloadV1(ctx, payloadId)
+	})
+	type getPayload func(context.Context, hexutil.Bytes) (*engine_types.GetPayloadResponse, error)
+	getPayloads := map[string][2]getPayload{
+		"engine_getPayloadV2": {engineServer.GetPayloadV2, client.GetPayloadV2},
+		"engine_getPayloadV3": {engineServer.GetPayloadV3, client.GetPayloadV3},
+		"engine_getPayloadV4": {engineServer.GetPayloadV4, client.GetPayloadV4},
+		"engine_getPayloadV5": {engineServer.GetPayloadV5, client.GetPayloadV5},
+	}
+	for method, f := range getPayloads {
+		requireRoundTrip(t, method, func() (*engine_types.GetPayloadResponse, error) {
+			return f[0](ctx, payloadId)
+		}, func() (*engine_types.GetPayloadResponse, error) {
+			return f[1](ctx, payloadId)
+		})
+	}
+
+	requireRoundTrip(t, "engine_getPayloadBodiesByHashV1", func() ([]*engine_types.ExecutionPayloadBody, error) {
+		return engineServer.GetPayloadBodiesByHashV1(ctx, []common.Hash{head.Hash(), {}})
+	}, func() ([]*engine_types.ExecutionPayloadBody, error) {
+		return client.GetPayloadBodiesByHashV1(ctx, []common.Hash{head.Hash(), {}})
+	})
+	requireRoundTrip(t, "engine_getPayloadBodiesByRangeV1", func() ([]*engine_types.ExecutionPayloadBody, error) {
+		return engineServer.GetPayloadBodiesByRangeV1(ctx, 0, 2)
+	}, func() ([]*engine_types.ExecutionPayloadBody, error) {
+		return client.GetPayloadBodiesByRangeV1(ctx, 0, 2)
+	})
+	callerVersion := &engine_types.ClientVersionV1{Code: "CL", Name: "test", Version: "1.0.0", Commit: "00000000"}
+	requireRoundTrip(t, "engine_getClientVersionV1", func() ([]engine_types.ClientVersionV1, error) {
+		return engineServer.GetClientVersionV1(ctx, callerVersion)
+	}, func() ([]engine_types.ClientVersionV1, error) {
+		return client.GetClientVersionV1(ctx, callerVersion)
+	})
+
+	blobHashes = append([]common.Hash{{}}, wrappedTxn.Tx.BlobVersionedHashes...)
+	requireRoundTrip(t, "engine_getBlobsV1", func() ([]*engine_types.BlobAndProofV1, error) {
+		return engineServer.GetBlobsV1(ctx, blobHashes)
+	}, func() ([]*engine_types.BlobAndProofV1, error) {
+		return client.GetBlobsV1(ctx, blobHashes)
+	})
+	requireRoundTrip(t, "engine_getBlobsV2", func() ([]*engine_types.BlobAndProofV2, error) {
+		return engineServer.GetBlobsV2(ctx, blobHashes[1:])
+	}, func() ([]*engine_types.BlobAndProofV2, error) {
+		return client.GetBlobsV2(ctx, blobHashes[1:])
+	})
+	blobs, err := client.GetBlobsV2(ctx, blobHashes[1:])
+	require.NoError(err)
+	require.Len(blobs, len(blobHashes)-1)
+	require.Equal(hexutil.Bytes(wrappedTxn.Blobs[0][:]), blobs[0].Blob)
+}
@@ -220,6 +220,14 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 	var err error
 	rules, header := txTask.Rules, txTask.Header
 
+	if rules.IsAmsterdam && txTask.BlockNum > 0 {
+		// block access index: 0 for the block initialisation, TxIndex+1 for the txs and the finalisation
+		txTask.BlockAccessList = state.NewBlockAccessListBuilder()
+		ibs.SetBlockAccessList(txTask.BlockAccessList, uint16(txTask.TxIndex+1))
+	} else {
+		ibs.SetBlockAccessList(nil, 0)
+	}
+
 	switch {
 	case txTask.TxIndex == -1:
 		if txTask.BlockNum == 0 {
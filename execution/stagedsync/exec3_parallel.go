@@ -96,6 +96,16 @@ type txExecutor struct {
 	outputTxNum    *atomic.Uint64
 	outputBlockNum metrics.Gauge
 	logger         log.Logger
+
+	bal        *state.BlockAccessListBuilder // EIP-7928 access list of the block being applied
+	balLock    sync.Mutex
+	balPending []pendingBlockAccessList // built access lists not yet written to the db
+}
+
+type pendingBlockAccessList struct {
+	hash   common.Hash
+	number uint64
+	bal    types.BlockAccessList
 }
 
 func (te *txExecutor) tx() kv.RwTx {
@@ -128,6 +138,56 @@ func (te *txExecutor) getHeader(ctx context.Context, hash common.Hash, number ui
 	return h, err
 }
 
+// collectBlockAccessList merges the EIP-7928 accesses recorded by txTask into the access list
+// of its block. At the end of the block the list is checked against the header and queued for
+// writeBlockAccessLists, or handed to the mining stage when the block is being produced.
+// Blocks whose execution resumed part way through are not checked.
+func (te *txExecutor) collectBlockAccessList(txTask *state.TxTask) error {
+	if txTask.BlockAccessList == nil {
+		return nil
+	}
+	if txTask.TxIndex == -1 {
+		te.bal = state.NewBlockAccessListBuilder()
+	}
+	if te.bal == nil {
+		return nil
+	}
+	te.bal.Merge(txTask.BlockAccessList)
+	if !txTask.Final {
+		return nil
+	}
+	bal := te.bal.Build()
+	te.bal = nil
+	if te.isMining {
+		// the header is not sealed yet, so there is no block hash to keep the list under
+		if te.cfg.minedAccessList != nil {
+			*te.cfg.minedAccessList = bal
+		}
+		return nil
+	}
+	if expected := txTask.Header.BlockAccessListHash; expected == nil || *expected != bal.Hash() {
+		return fmt.Errorf("%w: invalid block access list hash, have %x, header %v", consensus.ErrInvalidBlock, bal.Hash(), expected)
+	}
+	te.balLock.Lock()
+	defer te.balLock.Unlock()
+	te.balPending = append(te.balPending, pendingBlockAccessList{hash: txTask.BlockHash, number: txTask.BlockNum, bal: bal})
+	return nil
+}
+
+// writeBlockAccessLists writes the access lists queued by collectBlockAccessList, for
+// eth_getBlockAccessList.
+func (te *txExecutor) writeBlockAccessLists(tx kv.RwTx) error {
+	te.balLock.Lock()
+	defer te.balLock.Unlock()
+	for _, p := range te.balPending {
+		if err := rawdb.WriteBlockAccessList(tx, p.hash, p.number, p.bal); err != nil {
+			return err
+		}
+	}
+	te.balPending = te.balPending[:0]
+	return nil
+}
+
 type parallelExecutor struct {
 	txExecutor
 	rwLoopErrCh              chan error
@@ -253,6 +313,9 @@ func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger
 					if err = pe.doms.Flush(ctx, tx); err != nil {
 						return err
 					}
+					if err = pe.writeBlockAccessLists(tx); err != nil {
+						return err
+					}
 				}
 				break
 			}
@@ -319,6 +382,9 @@ func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger
 				if err := pe.doms.Flush(ctx, tx); err != nil {
 					return err
 				}
+				if err := pe.writeBlockAccessLists(tx); err != nil {
+					return err
+				}
 				pe.doms.ClearRam(true)
 				t3 = time.Since(tt)
 
@@ -360,6 +426,9 @@ func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger
 	if err := pe.doms.Flush(ctx, tx); err != nil {
 		return err
 	}
+	if err := pe.writeBlockAccessLists(tx); err != nil {
+		return err
+	}
 	if err := pe.execStage.Update(tx, pe.outputBlockNum.GetValueUint64()); err != nil {
 		return err
 	}
@@ -409,6 +478,11 @@ func (pe *parallelExecutor) processResultQueue(ctx context.Context, inputTxNum u
 			i++
 		}
 
+		// the apply loop has no RwTx to persist the list with, it is only checked here
+		if err := pe.collectBlockAccessList(txTask); err != nil {
+			return outputTxNum, conflicts, triggers, processedBlockNum, false, err
+		}
+
 		if txTask.Final {
 			pe.rs.SetTxNum(txTask.TxNum, txTask.BlockNum)
 			err := pe.rs.ApplyState(ctx, txTask)
@@ -60,6 +60,13 @@ func (se *serialExecutor) execute(ctx context.Context, tasks []*state.TxTask, gp
 				se.blobGasUsed += txTask.Tx.GetBlobGas()
 			}
 
+			if err := se.collectBlockAccessList(txTask); err != nil {
+				return err
+			}
+			if err := se.writeBlockAccessLists(se.applyTx); err != nil {
+				return err
+			}
+
 			if txTask.Final {
 				if !se.isMining && !se.skipPostEvaluation && !se.execStage.CurrentSyncCycle.IsInitialCycle {
 					// note this assumes the bloach reciepts is a fixed array shared by
@@ -86,6 +86,8 @@ type ExecuteBlockCfg struct {
 
 	silkworm        *silkworm.Silkworm
 	blockProduction bool
+	// minedAccessList receives the EIP-7928 access list of the block being produced
+	minedAccessList *types.BlockAccessList
 
 	applyWorker, applyWorkerMining *exec3.Worker
 }
@@ -383,6 +385,11 @@ func UnwindExecutionStage(u *UnwindState, s *StageState, txc wrap.TxContainer, c
 			return err
 		}
 	}
+	// Live tracers drop their output only once the unwind is persisted. With an external tx it's committed
+	// by the caller; if that fails, the blocks are executed again and the tracer rewinds on their OnBlockStart.
+	if cfg.vmConfig != nil && cfg.vmConfig.Tracer != nil && cfg.vmConfig.Tracer.OnUnwind != nil {
+		cfg.vmConfig.Tracer.OnUnwind(u.UnwindPoint)
+	}
 	return nil
 }
 
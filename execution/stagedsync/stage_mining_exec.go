@@ -231,6 +231,8 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 
 	// This flag will skip checking the state root
 	execCfg.blockProduction = true
+	var bal types.BlockAccessList
+	execCfg.minedAccessList = &bal
 	execS := &StageState{state: s.state, ID: stages.Execution, BlockNumber: blockHeight - 1}
 	if err = ExecBlockV3(execS, u, txc, blockHeight, context.Background(), execCfg, false, logger, true); err != nil {
 		logger.Error("cannot execute block execution", "err", err)
@@ -243,6 +245,11 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 	}
 	current.Header.Root = common.BytesToHash(rh)
 
+	if bal != nil {
+		balHash := bal.Hash()
+		current.Header.BlockAccessListHash = &balHash
+	}
+
 	logger.Info("FinalizeBlockExecution", "block", current.Header.Number, "txn", current.Txns.Len(), "gas", current.Header.GasUsed, "receipt", current.Receipts.Len(), "payload", cfg.payloadId)
 
 	return nil
@@ -275,6 +282,7 @@ func getNextTransactions(
 		txnprovider.WithBlobGasTarget(remainingBlobGas),
 		txnprovider.WithTxnIdsFilter(alreadyYielded),
 		txnprovider.WithAvailableRlpSpace(availableRlpSpace),
+		txnprovider.WithCoinbase(header.Coinbase),
 	}
 
 	txns, err := cfg.txnProvider.ProvideTxns(ctx, provideOpts...)
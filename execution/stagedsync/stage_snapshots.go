@@ -40,6 +40,7 @@ import (
 
 	"github.com/erigontech/erigon-db/downloader"
 	"github.com/erigontech/erigon-db/downloader/downloadercfg"
+	"github.com/erigontech/erigon-db/downloader/objectstore"
 	coresnaptype "github.com/erigontech/erigon-db/snaptype"
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
@@ -604,7 +605,7 @@ type snapshotUploader struct {
 	files           map[string]*uploadState
 	uploadFs        string
 	rclone          *downloader.RCloneClient
-	uploadSession   *downloader.RCloneSession
+	uploadSession   downloader.RemoteSession
 	uploadScheduled atomic.Bool
 	uploading       atomic.Bool
 	manifestMutex   sync.Mutex
@@ -998,14 +999,16 @@ func isLocalFs(ctx context.Context, rclient *downloader.RCloneClient, fs string)
 	return true
 }
 
-func (u *snapshotUploader) start(ctx context.Context, logger log.Logger) {
+// startRClone opens the upload session through an rclone process, for locations which are not
+// served by the native object store client.
+func (u *snapshotUploader) startRClone(ctx context.Context, logger log.Logger) bool {
 	var err error
 
 	u.rclone, err = downloader.NewRCloneClient(logger)
 
 	if err != nil {
 		logger.Warn("[uploader] Uploading disabled: rclone start failed", "err", err)
-		return
+		return false
 	}
 
 	uploadFs := u.uploadFs
@@ -1017,19 +1020,40 @@ func (u *snapshotUploader) start(ctx context.Context, logger log.Logger) {
 
 		if err != nil {
 			logger.Warn("[uploader] Uploading disabled: invalid upload fs", "err", err, "fs", u.uploadFs)
-			return
+			return false
 		}
 
 		if err := os.MkdirAll(uploadFs, 0755); err != nil {
 			logger.Warn("[uploader] Uploading disabled: can't create upload fs", "err", err, "fs", u.uploadFs)
-			return
+			return false
 		}
 	}
 
-	u.uploadSession, err = u.rclone.NewSession(ctx, u.cfg.dirs.Snap, uploadFs, nil)
+	session, err := u.rclone.NewSession(ctx, u.cfg.dirs.Snap, uploadFs, nil)
 
 	if err != nil {
 		logger.Warn("[uploader] Uploading disabled: rclone session failed", "err", err)
+		return false
+	}
+
+	u.uploadSession = session
+	return true
+}
+
+func (u *snapshotUploader) start(ctx context.Context, logger log.Logger) {
+	var err error
+
+	if objectstore.IsURL(u.uploadFs) {
+		snapCfg, _ := snapcfg.KnownCfg(u.cfg.chainConfig.ChainName)
+		session, err := downloader.NewS3Session(u.cfg.dirs.Snap, u.uploadFs, snapCfg.Preverified.Items, logger)
+
+		if err != nil {
+			logger.Warn("[uploader] Uploading disabled: invalid upload location", "err", err, "fs", u.uploadFs)
+			return
+		}
+
+		u.uploadSession = session
+	} else if !u.startRClone(ctx, logger) {
 		return
 	}
 
@@ -1294,7 +1318,7 @@ func (u *snapshotUploader) upload(ctx context.Context, logger log.Logger) {
 			err := func() error {
 				state.Lock()
 				defer state.Unlock()
-				if !state.remote && state.torrent != nil && len(state.uploads) == 0 && u.rclone != nil {
+				if !state.remote && state.torrent != nil && len(state.uploads) == 0 && u.uploadSession != nil {
 					state.uploads = []string{state.file, state.file + ".torrent"}
 					uploadList = append(uploadList, state)
 				}
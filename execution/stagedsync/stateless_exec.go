@@ -0,0 +1,154 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+var (
+	// ErrStatelessRootMismatch is returned when the state root computed by a stateless execution
+	// doesn't match the one of the block.
+	ErrStatelessRootMismatch = errors.New("stateless execution state root mismatch")
+	// ErrStatelessReceiptsMismatch is returned when the receipts of a stateless execution don't
+	// match the receipts root of the block.
+	ErrStatelessReceiptsMismatch = errors.New("stateless execution receipts mismatch")
+)
+
+// ExecuteStateless executes a block on top of an execution witness, without any database: the
+// pre-state comes from the witness trie nodes and codes, BLOCKHASH from its headers, the last of
+// which must be the parent of the block. It returns the state root after the block and the receipts,
+// with an error wrapping ErrStatelessRootMismatch or ErrStatelessReceiptsMismatch when they don't
+// match the block.
+func ExecuteStateless(chainConfig *chain.Config, engine consensus.Engine, block *types.Block, headers []*types.Header, nodes, codes [][]byte, logger log.Logger) (common.Hash, types.Receipts, error) {
+	chainReader, err := newWitnessChainReader(chainConfig, headers)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	parent := headers[len(headers)-1]
+	if parent.Hash() != block.ParentHash() {
+		return common.Hash{}, nil, fmt.Errorf("last witness header %d (%x) is not the parent of block %d", parent.Number.Uint64(), parent.Hash(), block.NumberU64())
+	}
+
+	t, err := trie.BuildTrieFromNodes(parent.Root, nodes, codes)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	stateless := state.NewStatelessFromTrie(t, parent.Number.Uint64(), false /* trace */)
+	ibs := state.New(stateless)
+	header := block.Header()
+	if err := core.InitializeBlockExecution(engine, chainReader, header, chainConfig, ibs, stateless, logger, nil); err != nil {
+		return common.Hash{}, nil, err
+	}
+
+	gp := new(core.GasPool).AddGas(block.GasLimit()).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock(block.Time()))
+	var gasUsed, usedBlobGas uint64
+	receipts := make(types.Receipts, 0, block.Transactions().Len())
+	for i, txn := range block.Transactions() {
+		ibs.SetTxContext(block.NumberU64(), i)
+		receipt, _, err := core.ApplyTransaction(chainConfig, chainReader.getHash, engine, nil, gp, ibs, stateless, header, txn, &gasUsed, &usedBlobGas, vm.Config{})
+		if err != nil {
+			return common.Hash{}, nil, fmt.Errorf("could not apply txn %d from block %d [%v]: %w", i, block.NumberU64(), txn.Hash().Hex(), err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	if gasUsed != header.GasUsed {
+		return common.Hash{}, nil, fmt.Errorf("gas used by execution: %d, in header: %d", gasUsed, header.GasUsed)
+	}
+
+	if _, _, err := core.FinalizeBlockExecution(engine, stateless, header, block.Transactions(), block.Uncles(), stateless, chainConfig, ibs, receipts, block.Withdrawals(), chainReader, false /* isMining */, logger, nil); err != nil {
+		return common.Hash{}, nil, err
+	}
+	root := stateless.Finalize()
+	if root != block.Root() {
+		return root, receipts, fmt.Errorf("%w at block %d: %x, expected %x", ErrStatelessRootMismatch, block.NumberU64(), root, block.Root())
+	}
+	if receiptsRoot := types.DeriveSha(receipts); receiptsRoot != block.ReceiptHash() {
+		return root, receipts, fmt.Errorf("%w at block %d: receipts root %x, expected %x", ErrStatelessReceiptsMismatch, block.NumberU64(), receiptsRoot, block.ReceiptHash())
+	}
+	return root, receipts, nil
+}
+
+// witnessChainReader serves the consensus engine and BLOCKHASH out of the headers of a witness.
+type witnessChainReader struct {
+	cfg     *chain.Config
+	headers []*types.Header
+	byHash  map[common.Hash]*types.Header
+}
+
+// newWitnessChainReader checks that the headers form a chain, ordered by number.
+func newWitnessChainReader(cfg *chain.Config, headers []*types.Header) (*witnessChainReader, error) {
+	if len(headers) == 0 {
+		return nil, errors.New("witness has no headers")
+	}
+	cr := &witnessChainReader{cfg: cfg, headers: headers, byHash: make(map[common.Hash]*types.Header, len(headers))}
+	for i, h := range headers {
+		if i > 0 && (h.ParentHash != headers[i-1].Hash() || h.Number.Uint64() != headers[i-1].Number.Uint64()+1) {
+			return nil, fmt.Errorf("witness header %d is not the child of header %d", h.Number.Uint64(), headers[i-1].Number.Uint64())
+		}
+		cr.byHash[h.Hash()] = h
+	}
+	return cr, nil
+}
+
+func (cr *witnessChainReader) getHash(n uint64) (common.Hash, error) {
+	if h := cr.GetHeaderByNumber(n); h != nil {
+		return h.Hash(), nil
+	}
+	return common.Hash{}, fmt.Errorf("header %d is not in the witness", n)
+}
+
+func (cr *witnessChainReader) Config() *chain.Config                 { return cr.cfg }
+func (cr *witnessChainReader) CurrentHeader() *types.Header          { return cr.headers[len(cr.headers)-1] }
+func (cr *witnessChainReader) CurrentFinalizedHeader() *types.Header { return nil }
+func (cr *witnessChainReader) CurrentSafeHeader() *types.Header      { return nil }
+func (cr *witnessChainReader) GetHeaderByNumber(number uint64) *types.Header {
+	first := cr.headers[0].Number.Uint64()
+	if number < first || number-first >= uint64(len(cr.headers)) {
+		return nil
+	}
+	return cr.headers[number-first]
+}
+func (cr *witnessChainReader) GetHeaderByHash(hash common.Hash) *types.Header { return cr.byHash[hash] }
+func (cr *witnessChainReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if h := cr.byHash[hash]; h != nil && h.Number.Uint64() == number {
+		return h
+	}
+	return nil
+}
+func (cr *witnessChainReader) GetTd(hash common.Hash, number uint64) *big.Int        { return nil }
+func (cr *witnessChainReader) FrozenBlocks() uint64                                  { return 0 }
+func (cr *witnessChainReader) FrozenBorBlocks(align bool) uint64                     { return 0 }
+func (cr *witnessChainReader) GetBlock(hash common.Hash, number uint64) *types.Block { return nil }
+func (cr *witnessChainReader) HasBlock(hash common.Hash, number uint64) bool         { return false }
+func (cr *witnessChainReader) BorEventsByBlock(hash common.Hash, number uint64) []rlp.RawValue {
+	return nil
+}
+func (cr *witnessChainReader) BorStartEventId(hash common.Hash, number uint64) uint64 { return 0 }
@@ -365,6 +365,36 @@ func TestTdStorage(t *testing.T) {
 	}
 }
 
+// Tests EIP-7928 block access list storage and retrieval operations.
+func TestBlockAccessListStorage(t *testing.T) {
+	t.Parallel()
+	m := mock.Mock(t)
+	tx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	hash := common.Hash{1}
+	bal, err := rawdb.ReadBlockAccessList(tx, hash, 1)
+	require.NoError(t, err)
+	require.Nil(t, bal)
+
+	want := types.BlockAccessList{{
+		Address:        common.Address{1},
+		StorageReads:   []common.Hash{{2}},
+		BalanceChanges: []types.BalanceChange{{Index: 1, Balance: *u256.Num1}},
+	}}
+	require.NoError(t, rawdb.WriteBlockAccessList(tx, hash, 1, want))
+	bal, err = rawdb.ReadBlockAccessList(tx, hash, 1)
+	require.NoError(t, err)
+	require.Equal(t, want.Hash(), bal.Hash())
+
+	// an executed block without any accesses is stored as an empty list, not as missing
+	require.NoError(t, rawdb.WriteBlockAccessList(tx, hash, 2, types.BlockAccessList{}))
+	bal, err = rawdb.ReadBlockAccessList(tx, hash, 2)
+	require.NoError(t, err)
+	require.NotNil(t, bal)
+}
+
 // Tests that canonical numbers can be mapped to hashes and retrieved.
 func TestCanonicalMappingStorage(t *testing.T) {
 	t.Parallel()
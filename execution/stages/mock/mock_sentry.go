@@ -149,6 +149,9 @@ func (ms *MockSentry) Close() {
 	if err := ms.bgComponentsEg.Wait(); err != nil {
 		require.Equal(ms.tb, context.Canceled, err) // upon waiting for clean exit we should get ctx cancelled
 	}
+	if ms.tb == nil {
+		_ = os.RemoveAll(ms.Dirs.DataDir)
+	}
 }
 
 // Stream returns stream, waiting if necessary
@@ -255,12 +258,30 @@ func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.Pr
 	return MockWithEverything(tb, gspec, key, prune, engine, blockBufferSize, false, withPosDownloader, checkStateRoot)
 }
 
+// MockWithGenesisEngineTracer is MockWithGenesisEngine with the tracer hooked into block execution.
+// tb may be nil, then the mock lives in its own temporary directory which is removed on Close.
+func MockWithGenesisEngineTracer(tb testing.TB, gspec *types.Genesis, engine consensus.Engine, withPosDownloader, checkStateRoot bool, tracer *tracers.Tracer) *MockSentry {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	return mockWithEverything(tb, gspec, key, prune.MockMode, engine, blockBufferSize, false, withPosDownloader, checkStateRoot, tracer)
+}
+
 func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
 	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool,
 ) *MockSentry {
-	tmpdir := os.TempDir()
+	return mockWithEverything(tb, gspec, key, prune, engine, blockBufferSize, withTxPool, withPosDownloader, checkStateRoot, nil)
+}
+
+func mockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
+	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool, tracer *tracers.Tracer,
+) *MockSentry {
+	var tmpdir string
 	if tb != nil {
 		tmpdir = tb.TempDir()
+	} else {
+		var err error
+		if tmpdir, err = os.MkdirTemp("", "mock-sentry-"); err != nil {
+			panic(err)
+		}
 	}
 	ctrl := gomock.NewController(tb)
 	dirs := datadir.New(tmpdir)
@@ -406,6 +427,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		mock.ChainConfig,
 		mock.Genesis,
 		mock.ChainConfig.ChainID.Uint64(),
+		prune,
 		logger,
 	)
 
@@ -508,6 +530,12 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		return block, nil
 	}
 
+	// a tracer given by the caller sees the blocks executed by the PoW stages as well
+	execVmConfig := &vm.Config{}
+	if tracer != nil {
+		execVmConfig.Tracer = tracer.Hooks
+	}
+
 	blockRetire := freezeblocks.NewBlockRetire(1, dirs, mock.BlockReader, blockWriter, mock.DB, nil, nil, mock.ChainConfig, &cfg, mock.Notifications.Events, nil, logger)
 	mock.Sync = stagedsync.New(
 		cfg.Sync,
@@ -522,7 +550,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 				cfg.BatchSize,
 				mock.ChainConfig,
 				mock.Engine,
-				&vm.Config{},
+				execVmConfig,
 				mock.Notifications,
 				cfg.StateStream,
 				/*stateStream=*/ false,
@@ -538,8 +566,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		logger, stages.ModeApplyingBlocks,
 	)
 
-	var tracer *tracers.Tracer
-	if dir, ok := os.LookupEnv("MOCK_SENTRY_DEBUG_TRACER_OUTPUT_DIR"); ok {
+	if dir, ok := os.LookupEnv("MOCK_SENTRY_DEBUG_TRACER_OUTPUT_DIR"); ok && tracer == nil {
 		tracer = debugtracer.New(dir, debugtracer.WithRecordOptions(debugtracer.RecordOptions{
 			DisableOnOpcodeStackRecording:  true,
 			DisableOnOpcodeMemoryRecording: true,
@@ -603,7 +630,11 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		TopBlock: mock.Genesis,
 	}
 	if err = mock.InsertChain(c); err != nil {
-		tb.Fatal(err)
+		if tb != nil {
+			tb.Fatal(err)
+		} else {
+			panic(err)
+		}
 	}
 
 	return mock
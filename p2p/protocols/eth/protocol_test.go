@@ -230,3 +230,72 @@ func TestEth66Messages(t *testing.T) {
 		}
 	}
 }
+
+// TestEth69Receipts tests that eth/69 receipts round-trip without blooms
+func TestEth69Receipts(t *testing.T) {
+	receipts := []*types.Receipt{
+		{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			Logs: []*types.Log{{
+				Address: common.BytesToAddress([]byte{0x11}),
+				Topics:  []common.Hash{common.HexToHash("dead"), common.HexToHash("beef")},
+				Data:    []byte{0x01, 0x00, 0xff},
+			}},
+		},
+		{
+			Type:              types.LegacyTxType,
+			Status:            types.ReceiptStatusFailed,
+			CumulativeGasUsed: 42000,
+			Logs:              []*types.Log{},
+		},
+	}
+	for _, r := range receipts {
+		r.Bloom = types.CreateBloom(types.Receipts{r})
+	}
+
+	packet := ReceiptsPacket69{RequestId: 1111, Receipts: []Receipts69{receipts, {}}}
+	enc, err := rlp.EncodeToBytes(&packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := rlp.EncodeToBytes(&ReceiptsPacket66{1111, ReceiptsPacket{receipts, {}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := len(legacy) - len(receipts)*(types.BloomByteLength+3); len(enc) > want {
+		t.Errorf("eth/69 receipts contain blooms: have %d bytes, want at most %d", len(enc), want)
+	}
+
+	var decoded ReceiptsPacket69
+	if err := rlp.DecodeBytes(enc, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.RequestId != packet.RequestId || len(decoded.Receipts) != 2 || len(decoded.Receipts[0]) != len(receipts) || len(decoded.Receipts[1]) != 0 {
+		t.Fatalf("decode mismatch: have %+v", decoded)
+	}
+	for i, r := range decoded.Receipts[0] {
+		if r.Type != receipts[i].Type || r.Status != receipts[i].Status || r.CumulativeGasUsed != receipts[i].CumulativeGasUsed || r.Bloom != receipts[i].Bloom {
+			t.Errorf("receipt %d mismatch: have %+v, want %+v", i, r, receipts[i])
+		}
+	}
+}
+
+// TestBlockRangeUpdateValidate tests the sanity checks of announced block ranges
+func TestBlockRangeUpdateValidate(t *testing.T) {
+	hash := common.HexToHash("0x01")
+	for i, tt := range []struct {
+		packet BlockRangeUpdatePacket
+		ok     bool
+	}{
+		{BlockRangeUpdatePacket{0, 0, hash}, true},
+		{BlockRangeUpdatePacket{10, 20, hash}, true},
+		{BlockRangeUpdatePacket{21, 20, hash}, false},
+		{BlockRangeUpdatePacket{0, 20, common.Hash{}}, false},
+	} {
+		if err := tt.packet.Validate(); (err == nil) != tt.ok {
+			t.Errorf("test %d: have err %v, want ok %v", i, err, tt.ok)
+		}
+	}
+}
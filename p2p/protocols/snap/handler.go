@@ -0,0 +1,185 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
+	// there to limit the number of disk lookups.
+	maxCodeLookups = 1024
+
+	// stateLookupSlack defines the ratio by how much a state response can exceed
+	// the requested limit in order to try and avoid breaking up contracts into
+	// multiple packages and proving them.
+	stateLookupSlack = 0.1
+
+	// maxTrieNodeLookups is the maximum number of state trie nodes to serve. This
+	// number is there to limit the number of disk lookups.
+	maxTrieNodeLookups = 1024
+
+	// maxTrieNodeTimeSpent is the maximum time we should spend on looking up trie nodes.
+	// If we spend too much time, then it's a fairly high chance of timing out
+	// at the remote side, which means all the work is in vain.
+	maxTrieNodeTimeSpent = 5 * time.Second
+
+	// maxServingDepth is the number of most recent blocks, which states are served.
+	// Older states are pruned quickly by the peers, so they are not worth serving.
+	maxServingDepth = 128
+
+	// codeHashesCacheSize is the number of code hashes, which addresses are remembered
+	// from the served accounts to find bytecodes by their hashes. The code domain is keyed
+	// by address, so bytecodes are served best-effort: only the ones of accounts served
+	// recently by this node can be found.
+	codeHashesCacheSize = 256 * 1024
+)
+
+// Handler serves the snap protocol requests from the flat state domains and the commitment trie.
+type Handler struct {
+	db          kv.TemporalRoDB
+	blockReader services.FullBlockReader
+	logger      log.Logger
+
+	// bytecodes are stored by address, so remember the addresses of served code hashes
+	codeHashes *lru.Cache[common.Hash, common.Address]
+
+	rootsLock sync.Mutex
+	roots     map[common.Hash]uint64 // state roots of the recent blocks
+	rootsHead common.Hash            // hash of the head block roots are collected for
+}
+
+func NewHandler(db kv.TemporalRoDB, blockReader services.FullBlockReader, logger log.Logger) (*Handler, error) {
+	codeHashes, err := lru.New[common.Hash, common.Address](codeHashesCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		db:          db,
+		blockReader: blockReader,
+		logger:      logger,
+		codeHashes:  codeHashes,
+	}, nil
+}
+
+// Run serves the snap protocol requests of the peer until the connection is closed or the peer misbehaves.
+// It is the Run function of the snap p2p.Protocol.
+func (h *Handler) Run(ctx context.Context, peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
+	for {
+		if err := common.Stopped(ctx.Done()); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscQuitting, ctx.Err(), "snap: context stopped")
+		}
+		msg, err := rw.ReadMsg()
+		if err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorMessageReceive, p2p.DiscNetworkError, err, "snap: ReadMsg error")
+		}
+		if msg.Size > maxMessageSize {
+			msg.Discard()
+			return p2p.NewPeerError(p2p.PeerErrorMessageSizeLimit, p2p.DiscSubprotocolError, nil, fmt.Sprintf("snap: message is too large %d, limit %d", msg.Size, maxMessageSize))
+		}
+		if err := h.handleMsg(ctx, rw, msg); err != nil {
+			msg.Discard()
+			h.logger.Debug("[snap] request failed", "peer", peer.ID(), "code", msg.Code, "err", err)
+			if errors.Is(err, errBadRequest) || errors.Is(err, errUnexpectedMsg) {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap: invalid message")
+			}
+			return p2p.NewPeerError(p2p.PeerErrorMessageSend, p2p.DiscNetworkError, err, "snap: failed to serve request")
+		}
+		msg.Discard()
+	}
+}
+
+var errUnexpectedMsg = errors.New("unexpected message")
+
+func (h *Handler) handleMsg(ctx context.Context, rw p2p.MsgReadWriter, msg p2p.Msg) error {
+	start := time.Now()
+	switch msg.Code {
+	case GetAccountRangeMsg:
+		var req GetAccountRangePacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %w", errBadRequest, msg, err)
+		}
+		var resp *AccountRangePacket
+		if err := h.db.ViewTemporal(ctx, func(tx kv.TemporalTx) (err error) {
+			resp, err = h.AnswerGetAccountRangeQuery(ctx, tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, AccountRangeMsg, resp)
+	case GetStorageRangesMsg:
+		var req GetStorageRangesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %w", errBadRequest, msg, err)
+		}
+		var resp *StorageRangesPacket
+		if err := h.db.ViewTemporal(ctx, func(tx kv.TemporalTx) (err error) {
+			resp, err = h.AnswerGetStorageRangesQuery(ctx, tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, StorageRangesMsg, resp)
+	case GetByteCodesMsg:
+		var req GetByteCodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %w", errBadRequest, msg, err)
+		}
+		var resp *ByteCodesPacket
+		if err := h.db.ViewTemporal(ctx, func(tx kv.TemporalTx) (err error) {
+			resp, err = h.AnswerGetByteCodesQuery(tx, &req)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, ByteCodesMsg, resp)
+	case GetTrieNodesMsg:
+		var req GetTrieNodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %w", errBadRequest, msg, err)
+		}
+		var resp *TrieNodesPacket
+		if err := h.db.ViewTemporal(ctx, func(tx kv.TemporalTx) (err error) {
+			resp, err = h.AnswerGetTrieNodesQuery(ctx, tx, &req, start)
+			return err
+		}); err != nil {
+			return err
+		}
+		return p2p.Send(rw, TrieNodesMsg, resp)
+	case AccountRangeMsg, StorageRangesMsg, ByteCodesMsg, TrieNodesMsg:
+		// the node only serves snap, so it never sends requests
+		return fmt.Errorf("%w: code %d", errUnexpectedMsg, msg.Code)
+	default:
+		return fmt.Errorf("%w: invalid code %d", errUnexpectedMsg, msg.Code)
+	}
+}
@@ -0,0 +1,472 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// AnswerGetAccountRangeQuery returns the consecutive accounts starting at the origin together with
+// the merkle proofs of the range bounds.
+func (h *Handler) AnswerGetAccountRangeQuery(ctx context.Context, tx kv.TemporalTx, req *GetAccountRangePacket) (*AccountRangePacket, error) {
+	resp := &AccountRangePacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	s, err := h.openState(ctx, tx, req.Root)
+	if errors.Is(err, errStateUnavailable) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	// accounts are read from the domain, but storage roots are only known to the trie
+	var (
+		addrs [][]byte
+		accs  []*accounts.Account
+		size  uint64
+	)
+	err = s.walkLeaves(nil, keybytesToNibbles(req.Origin[:]), func(plainKey []byte, hashedKey common.Hash) (bool, error) {
+		enc, err := s.get(kv.AccountsDomain, plainKey)
+		if err != nil {
+			return false, err
+		}
+		acc := new(accounts.Account)
+		if err := accounts.DeserialiseV3(acc, enc); err != nil {
+			return false, err
+		}
+		slim, err := EncodeSlimAccount(acc)
+		if err != nil {
+			return false, err
+		}
+		addrs, accs = append(addrs, common.Copy(plainKey)), append(accs, acc)
+		resp.Accounts = append(resp.Accounts, &AccountData{Hash: hashedKey})
+		size += uint64(length.Hash + len(slim))
+		return bytes.Compare(hashedKey[:], req.Limit[:]) < 0 && size <= req.Bytes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.originNeighbour(nil, req.Origin)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.proofTrie(ctx, append(addrs, before...), nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, acc := range resp.Accounts {
+		trieAcc, _ := t.GetAccount(acc.Hash[:])
+		if trieAcc == nil {
+			return nil, errors.New("account is missing in the proof trie")
+		}
+		accs[i].Root = trieAcc.Root
+		if acc.Body, err = EncodeSlimAccount(accs[i]); err != nil {
+			return nil, err
+		}
+		if accs[i].CodeHash != empty.CodeHash {
+			h.codeHashes.Add(accs[i].CodeHash, common.BytesToAddress(addrs[i]))
+		}
+	}
+
+	var proof proofSet
+	if err := proof.prove(t, req.Origin[:], 0, false); err != nil {
+		return nil, err
+	}
+	if len(resp.Accounts) > 0 {
+		if err := proof.prove(t, resp.Accounts[len(resp.Accounts)-1].Hash[:], 0, false); err != nil {
+			return nil, err
+		}
+	}
+	resp.Proof = proof.nodes
+	return resp, nil
+}
+
+// AnswerGetStorageRangesQuery returns the storage slots of the accounts. Only the last storage range
+// may be incomplete, in which case it comes with the merkle proofs of its bounds.
+func (h *Handler) AnswerGetStorageRangesQuery(ctx context.Context, tx kv.TemporalTx, req *GetStorageRangesPacket) (*StorageRangesPacket, error) {
+	resp := &StorageRangesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	hardLimit := uint64(float64(req.Bytes) * (1 + stateLookupSlack))
+	s, err := h.openState(ctx, tx, req.Root)
+	if errors.Is(err, errStateUnavailable) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	var size uint64
+	for _, accountHash := range req.Accounts {
+		// If we've exceeded the requested data limit, abort without opening
+		// a new storage range (that we'd need to prove due to exceeded size)
+		if size >= req.Bytes {
+			break
+		}
+		// The origin and the limit only apply to the first account
+		var origin, limit common.Hash
+		if len(req.Origin) > 0 {
+			origin, req.Origin = common.BytesToHash(req.Origin), nil
+		}
+		hasLimit := len(req.Limit) > 0
+		if hasLimit {
+			limit, req.Limit = common.BytesToHash(req.Limit), nil
+		}
+
+		addr, err := s.accountByHash(accountHash)
+		if err != nil {
+			return nil, err
+		}
+		if addr == nil {
+			continue
+		}
+
+		var (
+			storage []*StorageData
+			keys    [][]byte
+			abort   bool
+		)
+		err = s.walkLeaves(accountHash[:], keybytesToNibbles(origin[:]), func(plainKey []byte, hashedKey common.Hash) (bool, error) {
+			if size >= hardLimit {
+				abort = true
+				return false, nil
+			}
+			v, err := s.get(kv.StorageDomain, plainKey)
+			if err != nil {
+				return false, err
+			}
+			body, err := rlp.EncodeToBytes(v)
+			if err != nil {
+				return false, err
+			}
+			storage, keys = append(storage, &StorageData{Hash: hashedKey, Body: body}), append(keys, common.Copy(plainKey))
+			size += uint64(length.Hash + len(body))
+			return !hasLimit || bytes.Compare(hashedKey[:], limit[:]) < 0, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(storage) > 0 {
+			resp.Slots = append(resp.Slots, storage)
+		}
+		// Prove the range only if it starts in the middle or was capped prematurely,
+		// the entire storage trie doesn't need any proofs.
+		if origin == (common.Hash{}) && !(abort && len(storage) > 0) {
+			continue
+		}
+		before, err := s.originNeighbour(accountHash[:], origin)
+		if err != nil {
+			return nil, err
+		}
+		var boundKeys [][]byte
+		if len(keys) > 0 {
+			boundKeys = [][]byte{keys[0], keys[len(keys)-1]}
+		}
+		t, err := s.proofTrie(ctx, [][]byte{addr}, append(boundKeys, before...))
+		if err != nil {
+			return nil, err
+		}
+		accountProof, err := t.Prove(accountHash[:], 0, false)
+		if err != nil {
+			return nil, err
+		}
+		var proof proofSet
+		if err := proof.prove(t, append(accountHash[:], origin[:]...), len(accountProof), true); err != nil {
+			return nil, err
+		}
+		if len(storage) > 0 {
+			if err := proof.prove(t, append(accountHash[:], storage[len(storage)-1].Hash[:]...), len(accountProof), true); err != nil {
+				return nil, err
+			}
+		}
+		resp.Proof = proof.nodes
+		// Proof terminates the reply as proofs are only added if a node
+		// refuses to serve more data.
+		break
+	}
+	return resp, nil
+}
+
+// AnswerGetByteCodesQuery returns the bytecodes of the code hashes, which were served with the accounts
+// recently, skipping the unknown ones. There is no index of the bytecodes by hash, so they are served
+// best-effort: a peer syncing from this node gets the codes of the accounts it downloaded from it,
+// unless they were evicted from the cache, and has to retry the rest with other peers.
+func (h *Handler) AnswerGetByteCodesQuery(tx kv.TemporalTx, req *GetByteCodesPacket) (*ByteCodesPacket, error) {
+	resp := &ByteCodesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if len(req.Hashes) > maxCodeLookups {
+		req.Hashes = req.Hashes[:maxCodeLookups]
+	}
+	var size uint64
+	for _, hash := range req.Hashes {
+		if hash == empty.CodeHash {
+			resp.Codes = append(resp.Codes, []byte{})
+			continue
+		}
+		addr, ok := h.codeHashes.Get(hash)
+		if !ok {
+			continue
+		}
+		code, _, err := tx.GetLatest(kv.CodeDomain, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		// the account might have been destructed since
+		if len(code) == 0 || crypto.Keccak256Hash(code) != hash {
+			continue
+		}
+		resp.Codes = append(resp.Codes, common.Copy(code))
+		if size += uint64(len(code)); size > req.Bytes {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// trieNodeQuery is a trie node requested by path, it is found on the merkle path to a leaf below it.
+type trieNodeQuery struct {
+	path      []byte // in nibbles, relative to the storage root for storage nodes
+	leafKey   []byte // hashed key of the leaf, with hashed address prepended for storage slots
+	storage   bool
+	fromLevel int // number of account trie nodes to skip for storage nodes
+}
+
+// AnswerGetTrieNodesQuery returns the account and storage trie nodes at the requested paths, skipping the
+// missing ones.
+func (h *Handler) AnswerGetTrieNodesQuery(ctx context.Context, tx kv.TemporalTx, req *GetTrieNodesPacket, start time.Time) (*TrieNodesPacket, error) {
+	resp := &TrieNodesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	s, err := h.openState(ctx, tx, req.Root)
+	if errors.Is(err, errStateUnavailable) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	var (
+		queries                  []trieNodeQuery
+		accountKeys, storageKeys [][]byte
+		storageAccounts          []common.Hash
+	)
+	for _, pathset := range req.Paths {
+		if len(pathset) == 0 {
+			return nil, errBadRequest
+		}
+		if len(pathset) == 1 {
+			path := compactToNibbles(pathset[0])
+			plainKey, leafKey, err := s.leafBelow(nil, path)
+			if err != nil {
+				return nil, err
+			}
+			if plainKey != nil {
+				accountKeys = append(accountKeys, plainKey)
+				queries = append(queries, trieNodeQuery{path: path, leafKey: leafKey[:]})
+			}
+		} else {
+			if len(pathset[0]) != length.Hash {
+				return nil, errBadRequest
+			}
+			accountHash := common.BytesToHash(pathset[0])
+			addr, err := s.accountByHash(accountHash)
+			if err != nil {
+				return nil, err
+			}
+			if addr == nil {
+				continue
+			}
+			accountKeys, storageAccounts = append(accountKeys, addr), append(storageAccounts, accountHash)
+			for _, compactPath := range pathset[1:] {
+				path := compactToNibbles(compactPath)
+				plainKey, leafKey, err := s.leafBelow(accountHash[:], path)
+				if err != nil {
+					return nil, err
+				}
+				if plainKey != nil {
+					storageKeys = append(storageKeys, plainKey)
+					queries = append(queries, trieNodeQuery{path: path, leafKey: append(accountHash[:], leafKey[:]...), storage: true})
+				}
+				if len(queries) >= maxTrieNodeLookups {
+					break
+				}
+			}
+		}
+		if len(queries) >= maxTrieNodeLookups || time.Since(start) > maxTrieNodeTimeSpent {
+			break
+		}
+	}
+	if len(queries) == 0 {
+		return resp, nil
+	}
+
+	t, err := s.proofTrie(ctx, accountKeys, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+	accountProofLens := make(map[common.Hash]int, len(storageAccounts))
+	for _, accountHash := range storageAccounts {
+		accountProof, err := t.Prove(accountHash[:], 0, false)
+		if err != nil {
+			return nil, err
+		}
+		accountProofLens[accountHash] = len(accountProof)
+	}
+
+	var size uint64
+	for _, q := range queries {
+		if q.storage {
+			q.fromLevel = accountProofLens[common.BytesToHash(q.leafKey[:length.Hash])]
+		}
+		proof, err := t.Prove(q.leafKey, q.fromLevel, q.storage)
+		if err != nil {
+			return nil, err
+		}
+		node := nodeAtDepth(proof, len(q.path))
+		if node == nil {
+			continue
+		}
+		resp.Nodes = append(resp.Nodes, node)
+		if size += uint64(len(node)); size > req.Bytes {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// originNeighbour returns the plain key of the leaf preceding the origin. Together with the first
+// returned leaf, its merkle path proves the absence of the origin, if it is not in the trie.
+func (s *servingState) originNeighbour(prefix []byte, origin common.Hash) ([][]byte, error) {
+	if origin == (common.Hash{}) {
+		return nil, nil
+	}
+	plainKey, err := s.leafBefore(prefix, origin)
+	if err != nil || plainKey == nil {
+		return nil, err
+	}
+	return [][]byte{plainKey}, nil
+}
+
+// leafBelow returns the first account or storage slot (see walkLeaves) with the hashed key starting with path.
+func (s *servingState) leafBelow(prefix []byte, path []byte) (plainKey []byte, hashedKey common.Hash, err error) {
+	err = s.walkLeaves(prefix, path, func(k []byte, h common.Hash) (bool, error) {
+		if bytes.HasPrefix(keybytesToNibbles(h[:]), path) {
+			plainKey, hashedKey = common.Copy(k), h
+		}
+		return false, nil
+	})
+	return plainKey, hashedKey, err
+}
+
+// proofSet collects the nodes of merkle proofs without duplicates.
+type proofSet struct {
+	nodes [][]byte
+	seen  map[string]struct{}
+}
+
+func (p *proofSet) prove(t *trie.Trie, key []byte, fromLevel int, storage bool) error {
+	nodes, err := t.Prove(key, fromLevel, storage)
+	if err != nil {
+		return err
+	}
+	if p.seen == nil {
+		p.seen = make(map[string]struct{})
+	}
+	for _, node := range nodes {
+		if _, ok := p.seen[string(node)]; ok {
+			continue
+		}
+		p.seen[string(node)] = struct{}{}
+		p.nodes = append(p.nodes, node)
+	}
+	return nil
+}
+
+// nodeAtDepth returns the node of the merkle proof located depth nibbles below the first one,
+// or nil if there is none.
+func nodeAtDepth(proof [][]byte, depth int) []byte {
+	for _, node := range proof {
+		if depth == 0 {
+			return node
+		}
+		n, err := nodeKeyLen(node)
+		if err != nil || n > depth {
+			return nil
+		}
+		depth -= n
+	}
+	return nil
+}
+
+// nodeKeyLen returns the number of nibbles of the path the encoded trie node consumes:
+// one for the full nodes and the length of the key for the short nodes.
+func nodeKeyLen(node []byte) (int, error) {
+	elems, _, err := rlp.SplitList(node)
+	if err != nil {
+		return 0, err
+	}
+	count, err := rlp.CountValues(elems)
+	if err != nil {
+		return 0, err
+	}
+	switch count {
+	case 17:
+		return 1, nil
+	case 2:
+		_, key, _, err := rlp.Split(elems)
+		if err != nil {
+			return 0, err
+		}
+		return len(compactToNibbles(key)), nil
+	default:
+		return 0, errors.New("invalid number of list elements")
+	}
+}
+
+// compactToNibbles decodes the hex prefix encoded path, the terminator flag is ignored.
+func compactToNibbles(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+	nibbles := keybytesToNibbles(compact)
+	if nibbles[0]&1 == 1 {
+		return nibbles[1:]
+	}
+	return nibbles[2:]
+}
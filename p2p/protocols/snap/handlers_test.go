@@ -0,0 +1,343 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap_test
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/execution/stages/mock"
+	"github.com/erigontech/erigon/p2p/protocols/snap"
+)
+
+var (
+	testKey, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr     = crypto.PubkeyToAddress(testKey.PublicKey)
+	contractAddr = common.HexToAddress("0x00000000000000000000000000000000000c0de")
+	contractCode = []byte{0x60, 0x00, 0x54, 0x00} // PUSH1 0 SLOAD STOP
+	maxLimit     = common.BytesToHash(bytes.Repeat([]byte{0xff}, length.Hash))
+)
+
+const (
+	testAccounts  = 50
+	testSlots     = 40
+	testChainSize = snap.MaxServingDepth + 1
+)
+
+// testState is a chain with testChainSize blocks on top of the genesis with a number of accounts and a contract.
+type testState struct {
+	m       *mock.MockSentry
+	handler *snap.Handler
+	head    *types.Header
+	alloc   types.GenesisAlloc
+	tx      kv.TemporalTx
+}
+
+func newTestState(t *testing.T) *testState {
+	alloc := types.GenesisAlloc{testAddr: {Balance: big.NewInt(1000000)}}
+	for i := 0; i < testAccounts; i++ {
+		alloc[common.BigToAddress(big.NewInt(int64(i+1)))] = types.GenesisAccount{Balance: big.NewInt(int64(i + 1))}
+	}
+	storage := make(map[common.Hash]common.Hash, testSlots)
+	for i := 0; i < testSlots; i++ {
+		storage[common.BigToHash(big.NewInt(int64(i)))] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	alloc[contractAddr] = types.GenesisAccount{Balance: big.NewInt(1), Code: contractCode, Storage: storage}
+
+	m := mock.MockWithGenesis(t, &types.Genesis{Config: chain.TestChainConfig, Alloc: alloc}, testKey, false)
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, testChainSize, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{0xcb})
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chainPack))
+
+	handler, err := snap.NewHandler(m.DB, m.BlockReader, m.Log)
+	require.NoError(t, err)
+	tx, err := m.DB.BeginTemporalRo(m.Ctx)
+	require.NoError(t, err)
+	t.Cleanup(tx.Rollback)
+	head, err := m.BlockReader.CurrentBlock(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(testChainSize), head.NumberU64())
+	return &testState{m: m, handler: handler, head: head.Header(), alloc: alloc, tx: tx}
+}
+
+func (s *testState) accountRange(t *testing.T, origin common.Hash, bytes uint64) *snap.AccountRangePacket {
+	resp, err := s.handler.AnswerGetAccountRangeQuery(context.Background(), s.tx, &snap.GetAccountRangePacket{ID: 1, Root: s.head.Root, Origin: origin, Limit: maxLimit, Bytes: bytes})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), resp.ID)
+	return resp
+}
+
+func TestAccountRange(t *testing.T) {
+	s := newTestState(t)
+
+	resp := s.accountRange(t, common.Hash{}, math.MaxUint64)
+	// all allocated accounts and the coinbase
+	require.Len(t, resp.Accounts, len(s.alloc)+1)
+	expected := map[common.Hash]struct{}{crypto.Keccak256Hash(s.head.Coinbase[:]): {}}
+	for addr := range s.alloc {
+		expected[crypto.Keccak256Hash(addr[:])] = struct{}{}
+	}
+	for i, acc := range resp.Accounts {
+		require.Contains(t, expected, acc.Hash)
+		if i > 0 {
+			require.Equal(t, -1, bytes.Compare(resp.Accounts[i-1].Hash[:], acc.Hash[:]))
+		}
+	}
+	// the range starts at the zero hash, which is proven absent, and ends at the last account
+	require.Nil(t, proofValue(t, s.head.Root, common.Hash{}, resp.Proof))
+	last := resp.Accounts[len(resp.Accounts)-1]
+	requireAccountProof(t, s.head.Root, last, resp.Proof)
+
+	// the contract comes with its storage root and code hash
+	contract := findAccount(t, resp, crypto.Keccak256Hash(contractAddr[:]))
+	require.NotEqual(t, empty.RootHash, contract.Root)
+	require.Equal(t, crypto.Keccak256Hash(contractCode), contract.CodeHash)
+
+	// the range from the middle is proven from the origin to the last returned account
+	origin := nextHash(resp.Accounts[len(resp.Accounts)/2].Hash)
+	half := s.accountRange(t, origin, 1)
+	require.Len(t, half.Accounts, 1)
+	require.Equal(t, resp.Accounts[len(resp.Accounts)/2+1].Hash, half.Accounts[0].Hash)
+	require.Nil(t, proofValue(t, s.head.Root, origin, half.Proof))
+	requireAccountProof(t, s.head.Root, half.Accounts[0], half.Proof)
+}
+
+func TestServingCaps(t *testing.T) {
+	s := newTestState(t)
+
+	// requested sizes are capped by the soft response limit
+	req := &snap.GetAccountRangePacket{Root: s.head.Root, Limit: maxLimit, Bytes: math.MaxUint64}
+	_, err := s.handler.AnswerGetAccountRangeQuery(context.Background(), s.tx, req)
+	require.NoError(t, err)
+	require.Equal(t, uint64(snap.SoftResponseLimit), req.Bytes)
+
+	// states older than maxServingDepth blocks are not served
+	old, err := s.m.BlockReader.HeaderByNumber(context.Background(), s.tx, s.head.Number.Uint64()-snap.MaxServingDepth)
+	require.NoError(t, err)
+	resp, err := s.handler.AnswerGetAccountRangeQuery(context.Background(), s.tx, &snap.GetAccountRangePacket{Root: old.Root, Limit: maxLimit, Bytes: math.MaxUint64})
+	require.NoError(t, err)
+	require.Empty(t, resp.Accounts)
+	require.Empty(t, resp.Proof)
+	nodes, err := s.handler.AnswerGetTrieNodesQuery(context.Background(), s.tx, &snap.GetTrieNodesPacket{Root: old.Root, Paths: []snap.TrieNodePathSet{{{}}}, Bytes: math.MaxUint64}, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, nodes.Nodes)
+}
+
+func TestStorageRanges(t *testing.T) {
+	s := newTestState(t)
+	contractHash := crypto.Keccak256Hash(contractAddr[:])
+	contract := findAccount(t, s.accountRange(t, common.Hash{}, math.MaxUint64), contractHash)
+
+	// the entire storage needs no proof
+	resp, err := s.handler.AnswerGetStorageRangesQuery(context.Background(), s.tx, &snap.GetStorageRangesPacket{ID: 2, Root: s.head.Root, Accounts: []common.Hash{contractHash}, Bytes: math.MaxUint64})
+	require.NoError(t, err)
+	require.Len(t, resp.Slots, 1)
+	require.Len(t, resp.Slots[0], testSlots)
+	require.Empty(t, resp.Proof)
+	expected := make(map[common.Hash][]byte, testSlots)
+	for k, v := range s.alloc[contractAddr].Storage {
+		body, err := rlp.EncodeToBytes(common.TrimLeftZeroes(v[:]))
+		require.NoError(t, err)
+		expected[crypto.Keccak256Hash(k[:])] = body
+	}
+	for _, slot := range resp.Slots[0] {
+		require.Equal(t, expected[slot.Hash], slot.Body)
+	}
+
+	// capped range is proven against the storage root
+	resp, err = s.handler.AnswerGetStorageRangesQuery(context.Background(), s.tx, &snap.GetStorageRangesPacket{ID: 2, Root: s.head.Root, Accounts: []common.Hash{contractHash}, Bytes: 1})
+	require.NoError(t, err)
+	require.Len(t, resp.Slots, 1)
+	require.Len(t, resp.Slots[0], 1)
+	require.NotEmpty(t, resp.Proof)
+	require.Nil(t, proofValue(t, contract.Root, common.Hash{}, resp.Proof))
+	require.Equal(t, resp.Slots[0][0].Body, proofValue(t, contract.Root, resp.Slots[0][0].Hash, resp.Proof))
+
+	// the range from the middle is proven from the origin
+	origin := nextHash(resp.Slots[0][0].Hash)
+	resp, err = s.handler.AnswerGetStorageRangesQuery(context.Background(), s.tx, &snap.GetStorageRangesPacket{ID: 2, Root: s.head.Root, Accounts: []common.Hash{contractHash}, Origin: origin[:], Bytes: math.MaxUint64})
+	require.NoError(t, err)
+	require.Len(t, resp.Slots, 1)
+	require.Len(t, resp.Slots[0], testSlots-1)
+	require.Nil(t, proofValue(t, contract.Root, origin, resp.Proof))
+	last := resp.Slots[0][len(resp.Slots[0])-1]
+	require.Equal(t, last.Body, proofValue(t, contract.Root, last.Hash, resp.Proof))
+}
+
+func TestByteCodes(t *testing.T) {
+	s := newTestState(t)
+	codeHash := crypto.Keccak256Hash(contractCode)
+	req := &snap.GetByteCodesPacket{ID: 3, Hashes: []common.Hash{codeHash, {0x01}}, Bytes: math.MaxUint64}
+
+	// bytecodes are served best-effort, only for the accounts served before
+	resp, err := s.handler.AnswerGetByteCodesQuery(s.tx, req)
+	require.NoError(t, err)
+	require.Empty(t, resp.Codes)
+
+	s.accountRange(t, common.Hash{}, math.MaxUint64)
+	resp, err = s.handler.AnswerGetByteCodesQuery(s.tx, req)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{contractCode}, resp.Codes)
+}
+
+func TestTrieNodes(t *testing.T) {
+	s := newTestState(t)
+	contractHash := crypto.Keccak256Hash(contractAddr[:])
+	contract := findAccount(t, s.accountRange(t, common.Hash{}, math.MaxUint64), contractHash)
+	missingHash := common.Hash{0x1f}
+
+	resp, err := s.handler.AnswerGetTrieNodesQuery(context.Background(), s.tx, &snap.GetTrieNodesPacket{
+		ID:   4,
+		Root: s.head.Root,
+		Paths: []snap.TrieNodePathSet{
+			{{}},                     // account trie root
+			{contractHash[:], {}},    // storage trie root
+			{missingHash[:], {0x1f}}, // storage of a missing account
+		},
+		Bytes: math.MaxUint64,
+	}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, resp.Nodes, 2)
+	require.Equal(t, s.head.Root, crypto.Keccak256Hash(resp.Nodes[0]))
+	require.Equal(t, contract.Root, crypto.Keccak256Hash(resp.Nodes[1]))
+
+	// the root node caps the response
+	resp, err = s.handler.AnswerGetTrieNodesQuery(context.Background(), s.tx, &snap.GetTrieNodesPacket{
+		Root:  s.head.Root,
+		Paths: []snap.TrieNodePathSet{{{}}, {contractHash[:], {}}},
+		Bytes: 1,
+	}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, resp.Nodes, 1)
+}
+
+func findAccount(t *testing.T, resp *snap.AccountRangePacket, hash common.Hash) *accounts.Account {
+	for _, acc := range resp.Accounts {
+		if acc.Hash == hash {
+			dec, err := snap.DecodeSlimAccount(acc.Body)
+			require.NoError(t, err)
+			return dec
+		}
+	}
+	require.FailNow(t, "account not found", "hash %x", hash)
+	return nil
+}
+
+// requireAccountProof checks the proof of the account against the state root.
+func requireAccountProof(t *testing.T, root common.Hash, acc *snap.AccountData, proof [][]byte) {
+	dec, err := snap.DecodeSlimAccount(acc.Body)
+	require.NoError(t, err)
+	enc := make([]byte, dec.EncodingLengthForHashing())
+	dec.EncodeForHashing(enc)
+	require.Equal(t, enc, proofValue(t, root, acc.Hash, proof))
+}
+
+// proofValue follows the key through the merkle proof nodes starting from the root, and returns
+// the value of the leaf, or nil if the proof shows the key is not in the trie.
+func proofValue(t *testing.T, root common.Hash, key common.Hash, proof [][]byte) []byte {
+	nodes := make(map[common.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[crypto.Keccak256Hash(node)] = node
+	}
+	path := toNibbles(key[:])
+	node, ok := nodes[root]
+	require.True(t, ok, "root node is missing in the proof")
+	for {
+		elems, _, err := rlp.SplitList(node)
+		require.NoError(t, err)
+		count, err := rlp.CountValues(elems)
+		require.NoError(t, err)
+		var child []byte
+		switch count {
+		case 17:
+			require.NotEmpty(t, path)
+			child = elems
+			for i := byte(0); i < path[0]; i++ {
+				_, _, child, err = rlp.Split(child)
+				require.NoError(t, err)
+			}
+			path = path[1:]
+		case 2:
+			_, compact, rest, err := rlp.Split(elems)
+			require.NoError(t, err)
+			nibbles := toNibbles(compact)
+			leaf := nibbles[0] >= 2
+			if nibbles[0]&1 == 1 {
+				nibbles = nibbles[1:]
+			} else {
+				nibbles = nibbles[2:]
+			}
+			if !bytes.HasPrefix(path, nibbles) {
+				return nil
+			}
+			path = path[len(nibbles):]
+			if leaf {
+				require.Empty(t, path)
+				_, value, _, err := rlp.Split(rest)
+				require.NoError(t, err)
+				return value
+			}
+			child = rest
+		default:
+			require.FailNow(t, "invalid trie node", "%x", node)
+		}
+
+		kind, ref, rest, err := rlp.Split(child)
+		require.NoError(t, err)
+		switch {
+		case kind == rlp.List: // embedded node
+			node = child[:len(child)-len(rest)]
+		case len(ref) == 0:
+			return nil
+		default:
+			require.Len(t, ref, length.Hash)
+			node, ok = nodes[common.BytesToHash(ref)]
+			require.True(t, ok, "node %x is missing in the proof", ref)
+		}
+	}
+}
+
+// nextHash returns the hash following h, it is not in the trie as long as there are no collisions.
+func nextHash(h common.Hash) common.Hash {
+	return common.BigToHash(new(big.Int).Add(new(big.Int).SetBytes(h[:]), big.NewInt(1)))
+}
+
+func toNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2], nibbles[i*2+1] = b>>4, b&0x0f
+	}
+	return nibbles
+}
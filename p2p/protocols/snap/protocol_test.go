@@ -0,0 +1,105 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+func TestSlimAccount(t *testing.T) {
+	t.Parallel()
+
+	eoa := &accounts.Account{Nonce: 1, Balance: *uint256.NewInt(1000), Root: empty.RootHash, CodeHash: empty.CodeHash}
+	enc, err := EncodeSlimAccount(eoa)
+	require.NoError(t, err)
+	// empty storage root and code hash are encoded as empty strings
+	require.Equal(t, common.FromHex("0xc6018203e88080"), enc)
+	dec, err := DecodeSlimAccount(enc)
+	require.NoError(t, err)
+	require.Equal(t, eoa, dec)
+
+	contract := &accounts.Account{Nonce: 2, Root: common.HexToHash("0x01"), CodeHash: common.HexToHash("0x02")}
+	enc, err = EncodeSlimAccount(contract)
+	require.NoError(t, err)
+	dec, err = DecodeSlimAccount(enc)
+	require.NoError(t, err)
+	require.Equal(t, contract, dec)
+
+	_, err = DecodeSlimAccount(common.FromHex("0xc50180820102"))
+	require.Error(t, err)
+}
+
+func TestPacketsEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	req := &GetTrieNodesPacket{
+		ID:    7,
+		Root:  common.HexToHash("0x01"),
+		Paths: []TrieNodePathSet{{{0x00}}, {common.HexToHash("0x02").Bytes(), {0x1a}, {0x00, 0xab}}},
+		Bytes: softResponseLimit,
+	}
+	enc, err := rlp.EncodeToBytes(req)
+	require.NoError(t, err)
+	var decReq GetTrieNodesPacket
+	require.NoError(t, rlp.DecodeBytes(enc, &decReq))
+	require.Equal(t, req, &decReq)
+
+	resp := &StorageRangesPacket{
+		ID:    7,
+		Slots: [][]*StorageData{{{Hash: common.HexToHash("0x03"), Body: []byte{0x04}}}},
+		Proof: [][]byte{{0x05}},
+	}
+	enc, err = rlp.EncodeToBytes(resp)
+	require.NoError(t, err)
+	var decResp StorageRangesPacket
+	require.NoError(t, rlp.DecodeBytes(enc, &decResp))
+	require.Equal(t, resp, &decResp)
+}
+
+func TestNodeAtDepth(t *testing.T) {
+	t.Parallel()
+
+	// odd and even hex prefix encoded paths
+	require.Equal(t, []byte{0xa}, compactToNibbles([]byte{0x1a}))
+	require.Equal(t, []byte{0xa, 0xb}, compactToNibbles([]byte{0x00, 0xab}))
+	require.Equal(t, []byte{0x1, 0x2, 0x3}, compactToNibbles([]byte{0x31, 0x23}))
+	require.Empty(t, compactToNibbles(nil))
+
+	full := make([][]byte, 17)
+	fullNode, err := rlp.EncodeToBytes(full)
+	require.NoError(t, err)
+	extension, err := rlp.EncodeToBytes([][]byte{{0x00, 0xab}, common.HexToHash("0x01").Bytes()})
+	require.NoError(t, err)
+	leaf, err := rlp.EncodeToBytes([][]byte{{0x31, 0x23}, {0x01}})
+	require.NoError(t, err)
+
+	proof := [][]byte{fullNode, extension, fullNode, leaf}
+	require.Equal(t, fullNode, nodeAtDepth(proof, 0))
+	require.Equal(t, extension, nodeAtDepth(proof, 1))
+	require.Nil(t, nodeAtDepth(proof, 2)) // inside of the extension
+	require.Equal(t, fullNode, nodeAtDepth(proof, 3))
+	require.Equal(t, leaf, nodeAtDepth(proof, 4))
+	require.Nil(t, nodeAtDepth(proof, 5))
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/stream"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+)
+
+// errStateUnavailable is returned when the requested root is not one of the recent states served.
+var errStateUnavailable = errors.New("state unavailable")
+
+// servingState is the state as of one of the recent state roots, requests are answered from.
+type servingState struct {
+	tx      kv.TemporalTx
+	domains *libstate.SharedDomains
+	sdCtx   *libstate.SharedDomainsCommitmentContext
+	root    common.Hash
+	asOf    uint64 // txNum of the historical state, 0 for the latest state
+}
+
+// openState opens the state with the given root if it belongs to one of the last maxServingDepth executed blocks.
+func (h *Handler) openState(ctx context.Context, tx kv.TemporalTx, root common.Hash) (*servingState, error) {
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return nil, err
+	}
+	blockNum, ok, err := h.findRoot(ctx, tx, head, root)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errStateUnavailable
+	}
+
+	var asOf uint64
+	if blockNum < head {
+		// first txNum of the next block restores the state as of blockNum executed
+		if asOf, err = h.blockReader.TxnumReader(ctx).Min(tx, blockNum+1); err != nil {
+			return nil, err
+		}
+		if asOf < tx.Debug().HistoryStartFrom(kv.CommitmentDomain) {
+			return nil, errStateUnavailable
+		}
+	}
+
+	domains, err := libstate.NewSharedDomains(tx, h.logger)
+	if err != nil {
+		return nil, err
+	}
+	s := &servingState{tx: tx, domains: domains, sdCtx: domains.GetCommitmentContext(), root: root, asOf: asOf}
+	if asOf > 0 {
+		s.sdCtx.SetLimitReadAsOfTxNum(asOf, false)
+		if err := domains.SeekCommitment(ctx, tx); err != nil {
+			domains.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// findRoot looks for the block with the state root among the last maxServingDepth blocks up to head.
+// Roots of the recent blocks are cached until the head changes.
+func (h *Handler) findRoot(ctx context.Context, tx kv.TemporalTx, head uint64, root common.Hash) (uint64, bool, error) {
+	headHash, ok, err := h.blockReader.CanonicalHash(ctx, tx, head)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+
+	h.rootsLock.Lock()
+	defer h.rootsLock.Unlock()
+	if h.rootsHead != headHash {
+		roots := make(map[common.Hash]uint64, maxServingDepth)
+		for i := uint64(0); i < maxServingDepth && i <= head; i++ {
+			header, err := h.blockReader.HeaderByNumber(ctx, tx, head-i)
+			if err != nil {
+				return 0, false, err
+			}
+			if header == nil {
+				break
+			}
+			if _, ok := roots[header.Root]; !ok {
+				roots[header.Root] = head - i
+			}
+		}
+		h.roots, h.rootsHead = roots, headHash
+	}
+	blockNum, ok := h.roots[root]
+	return blockNum, ok, nil
+}
+
+func (s *servingState) Close() {
+	s.domains.Close()
+}
+
+func (s *servingState) get(domain kv.Domain, key []byte) ([]byte, error) {
+	if s.asOf == 0 {
+		v, _, err := s.tx.GetLatest(domain, key)
+		return v, err
+	}
+	v, _, err := s.tx.GetAsOf(domain, key, s.asOf)
+	return v, err
+}
+
+// walkLeaves iterates accounts (nil prefix) or storage slots of the account (its hashed address as prefix)
+// in the order of their hashed keys, starting from the given path (in nibbles, below the prefix).
+func (s *servingState) walkLeaves(prefix, from []byte, fn func(plainKey []byte, hashedKey common.Hash) (bool, error)) error {
+	prefixNibbles := keybytesToNibbles(prefix)
+	fromNibbles := append(common.Copy(prefixNibbles), from...)
+	walk := func(plainKey, hashedKey []byte) (bool, error) {
+		return fn(plainKey, common.BytesToHash(nibblesToKeybytes(hashedKey[len(prefixNibbles):])))
+	}
+	err := commitment.WalkLeaves(s.sdCtx.Branch, prefixNibbles, fromNibbles, walk)
+	if !errors.Is(err, commitment.ErrNoBranch) {
+		return err
+	}
+	// subtrie has no branches, it is small enough to be scanned in plain key order
+	leaves, err := s.scanLeaves(prefix)
+	if err != nil {
+		return err
+	}
+	for _, l := range leaves {
+		if bytes.Compare(keybytesToNibbles(l.hashedKey[:]), from) < 0 {
+			continue
+		}
+		if next, err := fn(l.plainKey, l.hashedKey); err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+// leafBefore returns the plain key of the last account or storage slot (see walkLeaves) with hashed key less than key.
+// It is needed to prove the absence of a key, which is not in the trie.
+func (s *servingState) leafBefore(prefix []byte, key common.Hash) ([]byte, error) {
+	plainKey, err := commitment.LastLeafBefore(s.sdCtx.Branch, keybytesToNibbles(prefix), keybytesToNibbles(append(common.Copy(prefix), key[:]...)))
+	if !errors.Is(err, commitment.ErrNoBranch) {
+		return plainKey, err
+	}
+	leaves, err := s.scanLeaves(prefix)
+	if err != nil {
+		return nil, err
+	}
+	i, _ := slices.BinarySearchFunc(leaves, key, func(l leaf, key common.Hash) int { return bytes.Compare(l.hashedKey[:], key[:]) })
+	if i == 0 {
+		return nil, nil
+	}
+	return leaves[i-1].plainKey, nil
+}
+
+type leaf struct {
+	plainKey  []byte
+	hashedKey common.Hash
+}
+
+// scanLeaves reads all accounts or storage slots of the account whose hashed address is the prefix,
+// sorted by their hashed keys.
+func (s *servingState) scanLeaves(prefix []byte) ([]leaf, error) {
+	domain, from, to := kv.AccountsDomain, []byte(nil), []byte(nil)
+	if len(prefix) > 0 {
+		domain = kv.StorageDomain
+		plainKey, err := s.accountByHash(common.BytesToHash(prefix))
+		if err != nil || plainKey == nil {
+			return nil, err
+		}
+		from = plainKey
+		to, _ = kv.NextSubtree(plainKey)
+	}
+
+	var it stream.KV
+	var err error
+	if s.asOf == 0 {
+		it, err = s.tx.Debug().RangeLatest(domain, from, to, -1)
+	} else {
+		it, err = s.tx.RangeAsOf(domain, from, to, s.asOf, order.Asc, -1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var leaves []leaf
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if len(v) == 0 {
+			continue
+		}
+		hashedKey := crypto.Keccak256Hash(k)
+		if domain == kv.StorageDomain {
+			hashedKey = crypto.Keccak256Hash(k[len(from):])
+		}
+		leaves = append(leaves, leaf{plainKey: common.Copy(k), hashedKey: hashedKey})
+	}
+	slices.SortFunc(leaves, func(a, b leaf) int { return bytes.Compare(a.hashedKey[:], b.hashedKey[:]) })
+	return leaves, nil
+}
+
+// accountByHash finds the address of the account with the hashed address, nil if there is no such account.
+func (s *servingState) accountByHash(hashedAddr common.Hash) ([]byte, error) {
+	var addr []byte
+	err := s.walkLeaves(nil, keybytesToNibbles(hashedAddr[:]), func(plainKey []byte, hashedKey common.Hash) (bool, error) {
+		if hashedKey == hashedAddr {
+			addr = common.Copy(plainKey)
+		}
+		return false, nil
+	})
+	return addr, err
+}
+
+// proofTrie loads the merkle paths to the accounts and storage slots (address followed by location) into a trie.
+func (s *servingState) proofTrie(ctx context.Context, accountKeys, storageKeys [][]byte) (*trie.Trie, error) {
+	for _, k := range accountKeys {
+		s.sdCtx.TouchKey(kv.AccountsDomain, string(k), nil)
+	}
+	for _, k := range storageKeys {
+		s.sdCtx.TouchKey(kv.StorageDomain, string(k), nil)
+	}
+	t, _, err := s.sdCtx.Witness(ctx, nil, s.root[:], "snap")
+	return t, err
+}
+
+func keybytesToNibbles(key []byte) []byte {
+	nibbles := make([]byte, len(key)*2)
+	for i, b := range key {
+		nibbles[i*2], nibbles[i*2+1] = b>>4, b&0x0f
+	}
+	return nibbles
+}
+
+func nibblesToKeybytes(nibbles []byte) []byte {
+	key := make([]byte, len(nibbles)/2)
+	for i := range key {
+		key[i] = nibbles[i*2]<<4 | nibbles[i*2+1]
+	}
+	return key
+}
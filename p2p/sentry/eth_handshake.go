@@ -51,21 +51,55 @@ func readAndValidatePeerStatusMessage(
 	return reply, nil
 }
 
+// readAndValidatePeerStatus69Message is readAndValidatePeerStatusMessage for eth/69 and later.
+func readAndValidatePeerStatus69Message(
+	rw p2p.MsgReadWriter,
+	status *proto_sentry.StatusData,
+	version uint,
+	minVersion uint,
+) (*eth.StatusPacket69, *p2p.PeerError) {
+	msg, err := rw.ReadMsg()
+	if err != nil {
+		return nil, p2p.NewPeerError(p2p.PeerErrorStatusReceive, p2p.DiscNetworkError, err, "readAndValidatePeerStatus69Message rw.ReadMsg error")
+	}
+
+	var reply eth.StatusPacket69
+	err = tryDecodeStatus(&msg, &reply)
+	msg.Discard()
+	if err != nil {
+		return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatus69Message tryDecodeStatus error")
+	}
+
+	err = checkPeerStatus69Compatibility(&reply, status, version, minVersion)
+	if err != nil {
+		return nil, p2p.NewPeerError(p2p.PeerErrorStatusIncompatible, p2p.DiscUselessPeer, err, "readAndValidatePeerStatus69Message checkPeerStatus69Compatibility error")
+	}
+
+	return &reply, nil
+}
+
 func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
+	var reply eth.StatusPacket
+	if err := tryDecodeStatus(msg, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func tryDecodeStatus(msg *p2p.Msg, reply eth.Packet) error {
 	if msg.Code != eth.StatusMsg {
-		return nil, fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
+		return fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
 	}
 
 	if msg.Size > eth.ProtocolMaxMsgSize {
-		return nil, fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
+		return fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
 	}
 
-	var reply eth.StatusPacket
-	if err := msg.Decode(&reply); err != nil {
-		return nil, fmt.Errorf("decode message %v: %w", msg, err)
+	if err := msg.Decode(reply); err != nil {
+		return fmt.Errorf("decode message %v: %w", msg, err)
 	}
 
-	return &reply, nil
+	return nil
 }
 
 func checkPeerStatusCompatibility(
@@ -94,3 +128,28 @@ func checkPeerStatusCompatibility(
 	forkFilter := forkid.NewFilterFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
 	return forkFilter(reply.ForkID)
 }
+
+// checkPeerStatus69Compatibility additionally validates the block range announced by the peer,
+// eth/69 status has no total difficulty to check.
+func checkPeerStatus69Compatibility(
+	reply *eth.StatusPacket69,
+	status *proto_sentry.StatusData,
+	version uint,
+	minVersion uint,
+) error {
+	err := checkPeerStatusCompatibility(&eth.StatusPacket{
+		ProtocolVersion: reply.ProtocolVersion,
+		NetworkID:       reply.NetworkID,
+		Genesis:         reply.Genesis,
+		ForkID:          reply.ForkID,
+	}, status, version, minVersion)
+	if err != nil {
+		return err
+	}
+	blockRange := eth.BlockRangeUpdatePacket{
+		EarliestBlock:   reply.EarliestBlock,
+		LatestBlock:     reply.LatestBlock,
+		LatestBlockHash: reply.LatestBlockHash,
+	}
+	return blockRange.Validate()
+}
@@ -96,3 +96,52 @@ func TestCheckPeerStatusCompatibility(t *testing.T) {
 		assert.ErrorIs(t, err, forkid.ErrLocalIncompatibleOrStale)
 	})
 }
+
+func TestCheckPeerStatus69Compatibility(t *testing.T) {
+	var version uint = direct.ETH69
+	networkID := chainspec.MainnetChainConfig.ChainID.Uint64()
+	heightForks, timeForks := forkid.GatherForks(chainspec.MainnetChainConfig, 0 /* genesisTime */)
+	goodReply := eth.StatusPacket69{
+		ProtocolVersion: uint32(version),
+		NetworkID:       networkID,
+		Genesis:         chainspec.MainnetGenesisHash,
+		ForkID:          forkid.NewIDFromForks(heightForks, timeForks, chainspec.MainnetGenesisHash, 0, 0),
+		EarliestBlock:   0,
+		LatestBlock:     0,
+		LatestBlockHash: chainspec.MainnetGenesisHash,
+	}
+	status := proto_sentry.StatusData{
+		NetworkId: networkID,
+		ForkData: &proto_sentry.Forks{
+			Genesis:     gointerfaces.ConvertHashToH256(chainspec.MainnetGenesisHash),
+			HeightForks: heightForks,
+			TimeForks:   timeForks,
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		err := checkPeerStatus69Compatibility(&goodReply, &status, version, version)
+		assert.NoError(t, err)
+	})
+	t.Run("network mismatch", func(t *testing.T) {
+		reply := goodReply
+		reply.NetworkID = 0
+		err := checkPeerStatus69Compatibility(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "network")
+	})
+	t.Run("invalid block range", func(t *testing.T) {
+		reply := goodReply
+		reply.EarliestBlock = 1
+		err := checkPeerStatus69Compatibility(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid block range")
+	})
+	t.Run("zero latest hash", func(t *testing.T) {
+		reply := goodReply
+		reply.LatestBlockHash = common.Hash{}
+		err := checkPeerStatus69Compatibility(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "zero latest block hash")
+	})
+}
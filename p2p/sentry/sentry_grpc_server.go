@@ -58,6 +58,8 @@ import (
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/forkid"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/p2p/protocols/snap"
+	"github.com/erigontech/erigon/turbo/telemetry"
 
 	_ "github.com/erigontech/erigon/polygon/chain" // Register Polygon chains
 )
@@ -67,6 +69,8 @@ const (
 	// complete before dropping the connection.= as malicious.
 	handshakeTimeout  = 5 * time.Second
 	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+	// blockRangeUpdateInterval is how many blocks the head advances before eth/69 peers are sent a BlockRangeUpdate
+	blockRangeUpdateInterval = 32
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -77,6 +81,7 @@ type PeerInfo struct {
 	deadlines     []time.Time // Request deadlines
 	latestDealine time.Time
 	height        uint64
+	earliestBlock uint64 // Lowest block the peer serves bodies and receipts for, only announced by eth/69 peers
 	rw            p2p.MsgReadWriter
 	protocol      uint
 
@@ -197,6 +202,16 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 	}
 }
 
+func (pi *PeerInfo) EarliestBlock() uint64 {
+	return atomic.LoadUint64(&pi.earliestBlock)
+}
+
+// SetBlockRange updates the range of blocks the peer serves, as announced in its eth/69 status or BlockRangeUpdate
+func (pi *PeerInfo) SetBlockRange(blockRange *eth.BlockRangeUpdatePacket) {
+	atomic.StoreUint64(&pi.earliestBlock, blockRange.EarliestBlock)
+	pi.SetIncreasedHeight(blockRange.LatestBlock)
+}
+
 // ClearDeadlines goes through the deadlines of
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
@@ -298,26 +313,41 @@ func handShake(
 	rw p2p.MsgReadWriter,
 	version uint,
 	minVersion uint,
-) (*common.Hash, *p2p.PeerError) {
+) (*eth.BlockRangeUpdatePacket, *p2p.PeerError) {
 	// Send out own handshake in a new thread
 	errChan := make(chan *p2p.PeerError, 2)
-	resultChan := make(chan *eth.StatusPacket, 1)
+	// Head of the peer, and for eth/69 peers the range of blocks it serves
+	resultChan := make(chan *eth.BlockRangeUpdatePacket, 1)
 
 	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
 	// Convert proto status data into the one required by devp2p
 	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	forkID := forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
 
 	go func() {
 		defer debug.LogPanic()
-		status := &eth.StatusPacket{
-			ProtocolVersion: uint32(version),
-			NetworkID:       status.NetworkId,
-			TD:              ourTD.ToBig(),
-			Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
-			Genesis:         genesisHash,
-			ForkID:          forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime),
+		var packet eth.Packet
+		if version >= direct.ETH69 {
+			packet = &eth.StatusPacket69{
+				ProtocolVersion: uint32(version),
+				NetworkID:       status.NetworkId,
+				Genesis:         genesisHash,
+				ForkID:          forkID,
+				EarliestBlock:   min(status.MinimumBlockHeight, status.MaxBlockHeight),
+				LatestBlock:     status.MaxBlockHeight,
+				LatestBlockHash: gointerfaces.ConvertH256ToHash(status.BestHash),
+			}
+		} else {
+			packet = &eth.StatusPacket{
+				ProtocolVersion: uint32(version),
+				NetworkID:       status.NetworkId,
+				TD:              ourTD.ToBig(),
+				Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
+				Genesis:         genesisHash,
+				ForkID:          forkID,
+			}
 		}
-		err := p2p.Send(rw, eth.StatusMsg, status)
+		err := p2p.Send(rw, eth.StatusMsg, packet)
 
 		if err == nil {
 			errChan <- nil
@@ -328,10 +358,25 @@ func handShake(
 
 	go func() {
 		defer debug.LogPanic()
+		if version >= direct.ETH69 {
+			status, err := readAndValidatePeerStatus69Message(rw, status, version, minVersion)
+			if err == nil {
+				resultChan <- &eth.BlockRangeUpdatePacket{
+					EarliestBlock:   status.EarliestBlock,
+					LatestBlock:     status.LatestBlock,
+					LatestBlockHash: status.LatestBlockHash,
+				}
+				errChan <- nil
+			} else {
+				errChan <- err
+			}
+			return
+		}
+
 		status, err := readAndValidatePeerStatusMessage(rw, status, version, minVersion)
 
 		if err == nil {
-			resultChan <- status
+			resultChan <- &eth.BlockRangeUpdatePacket{LatestBlockHash: status.Head}
 			errChan <- nil
 		} else {
 			errChan <- err
@@ -353,8 +398,7 @@ func handShake(
 		}
 	}
 
-	peerStatus := <-resultChan
-	return &peerStatus.Head, nil
+	return <-resultChan, nil
 }
 
 func runPeer(
@@ -530,6 +574,19 @@ func runPeer(
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", hex.EncodeToString(peerID[:]), err))
 			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
+		case eth.BlockRangeUpdateMsg:
+			if protocol < direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscProtocolError, nil, fmt.Sprintf("sentry.runPeer: BlockRangeUpdate over eth/%d", protocol))
+			}
+			var blockRange eth.BlockRangeUpdatePacket
+			if err := msg.Decode(&blockRange); err != nil {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: decoding BlockRangeUpdate")
+			}
+			if err := blockRange.Validate(); err != nil {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: invalid BlockRangeUpdate")
+			}
+			peerInfo.SetBlockRange(&blockRange)
 		case 11:
 			// Ignore
 			// TODO: Investigate why BSC peers for eth/67 send these messages
@@ -577,7 +634,7 @@ func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, he
 	if err != nil {
 		return nil, fmt.Errorf("could not create Sentry P2P listener: %w, addr=%s", err, sentryAddr)
 	}
-	grpcServer := grpcutil.NewServer(100, nil)
+	grpcServer := grpcutil.NewServer(100, nil, telemetry.ServerOption())
 	proto_sentry.RegisterSentryServer(grpcServer, ss)
 	var healthServer *health.Server
 	if healthCheck {
@@ -614,7 +671,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 	ss.Protocols = append(ss.Protocols, p2p.Protocol{
 		Name:           eth.ProtocolName,
 		Version:        protocol,
-		Length:         17,
+		Length:         eth.ProtocolLengths[protocol],
 		DialCandidates: disc,
 		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
 			peerID := peer.Pubkey()
@@ -636,10 +693,13 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 				return p2p.NewPeerError(p2p.PeerErrorLocalStatusNeeded, p2p.DiscProtocolError, nil, "could not get status message from core")
 			}
 
-			peerBestHash, err := handShake(ctx, status, rw, protocol, protocol)
+			peerBlockRange, err := handShake(ctx, status, rw, protocol, protocol)
 			if err != nil {
 				return err
 			}
+			if protocol >= direct.ETH69 {
+				peerInfo.SetBlockRange(peerBlockRange)
+			}
 
 			// handshake is successful
 			logger.Trace("[p2p] Received status message OK", "peerId", printablePeerID, "name", peer.Name())
@@ -647,7 +707,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 			ss.GoodPeers.Store(peerID, peerInfo)
 			ss.sendNewPeerToClients(gointerfaces.ConvertHashToH512(peerID))
 			defer ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID))
-			getBlockHeadersErr := ss.getBlockHeaders(ctx, *peerBestHash, peerID)
+			getBlockHeadersErr := ss.getBlockHeaders(ctx, peerBlockRange.LatestBlockHash, peerID)
 			if getBlockHeadersErr != nil {
 				return p2p.NewPeerError(p2p.PeerErrorFirstMessageSend, p2p.DiscNetworkError, getBlockHeadersErr, "p2p.Protocol.Run getBlockHeaders failure")
 			}
@@ -678,6 +738,29 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 	return ss
 }
 
+// AddSnapProtocol makes the sentry serve the snap protocol with the handler. Snap is a satellite
+// protocol, so it's only served to the peers running eth as well. Must be called before Start.
+func (ss *GrpcServer) AddSnapProtocol(handler *snap.Handler) {
+	ethVersion := ss.Protocols[0].Version
+	ss.Protocols = append(ss.Protocols, p2p.Protocol{
+		Name:    snap.ProtocolName,
+		Version: snap.SNAP1,
+		Length:  snap.ProtocolLength,
+		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
+			if !peer.RunningCap(eth.ProtocolName, []uint{ethVersion}) {
+				return p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscUselessPeer, nil, "snap: peer doesn't run eth")
+			}
+			return handler.Run(ss.ctx, peer, rw)
+		},
+		NodeInfo: func() interface{} {
+			return nil
+		},
+		PeerInfo: func(peerID [64]byte) interface{} {
+			return nil
+		},
+	})
+}
+
 // Sentry creates and runs standalone sentry
 func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discoveryDNS []string, cfg *p2p.Config, protocolVersion uint, healthCheck bool, logger log.Logger) error {
 	dir.MustExist(dirs.DataDir)
@@ -713,6 +796,7 @@ type GrpcServer struct {
 	p2pServerLock        sync.RWMutex
 	statusData           *proto_sentry.StatusData
 	statusDataLock       sync.RWMutex
+	lastBlockRangeUpdate uint64 // Head height of the last BlockRangeUpdate sent to peers, guarded by statusDataLock
 	messageStreams       map[proto_sentry.MessageId]map[uint64]chan *proto_sentry.InboundMessage
 	messagesSubscriberID uint64
 	messageStreamsLock   sync.RWMutex
@@ -811,13 +895,22 @@ func (ss *GrpcServer) PeerMinBlock(_ context.Context, req *proto_sentry.PeerMinB
 	return &emptypb.Empty{}, nil
 }
 
-func (ss *GrpcServer) findBestPeersWithPermit(peerCount int) []*PeerInfo {
+// servesHistory reports whether the peer serves bodies and receipts of the given block, zero means that
+// the request doesn't need history. Only eth/69 peers announce pruned history, older ones are assumed to serve everything.
+func (pi *PeerInfo) servesHistory(blockNum uint64) bool {
+	return blockNum == 0 || pi.EarliestBlock() <= blockNum
+}
+
+func (ss *GrpcServer) findBestPeersWithPermit(peerCount int, historyBlock uint64) []*PeerInfo {
 	// Choose peer(s) that we can send this request to, with maximum number of permits
 	now := time.Now()
 	byMinBlock := make(PeersByMinBlock, 0, peerCount)
 	var pokePeer *PeerInfo // Peer with the earliest dealine, to be "poked" by the request
 	var pokeDeadline time.Time
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if !peerInfo.servesHistory(historyBlock) {
+			return true
+		}
 		deadlines := peerInfo.ClearDeadlines(now, false /* givePermit */)
 		height := peerInfo.Height()
 		//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
@@ -848,13 +941,13 @@ func (ss *GrpcServer) findBestPeersWithPermit(peerCount int) []*PeerInfo {
 	return foundPeers
 }
 
-func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
+func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64, historyBlock uint64) (*PeerInfo, bool) {
 	// Choose a peer that we can send this request to, with maximum number of permits
 	var foundPeerInfo *PeerInfo
 	var maxPermits int
 	now := time.Now()
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
-		if peerInfo.Height() >= minBlock {
+		if peerInfo.Height() >= minBlock && peerInfo.servesHistory(historyBlock) {
 			deadlines := peerInfo.ClearDeadlines(now, false /* givePermit */)
 			//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
 			if deadlines < maxPermitsPerPeer {
@@ -875,18 +968,24 @@ func (ss *GrpcServer) SendMessageByMinBlock(_ context.Context, inreq *proto_sent
 	msgcode := eth.FromProto[ss.Protocols[0].Version][inreq.Data.Id]
 	if msgcode != eth.GetBlockHeadersMsg &&
 		msgcode != eth.GetBlockBodiesMsg &&
+		msgcode != eth.GetReceiptsMsg &&
 		msgcode != eth.GetPooledTransactionsMsg {
 		return reply, fmt.Errorf("sendMessageByMinBlock not implemented for message Id: %s", inreq.Data.Id)
 	}
+	// bodies and receipts must come from peers that have not pruned them
+	var historyBlock uint64
+	if msgcode == eth.GetBlockBodiesMsg || msgcode == eth.GetReceiptsMsg {
+		historyBlock = inreq.MinHistoryBlock
+	}
 	if inreq.MaxPeers == 1 {
-		peerInfo, found := ss.findPeerByMinBlock(inreq.MinBlock)
+		peerInfo, found := ss.findPeerByMinBlock(inreq.MinBlock, historyBlock)
 		if found {
 			ss.writePeer("[sentry] sendMessageByMinBlock", peerInfo, msgcode, inreq.Data.Data, 30*time.Second)
 			reply.Peers = []*proto_types.H512{gointerfaces.ConvertHashToH512(peerInfo.ID())}
 			return reply, nil
 		}
 	}
-	peerInfos := ss.findBestPeersWithPermit(int(inreq.MaxPeers))
+	peerInfos := ss.findBestPeersWithPermit(int(inreq.MaxPeers), historyBlock)
 	reply.Peers = make([]*proto_types.H512, len(peerInfos))
 	for i, peerInfo := range peerInfos {
 		ss.writePeer("[sentry] sendMessageByMinBlock", peerInfo, msgcode, inreq.Data.Data, 15*time.Second)
@@ -996,6 +1095,8 @@ func (ss *GrpcServer) HandShake(context.Context, *emptypb.Empty) (*proto_sentry.
 		reply.Protocol = proto_sentry.Protocol_ETH67
 	case direct.ETH68:
 		reply.Protocol = proto_sentry.Protocol_ETH68
+	case direct.ETH69:
+		reply.Protocol = proto_sentry.Protocol_ETH69
 	}
 	return reply, nil
 }
@@ -1059,9 +1160,46 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
 	}
+	if ss.blockRangeUpdateDue(statusData.MaxBlockHeight) {
+		ss.sendBlockRangeUpdate(statusData)
+	}
 	return reply, nil
 }
 
+// blockRangeUpdateDue reports whether eth/69 peers have to be sent a BlockRangeUpdate for the new head height, and
+// remembers the height if so. Updates are throttled while the head advances, an unwound head is announced right away.
+// Must be called with statusDataLock held.
+func (ss *GrpcServer) blockRangeUpdateDue(height uint64) bool {
+	if height == 0 {
+		// standalone transaction pool doesn't know the head
+		return false
+	}
+	if height < ss.lastBlockRangeUpdate || height >= ss.lastBlockRangeUpdate+blockRangeUpdateInterval {
+		ss.lastBlockRangeUpdate = height
+		return true
+	}
+	return false
+}
+
+// sendBlockRangeUpdate announces the range of blocks we serve to all eth/69 peers
+func (ss *GrpcServer) sendBlockRangeUpdate(statusData *proto_sentry.StatusData) {
+	b, err := rlp.EncodeToBytes(&eth.BlockRangeUpdatePacket{
+		EarliestBlock:   min(statusData.MinimumBlockHeight, statusData.MaxBlockHeight),
+		LatestBlock:     statusData.MaxBlockHeight,
+		LatestBlockHash: gointerfaces.ConvertH256ToHash(statusData.BestHash),
+	})
+	if err != nil {
+		ss.logger.Error("[sentry] could not encode BlockRangeUpdate", "err", err)
+		return
+	}
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if peerInfo.protocol >= direct.ETH69 {
+			ss.writePeer("[sentry] sendBlockRangeUpdate", peerInfo, eth.BlockRangeUpdateMsg, b, 0)
+		}
+		return true
+	})
+}
+
 func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeersReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -39,6 +39,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/forkid"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
 )
 
 func testSentryServer(db kv.Getter, genesis *types.Genesis, genesisHash common.Hash) *GrpcServer {
@@ -87,6 +88,7 @@ func startHandshake(
 // Tests that peers are correctly accepted (or rejected) based on the advertised
 // fork IDs in the protocol handshake.
 func TestForkIDSplit67(t *testing.T) { testForkIDSplit(t, direct.ETH67) }
+func TestForkIDSplit69(t *testing.T) { testForkIDSplit(t, direct.ETH69) }
 
 func testForkIDSplit(t *testing.T, protocol uint) {
 	var (
@@ -212,3 +214,40 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestFindPeerServesHistory(t *testing.T) {
+	ss := &GrpcServer{}
+	full, pruned := &PeerInfo{}, &PeerInfo{}
+	full.SetIncreasedHeight(100)
+	pruned.SetIncreasedHeight(200)
+	pruned.SetBlockRange(&eth.BlockRangeUpdatePacket{EarliestBlock: 50, LatestBlock: 200})
+	ss.GoodPeers.Store([64]byte{1}, full)
+	ss.GoodPeers.Store([64]byte{2}, pruned)
+
+	// headers and transactions don't need history, the pruned peer is the best one
+	peerInfo, found := ss.findPeerByMinBlock(150, 0)
+	require.True(t, found)
+	require.Same(t, pruned, peerInfo)
+	require.Len(t, ss.findBestPeersWithPermit(2, 0), 2)
+
+	// bodies below the earliest block of the pruned peer
+	_, found = ss.findPeerByMinBlock(150, 10)
+	require.False(t, found)
+	peerInfo, found = ss.findPeerByMinBlock(90, 10)
+	require.True(t, found)
+	require.Same(t, full, peerInfo)
+	require.Equal(t, []*PeerInfo{full}, ss.findBestPeersWithPermit(2, 10))
+}
+
+func TestBlockRangeUpdateDue(t *testing.T) {
+	ss := &GrpcServer{}
+	require.False(t, ss.blockRangeUpdateDue(0))
+	require.True(t, ss.blockRangeUpdateDue(100))
+	require.False(t, ss.blockRangeUpdateDue(100+blockRangeUpdateInterval-1))
+	require.True(t, ss.blockRangeUpdateDue(100+blockRangeUpdateInterval))
+
+	// the head was unwound, it is announced without waiting for the interval
+	require.True(t, ss.blockRangeUpdateDue(120))
+	require.False(t, ss.blockRangeUpdateDue(121))
+	require.True(t, ss.blockRangeUpdateDue(120+blockRangeUpdateInterval))
+}
@@ -74,7 +74,8 @@ func (cs *MultiClient) SendBodyRequest(ctx context.Context, req *bodydownload.Bo
 			return [64]byte{}, false
 		}
 		outreq := proto_sentry.SendMessageByMinBlockRequest{
-			MinBlock: req.BlockNums[len(req.BlockNums)-1],
+			MinBlock:        req.BlockNums[len(req.BlockNums)-1],
+			MinHistoryBlock: req.FromBlockNum(),
 			Data: &proto_sentry.OutboundMessageData{
 				Id:   proto_sentry.MessageId_GET_BLOCK_BODIES_66,
 				Data: bytes,
@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/p2p/forkid"
@@ -52,6 +53,7 @@ type StatusDataProvider struct {
 	genesisHead ChainHead
 	heightForks []uint64
 	timeForks   []uint64
+	pruneBlocks prune.BlockAmount
 
 	logger log.Logger
 }
@@ -61,6 +63,7 @@ func NewStatusDataProvider(
 	chainConfig *chain.Config,
 	genesis *types.Block,
 	networkId uint64,
+	pruneMode prune.Mode,
 	logger log.Logger,
 ) *StatusDataProvider {
 	s := &StatusDataProvider{
@@ -68,6 +71,7 @@ func NewStatusDataProvider(
 		networkId:   networkId,
 		genesisHash: genesis.Hash(),
 		genesisHead: makeGenesisChainHead(genesis),
+		pruneBlocks: pruneMode.Blocks,
 		logger:      logger,
 	}
 
@@ -109,6 +113,8 @@ func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.Status
 		BestHash:        gointerfaces.ConvertHashToH256(head.HeadHash),
 		MaxBlockHeight:  head.HeadHeight,
 		MaxBlockTime:    head.HeadTime,
+		// announced to eth/69 peers, so that they don't request pruned bodies and receipts from us
+		MinimumBlockHeight: s.minimumBlockHeight(head.HeadHeight),
 		ForkData: &proto_sentry.Forks{
 			Genesis:     gointerfaces.ConvertHashToH256(s.genesisHash),
 			HeightForks: s.heightForks,
@@ -117,6 +123,14 @@ func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.Status
 	}
 }
 
+// minimumBlockHeight is the lowest block whose body and receipts are kept by the blocks prune mode.
+func (s *StatusDataProvider) minimumBlockHeight(headHeight uint64) uint64 {
+	if s.pruneBlocks == nil || !s.pruneBlocks.Enabled() {
+		return 0
+	}
+	return s.pruneBlocks.PruneTo(headHeight)
+}
+
 func (s *StatusDataProvider) GetStatusData(ctx context.Context) (*proto_sentry.StatusData, error) {
 	chainHead, err := ReadChainHead(ctx, s.db)
 	if err != nil {
@@ -0,0 +1,461 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// ErrAllEndpointsFailed is returned by the FailoverClient when none of its endpoints could serve a request
+var ErrAllEndpointsFailed = errors.New("all heimdall endpoints failed")
+
+const (
+	failoverHealthCheckPeriod  = 10 * time.Second
+	failoverHealthCheckTimeout = 5 * time.Second
+)
+
+// FailoverEndpoint is a named heimdall endpoint of the FailoverClient. Its client should retry
+// little, if at all, since the FailoverClient does the retrying across all endpoints.
+type FailoverEndpoint struct {
+	Name   string
+	Client Client
+}
+
+type failoverEndpoint struct {
+	FailoverEndpoint
+	healthy atomic.Bool
+	meter   endpointMeter
+}
+
+// setHealthy returns whether the health of the endpoint changed
+func (e *failoverEndpoint) setHealthy(healthy bool) bool {
+	e.meter.setHealthy(healthy)
+	return e.healthy.Swap(healthy) != healthy
+}
+
+var _ Client = &FailoverClient{}
+
+// FailoverClient serves requests from several heimdall endpoints, which are health-checked in
+// the background. By default each request goes to the first healthy endpoint, in the given
+// order, and fails over to the next ones on errors of the endpoint. In race mode each request
+// goes to all healthy endpoints at once and the first answer wins. Errors about the requested
+// entity, e.g. ErrNotInMilestoneList, are returned straight away.
+type FailoverClient struct {
+	endpoints         []*failoverEndpoint
+	race              bool
+	healthCheckPeriod time.Duration
+	retryBackOff      time.Duration
+	maxRetries        int
+	stopHealthCheck   context.CancelFunc
+	healthCheckWg     sync.WaitGroup
+	closeCh           chan struct{}
+	logger            log.Logger
+}
+
+type FailoverClientOption func(*FailoverClient)
+
+// WithFailoverRace makes every request go to all healthy endpoints at once
+func WithFailoverRace() FailoverClientOption {
+	return func(client *FailoverClient) {
+		client.race = true
+	}
+}
+
+func WithFailoverHealthCheckPeriod(healthCheckPeriod time.Duration) FailoverClientOption {
+	return func(client *FailoverClient) {
+		client.healthCheckPeriod = healthCheckPeriod
+	}
+}
+
+func WithFailoverRetryBackOff(retryBackOff time.Duration) FailoverClientOption {
+	return func(client *FailoverClient) {
+		client.retryBackOff = retryBackOff
+	}
+}
+
+// WithFailoverMaxRetries sets how many times all endpoints are tried before giving up
+func WithFailoverMaxRetries(maxRetries int) FailoverClientOption {
+	return func(client *FailoverClient) {
+		client.maxRetries = maxRetries
+	}
+}
+
+func NewFailoverClient(ctx context.Context, endpoints []FailoverEndpoint, logger log.Logger, opts ...FailoverClientOption) *FailoverClient {
+	c := &FailoverClient{
+		endpoints:         make([]*failoverEndpoint, 0, len(endpoints)),
+		healthCheckPeriod: failoverHealthCheckPeriod,
+		retryBackOff:      retryBackOff,
+		maxRetries:        maxRetries,
+		closeCh:           make(chan struct{}),
+		logger:            logger,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	for _, endpoint := range endpoints {
+		e := &failoverEndpoint{FailoverEndpoint: endpoint, meter: newEndpointMeter(endpoint.Name)}
+		// assume healthy until a request or the first health check says otherwise
+		e.setHealthy(true)
+		c.endpoints = append(c.endpoints, e)
+	}
+
+	ctx, c.stopHealthCheck = context.WithCancel(ctx)
+	c.healthCheckWg.Add(1)
+	go func() {
+		defer c.healthCheckWg.Done()
+		c.runHealthChecks(ctx)
+	}()
+
+	return c
+}
+
+func (c *FailoverClient) FetchStateSyncEvents(ctx context.Context, fromID uint64, to time.Time, limit int) ([]*EventRecordWithTime, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) ([]*EventRecordWithTime, error) {
+		return client.FetchStateSyncEvents(ctx, fromID, to, limit)
+	})
+}
+
+func (c *FailoverClient) FetchLatestSpan(ctx context.Context) (*Span, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (*Span, error) {
+		return client.FetchLatestSpan(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchSpan(ctx context.Context, spanID uint64) (*Span, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (*Span, error) {
+		return client.FetchSpan(ctx, spanID)
+	})
+}
+
+func (c *FailoverClient) FetchSpans(ctx context.Context, page uint64, limit uint64) ([]*Span, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) ([]*Span, error) {
+		return client.FetchSpans(ctx, page, limit)
+	})
+}
+
+func (c *FailoverClient) FetchChainManagerStatus(ctx context.Context) (*ChainManagerStatus, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (*ChainManagerStatus, error) {
+		return client.FetchChainManagerStatus(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchStatus(ctx context.Context) (*Status, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (*Status, error) {
+		return client.FetchStatus(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchCheckpoint(ctx context.Context, number int64) (*Checkpoint, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (*Checkpoint, error) {
+		return client.FetchCheckpoint(ctx, number)
+	})
+}
+
+func (c *FailoverClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (int64, error) {
+		return client.FetchCheckpointCount(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*Checkpoint, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) ([]*Checkpoint, error) {
+		return client.FetchCheckpoints(ctx, page, limit)
+	})
+}
+
+func (c *FailoverClient) FetchMilestone(ctx context.Context, number int64) (*Milestone, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (*Milestone, error) {
+		return client.FetchMilestone(ctx, number)
+	})
+}
+
+func (c *FailoverClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (int64, error) {
+		return client.FetchMilestoneCount(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchFirstMilestoneNum(ctx context.Context) (int64, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (int64, error) {
+		return client.FetchFirstMilestoneNum(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return failoverFetch(ctx, c, func(ctx context.Context, client Client) (string, error) {
+		return client.FetchLastNoAckMilestone(ctx)
+	})
+}
+
+func (c *FailoverClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	_, err := failoverFetch(ctx, c, func(ctx context.Context, client Client) (struct{}, error) {
+		return struct{}{}, client.FetchNoAckMilestone(ctx, milestoneID)
+	})
+	return err
+}
+
+func (c *FailoverClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	_, err := failoverFetch(ctx, c, func(ctx context.Context, client Client) (struct{}, error) {
+		return struct{}{}, client.FetchMilestoneID(ctx, milestoneID)
+	})
+	return err
+}
+
+func (c *FailoverClient) Close() {
+	close(c.closeCh)
+	c.stopHealthCheck()
+	c.healthCheckWg.Wait()
+
+	for _, endpoint := range c.endpoints {
+		endpoint.Client.Close()
+	}
+}
+
+func (c *FailoverClient) runHealthChecks(ctx context.Context) {
+	ticker := time.NewTicker(c.healthCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkHealth(ctx)
+		}
+	}
+}
+
+// checkHealth marks endpoints healthy when they answer and aren't catching up
+func (c *FailoverClient) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, endpoint := range c.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, failoverHealthCheckTimeout)
+			defer cancel()
+
+			status, err := endpoint.Client.FetchStatus(checkCtx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			catchingUp := false
+			if err == nil {
+				catchingUp, err = isCatchingUp(status)
+			}
+
+			healthy := err == nil && !catchingUp
+			if !endpoint.setHealthy(healthy) {
+				return
+			}
+
+			if healthy {
+				c.logger.Info(heimdallLogPrefix("endpoint is healthy again"), "endpoint", endpoint.Name)
+			} else {
+				c.logger.Warn(heimdallLogPrefix("endpoint is unhealthy"), "endpoint", endpoint.Name, "catchingUp", catchingUp, "err", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// orderedEndpoints returns the healthy endpoints followed by the unhealthy ones, both in the given order
+func (c *FailoverClient) orderedEndpoints() []*failoverEndpoint {
+	endpoints := make([]*failoverEndpoint, 0, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		if endpoint.healthy.Load() {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	for _, endpoint := range c.endpoints {
+		if !endpoint.healthy.Load() {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints
+}
+
+// healthyEndpoints returns the healthy endpoints, or all of them when none is healthy
+func (c *FailoverClient) healthyEndpoints() []*failoverEndpoint {
+	endpoints := make([]*failoverEndpoint, 0, len(c.endpoints))
+	for _, endpoint := range c.endpoints {
+		if endpoint.healthy.Load() {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	if len(endpoints) == 0 {
+		return c.endpoints
+	}
+
+	return endpoints
+}
+
+// failoverFetch tries all endpoints until one of them serves the request, with back off between rounds
+func failoverFetch[T any](ctx context.Context, c *FailoverClient, fetch func(ctx context.Context, client Client) (T, error)) (result T, err error) {
+	attempt := 0
+	// create a new ticker for retrying the request
+	ticker := time.NewTicker(c.retryBackOff)
+	defer ticker.Stop()
+
+	for c.maxRetries == MaxRetriesUnlimited || attempt < c.maxRetries {
+		attempt++
+
+		if c.race {
+			result, err = raceFetch(ctx, c, fetch)
+		} else {
+			result, err = sequentialFetch(ctx, c, fetch)
+		}
+
+		if err == nil || !isEndpointError(err) || errors.Is(err, ErrUnsupportedRequest) {
+			return result, err
+		}
+
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		c.logger.Debug(heimdallLogPrefix("all endpoints failed"), "attempt", attempt, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-c.closeCh:
+			return result, ErrShutdownDetected
+		case <-ticker.C:
+			// retry
+		}
+	}
+
+	return result, fmt.Errorf("%w: %w", ErrAllEndpointsFailed, err)
+}
+
+func sequentialFetch[T any](ctx context.Context, c *FailoverClient, fetch func(ctx context.Context, client Client) (T, error)) (result T, err error) {
+	endpoints := c.orderedEndpoints()
+	for i, endpoint := range endpoints {
+		var endpointErr error
+		result, endpointErr = fetchFromEndpoint(ctx, endpoint, fetch)
+		if endpointErr == nil || !isEndpointError(endpointErr) || ctx.Err() != nil {
+			return result, endpointErr
+		}
+
+		err = moreRelevantError(err, endpointErr)
+
+		if i < len(endpoints)-1 {
+			endpoint.meter.failovers.Inc()
+			c.logger.Debug(heimdallLogPrefix("failing over to the next endpoint"), "endpoint", endpoint.Name, "err", endpointErr)
+		}
+	}
+
+	return result, err
+}
+
+func raceFetch[T any](ctx context.Context, c *FailoverClient, fetch func(ctx context.Context, client Client) (T, error)) (result T, err error) {
+	endpoints := c.healthyEndpoints()
+	if len(endpoints) == 1 {
+		return fetchFromEndpoint(ctx, endpoints[0], fetch)
+	}
+
+	// the losers are cancelled as soon as there is a winner
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type raceResult struct {
+		result T
+		err    error
+	}
+
+	results := make(chan raceResult, len(endpoints))
+	for _, endpoint := range endpoints {
+		go func() {
+			result, err := fetchFromEndpoint(raceCtx, endpoint, fetch)
+			results <- raceResult{result: result, err: err}
+		}()
+	}
+
+	for range endpoints {
+		r := <-results
+		if r.err == nil {
+			return r.result, nil
+		}
+
+		err = moreRelevantError(err, r.err)
+	}
+
+	return result, err
+}
+
+func fetchFromEndpoint[T any](ctx context.Context, endpoint *failoverEndpoint, fetch func(ctx context.Context, client Client) (T, error)) (T, error) {
+	start := time.Now()
+
+	result, err := fetch(ctx, endpoint.Client)
+	if ctx.Err() != nil || errors.Is(err, ErrUnsupportedRequest) {
+		// says nothing about the endpoint, either cancelled or never sent
+		return result, err
+	}
+
+	isSuccessful := err == nil || !isEndpointError(err)
+	endpoint.meter.observeRequest(start, isSuccessful)
+
+	// endpoints only become healthy again through the health checks, which also check for staleness
+	if !isSuccessful {
+		endpoint.setHealthy(false)
+	}
+
+	return result, err
+}
+
+// isEndpointError tells whether err is down to the endpoint rather than to the requested entity,
+// in which case another endpoint may serve the request
+func isEndpointError(err error) bool {
+	return !errors.Is(err, ErrNotInMilestoneList) &&
+		!errors.Is(err, ErrNotInCheckpointList) &&
+		!errors.Is(err, ErrNotInRejectedList) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, ErrShutdownDetected)
+}
+
+// moreRelevantError picks the error to report out of the ones of several endpoints: errors about
+// the requested entity win over the ones of the endpoints, and ErrUnsupportedRequest loses to all
+func moreRelevantError(current, next error) error {
+	switch {
+	case current == nil:
+		return next
+	case !isEndpointError(current):
+		return current
+	case !isEndpointError(next):
+		return next
+	case errors.Is(current, ErrUnsupportedRequest):
+		return next
+	default:
+		return current
+	}
+}
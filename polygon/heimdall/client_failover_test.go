@@ -0,0 +1,215 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/testlog"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/borproto"
+)
+
+func TestFailoverClientFailsOverToNextEndpoint(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	first := newFailoverMockClient(ctrl, true)
+	second := newFailoverMockClient(ctrl, true)
+
+	first.EXPECT().
+		FetchLatestSpan(gomock.Any()).
+		Return(nil, ErrBadGateway).
+		Times(1)
+	second.EXPECT().
+		FetchLatestSpan(gomock.Any()).
+		Return(&Span{Id: 7}, nil).
+		Times(2)
+
+	client := newTestFailoverClient(t, first, second)
+
+	span, err := client.FetchLatestSpan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SpanId(7), span.Id)
+	require.False(t, client.endpoints[0].healthy.Load())
+
+	// the failed endpoint stays unhealthy until the next health check, so it is tried last
+	span, err = client.FetchLatestSpan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SpanId(7), span.Id)
+}
+
+func TestFailoverClientReturnsEntityErrors(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	first := newFailoverMockClient(ctrl, true)
+	second := newFailoverMockClient(ctrl, true)
+
+	first.EXPECT().
+		FetchMilestone(gomock.Any(), int64(10)).
+		Return(nil, ErrNotInMilestoneList).
+		Times(1)
+
+	client := newTestFailoverClient(t, first, second)
+
+	_, err := client.FetchMilestone(ctx, 10)
+	require.ErrorIs(t, err, ErrNotInMilestoneList)
+	require.True(t, client.endpoints[0].healthy.Load())
+}
+
+func TestFailoverClientGivesUpAfterMaxRetries(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	first := newFailoverMockClient(ctrl, true)
+	second := newFailoverMockClient(ctrl, true)
+
+	first.EXPECT().
+		FetchCheckpointCount(gomock.Any()).
+		Return(int64(0), ErrServiceUnavailable).
+		Times(2)
+	second.EXPECT().
+		FetchCheckpointCount(gomock.Any()).
+		Return(int64(0), ErrUnsupportedRequest).
+		Times(2)
+
+	client := newTestFailoverClient(t, first, second)
+
+	_, err := client.FetchCheckpointCount(ctx)
+	require.ErrorIs(t, err, ErrAllEndpointsFailed)
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+}
+
+func TestFailoverClientSkipsUnhealthyEndpoints(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	first := newFailoverMockClient(ctrl, false)
+	second := newFailoverMockClient(ctrl, true)
+
+	second.EXPECT().
+		FetchCheckpointCount(gomock.Any()).
+		Return(int64(5), nil).
+		Times(1)
+
+	client := newTestFailoverClient(t, first, second, WithFailoverHealthCheckPeriod(10*time.Millisecond))
+	require.Eventually(t, func() bool {
+		return !client.endpoints[0].healthy.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	count, err := client.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
+
+func TestFailoverClientRace(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	slow := newFailoverMockClient(ctrl, true)
+	fast := newFailoverMockClient(ctrl, true)
+
+	slow.EXPECT().
+		FetchSpan(gomock.Any(), uint64(3)).
+		DoAndReturn(func(ctx context.Context, _ uint64) (*Span, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).
+		MaxTimes(1)
+	fast.EXPECT().
+		FetchSpan(gomock.Any(), uint64(3)).
+		Return(&Span{Id: 3}, nil).
+		Times(1)
+
+	client := newTestFailoverClient(t, slow, fast, WithFailoverRace())
+
+	span, err := client.FetchSpan(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, SpanId(3), span.Id)
+	// losing the race says nothing about the health of the endpoint
+	require.True(t, client.endpoints[0].healthy.Load())
+}
+
+func TestFailoverClientOverGrpcStandIns(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlDebug)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	downAddress := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	upAddress := newGrpcStandIn(t, func(server *grpc.Server) {
+		borproto.RegisterQueryServer(server, &standInBor{span: &borproto.Span{Id: 11, StartBlock: 1, EndBlock: 6400}})
+	})
+
+	var endpoints []FailoverEndpoint
+	for _, address := range []string{downAddress, upAddress} {
+		grpcClient, err := NewGrpcClient(address, logger, WithGrpcMaxRetries(1))
+		require.NoError(t, err)
+		endpoints = append(endpoints, FailoverEndpoint{Name: address, Client: grpcClient})
+	}
+
+	client := NewFailoverClient(
+		ctx,
+		endpoints,
+		logger,
+		WithFailoverHealthCheckPeriod(time.Hour),
+		WithFailoverRetryBackOff(10*time.Millisecond),
+		WithFailoverMaxRetries(2),
+	)
+	t.Cleanup(client.Close)
+
+	span, err := client.FetchLatestSpan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SpanId(11), span.Id)
+	require.False(t, client.endpoints[0].healthy.Load())
+}
+
+func newFailoverMockClient(ctrl *gomock.Controller, healthy bool) *MockClient {
+	client := NewMockClient(ctrl)
+	client.EXPECT().
+		FetchStatus(gomock.Any()).
+		Return(&Status{LatestBlockTime: time.Now().Format(time.RFC3339), CatchingUp: !healthy}, nil).
+		AnyTimes()
+	client.EXPECT().
+		Close().
+		Times(1)
+
+	return client
+}
+
+func newTestFailoverClient(t *testing.T, first, second Client, opts ...FailoverClientOption) *FailoverClient {
+	opts = append([]FailoverClientOption{
+		WithFailoverHealthCheckPeriod(time.Hour),
+		WithFailoverRetryBackOff(10 * time.Millisecond),
+		WithFailoverMaxRetries(2),
+	}, opts...)
+
+	client := NewFailoverClient(
+		context.Background(),
+		[]FailoverEndpoint{{Name: "first", Client: first}, {Name: "second", Client: second}},
+		testlog.Logger(t, log.LvlDebug),
+		opts...,
+	)
+	t.Cleanup(client.Close)
+
+	return client
+}
@@ -0,0 +1,562 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/borproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/chainmanagerproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/checkpointproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/clerkproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/milestoneproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/queryproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/stakeproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/tendermintproto"
+)
+
+// ErrUnsupportedRequest is returned for requests the queried heimdall endpoint has no API for
+var ErrUnsupportedRequest = errors.New("request not supported by heimdall endpoint")
+
+var _ Client = &GrpcClient{}
+
+// GrpcClient talks to the Cosmos-SDK query services of a Heimdall v2 node. Heimdall v1 only
+// requests (no-ack milestones and milestone IDs) are answered with ErrUnsupportedRequest.
+type GrpcClient struct {
+	address      string
+	conn         *grpc.ClientConn
+	bor          borproto.QueryClient
+	checkpoint   checkpointproto.QueryClient
+	milestone    milestoneproto.QueryClient
+	clerk        clerkproto.QueryClient
+	chainManager chainmanagerproto.QueryClient
+	node         tendermintproto.ServiceClient
+	retryBackOff time.Duration
+	maxRetries   int
+	closeCh      chan struct{}
+	logger       log.Logger
+}
+
+type GrpcClientOption func(*GrpcClient)
+
+func WithGrpcRetryBackOff(retryBackOff time.Duration) GrpcClientOption {
+	return func(client *GrpcClient) {
+		client.retryBackOff = retryBackOff
+	}
+}
+
+func WithGrpcMaxRetries(maxRetries int) GrpcClientOption {
+	return func(client *GrpcClient) {
+		client.maxRetries = maxRetries
+	}
+}
+
+// NewGrpcClient creates a client for the heimdall gRPC endpoint at address (host:port). The
+// connection is established lazily, so an unreachable endpoint only fails the requests.
+func NewGrpcClient(address string, logger log.Logger, opts ...GrpcClientOption) (*GrpcClient, error) {
+	conn, err := grpcutil.Connect(nil, address)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to heimdall gRPC endpoint %s: %w", address, err)
+	}
+
+	c := &GrpcClient{
+		address:      address,
+		conn:         conn,
+		bor:          borproto.NewQueryClient(conn),
+		checkpoint:   checkpointproto.NewQueryClient(conn),
+		milestone:    milestoneproto.NewQueryClient(conn),
+		clerk:        clerkproto.NewQueryClient(conn),
+		chainManager: chainmanagerproto.NewQueryClient(conn),
+		node:         tendermintproto.NewServiceClient(conn),
+		retryBackOff: retryBackOff,
+		maxRetries:   maxRetries,
+		closeCh:      make(chan struct{}),
+		logger:       logger,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+func (c *GrpcClient) FetchStateSyncEvents(ctx context.Context, fromID uint64, to time.Time, limit int) ([]*EventRecordWithTime, error) {
+	eventRecords := make([]*EventRecordWithTime, 0)
+	ctx = withRequestType(ctx, stateSyncRequest)
+
+	for {
+		c.logger.Trace(heimdallLogPrefix("Fetching state sync events"), "fromID", fromID, "to", to.Unix())
+
+		request := &clerkproto.RecordListWithTimeRequest{
+			FromId:     fromID,
+			ToTime:     timestamppb.New(to),
+			Pagination: &queryproto.PageRequest{Limit: StateEventsFetchLimit},
+		}
+		response, err := grpcFetchWithRetry(ctx, c, "GetRecordListWithTime", nil, func(ctx context.Context) (*clerkproto.RecordListWithTimeResponse, error) {
+			return c.clerk.GetRecordListWithTime(ctx, request)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range response.EventRecords {
+			eventRecords = append(eventRecords, eventRecordFromProto(record))
+		}
+
+		if len(response.EventRecords) < StateEventsFetchLimit || (limit > 0 && len(eventRecords) >= limit) {
+			break
+		}
+
+		fromID += uint64(StateEventsFetchLimit)
+	}
+
+	sort.SliceStable(eventRecords, func(i, j int) bool {
+		return eventRecords[i].ID < eventRecords[j].ID
+	})
+
+	return eventRecords, nil
+}
+
+func (c *GrpcClient) FetchLatestSpan(ctx context.Context) (*Span, error) {
+	ctx = withRequestType(ctx, spanRequest)
+
+	response, err := grpcFetchWithRetry(ctx, c, "GetLatestSpan", nil, func(ctx context.Context) (*borproto.QueryLatestSpanResponse, error) {
+		return c.bor.GetLatestSpan(ctx, &borproto.QueryLatestSpanRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return spanFromProto(response.Span), nil
+}
+
+func (c *GrpcClient) FetchSpan(ctx context.Context, spanID uint64) (*Span, error) {
+	ctx = withRequestType(ctx, spanRequest)
+
+	request := &borproto.QuerySpanByIdRequest{Id: strconv.FormatUint(spanID, 10)}
+	response, err := grpcFetchWithRetry(ctx, c, "GetSpanById", nil, func(ctx context.Context) (*borproto.QuerySpanByIdResponse, error) {
+		return c.bor.GetSpanById(ctx, request)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w, spanID=%d", err, spanID)
+	}
+
+	return spanFromProto(response.Span), nil
+}
+
+func (c *GrpcClient) FetchSpans(ctx context.Context, page uint64, limit uint64) ([]*Span, error) {
+	ctx = withRequestType(ctx, checkpointListRequest)
+
+	request := &borproto.QuerySpanListRequest{
+		Pagination: &queryproto.PageRequest{Offset: (page - 1) * limit, Limit: limit}, // page start from 1
+	}
+	response, err := grpcFetchWithRetry(ctx, c, "GetSpanList", nil, func(ctx context.Context) (*borproto.QuerySpanListResponse, error) {
+		return c.bor.GetSpanList(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spans := make([]*Span, 0, len(response.SpanList))
+	for _, span := range response.SpanList {
+		spans = append(spans, spanFromProto(span))
+	}
+
+	return spans, nil
+}
+
+func (c *GrpcClient) FetchChainManagerStatus(ctx context.Context) (*ChainManagerStatus, error) {
+	ctx = withRequestType(ctx, statusRequest)
+
+	response, err := grpcFetchWithRetry(ctx, c, "GetChainManagerParams", nil, func(ctx context.Context) (*chainmanagerproto.QueryParamsResponse, error) {
+		return c.chainManager.GetChainManagerParams(ctx, &chainmanagerproto.QueryParamsRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	polTokenAddress := response.GetParams().GetChainParams().GetPolTokenAddress()
+
+	var chainManagerStatus ChainManagerStatus
+	chainManagerStatus.Params.ChainParams.PolTokenAddress = &polTokenAddress
+
+	return &chainManagerStatus, nil
+}
+
+func (c *GrpcClient) FetchStatus(ctx context.Context) (*Status, error) {
+	ctx = withRequestType(ctx, statusRequest)
+
+	latestBlock, err := grpcFetchWithRetry(ctx, c, "GetLatestBlock", nil, func(ctx context.Context) (*tendermintproto.GetLatestBlockResponse, error) {
+		return c.node.GetLatestBlock(ctx, &tendermintproto.GetLatestBlockRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	syncing, err := grpcFetchWithRetry(ctx, c, "GetSyncing", nil, func(ctx context.Context) (*tendermintproto.GetSyncingResponse, error) {
+		return c.node.GetSyncing(ctx, &tendermintproto.GetSyncingRequest{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	header := latestBlock.GetSdkBlock().GetHeader()
+
+	return &Status{
+		LatestBlockHash: fmt.Sprintf("%X", latestBlock.GetBlockId().GetHash()),
+		LatestAppHash:   fmt.Sprintf("%X", header.GetAppHash()),
+		LatestBlockTime: header.GetTime().AsTime().UTC().Format(time.RFC3339Nano),
+		CatchingUp:      syncing.Syncing,
+	}, nil
+}
+
+// FetchCheckpoint fetches the checkpoint from heimdall
+func (c *GrpcClient) FetchCheckpoint(ctx context.Context, number int64) (*Checkpoint, error) {
+	ctx = withRequestType(ctx, checkpointRequest)
+
+	if number == -1 {
+		response, err := grpcFetchWithRetry(ctx, c, "GetCheckpointLatest", nil, func(ctx context.Context) (*checkpointproto.QueryCheckpointLatestResponse, error) {
+			return c.checkpoint.GetCheckpointLatest(ctx, &checkpointproto.QueryCheckpointLatestRequest{})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return checkpointFromProto(response.Checkpoint), nil
+	}
+
+	request := &checkpointproto.QueryCheckpointRequest{Number: uint64(number)}
+	response, err := grpcFetchWithRetry(ctx, c, "GetCheckpoint", isNotFoundUnrecoverable, func(ctx context.Context) (*checkpointproto.QueryCheckpointResponse, error) {
+		return c.checkpoint.GetCheckpoint(ctx, request)
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: number %d", ErrNotInCheckpointList, number)
+		}
+		return nil, err
+	}
+
+	checkpoint := checkpointFromProto(response.Checkpoint)
+	checkpoint.Id = CheckpointId(number)
+
+	return checkpoint, nil
+}
+
+func (c *GrpcClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	ctx = withRequestType(ctx, checkpointCountRequest)
+
+	response, err := grpcFetchWithRetry(ctx, c, "GetAckCount", nil, func(ctx context.Context) (*checkpointproto.QueryAckCountResponse, error) {
+		return c.checkpoint.GetAckCount(ctx, &checkpointproto.QueryAckCountRequest{})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(response.AckCount), nil
+}
+
+func (c *GrpcClient) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*Checkpoint, error) {
+	ctx = withRequestType(ctx, checkpointListRequest)
+
+	request := &checkpointproto.QueryCheckpointListRequest{
+		Pagination: &queryproto.PageRequest{Offset: (page - 1) * limit, Limit: limit}, // page start from 1
+	}
+	response, err := grpcFetchWithRetry(ctx, c, "GetCheckpointList", nil, func(ctx context.Context) (*checkpointproto.QueryCheckpointListResponse, error) {
+		return c.checkpoint.GetCheckpointList(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints := make([]*Checkpoint, 0, len(response.CheckpointList))
+	for _, checkpoint := range response.CheckpointList {
+		checkpoints = append(checkpoints, checkpointFromProto(checkpoint))
+	}
+
+	return checkpoints, nil
+}
+
+// FetchMilestone fetches a milestone from heimdall
+func (c *GrpcClient) FetchMilestone(ctx context.Context, number int64) (*Milestone, error) {
+	ctx = withRequestType(ctx, milestoneRequest)
+
+	if number == -1 {
+		response, err := grpcFetchWithRetry(ctx, c, "GetLatestMilestone", nil, func(ctx context.Context) (*milestoneproto.QueryLatestMilestoneResponse, error) {
+			return c.milestone.GetLatestMilestone(ctx, &milestoneproto.QueryLatestMilestoneRequest{})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return milestoneFromProto(number, response.Milestone), nil
+	}
+
+	isRecoverableError := func(err error) bool {
+		if status.Code(err) != codes.NotFound {
+			return true
+		}
+
+		firstNum, err := c.FetchFirstMilestoneNum(ctx)
+		if err != nil {
+			c.logger.Warn(
+				heimdallLogPrefix("issue fetching milestone count when deciding if not found err is recoverable"),
+				"err", err,
+			)
+
+			return false
+		}
+
+		// if number is within expected non pruned range then it should be retried
+		return firstNum <= number && number <= firstNum+milestonePruneNumber-1
+	}
+
+	request := &milestoneproto.QueryMilestoneRequest{Number: uint64(number)}
+	response, err := grpcFetchWithRetry(ctx, c, "GetMilestoneByNumber", isRecoverableError, func(ctx context.Context) (*milestoneproto.QueryMilestoneResponse, error) {
+		return c.milestone.GetMilestoneByNumber(ctx, request)
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: number %d", ErrNotInMilestoneList, number)
+		}
+		return nil, err
+	}
+
+	return milestoneFromProto(number, response.Milestone), nil
+}
+
+// FetchMilestoneCount fetches the milestone count from heimdall
+func (c *GrpcClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	ctx = withRequestType(ctx, milestoneCountRequest)
+
+	response, err := grpcFetchWithRetry(ctx, c, "GetMilestoneCount", nil, func(ctx context.Context) (*milestoneproto.QueryCountResponse, error) {
+		return c.milestone.GetMilestoneCount(ctx, &milestoneproto.QueryCountRequest{})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(response.Count), nil
+}
+
+func (c *GrpcClient) FetchFirstMilestoneNum(ctx context.Context) (int64, error) {
+	count, err := c.FetchMilestoneCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return firstMilestoneNum(count), nil
+}
+
+// FetchLastNoAckMilestone is heimdall v1 only
+func (c *GrpcClient) FetchLastNoAckMilestone(context.Context) (string, error) {
+	return "", fmt.Errorf("%w: last no-ack milestone", ErrUnsupportedRequest)
+}
+
+// FetchNoAckMilestone is heimdall v1 only
+func (c *GrpcClient) FetchNoAckMilestone(_ context.Context, milestoneID string) error {
+	return fmt.Errorf("%w: no-ack milestone %q", ErrUnsupportedRequest, milestoneID)
+}
+
+// FetchMilestoneID is heimdall v1 only
+func (c *GrpcClient) FetchMilestoneID(_ context.Context, milestoneID string) error {
+	return fmt.Errorf("%w: milestoneID %q", ErrUnsupportedRequest, milestoneID)
+}
+
+func (c *GrpcClient) Close() {
+	close(c.closeCh)
+	if err := c.conn.Close(); err != nil {
+		c.logger.Debug(heimdallLogPrefix("closing gRPC connection"), "address", c.address, "err", err)
+	}
+}
+
+// grpcFetchWithRetry calls fetch until it succeeds, like FetchWithRetryEx does for http. Errors
+// are mapped to the ones of the http client, so callers and the scrapers treat both alike.
+func grpcFetchWithRetry[T any](
+	ctx context.Context,
+	client *GrpcClient,
+	method string,
+	isRecoverableError func(error) bool,
+	fetch func(ctx context.Context) (T, error),
+) (result T, err error) {
+	attempt := 0
+	// create a new ticker for retrying the request
+	ticker := time.NewTicker(client.retryBackOff)
+	defer ticker.Stop()
+
+	for client.maxRetries == MaxRetriesUnlimited || attempt < client.maxRetries {
+		attempt++
+
+		result, err = grpcFetch(ctx, fetch)
+		if err == nil {
+			return result, nil
+		}
+
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			// e.g. a heimdall v1 node, which has no gRPC query services
+			return result, fmt.Errorf("%w: %w", ErrUnsupportedRequest, err)
+		case codes.InvalidArgument:
+			return result, fmt.Errorf("%w: %w", ErrNotSuccessfulResponse, err)
+		}
+
+		if (isRecoverableError != nil) && !isRecoverableError(err) {
+			return result, err
+		}
+
+		client.logger.Debug(heimdallLogPrefix("an error while fetching"), "address", client.address, "method", method, "attempt", attempt, "err", err)
+
+		select {
+		case <-ctx.Done():
+			client.logger.Debug(heimdallLogPrefix("request canceled"), "reason", ctx.Err(), "address", client.address, "method", method, "attempt", attempt)
+			return result, ctx.Err()
+		case <-client.closeCh:
+			client.logger.Debug(heimdallLogPrefix("shutdown detected, terminating request"), "address", client.address, "method", method)
+			return result, ErrShutdownDetected
+		case <-ticker.C:
+			// retry
+		}
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return result, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	case codes.DeadlineExceeded:
+		return result, fmt.Errorf("%w: %w", ErrOperationTimeout, err)
+	default:
+		return result, fmt.Errorf("%w: %w", ErrNotSuccessfulResponse, err)
+	}
+}
+
+func grpcFetch[T any](ctx context.Context, fetch func(ctx context.Context) (T, error)) (T, error) {
+	start := time.Now()
+
+	requestCtx, cancel := context.WithTimeout(ctx, apiHeimdallTimeout)
+	defer cancel()
+
+	result, err := fetch(requestCtx)
+	if metrics.EnabledExpensive {
+		sendMetrics(ctx, start, err == nil)
+	}
+
+	return result, err
+}
+
+func isNotFoundUnrecoverable(err error) bool {
+	return status.Code(err) != codes.NotFound
+}
+
+func spanFromProto(s *borproto.Span) *Span {
+	span := &Span{
+		Id:         SpanId(s.GetId()),
+		StartBlock: s.GetStartBlock(),
+		EndBlock:   s.GetEndBlock(),
+		ValidatorSet: valset.ValidatorSet{
+			Validators: make([]*valset.Validator, 0, len(s.GetValidatorSet().GetValidators())),
+		},
+		SelectedProducers: make([]valset.Validator, 0, len(s.GetSelectedProducers())),
+		ChainID:           s.GetBorChainId(),
+	}
+
+	if proposer := s.GetValidatorSet().GetProposer(); proposer != nil {
+		validator := validatorFromProto(proposer)
+		span.ValidatorSet.Proposer = &validator
+	}
+
+	for _, v := range s.GetValidatorSet().GetValidators() {
+		validator := validatorFromProto(v)
+		span.ValidatorSet.Validators = append(span.ValidatorSet.Validators, &validator)
+	}
+
+	for _, v := range s.GetSelectedProducers() {
+		span.SelectedProducers = append(span.SelectedProducers, validatorFromProto(v))
+	}
+
+	return span
+}
+
+func validatorFromProto(v *stakeproto.Validator) valset.Validator {
+	return valset.Validator{
+		ID:               v.GetValId(),
+		Address:          common.HexToAddress(v.GetSigner()),
+		VotingPower:      v.GetVotingPower(),
+		ProposerPriority: v.GetProposerPriority(),
+	}
+}
+
+func checkpointFromProto(c *checkpointproto.Checkpoint) *Checkpoint {
+	return &Checkpoint{
+		Id: CheckpointId(c.GetId()),
+		Fields: WaypointFields{
+			Proposer:   common.HexToAddress(c.GetProposer()),
+			StartBlock: new(big.Int).SetUint64(c.GetStartBlock()),
+			EndBlock:   new(big.Int).SetUint64(c.GetEndBlock()),
+			RootHash:   common.BytesToHash(c.GetRootHash()),
+			ChainID:    c.GetBorChainId(),
+			Timestamp:  c.GetTimestamp(),
+		},
+	}
+}
+
+func milestoneFromProto(id int64, m *milestoneproto.Milestone) *Milestone {
+	return &Milestone{
+		Id:          MilestoneId(id),
+		MilestoneId: m.GetMilestoneId(),
+		Fields: WaypointFields{
+			Proposer:   common.HexToAddress(m.GetProposer()),
+			StartBlock: new(big.Int).SetUint64(m.GetStartBlock()),
+			EndBlock:   new(big.Int).SetUint64(m.GetEndBlock()),
+			RootHash:   common.BytesToHash(m.GetHash()),
+			ChainID:    m.GetBorChainId(),
+			Timestamp:  m.GetTimestamp(),
+		},
+	}
+}
+
+func eventRecordFromProto(r *clerkproto.EventRecord) *EventRecordWithTime {
+	return &EventRecordWithTime{
+		EventRecord: EventRecord{
+			ID:       r.GetId(),
+			Contract: common.HexToAddress(r.GetContract()),
+			Data:     r.GetData(),
+			TxHash:   common.HexToHash(r.GetTxHash()),
+			LogIndex: r.GetLogIndex(),
+			ChainID:  r.GetBorChainId(),
+		},
+		Time: r.GetRecordTime().AsTime(),
+	}
+}
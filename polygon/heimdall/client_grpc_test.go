@@ -0,0 +1,287 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/testlog"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/borproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/checkpointproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/clerkproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/stakeproto"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/tendermintproto"
+)
+
+func TestGrpcClientFetchesSpans(t *testing.T) {
+	ctx := context.Background()
+	validator := &stakeproto.Validator{
+		ValId:            3,
+		Signer:           "0x6ab3d36c46ecfb9b9c0bd51cb1c3da5a2c81cea6",
+		VotingPower:      1000,
+		ProposerPriority: -25,
+	}
+	bor := &standInBor{span: &borproto.Span{
+		Id:                7,
+		StartBlock:        256,
+		EndBlock:          6655,
+		ValidatorSet:      &stakeproto.ValidatorSet{Validators: []*stakeproto.Validator{validator}, Proposer: validator},
+		SelectedProducers: []*stakeproto.Validator{validator},
+		BorChainId:        "80002",
+	}}
+	client := newTestGrpcClient(t, newGrpcStandIn(t, func(server *grpc.Server) {
+		borproto.RegisterQueryServer(server, bor)
+	}))
+
+	span, err := client.FetchLatestSpan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, SpanId(7), span.Id)
+	require.Equal(t, uint64(256), span.StartBlock)
+	require.Equal(t, uint64(6655), span.EndBlock)
+	require.Equal(t, "80002", span.ChainID)
+	require.Len(t, span.ValidatorSet.Validators, 1)
+	require.Equal(t, common.HexToAddress(validator.Signer), span.ValidatorSet.Proposer.Address)
+	require.Equal(t, int64(1000), span.ValidatorSet.Validators[0].VotingPower)
+	require.Equal(t, int64(-25), span.SelectedProducers[0].ProposerPriority)
+
+	span, err = client.FetchSpan(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, SpanId(7), span.Id)
+}
+
+func TestGrpcClientFetchesCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	checkpoint := &standInCheckpoint{checkpoints: []*checkpointproto.Checkpoint{
+		{Id: 1, Proposer: "0x20d0a6ac0c0bbd0ac2f2f3fb6d5b7aa5c1b2a3d4", StartBlock: 0, EndBlock: 255, RootHash: common.HexToHash("0x01").Bytes(), BorChainId: "80002", Timestamp: 1700000000},
+		{Id: 2, Proposer: "0x20d0a6ac0c0bbd0ac2f2f3fb6d5b7aa5c1b2a3d4", StartBlock: 256, EndBlock: 511, RootHash: common.HexToHash("0x02").Bytes(), BorChainId: "80002", Timestamp: 1700000100},
+	}}
+	client := newTestGrpcClient(t, newGrpcStandIn(t, func(server *grpc.Server) {
+		checkpointproto.RegisterQueryServer(server, checkpoint)
+	}))
+
+	count, err := client.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	fetched, err := client.FetchCheckpoint(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, CheckpointId(2), fetched.Id)
+	require.Equal(t, uint64(256), fetched.StartBlock().Uint64())
+	require.Equal(t, uint64(511), fetched.EndBlock().Uint64())
+	require.Equal(t, common.HexToHash("0x02"), fetched.RootHash())
+	require.Equal(t, uint64(1700000100), fetched.Fields.Timestamp)
+
+	_, err = client.FetchCheckpoint(ctx, 3)
+	require.ErrorIs(t, err, ErrNotInCheckpointList)
+}
+
+func TestGrpcClientFetchesStateSyncEventsInPages(t *testing.T) {
+	ctx := context.Background()
+	recordTime := time.Unix(1700000000, 0).UTC()
+	clerk := &standInClerk{}
+	for id := uint64(1); id <= StateEventsFetchLimit+20; id++ {
+		clerk.records = append(clerk.records, &clerkproto.EventRecord{
+			Id:         id,
+			Contract:   "0x0000000000000000000000000000000000001001",
+			Data:       []byte{byte(id)},
+			TxHash:     common.HexToHash("0x" + strconv.FormatUint(id, 16)).Hex(),
+			LogIndex:   id % 3,
+			BorChainId: "80002",
+			RecordTime: timestamppb.New(recordTime.Add(time.Duration(id) * time.Second)),
+		})
+	}
+	client := newTestGrpcClient(t, newGrpcStandIn(t, func(server *grpc.Server) {
+		clerkproto.RegisterQueryServer(server, clerk)
+	}))
+
+	events, err := client.FetchStateSyncEvents(ctx, 1, recordTime.Add(time.Hour), 0)
+	require.NoError(t, err)
+	require.Len(t, events, StateEventsFetchLimit+20)
+	for i, event := range events {
+		id := uint64(i + 1)
+		require.Equal(t, id, event.ID)
+		require.Equal(t, common.HexToAddress("0x1001"), event.Contract)
+		require.Equal(t, []byte{byte(id)}, []byte(event.Data))
+		require.Equal(t, recordTime.Add(time.Duration(id)*time.Second), event.Time.UTC())
+	}
+
+	events, err = client.FetchStateSyncEvents(ctx, 1, recordTime.Add(10*time.Second), 0)
+	require.NoError(t, err)
+	require.Len(t, events, 10)
+}
+
+func TestGrpcClientFetchesStatus(t *testing.T) {
+	ctx := context.Background()
+	node := &standInNode{blockTime: time.Now().Add(-time.Minute)}
+	node.syncing.Store(true)
+	client := newTestGrpcClient(t, newGrpcStandIn(t, func(server *grpc.Server) {
+		tendermintproto.RegisterServiceServer(server, node)
+	}))
+
+	status, err := client.FetchStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, status.CatchingUp)
+	require.Equal(t, "0102", status.LatestBlockHash)
+
+	catchingUp, err := isCatchingUp(status)
+	require.NoError(t, err)
+	require.True(t, catchingUp)
+
+	node.syncing.Store(false)
+	status, err = client.FetchStatus(ctx)
+	require.NoError(t, err)
+
+	catchingUp, err = isCatchingUp(status)
+	require.NoError(t, err)
+	require.False(t, catchingUp)
+}
+
+func TestGrpcClientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	// the stand-in only serves the bor queries
+	client := newTestGrpcClient(t, newGrpcStandIn(t, func(server *grpc.Server) {
+		borproto.RegisterQueryServer(server, &standInBor{})
+	}))
+
+	_, err := client.FetchMilestoneCount(ctx)
+	require.ErrorIs(t, err, ErrUnsupportedRequest)
+
+	err = client.FetchNoAckMilestone(ctx, "milestone")
+	require.ErrorIs(t, err, ErrUnsupportedRequest)
+
+	// nothing listens on the address of a stopped stand-in
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	client = newTestGrpcClient(t, address)
+	_, err = client.FetchLatestSpan(ctx)
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+}
+
+func newGrpcStandIn(t *testing.T, register func(server *grpc.Server)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func newTestGrpcClient(t *testing.T, address string) *GrpcClient {
+	client, err := NewGrpcClient(
+		address,
+		testlog.Logger(t, log.LvlDebug),
+		WithGrpcRetryBackOff(10*time.Millisecond),
+		WithGrpcMaxRetries(2),
+	)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+type standInBor struct {
+	borproto.UnimplementedQueryServer
+	span *borproto.Span
+}
+
+func (s *standInBor) GetLatestSpan(context.Context, *borproto.QueryLatestSpanRequest) (*borproto.QueryLatestSpanResponse, error) {
+	return &borproto.QueryLatestSpanResponse{Span: s.span}, nil
+}
+
+func (s *standInBor) GetSpanById(_ context.Context, request *borproto.QuerySpanByIdRequest) (*borproto.QuerySpanByIdResponse, error) {
+	if request.Id != strconv.FormatUint(s.span.GetId(), 10) {
+		return nil, status.Errorf(codes.NotFound, "span %s not found", request.Id)
+	}
+
+	return &borproto.QuerySpanByIdResponse{Span: s.span}, nil
+}
+
+type standInCheckpoint struct {
+	checkpointproto.UnimplementedQueryServer
+	checkpoints []*checkpointproto.Checkpoint
+}
+
+func (s *standInCheckpoint) GetAckCount(context.Context, *checkpointproto.QueryAckCountRequest) (*checkpointproto.QueryAckCountResponse, error) {
+	return &checkpointproto.QueryAckCountResponse{AckCount: uint64(len(s.checkpoints))}, nil
+}
+
+func (s *standInCheckpoint) GetCheckpoint(_ context.Context, request *checkpointproto.QueryCheckpointRequest) (*checkpointproto.QueryCheckpointResponse, error) {
+	if request.Number == 0 || request.Number > uint64(len(s.checkpoints)) {
+		return nil, status.Errorf(codes.NotFound, "checkpoint %d not found", request.Number)
+	}
+
+	return &checkpointproto.QueryCheckpointResponse{Checkpoint: s.checkpoints[request.Number-1]}, nil
+}
+
+type standInClerk struct {
+	clerkproto.UnimplementedQueryServer
+	records []*clerkproto.EventRecord
+}
+
+func (s *standInClerk) GetRecordListWithTime(_ context.Context, request *clerkproto.RecordListWithTimeRequest) (*clerkproto.RecordListWithTimeResponse, error) {
+	response := &clerkproto.RecordListWithTimeResponse{}
+	for _, record := range s.records {
+		if uint64(len(response.EventRecords)) == request.Pagination.GetLimit() {
+			break
+		}
+
+		if record.Id >= request.FromId && !record.RecordTime.AsTime().After(request.ToTime.AsTime()) {
+			response.EventRecords = append(response.EventRecords, record)
+		}
+	}
+
+	return response, nil
+}
+
+type standInNode struct {
+	tendermintproto.UnimplementedServiceServer
+	blockTime time.Time
+	syncing   atomic.Bool
+}
+
+func (s *standInNode) GetLatestBlock(context.Context, *tendermintproto.GetLatestBlockRequest) (*tendermintproto.GetLatestBlockResponse, error) {
+	return &tendermintproto.GetLatestBlockResponse{
+		BlockId:  &tendermintproto.BlockID{Hash: []byte{1, 2}},
+		SdkBlock: &tendermintproto.Block{Header: &tendermintproto.Header{Height: 100, Time: timestamppb.New(s.blockTime)}},
+	}, nil
+}
+
+func (s *standInNode) GetSyncing(context.Context, *tendermintproto.GetSyncingRequest) (*tendermintproto.GetSyncingResponse, error) {
+	return &tendermintproto.GetSyncingResponse{Syncing: s.syncing.Load()}, nil
+}
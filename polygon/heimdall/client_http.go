@@ -56,6 +56,7 @@ var (
 		ErrCloudflareAccessNoApp,
 		ErrOperationTimeout,
 		ErrNoHost,
+		ErrAllEndpointsFailed,
 		context.DeadlineExceeded,
 	}
 )
@@ -598,14 +599,16 @@ func (c *HttpClient) FetchFirstMilestoneNum(ctx context.Context) (int64, error)
 		return 0, err
 	}
 
-	var first int64
+	return firstMilestoneNum(count), nil
+}
+
+// firstMilestoneNum returns the first milestone heimdall keeps, older ones are pruned
+func firstMilestoneNum(count int64) int64 {
 	if count < milestonePruneNumber {
-		first = 1
-	} else {
-		first = count - milestonePruneNumber + 1
+		return 1
 	}
 
-	return first, nil
+	return count - milestonePruneNumber + 1
 }
 
 // FetchLastNoAckMilestone fetches the last no-ack-milestone from heimdall
@@ -0,0 +1,478 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: heimdallv2/bor/query.proto
+
+package borproto
+
+import (
+	queryproto "github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/queryproto"
+	stakeproto "github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/stakeproto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Span struct {
+	state             protoimpl.MessageState   `protogen:"open.v1"`
+	Id                uint64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartBlock        uint64                   `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock          uint64                   `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	ValidatorSet      *stakeproto.ValidatorSet `protobuf:"bytes,4,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
+	SelectedProducers []*stakeproto.Validator  `protobuf:"bytes,5,rep,name=selected_producers,json=selectedProducers,proto3" json:"selected_producers,omitempty"`
+	BorChainId        string                   `protobuf:"bytes,6,opt,name=bor_chain_id,json=borChainId,proto3" json:"bor_chain_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Span) Reset() {
+	*x = Span{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Span) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Span) ProtoMessage() {}
+
+func (x *Span) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Span.ProtoReflect.Descriptor instead.
+func (*Span) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Span) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Span) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
+	return 0
+}
+
+func (x *Span) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+	return 0
+}
+
+func (x *Span) GetValidatorSet() *stakeproto.ValidatorSet {
+	if x != nil {
+		return x.ValidatorSet
+	}
+	return nil
+}
+
+func (x *Span) GetSelectedProducers() []*stakeproto.Validator {
+	if x != nil {
+		return x.SelectedProducers
+	}
+	return nil
+}
+
+func (x *Span) GetBorChainId() string {
+	if x != nil {
+		return x.BorChainId
+	}
+	return ""
+}
+
+type QuerySpanListRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Pagination    *queryproto.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySpanListRequest) Reset() {
+	*x = QuerySpanListRequest{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySpanListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySpanListRequest) ProtoMessage() {}
+
+func (x *QuerySpanListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySpanListRequest.ProtoReflect.Descriptor instead.
+func (*QuerySpanListRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuerySpanListRequest) GetPagination() *queryproto.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type QuerySpanListResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	SpanList      []*Span                  `protobuf:"bytes,1,rep,name=span_list,json=spanList,proto3" json:"span_list,omitempty"`
+	Pagination    *queryproto.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySpanListResponse) Reset() {
+	*x = QuerySpanListResponse{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySpanListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySpanListResponse) ProtoMessage() {}
+
+func (x *QuerySpanListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySpanListResponse.ProtoReflect.Descriptor instead.
+func (*QuerySpanListResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuerySpanListResponse) GetSpanList() []*Span {
+	if x != nil {
+		return x.SpanList
+	}
+	return nil
+}
+
+func (x *QuerySpanListResponse) GetPagination() *queryproto.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type QueryLatestSpanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLatestSpanRequest) Reset() {
+	*x = QueryLatestSpanRequest{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLatestSpanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLatestSpanRequest) ProtoMessage() {}
+
+func (x *QueryLatestSpanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLatestSpanRequest.ProtoReflect.Descriptor instead.
+func (*QueryLatestSpanRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{3}
+}
+
+type QueryLatestSpanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Span          *Span                  `protobuf:"bytes,1,opt,name=span,proto3" json:"span,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLatestSpanResponse) Reset() {
+	*x = QueryLatestSpanResponse{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLatestSpanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLatestSpanResponse) ProtoMessage() {}
+
+func (x *QueryLatestSpanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLatestSpanResponse.ProtoReflect.Descriptor instead.
+func (*QueryLatestSpanResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryLatestSpanResponse) GetSpan() *Span {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+type QuerySpanByIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySpanByIdRequest) Reset() {
+	*x = QuerySpanByIdRequest{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySpanByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySpanByIdRequest) ProtoMessage() {}
+
+func (x *QuerySpanByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySpanByIdRequest.ProtoReflect.Descriptor instead.
+func (*QuerySpanByIdRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QuerySpanByIdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type QuerySpanByIdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Span          *Span                  `protobuf:"bytes,1,opt,name=span,proto3" json:"span,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySpanByIdResponse) Reset() {
+	*x = QuerySpanByIdResponse{}
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySpanByIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySpanByIdResponse) ProtoMessage() {}
+
+func (x *QuerySpanByIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_bor_query_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySpanByIdResponse.ProtoReflect.Descriptor instead.
+func (*QuerySpanByIdResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_bor_query_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QuerySpanByIdResponse) GetSpan() *Span {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+var File_heimdallv2_bor_query_proto protoreflect.FileDescriptor
+
+const file_heimdallv2_bor_query_proto_rawDesc = "" +
+	"\n" +
+	"\x1aheimdallv2/bor/query.proto\x12\x0eheimdallv2.bor\x1a*cosmos/base/query/v1beta1/pagination.proto\x1a heimdallv2/stake/validator.proto\"\x87\x02\n" +
+	"\x04Span\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1f\n" +
+	"\vstart_block\x18\x02 \x01(\x04R\n" +
+	"startBlock\x12\x1b\n" +
+	"\tend_block\x18\x03 \x01(\x04R\bendBlock\x12C\n" +
+	"\rvalidator_set\x18\x04 \x01(\v2\x1e.heimdallv2.stake.ValidatorSetR\fvalidatorSet\x12J\n" +
+	"\x12selected_producers\x18\x05 \x03(\v2\x1b.heimdallv2.stake.ValidatorR\x11selectedProducers\x12 \n" +
+	"\fbor_chain_id\x18\x06 \x01(\tR\n" +
+	"borChainId\"^\n" +
+	"\x14QuerySpanListRequest\x12F\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2&.cosmos.base.query.v1beta1.PageRequestR\n" +
+	"pagination\"\x93\x01\n" +
+	"\x15QuerySpanListResponse\x121\n" +
+	"\tspan_list\x18\x01 \x03(\v2\x14.heimdallv2.bor.SpanR\bspanList\x12G\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2'.cosmos.base.query.v1beta1.PageResponseR\n" +
+	"pagination\"\x18\n" +
+	"\x16QueryLatestSpanRequest\"C\n" +
+	"\x17QueryLatestSpanResponse\x12(\n" +
+	"\x04span\x18\x01 \x01(\v2\x14.heimdallv2.bor.SpanR\x04span\"&\n" +
+	"\x14QuerySpanByIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"A\n" +
+	"\x15QuerySpanByIdResponse\x12(\n" +
+	"\x04span\x18\x01 \x01(\v2\x14.heimdallv2.bor.SpanR\x04span2\xa1\x02\n" +
+	"\x05Query\x12Z\n" +
+	"\vGetSpanList\x12$.heimdallv2.bor.QuerySpanListRequest\x1a%.heimdallv2.bor.QuerySpanListResponse\x12`\n" +
+	"\rGetLatestSpan\x12&.heimdallv2.bor.QueryLatestSpanRequest\x1a'.heimdallv2.bor.QueryLatestSpanResponse\x12Z\n" +
+	"\vGetSpanById\x12$.heimdallv2.bor.QuerySpanByIdRequest\x1a%.heimdallv2.bor.QuerySpanByIdResponseBQZOgithub.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/borproto;borprotob\x06proto3"
+
+var (
+	file_heimdallv2_bor_query_proto_rawDescOnce sync.Once
+	file_heimdallv2_bor_query_proto_rawDescData []byte
+)
+
+func file_heimdallv2_bor_query_proto_rawDescGZIP() []byte {
+	file_heimdallv2_bor_query_proto_rawDescOnce.Do(func() {
+		file_heimdallv2_bor_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_heimdallv2_bor_query_proto_rawDesc), len(file_heimdallv2_bor_query_proto_rawDesc)))
+	})
+	return file_heimdallv2_bor_query_proto_rawDescData
+}
+
+var file_heimdallv2_bor_query_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_heimdallv2_bor_query_proto_goTypes = []any{
+	(*Span)(nil),                    // 0: heimdallv2.bor.Span
+	(*QuerySpanListRequest)(nil),    // 1: heimdallv2.bor.QuerySpanListRequest
+	(*QuerySpanListResponse)(nil),   // 2: heimdallv2.bor.QuerySpanListResponse
+	(*QueryLatestSpanRequest)(nil),  // 3: heimdallv2.bor.QueryLatestSpanRequest
+	(*QueryLatestSpanResponse)(nil), // 4: heimdallv2.bor.QueryLatestSpanResponse
+	(*QuerySpanByIdRequest)(nil),    // 5: heimdallv2.bor.QuerySpanByIdRequest
+	(*QuerySpanByIdResponse)(nil),   // 6: heimdallv2.bor.QuerySpanByIdResponse
+	(*stakeproto.ValidatorSet)(nil), // 7: heimdallv2.stake.ValidatorSet
+	(*stakeproto.Validator)(nil),    // 8: heimdallv2.stake.Validator
+	(*queryproto.PageRequest)(nil),  // 9: cosmos.base.query.v1beta1.PageRequest
+	(*queryproto.PageResponse)(nil), // 10: cosmos.base.query.v1beta1.PageResponse
+}
+var file_heimdallv2_bor_query_proto_depIdxs = []int32{
+	7,  // 0: heimdallv2.bor.Span.validator_set:type_name -> heimdallv2.stake.ValidatorSet
+	8,  // 1: heimdallv2.bor.Span.selected_producers:type_name -> heimdallv2.stake.Validator
+	9,  // 2: heimdallv2.bor.QuerySpanListRequest.pagination:type_name -> cosmos.base.query.v1beta1.PageRequest
+	0,  // 3: heimdallv2.bor.QuerySpanListResponse.span_list:type_name -> heimdallv2.bor.Span
+	10, // 4: heimdallv2.bor.QuerySpanListResponse.pagination:type_name -> cosmos.base.query.v1beta1.PageResponse
+	0,  // 5: heimdallv2.bor.QueryLatestSpanResponse.span:type_name -> heimdallv2.bor.Span
+	0,  // 6: heimdallv2.bor.QuerySpanByIdResponse.span:type_name -> heimdallv2.bor.Span
+	1,  // 7: heimdallv2.bor.Query.GetSpanList:input_type -> heimdallv2.bor.QuerySpanListRequest
+	3,  // 8: heimdallv2.bor.Query.GetLatestSpan:input_type -> heimdallv2.bor.QueryLatestSpanRequest
+	5,  // 9: heimdallv2.bor.Query.GetSpanById:input_type -> heimdallv2.bor.QuerySpanByIdRequest
+	2,  // 10: heimdallv2.bor.Query.GetSpanList:output_type -> heimdallv2.bor.QuerySpanListResponse
+	4,  // 11: heimdallv2.bor.Query.GetLatestSpan:output_type -> heimdallv2.bor.QueryLatestSpanResponse
+	6,  // 12: heimdallv2.bor.Query.GetSpanById:output_type -> heimdallv2.bor.QuerySpanByIdResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_heimdallv2_bor_query_proto_init() }
+func file_heimdallv2_bor_query_proto_init() {
+	if File_heimdallv2_bor_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_heimdallv2_bor_query_proto_rawDesc), len(file_heimdallv2_bor_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_heimdallv2_bor_query_proto_goTypes,
+		DependencyIndexes: file_heimdallv2_bor_query_proto_depIdxs,
+		MessageInfos:      file_heimdallv2_bor_query_proto_msgTypes,
+	}.Build()
+	File_heimdallv2_bor_query_proto = out.File
+	file_heimdallv2_bor_query_proto_goTypes = nil
+	file_heimdallv2_bor_query_proto_depIdxs = nil
+}
@@ -0,0 +1,351 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: heimdallv2/chainmanager/query.proto
+
+package chainmanagerproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChainParams struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	BorChainId            string                 `protobuf:"bytes,1,opt,name=bor_chain_id,json=borChainId,proto3" json:"bor_chain_id,omitempty"`
+	HeimdallChainId       string                 `protobuf:"bytes,2,opt,name=heimdall_chain_id,json=heimdallChainId,proto3" json:"heimdall_chain_id,omitempty"`
+	PolTokenAddress       string                 `protobuf:"bytes,3,opt,name=pol_token_address,json=polTokenAddress,proto3" json:"pol_token_address,omitempty"`
+	StakingManagerAddress string                 `protobuf:"bytes,4,opt,name=staking_manager_address,json=stakingManagerAddress,proto3" json:"staking_manager_address,omitempty"`
+	SlashManagerAddress   string                 `protobuf:"bytes,5,opt,name=slash_manager_address,json=slashManagerAddress,proto3" json:"slash_manager_address,omitempty"`
+	RootChainAddress      string                 `protobuf:"bytes,6,opt,name=root_chain_address,json=rootChainAddress,proto3" json:"root_chain_address,omitempty"`
+	StakingInfoAddress    string                 `protobuf:"bytes,7,opt,name=staking_info_address,json=stakingInfoAddress,proto3" json:"staking_info_address,omitempty"`
+	StateSenderAddress    string                 `protobuf:"bytes,8,opt,name=state_sender_address,json=stateSenderAddress,proto3" json:"state_sender_address,omitempty"`
+	StateReceiverAddress  string                 `protobuf:"bytes,9,opt,name=state_receiver_address,json=stateReceiverAddress,proto3" json:"state_receiver_address,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ChainParams) Reset() {
+	*x = ChainParams{}
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChainParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainParams) ProtoMessage() {}
+
+func (x *ChainParams) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainParams.ProtoReflect.Descriptor instead.
+func (*ChainParams) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_chainmanager_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChainParams) GetBorChainId() string {
+	if x != nil {
+		return x.BorChainId
+	}
+	return ""
+}
+
+func (x *ChainParams) GetHeimdallChainId() string {
+	if x != nil {
+		return x.HeimdallChainId
+	}
+	return ""
+}
+
+func (x *ChainParams) GetPolTokenAddress() string {
+	if x != nil {
+		return x.PolTokenAddress
+	}
+	return ""
+}
+
+func (x *ChainParams) GetStakingManagerAddress() string {
+	if x != nil {
+		return x.StakingManagerAddress
+	}
+	return ""
+}
+
+func (x *ChainParams) GetSlashManagerAddress() string {
+	if x != nil {
+		return x.SlashManagerAddress
+	}
+	return ""
+}
+
+func (x *ChainParams) GetRootChainAddress() string {
+	if x != nil {
+		return x.RootChainAddress
+	}
+	return ""
+}
+
+func (x *ChainParams) GetStakingInfoAddress() string {
+	if x != nil {
+		return x.StakingInfoAddress
+	}
+	return ""
+}
+
+func (x *ChainParams) GetStateSenderAddress() string {
+	if x != nil {
+		return x.StateSenderAddress
+	}
+	return ""
+}
+
+func (x *ChainParams) GetStateReceiverAddress() string {
+	if x != nil {
+		return x.StateReceiverAddress
+	}
+	return ""
+}
+
+type Params struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	ChainParams              *ChainParams           `protobuf:"bytes,1,opt,name=chain_params,json=chainParams,proto3" json:"chain_params,omitempty"`
+	MainChainTxConfirmations uint64                 `protobuf:"varint,2,opt,name=main_chain_tx_confirmations,json=mainChainTxConfirmations,proto3" json:"main_chain_tx_confirmations,omitempty"`
+	BorChainTxConfirmations  uint64                 `protobuf:"varint,3,opt,name=bor_chain_tx_confirmations,json=borChainTxConfirmations,proto3" json:"bor_chain_tx_confirmations,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Params) Reset() {
+	*x = Params{}
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Params) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Params) ProtoMessage() {}
+
+func (x *Params) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Params.ProtoReflect.Descriptor instead.
+func (*Params) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_chainmanager_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Params) GetChainParams() *ChainParams {
+	if x != nil {
+		return x.ChainParams
+	}
+	return nil
+}
+
+func (x *Params) GetMainChainTxConfirmations() uint64 {
+	if x != nil {
+		return x.MainChainTxConfirmations
+	}
+	return 0
+}
+
+func (x *Params) GetBorChainTxConfirmations() uint64 {
+	if x != nil {
+		return x.BorChainTxConfirmations
+	}
+	return 0
+}
+
+type QueryParamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryParamsRequest) Reset() {
+	*x = QueryParamsRequest{}
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryParamsRequest) ProtoMessage() {}
+
+func (x *QueryParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryParamsRequest.ProtoReflect.Descriptor instead.
+func (*QueryParamsRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_chainmanager_query_proto_rawDescGZIP(), []int{2}
+}
+
+type QueryParamsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Params        *Params                `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryParamsResponse) Reset() {
+	*x = QueryParamsResponse{}
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryParamsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryParamsResponse) ProtoMessage() {}
+
+func (x *QueryParamsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_chainmanager_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryParamsResponse.ProtoReflect.Descriptor instead.
+func (*QueryParamsResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_chainmanager_query_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QueryParamsResponse) GetParams() *Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+var File_heimdallv2_chainmanager_query_proto protoreflect.FileDescriptor
+
+const file_heimdallv2_chainmanager_query_proto_rawDesc = "" +
+	"\n" +
+	"#heimdallv2/chainmanager/query.proto\x12\x17heimdallv2.chainmanager\"\xbb\x03\n" +
+	"\vChainParams\x12 \n" +
+	"\fbor_chain_id\x18\x01 \x01(\tR\n" +
+	"borChainId\x12*\n" +
+	"\x11heimdall_chain_id\x18\x02 \x01(\tR\x0fheimdallChainId\x12*\n" +
+	"\x11pol_token_address\x18\x03 \x01(\tR\x0fpolTokenAddress\x126\n" +
+	"\x17staking_manager_address\x18\x04 \x01(\tR\x15stakingManagerAddress\x122\n" +
+	"\x15slash_manager_address\x18\x05 \x01(\tR\x13slashManagerAddress\x12,\n" +
+	"\x12root_chain_address\x18\x06 \x01(\tR\x10rootChainAddress\x120\n" +
+	"\x14staking_info_address\x18\a \x01(\tR\x12stakingInfoAddress\x120\n" +
+	"\x14state_sender_address\x18\b \x01(\tR\x12stateSenderAddress\x124\n" +
+	"\x16state_receiver_address\x18\t \x01(\tR\x14stateReceiverAddress\"\xcd\x01\n" +
+	"\x06Params\x12G\n" +
+	"\fchain_params\x18\x01 \x01(\v2$.heimdallv2.chainmanager.ChainParamsR\vchainParams\x12=\n" +
+	"\x1bmain_chain_tx_confirmations\x18\x02 \x01(\x04R\x18mainChainTxConfirmations\x12;\n" +
+	"\x1abor_chain_tx_confirmations\x18\x03 \x01(\x04R\x17borChainTxConfirmations\"\x14\n" +
+	"\x12QueryParamsRequest\"N\n" +
+	"\x13QueryParamsResponse\x127\n" +
+	"\x06params\x18\x01 \x01(\v2\x1f.heimdallv2.chainmanager.ParamsR\x06params2{\n" +
+	"\x05Query\x12r\n" +
+	"\x15GetChainManagerParams\x12+.heimdallv2.chainmanager.QueryParamsRequest\x1a,.heimdallv2.chainmanager.QueryParamsResponseBcZagithub.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/chainmanagerproto;chainmanagerprotob\x06proto3"
+
+var (
+	file_heimdallv2_chainmanager_query_proto_rawDescOnce sync.Once
+	file_heimdallv2_chainmanager_query_proto_rawDescData []byte
+)
+
+func file_heimdallv2_chainmanager_query_proto_rawDescGZIP() []byte {
+	file_heimdallv2_chainmanager_query_proto_rawDescOnce.Do(func() {
+		file_heimdallv2_chainmanager_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_heimdallv2_chainmanager_query_proto_rawDesc), len(file_heimdallv2_chainmanager_query_proto_rawDesc)))
+	})
+	return file_heimdallv2_chainmanager_query_proto_rawDescData
+}
+
+var file_heimdallv2_chainmanager_query_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_heimdallv2_chainmanager_query_proto_goTypes = []any{
+	(*ChainParams)(nil),         // 0: heimdallv2.chainmanager.ChainParams
+	(*Params)(nil),              // 1: heimdallv2.chainmanager.Params
+	(*QueryParamsRequest)(nil),  // 2: heimdallv2.chainmanager.QueryParamsRequest
+	(*QueryParamsResponse)(nil), // 3: heimdallv2.chainmanager.QueryParamsResponse
+}
+var file_heimdallv2_chainmanager_query_proto_depIdxs = []int32{
+	0, // 0: heimdallv2.chainmanager.Params.chain_params:type_name -> heimdallv2.chainmanager.ChainParams
+	1, // 1: heimdallv2.chainmanager.QueryParamsResponse.params:type_name -> heimdallv2.chainmanager.Params
+	2, // 2: heimdallv2.chainmanager.Query.GetChainManagerParams:input_type -> heimdallv2.chainmanager.QueryParamsRequest
+	3, // 3: heimdallv2.chainmanager.Query.GetChainManagerParams:output_type -> heimdallv2.chainmanager.QueryParamsResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_heimdallv2_chainmanager_query_proto_init() }
+func file_heimdallv2_chainmanager_query_proto_init() {
+	if File_heimdallv2_chainmanager_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_heimdallv2_chainmanager_query_proto_rawDesc), len(file_heimdallv2_chainmanager_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_heimdallv2_chainmanager_query_proto_goTypes,
+		DependencyIndexes: file_heimdallv2_chainmanager_query_proto_depIdxs,
+		MessageInfos:      file_heimdallv2_chainmanager_query_proto_msgTypes,
+	}.Build()
+	File_heimdallv2_chainmanager_query_proto = out.File
+	file_heimdallv2_chainmanager_query_proto_goTypes = nil
+	file_heimdallv2_chainmanager_query_proto_depIdxs = nil
+}
@@ -0,0 +1,575 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: heimdallv2/checkpoint/query.proto
+
+package checkpointproto
+
+import (
+	queryproto "github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/queryproto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Checkpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Proposer      string                 `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	StartBlock    uint64                 `protobuf:"varint,3,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock      uint64                 `protobuf:"varint,4,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	RootHash      []byte                 `protobuf:"bytes,5,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	BorChainId    string                 `protobuf:"bytes,6,opt,name=bor_chain_id,json=borChainId,proto3" json:"bor_chain_id,omitempty"`
+	Timestamp     uint64                 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Checkpoint) Reset() {
+	*x = Checkpoint{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Checkpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checkpoint) ProtoMessage() {}
+
+func (x *Checkpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checkpoint.ProtoReflect.Descriptor instead.
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Checkpoint) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *Checkpoint) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+	return 0
+}
+
+func (x *Checkpoint) GetRootHash() []byte {
+	if x != nil {
+		return x.RootHash
+	}
+	return nil
+}
+
+func (x *Checkpoint) GetBorChainId() string {
+	if x != nil {
+		return x.BorChainId
+	}
+	return ""
+}
+
+func (x *Checkpoint) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type QueryAckCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAckCountRequest) Reset() {
+	*x = QueryAckCountRequest{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAckCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAckCountRequest) ProtoMessage() {}
+
+func (x *QueryAckCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAckCountRequest.ProtoReflect.Descriptor instead.
+func (*QueryAckCountRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{1}
+}
+
+type QueryAckCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AckCount      uint64                 `protobuf:"varint,1,opt,name=ack_count,json=ackCount,proto3" json:"ack_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAckCountResponse) Reset() {
+	*x = QueryAckCountResponse{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAckCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAckCountResponse) ProtoMessage() {}
+
+func (x *QueryAckCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAckCountResponse.ProtoReflect.Descriptor instead.
+func (*QueryAckCountResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QueryAckCountResponse) GetAckCount() uint64 {
+	if x != nil {
+		return x.AckCount
+	}
+	return 0
+}
+
+type QueryCheckpointLatestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCheckpointLatestRequest) Reset() {
+	*x = QueryCheckpointLatestRequest{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCheckpointLatestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCheckpointLatestRequest) ProtoMessage() {}
+
+func (x *QueryCheckpointLatestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCheckpointLatestRequest.ProtoReflect.Descriptor instead.
+func (*QueryCheckpointLatestRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{3}
+}
+
+type QueryCheckpointLatestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checkpoint    *Checkpoint            `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCheckpointLatestResponse) Reset() {
+	*x = QueryCheckpointLatestResponse{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCheckpointLatestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCheckpointLatestResponse) ProtoMessage() {}
+
+func (x *QueryCheckpointLatestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCheckpointLatestResponse.ProtoReflect.Descriptor instead.
+func (*QueryCheckpointLatestResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryCheckpointLatestResponse) GetCheckpoint() *Checkpoint {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return nil
+}
+
+type QueryCheckpointListRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Pagination    *queryproto.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCheckpointListRequest) Reset() {
+	*x = QueryCheckpointListRequest{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCheckpointListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCheckpointListRequest) ProtoMessage() {}
+
+func (x *QueryCheckpointListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCheckpointListRequest.ProtoReflect.Descriptor instead.
+func (*QueryCheckpointListRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryCheckpointListRequest) GetPagination() *queryproto.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type QueryCheckpointListResponse struct {
+	state          protoimpl.MessageState   `protogen:"open.v1"`
+	CheckpointList []*Checkpoint            `protobuf:"bytes,1,rep,name=checkpoint_list,json=checkpointList,proto3" json:"checkpoint_list,omitempty"`
+	Pagination     *queryproto.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QueryCheckpointListResponse) Reset() {
+	*x = QueryCheckpointListResponse{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCheckpointListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCheckpointListResponse) ProtoMessage() {}
+
+func (x *QueryCheckpointListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCheckpointListResponse.ProtoReflect.Descriptor instead.
+func (*QueryCheckpointListResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueryCheckpointListResponse) GetCheckpointList() []*Checkpoint {
+	if x != nil {
+		return x.CheckpointList
+	}
+	return nil
+}
+
+func (x *QueryCheckpointListResponse) GetPagination() *queryproto.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type QueryCheckpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        uint64                 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCheckpointRequest) Reset() {
+	*x = QueryCheckpointRequest{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCheckpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCheckpointRequest) ProtoMessage() {}
+
+func (x *QueryCheckpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCheckpointRequest.ProtoReflect.Descriptor instead.
+func (*QueryCheckpointRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QueryCheckpointRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type QueryCheckpointResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checkpoint    *Checkpoint            `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCheckpointResponse) Reset() {
+	*x = QueryCheckpointResponse{}
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCheckpointResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCheckpointResponse) ProtoMessage() {}
+
+func (x *QueryCheckpointResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_checkpoint_query_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCheckpointResponse.ProtoReflect.Descriptor instead.
+func (*QueryCheckpointResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_checkpoint_query_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QueryCheckpointResponse) GetCheckpoint() *Checkpoint {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return nil
+}
+
+var File_heimdallv2_checkpoint_query_proto protoreflect.FileDescriptor
+
+const file_heimdallv2_checkpoint_query_proto_rawDesc = "" +
+	"\n" +
+	"!heimdallv2/checkpoint/query.proto\x12\x15heimdallv2.checkpoint\x1a*cosmos/base/query/v1beta1/pagination.proto\"\xd3\x01\n" +
+	"\n" +
+	"Checkpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
+	"\bproposer\x18\x02 \x01(\tR\bproposer\x12\x1f\n" +
+	"\vstart_block\x18\x03 \x01(\x04R\n" +
+	"startBlock\x12\x1b\n" +
+	"\tend_block\x18\x04 \x01(\x04R\bendBlock\x12\x1b\n" +
+	"\troot_hash\x18\x05 \x01(\fR\brootHash\x12 \n" +
+	"\fbor_chain_id\x18\x06 \x01(\tR\n" +
+	"borChainId\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x04R\ttimestamp\"\x16\n" +
+	"\x14QueryAckCountRequest\"4\n" +
+	"\x15QueryAckCountResponse\x12\x1b\n" +
+	"\tack_count\x18\x01 \x01(\x04R\backCount\"\x1e\n" +
+	"\x1cQueryCheckpointLatestRequest\"b\n" +
+	"\x1dQueryCheckpointLatestResponse\x12A\n" +
+	"\n" +
+	"checkpoint\x18\x01 \x01(\v2!.heimdallv2.checkpoint.CheckpointR\n" +
+	"checkpoint\"d\n" +
+	"\x1aQueryCheckpointListRequest\x12F\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2&.cosmos.base.query.v1beta1.PageRequestR\n" +
+	"pagination\"\xb2\x01\n" +
+	"\x1bQueryCheckpointListResponse\x12J\n" +
+	"\x0fcheckpoint_list\x18\x01 \x03(\v2!.heimdallv2.checkpoint.CheckpointR\x0echeckpointList\x12G\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2'.cosmos.base.query.v1beta1.PageResponseR\n" +
+	"pagination\"0\n" +
+	"\x16QueryCheckpointRequest\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\"\\\n" +
+	"\x17QueryCheckpointResponse\x12A\n" +
+	"\n" +
+	"checkpoint\x18\x01 \x01(\v2!.heimdallv2.checkpoint.CheckpointR\n" +
+	"checkpoint2\xe0\x03\n" +
+	"\x05Query\x12h\n" +
+	"\vGetAckCount\x12+.heimdallv2.checkpoint.QueryAckCountRequest\x1a,.heimdallv2.checkpoint.QueryAckCountResponse\x12\x80\x01\n" +
+	"\x13GetCheckpointLatest\x123.heimdallv2.checkpoint.QueryCheckpointLatestRequest\x1a4.heimdallv2.checkpoint.QueryCheckpointLatestResponse\x12z\n" +
+	"\x11GetCheckpointList\x121.heimdallv2.checkpoint.QueryCheckpointListRequest\x1a2.heimdallv2.checkpoint.QueryCheckpointListResponse\x12n\n" +
+	"\rGetCheckpoint\x12-.heimdallv2.checkpoint.QueryCheckpointRequest\x1a..heimdallv2.checkpoint.QueryCheckpointResponseB_Z]github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/checkpointproto;checkpointprotob\x06proto3"
+
+var (
+	file_heimdallv2_checkpoint_query_proto_rawDescOnce sync.Once
+	file_heimdallv2_checkpoint_query_proto_rawDescData []byte
+)
+
+func file_heimdallv2_checkpoint_query_proto_rawDescGZIP() []byte {
+	file_heimdallv2_checkpoint_query_proto_rawDescOnce.Do(func() {
+		file_heimdallv2_checkpoint_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_heimdallv2_checkpoint_query_proto_rawDesc), len(file_heimdallv2_checkpoint_query_proto_rawDesc)))
+	})
+	return file_heimdallv2_checkpoint_query_proto_rawDescData
+}
+
+var file_heimdallv2_checkpoint_query_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_heimdallv2_checkpoint_query_proto_goTypes = []any{
+	(*Checkpoint)(nil),                    // 0: heimdallv2.checkpoint.Checkpoint
+	(*QueryAckCountRequest)(nil),          // 1: heimdallv2.checkpoint.QueryAckCountRequest
+	(*QueryAckCountResponse)(nil),         // 2: heimdallv2.checkpoint.QueryAckCountResponse
+	(*QueryCheckpointLatestRequest)(nil),  // 3: heimdallv2.checkpoint.QueryCheckpointLatestRequest
+	(*QueryCheckpointLatestResponse)(nil), // 4: heimdallv2.checkpoint.QueryCheckpointLatestResponse
+	(*QueryCheckpointListRequest)(nil),    // 5: heimdallv2.checkpoint.QueryCheckpointListRequest
+	(*QueryCheckpointListResponse)(nil),   // 6: heimdallv2.checkpoint.QueryCheckpointListResponse
+	(*QueryCheckpointRequest)(nil),        // 7: heimdallv2.checkpoint.QueryCheckpointRequest
+	(*QueryCheckpointResponse)(nil),       // 8: heimdallv2.checkpoint.QueryCheckpointResponse
+	(*queryproto.PageRequest)(nil),        // 9: cosmos.base.query.v1beta1.PageRequest
+	(*queryproto.PageResponse)(nil),       // 10: cosmos.base.query.v1beta1.PageResponse
+}
+var file_heimdallv2_checkpoint_query_proto_depIdxs = []int32{
+	0,  // 0: heimdallv2.checkpoint.QueryCheckpointLatestResponse.checkpoint:type_name -> heimdallv2.checkpoint.Checkpoint
+	9,  // 1: heimdallv2.checkpoint.QueryCheckpointListRequest.pagination:type_name -> cosmos.base.query.v1beta1.PageRequest
+	0,  // 2: heimdallv2.checkpoint.QueryCheckpointListResponse.checkpoint_list:type_name -> heimdallv2.checkpoint.Checkpoint
+	10, // 3: heimdallv2.checkpoint.QueryCheckpointListResponse.pagination:type_name -> cosmos.base.query.v1beta1.PageResponse
+	0,  // 4: heimdallv2.checkpoint.QueryCheckpointResponse.checkpoint:type_name -> heimdallv2.checkpoint.Checkpoint
+	1,  // 5: heimdallv2.checkpoint.Query.GetAckCount:input_type -> heimdallv2.checkpoint.QueryAckCountRequest
+	3,  // 6: heimdallv2.checkpoint.Query.GetCheckpointLatest:input_type -> heimdallv2.checkpoint.QueryCheckpointLatestRequest
+	5,  // 7: heimdallv2.checkpoint.Query.GetCheckpointList:input_type -> heimdallv2.checkpoint.QueryCheckpointListRequest
+	7,  // 8: heimdallv2.checkpoint.Query.GetCheckpoint:input_type -> heimdallv2.checkpoint.QueryCheckpointRequest
+	2,  // 9: heimdallv2.checkpoint.Query.GetAckCount:output_type -> heimdallv2.checkpoint.QueryAckCountResponse
+	4,  // 10: heimdallv2.checkpoint.Query.GetCheckpointLatest:output_type -> heimdallv2.checkpoint.QueryCheckpointLatestResponse
+	6,  // 11: heimdallv2.checkpoint.Query.GetCheckpointList:output_type -> heimdallv2.checkpoint.QueryCheckpointListResponse
+	8,  // 12: heimdallv2.checkpoint.Query.GetCheckpoint:output_type -> heimdallv2.checkpoint.QueryCheckpointResponse
+	9,  // [9:13] is the sub-list for method output_type
+	5,  // [5:9] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_heimdallv2_checkpoint_query_proto_init() }
+func file_heimdallv2_checkpoint_query_proto_init() {
+	if File_heimdallv2_checkpoint_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_heimdallv2_checkpoint_query_proto_rawDesc), len(file_heimdallv2_checkpoint_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_heimdallv2_checkpoint_query_proto_goTypes,
+		DependencyIndexes: file_heimdallv2_checkpoint_query_proto_depIdxs,
+		MessageInfos:      file_heimdallv2_checkpoint_query_proto_msgTypes,
+	}.Build()
+	File_heimdallv2_checkpoint_query_proto = out.File
+	file_heimdallv2_checkpoint_query_proto_goTypes = nil
+	file_heimdallv2_checkpoint_query_proto_depIdxs = nil
+}
@@ -0,0 +1,317 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: heimdallv2/clerk/query.proto
+
+package clerkproto
+
+import (
+	queryproto "github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/queryproto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Contract      string                 `protobuf:"bytes,2,opt,name=contract,proto3" json:"contract,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	TxHash        string                 `protobuf:"bytes,4,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	LogIndex      uint64                 `protobuf:"varint,5,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	BorChainId    string                 `protobuf:"bytes,6,opt,name=bor_chain_id,json=borChainId,proto3" json:"bor_chain_id,omitempty"`
+	RecordTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=record_time,json=recordTime,proto3" json:"record_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventRecord) Reset() {
+	*x = EventRecord{}
+	mi := &file_heimdallv2_clerk_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventRecord) ProtoMessage() {}
+
+func (x *EventRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_clerk_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventRecord.ProtoReflect.Descriptor instead.
+func (*EventRecord) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_clerk_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventRecord) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EventRecord) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *EventRecord) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EventRecord) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *EventRecord) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *EventRecord) GetBorChainId() string {
+	if x != nil {
+		return x.BorChainId
+	}
+	return ""
+}
+
+func (x *EventRecord) GetRecordTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordTime
+	}
+	return nil
+}
+
+type RecordListWithTimeRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	FromId        uint64                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToTime        *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`
+	Pagination    *queryproto.PageRequest `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordListWithTimeRequest) Reset() {
+	*x = RecordListWithTimeRequest{}
+	mi := &file_heimdallv2_clerk_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordListWithTimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordListWithTimeRequest) ProtoMessage() {}
+
+func (x *RecordListWithTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_clerk_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordListWithTimeRequest.ProtoReflect.Descriptor instead.
+func (*RecordListWithTimeRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_clerk_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecordListWithTimeRequest) GetFromId() uint64 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *RecordListWithTimeRequest) GetToTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToTime
+	}
+	return nil
+}
+
+func (x *RecordListWithTimeRequest) GetPagination() *queryproto.PageRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type RecordListWithTimeResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	EventRecords  []*EventRecord           `protobuf:"bytes,1,rep,name=event_records,json=eventRecords,proto3" json:"event_records,omitempty"`
+	Pagination    *queryproto.PageResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordListWithTimeResponse) Reset() {
+	*x = RecordListWithTimeResponse{}
+	mi := &file_heimdallv2_clerk_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordListWithTimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordListWithTimeResponse) ProtoMessage() {}
+
+func (x *RecordListWithTimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_clerk_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordListWithTimeResponse.ProtoReflect.Descriptor instead.
+func (*RecordListWithTimeResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_clerk_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecordListWithTimeResponse) GetEventRecords() []*EventRecord {
+	if x != nil {
+		return x.EventRecords
+	}
+	return nil
+}
+
+func (x *RecordListWithTimeResponse) GetPagination() *queryproto.PageResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_heimdallv2_clerk_query_proto protoreflect.FileDescriptor
+
+const file_heimdallv2_clerk_query_proto_rawDesc = "" +
+	"\n" +
+	"\x1cheimdallv2/clerk/query.proto\x12\x10heimdallv2.clerk\x1a\x1fgoogle/protobuf/timestamp.proto\x1a*cosmos/base/query/v1beta1/pagination.proto\"\xe2\x01\n" +
+	"\vEventRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
+	"\bcontract\x18\x02 \x01(\tR\bcontract\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x17\n" +
+	"\atx_hash\x18\x04 \x01(\tR\x06txHash\x12\x1b\n" +
+	"\tlog_index\x18\x05 \x01(\x04R\blogIndex\x12 \n" +
+	"\fbor_chain_id\x18\x06 \x01(\tR\n" +
+	"borChainId\x12;\n" +
+	"\vrecord_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordTime\"\xb1\x01\n" +
+	"\x19RecordListWithTimeRequest\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\x04R\x06fromId\x123\n" +
+	"\ato_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06toTime\x12F\n" +
+	"\n" +
+	"pagination\x18\x03 \x01(\v2&.cosmos.base.query.v1beta1.PageRequestR\n" +
+	"pagination\"\xa9\x01\n" +
+	"\x1aRecordListWithTimeResponse\x12B\n" +
+	"\revent_records\x18\x01 \x03(\v2\x1d.heimdallv2.clerk.EventRecordR\feventRecords\x12G\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2'.cosmos.base.query.v1beta1.PageResponseR\n" +
+	"pagination2{\n" +
+	"\x05Query\x12r\n" +
+	"\x15GetRecordListWithTime\x12+.heimdallv2.clerk.RecordListWithTimeRequest\x1a,.heimdallv2.clerk.RecordListWithTimeResponseBUZSgithub.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/clerkproto;clerkprotob\x06proto3"
+
+var (
+	file_heimdallv2_clerk_query_proto_rawDescOnce sync.Once
+	file_heimdallv2_clerk_query_proto_rawDescData []byte
+)
+
+func file_heimdallv2_clerk_query_proto_rawDescGZIP() []byte {
+	file_heimdallv2_clerk_query_proto_rawDescOnce.Do(func() {
+		file_heimdallv2_clerk_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_heimdallv2_clerk_query_proto_rawDesc), len(file_heimdallv2_clerk_query_proto_rawDesc)))
+	})
+	return file_heimdallv2_clerk_query_proto_rawDescData
+}
+
+var file_heimdallv2_clerk_query_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_heimdallv2_clerk_query_proto_goTypes = []any{
+	(*EventRecord)(nil),                // 0: heimdallv2.clerk.EventRecord
+	(*RecordListWithTimeRequest)(nil),  // 1: heimdallv2.clerk.RecordListWithTimeRequest
+	(*RecordListWithTimeResponse)(nil), // 2: heimdallv2.clerk.RecordListWithTimeResponse
+	(*timestamppb.Timestamp)(nil),      // 3: google.protobuf.Timestamp
+	(*queryproto.PageRequest)(nil),     // 4: cosmos.base.query.v1beta1.PageRequest
+	(*queryproto.PageResponse)(nil),    // 5: cosmos.base.query.v1beta1.PageResponse
+}
+var file_heimdallv2_clerk_query_proto_depIdxs = []int32{
+	3, // 0: heimdallv2.clerk.EventRecord.record_time:type_name -> google.protobuf.Timestamp
+	3, // 1: heimdallv2.clerk.RecordListWithTimeRequest.to_time:type_name -> google.protobuf.Timestamp
+	4, // 2: heimdallv2.clerk.RecordListWithTimeRequest.pagination:type_name -> cosmos.base.query.v1beta1.PageRequest
+	0, // 3: heimdallv2.clerk.RecordListWithTimeResponse.event_records:type_name -> heimdallv2.clerk.EventRecord
+	5, // 4: heimdallv2.clerk.RecordListWithTimeResponse.pagination:type_name -> cosmos.base.query.v1beta1.PageResponse
+	1, // 5: heimdallv2.clerk.Query.GetRecordListWithTime:input_type -> heimdallv2.clerk.RecordListWithTimeRequest
+	2, // 6: heimdallv2.clerk.Query.GetRecordListWithTime:output_type -> heimdallv2.clerk.RecordListWithTimeResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_heimdallv2_clerk_query_proto_init() }
+func file_heimdallv2_clerk_query_proto_init() {
+	if File_heimdallv2_clerk_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_heimdallv2_clerk_query_proto_rawDesc), len(file_heimdallv2_clerk_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_heimdallv2_clerk_query_proto_goTypes,
+		DependencyIndexes: file_heimdallv2_clerk_query_proto_depIdxs,
+		MessageInfos:      file_heimdallv2_clerk_query_proto_msgTypes,
+	}.Build()
+	File_heimdallv2_clerk_query_proto = out.File
+	file_heimdallv2_clerk_query_proto_goTypes = nil
+	file_heimdallv2_clerk_query_proto_depIdxs = nil
+}
@@ -0,0 +1,454 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: heimdallv2/milestone/query.proto
+
+package milestoneproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Milestone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proposer      string                 `protobuf:"bytes,1,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	StartBlock    uint64                 `protobuf:"varint,3,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock      uint64                 `protobuf:"varint,4,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	BorChainId    string                 `protobuf:"bytes,5,opt,name=bor_chain_id,json=borChainId,proto3" json:"bor_chain_id,omitempty"`
+	MilestoneId   string                 `protobuf:"bytes,6,opt,name=milestone_id,json=milestoneId,proto3" json:"milestone_id,omitempty"`
+	Timestamp     uint64                 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Milestone) Reset() {
+	*x = Milestone{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Milestone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Milestone) ProtoMessage() {}
+
+func (x *Milestone) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Milestone.ProtoReflect.Descriptor instead.
+func (*Milestone) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Milestone) GetProposer() string {
+	if x != nil {
+		return x.Proposer
+	}
+	return ""
+}
+
+func (x *Milestone) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Milestone) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
+	return 0
+}
+
+func (x *Milestone) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+	return 0
+}
+
+func (x *Milestone) GetBorChainId() string {
+	if x != nil {
+		return x.BorChainId
+	}
+	return ""
+}
+
+func (x *Milestone) GetMilestoneId() string {
+	if x != nil {
+		return x.MilestoneId
+	}
+	return ""
+}
+
+func (x *Milestone) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type QueryCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCountRequest) Reset() {
+	*x = QueryCountRequest{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCountRequest) ProtoMessage() {}
+
+func (x *QueryCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCountRequest.ProtoReflect.Descriptor instead.
+func (*QueryCountRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{1}
+}
+
+type QueryCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryCountResponse) Reset() {
+	*x = QueryCountResponse{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryCountResponse) ProtoMessage() {}
+
+func (x *QueryCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryCountResponse.ProtoReflect.Descriptor instead.
+func (*QueryCountResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QueryCountResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type QueryLatestMilestoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLatestMilestoneRequest) Reset() {
+	*x = QueryLatestMilestoneRequest{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLatestMilestoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLatestMilestoneRequest) ProtoMessage() {}
+
+func (x *QueryLatestMilestoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLatestMilestoneRequest.ProtoReflect.Descriptor instead.
+func (*QueryLatestMilestoneRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{3}
+}
+
+type QueryLatestMilestoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Milestone     *Milestone             `protobuf:"bytes,1,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLatestMilestoneResponse) Reset() {
+	*x = QueryLatestMilestoneResponse{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLatestMilestoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLatestMilestoneResponse) ProtoMessage() {}
+
+func (x *QueryLatestMilestoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLatestMilestoneResponse.ProtoReflect.Descriptor instead.
+func (*QueryLatestMilestoneResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryLatestMilestoneResponse) GetMilestone() *Milestone {
+	if x != nil {
+		return x.Milestone
+	}
+	return nil
+}
+
+type QueryMilestoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        uint64                 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryMilestoneRequest) Reset() {
+	*x = QueryMilestoneRequest{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryMilestoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryMilestoneRequest) ProtoMessage() {}
+
+func (x *QueryMilestoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryMilestoneRequest.ProtoReflect.Descriptor instead.
+func (*QueryMilestoneRequest) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryMilestoneRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type QueryMilestoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Milestone     *Milestone             `protobuf:"bytes,1,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryMilestoneResponse) Reset() {
+	*x = QueryMilestoneResponse{}
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryMilestoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryMilestoneResponse) ProtoMessage() {}
+
+func (x *QueryMilestoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_milestone_query_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryMilestoneResponse.ProtoReflect.Descriptor instead.
+func (*QueryMilestoneResponse) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_milestone_query_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueryMilestoneResponse) GetMilestone() *Milestone {
+	if x != nil {
+		return x.Milestone
+	}
+	return nil
+}
+
+var File_heimdallv2_milestone_query_proto protoreflect.FileDescriptor
+
+const file_heimdallv2_milestone_query_proto_rawDesc = "" +
+	"\n" +
+	" heimdallv2/milestone/query.proto\x12\x14heimdallv2.milestone\"\xdc\x01\n" +
+	"\tMilestone\x12\x1a\n" +
+	"\bproposer\x18\x01 \x01(\tR\bproposer\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\fR\x04hash\x12\x1f\n" +
+	"\vstart_block\x18\x03 \x01(\x04R\n" +
+	"startBlock\x12\x1b\n" +
+	"\tend_block\x18\x04 \x01(\x04R\bendBlock\x12 \n" +
+	"\fbor_chain_id\x18\x05 \x01(\tR\n" +
+	"borChainId\x12!\n" +
+	"\fmilestone_id\x18\x06 \x01(\tR\vmilestoneId\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x04R\ttimestamp\"\x13\n" +
+	"\x11QueryCountRequest\"*\n" +
+	"\x12QueryCountResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\"\x1d\n" +
+	"\x1bQueryLatestMilestoneRequest\"]\n" +
+	"\x1cQueryLatestMilestoneResponse\x12=\n" +
+	"\tmilestone\x18\x01 \x01(\v2\x1f.heimdallv2.milestone.MilestoneR\tmilestone\"/\n" +
+	"\x15QueryMilestoneRequest\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x04R\x06number\"W\n" +
+	"\x16QueryMilestoneResponse\x12=\n" +
+	"\tmilestone\x18\x01 \x01(\v2\x1f.heimdallv2.milestone.MilestoneR\tmilestone2\xdf\x02\n" +
+	"\x05Query\x12f\n" +
+	"\x11GetMilestoneCount\x12'.heimdallv2.milestone.QueryCountRequest\x1a(.heimdallv2.milestone.QueryCountResponse\x12{\n" +
+	"\x12GetLatestMilestone\x121.heimdallv2.milestone.QueryLatestMilestoneRequest\x1a2.heimdallv2.milestone.QueryLatestMilestoneResponse\x12q\n" +
+	"\x14GetMilestoneByNumber\x12+.heimdallv2.milestone.QueryMilestoneRequest\x1a,.heimdallv2.milestone.QueryMilestoneResponseB]Z[github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/milestoneproto;milestoneprotob\x06proto3"
+
+var (
+	file_heimdallv2_milestone_query_proto_rawDescOnce sync.Once
+	file_heimdallv2_milestone_query_proto_rawDescData []byte
+)
+
+func file_heimdallv2_milestone_query_proto_rawDescGZIP() []byte {
+	file_heimdallv2_milestone_query_proto_rawDescOnce.Do(func() {
+		file_heimdallv2_milestone_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_heimdallv2_milestone_query_proto_rawDesc), len(file_heimdallv2_milestone_query_proto_rawDesc)))
+	})
+	return file_heimdallv2_milestone_query_proto_rawDescData
+}
+
+var file_heimdallv2_milestone_query_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_heimdallv2_milestone_query_proto_goTypes = []any{
+	(*Milestone)(nil),                    // 0: heimdallv2.milestone.Milestone
+	(*QueryCountRequest)(nil),            // 1: heimdallv2.milestone.QueryCountRequest
+	(*QueryCountResponse)(nil),           // 2: heimdallv2.milestone.QueryCountResponse
+	(*QueryLatestMilestoneRequest)(nil),  // 3: heimdallv2.milestone.QueryLatestMilestoneRequest
+	(*QueryLatestMilestoneResponse)(nil), // 4: heimdallv2.milestone.QueryLatestMilestoneResponse
+	(*QueryMilestoneRequest)(nil),        // 5: heimdallv2.milestone.QueryMilestoneRequest
+	(*QueryMilestoneResponse)(nil),       // 6: heimdallv2.milestone.QueryMilestoneResponse
+}
+var file_heimdallv2_milestone_query_proto_depIdxs = []int32{
+	0, // 0: heimdallv2.milestone.QueryLatestMilestoneResponse.milestone:type_name -> heimdallv2.milestone.Milestone
+	0, // 1: heimdallv2.milestone.QueryMilestoneResponse.milestone:type_name -> heimdallv2.milestone.Milestone
+	1, // 2: heimdallv2.milestone.Query.GetMilestoneCount:input_type -> heimdallv2.milestone.QueryCountRequest
+	3, // 3: heimdallv2.milestone.Query.GetLatestMilestone:input_type -> heimdallv2.milestone.QueryLatestMilestoneRequest
+	5, // 4: heimdallv2.milestone.Query.GetMilestoneByNumber:input_type -> heimdallv2.milestone.QueryMilestoneRequest
+	2, // 5: heimdallv2.milestone.Query.GetMilestoneCount:output_type -> heimdallv2.milestone.QueryCountResponse
+	4, // 6: heimdallv2.milestone.Query.GetLatestMilestone:output_type -> heimdallv2.milestone.QueryLatestMilestoneResponse
+	6, // 7: heimdallv2.milestone.Query.GetMilestoneByNumber:output_type -> heimdallv2.milestone.QueryMilestoneResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_heimdallv2_milestone_query_proto_init() }
+func file_heimdallv2_milestone_query_proto_init() {
+	if File_heimdallv2_milestone_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_heimdallv2_milestone_query_proto_rawDesc), len(file_heimdallv2_milestone_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_heimdallv2_milestone_query_proto_goTypes,
+		DependencyIndexes: file_heimdallv2_milestone_query_proto_depIdxs,
+		MessageInfos:      file_heimdallv2_milestone_query_proto_msgTypes,
+	}.Build()
+	File_heimdallv2_milestone_query_proto = out.File
+	file_heimdallv2_milestone_query_proto_goTypes = nil
+	file_heimdallv2_milestone_query_proto_depIdxs = nil
+}
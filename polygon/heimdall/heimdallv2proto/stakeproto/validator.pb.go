@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: heimdallv2/stake/validator.proto
+
+package stakeproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Validator struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ValId            uint64                 `protobuf:"varint,1,opt,name=val_id,json=valId,proto3" json:"val_id,omitempty"`
+	StartEpoch       uint64                 `protobuf:"varint,2,opt,name=start_epoch,json=startEpoch,proto3" json:"start_epoch,omitempty"`
+	EndEpoch         uint64                 `protobuf:"varint,3,opt,name=end_epoch,json=endEpoch,proto3" json:"end_epoch,omitempty"`
+	Nonce            uint64                 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	VotingPower      int64                  `protobuf:"varint,5,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
+	PubKey           []byte                 `protobuf:"bytes,6,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signer           string                 `protobuf:"bytes,7,opt,name=signer,proto3" json:"signer,omitempty"`
+	LastUpdated      string                 `protobuf:"bytes,8,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Jailed           bool                   `protobuf:"varint,9,opt,name=jailed,proto3" json:"jailed,omitempty"`
+	ProposerPriority int64                  `protobuf:"varint,10,opt,name=proposer_priority,json=proposerPriority,proto3" json:"proposer_priority,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Validator) Reset() {
+	*x = Validator{}
+	mi := &file_heimdallv2_stake_validator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Validator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Validator) ProtoMessage() {}
+
+func (x *Validator) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_stake_validator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Validator.ProtoReflect.Descriptor instead.
+func (*Validator) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_stake_validator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Validator) GetValId() uint64 {
+	if x != nil {
+		return x.ValId
+	}
+	return 0
+}
+
+func (x *Validator) GetStartEpoch() uint64 {
+	if x != nil {
+		return x.StartEpoch
+	}
+	return 0
+}
+
+func (x *Validator) GetEndEpoch() uint64 {
+	if x != nil {
+		return x.EndEpoch
+	}
+	return 0
+}
+
+func (x *Validator) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Validator) GetVotingPower() int64 {
+	if x != nil {
+		return x.VotingPower
+	}
+	return 0
+}
+
+func (x *Validator) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *Validator) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *Validator) GetLastUpdated() string {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return ""
+}
+
+func (x *Validator) GetJailed() bool {
+	if x != nil {
+		return x.Jailed
+	}
+	return false
+}
+
+func (x *Validator) GetProposerPriority() int64 {
+	if x != nil {
+		return x.ProposerPriority
+	}
+	return 0
+}
+
+type ValidatorSet struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Validators       []*Validator           `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	Proposer         *Validator             `protobuf:"bytes,2,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	TotalVotingPower int64                  `protobuf:"varint,3,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ValidatorSet) Reset() {
+	*x = ValidatorSet{}
+	mi := &file_heimdallv2_stake_validator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatorSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSet) ProtoMessage() {}
+
+func (x *ValidatorSet) ProtoReflect() protoreflect.Message {
+	mi := &file_heimdallv2_stake_validator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSet.ProtoReflect.Descriptor instead.
+func (*ValidatorSet) Descriptor() ([]byte, []int) {
+	return file_heimdallv2_stake_validator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidatorSet) GetValidators() []*Validator {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+func (x *ValidatorSet) GetProposer() *Validator {
+	if x != nil {
+		return x.Proposer
+	}
+	return nil
+}
+
+func (x *ValidatorSet) GetTotalVotingPower() int64 {
+	if x != nil {
+		return x.TotalVotingPower
+	}
+	return 0
+}
+
+var File_heimdallv2_stake_validator_proto protoreflect.FileDescriptor
+
+const file_heimdallv2_stake_validator_proto_rawDesc = "" +
+	"\n" +
+	" heimdallv2/stake/validator.proto\x12\x10heimdallv2.stake\"\xb2\x02\n" +
+	"\tValidator\x12\x15\n" +
+	"\x06val_id\x18\x01 \x01(\x04R\x05valId\x12\x1f\n" +
+	"\vstart_epoch\x18\x02 \x01(\x04R\n" +
+	"startEpoch\x12\x1b\n" +
+	"\tend_epoch\x18\x03 \x01(\x04R\bendEpoch\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\x04R\x05nonce\x12!\n" +
+	"\fvoting_power\x18\x05 \x01(\x03R\vvotingPower\x12\x17\n" +
+	"\apub_key\x18\x06 \x01(\fR\x06pubKey\x12\x16\n" +
+	"\x06signer\x18\a \x01(\tR\x06signer\x12!\n" +
+	"\flast_updated\x18\b \x01(\tR\vlastUpdated\x12\x16\n" +
+	"\x06jailed\x18\t \x01(\bR\x06jailed\x12+\n" +
+	"\x11proposer_priority\x18\n" +
+	" \x01(\x03R\x10proposerPriority\"\xb2\x01\n" +
+	"\fValidatorSet\x12;\n" +
+	"\n" +
+	"validators\x18\x01 \x03(\v2\x1b.heimdallv2.stake.ValidatorR\n" +
+	"validators\x127\n" +
+	"\bproposer\x18\x02 \x01(\v2\x1b.heimdallv2.stake.ValidatorR\bproposer\x12,\n" +
+	"\x12total_voting_power\x18\x03 \x01(\x03R\x10totalVotingPowerBUZSgithub.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/stakeproto;stakeprotob\x06proto3"
+
+var (
+	file_heimdallv2_stake_validator_proto_rawDescOnce sync.Once
+	file_heimdallv2_stake_validator_proto_rawDescData []byte
+)
+
+func file_heimdallv2_stake_validator_proto_rawDescGZIP() []byte {
+	file_heimdallv2_stake_validator_proto_rawDescOnce.Do(func() {
+		file_heimdallv2_stake_validator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_heimdallv2_stake_validator_proto_rawDesc), len(file_heimdallv2_stake_validator_proto_rawDesc)))
+	})
+	return file_heimdallv2_stake_validator_proto_rawDescData
+}
+
+var file_heimdallv2_stake_validator_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_heimdallv2_stake_validator_proto_goTypes = []any{
+	(*Validator)(nil),    // 0: heimdallv2.stake.Validator
+	(*ValidatorSet)(nil), // 1: heimdallv2.stake.ValidatorSet
+}
+var file_heimdallv2_stake_validator_proto_depIdxs = []int32{
+	0, // 0: heimdallv2.stake.ValidatorSet.validators:type_name -> heimdallv2.stake.Validator
+	0, // 1: heimdallv2.stake.ValidatorSet.proposer:type_name -> heimdallv2.stake.Validator
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_heimdallv2_stake_validator_proto_init() }
+func file_heimdallv2_stake_validator_proto_init() {
+	if File_heimdallv2_stake_validator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_heimdallv2_stake_validator_proto_rawDesc), len(file_heimdallv2_stake_validator_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_heimdallv2_stake_validator_proto_goTypes,
+		DependencyIndexes: file_heimdallv2_stake_validator_proto_depIdxs,
+		MessageInfos:      file_heimdallv2_stake_validator_proto_msgTypes,
+	}.Build()
+	File_heimdallv2_stake_validator_proto = out.File
+	file_heimdallv2_stake_validator_proto_goTypes = nil
+	file_heimdallv2_stake_validator_proto_depIdxs = nil
+}
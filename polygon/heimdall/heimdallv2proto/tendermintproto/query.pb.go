@@ -0,0 +1,400 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: cosmos/base/tendermint/v1beta1/query.proto
+
+package tendermintproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSyncingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncingRequest) Reset() {
+	*x = GetSyncingRequest{}
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncingRequest) ProtoMessage() {}
+
+func (x *GetSyncingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncingRequest.ProtoReflect.Descriptor instead.
+func (*GetSyncingRequest) Descriptor() ([]byte, []int) {
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP(), []int{0}
+}
+
+type GetSyncingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Syncing       bool                   `protobuf:"varint,1,opt,name=syncing,proto3" json:"syncing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSyncingResponse) Reset() {
+	*x = GetSyncingResponse{}
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSyncingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSyncingResponse) ProtoMessage() {}
+
+func (x *GetSyncingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSyncingResponse.ProtoReflect.Descriptor instead.
+func (*GetSyncingResponse) Descriptor() ([]byte, []int) {
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSyncingResponse) GetSyncing() bool {
+	if x != nil {
+		return x.Syncing
+	}
+	return false
+}
+
+type GetLatestBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBlockRequest) Reset() {
+	*x = GetLatestBlockRequest{}
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBlockRequest) ProtoMessage() {}
+
+func (x *GetLatestBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestBlockRequest) Descriptor() ([]byte, []int) {
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP(), []int{2}
+}
+
+type GetLatestBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockId       *BlockID               `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	SdkBlock      *Block                 `protobuf:"bytes,3,opt,name=sdk_block,json=sdkBlock,proto3" json:"sdk_block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBlockResponse) Reset() {
+	*x = GetLatestBlockResponse{}
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBlockResponse) ProtoMessage() {}
+
+func (x *GetLatestBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBlockResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestBlockResponse) Descriptor() ([]byte, []int) {
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLatestBlockResponse) GetBlockId() *BlockID {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetLatestBlockResponse) GetSdkBlock() *Block {
+	if x != nil {
+		return x.SdkBlock
+	}
+	return nil
+}
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *Header                `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Block) GetHeader() *Header {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+type Header struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         string                 `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Height          int64                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Time            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	AppHash         []byte                 `protobuf:"bytes,11,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	ProposerAddress string                 `protobuf:"bytes,14,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Header) Reset() {
+	*x = Header{}
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Header) ProtoMessage() {}
+
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Header) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Header) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Header) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Header) GetAppHash() []byte {
+	if x != nil {
+		return x.AppHash
+	}
+	return nil
+}
+
+func (x *Header) GetProposerAddress() string {
+	if x != nil {
+		return x.ProposerAddress
+	}
+	return ""
+}
+
+var File_cosmos_base_tendermint_v1beta1_query_proto protoreflect.FileDescriptor
+
+const file_cosmos_base_tendermint_v1beta1_query_proto_rawDesc = "" +
+	"\n" +
+	"*cosmos/base/tendermint/v1beta1/query.proto\x12\x1ecosmos.base.tendermint.v1beta1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1ctendermint/types/types.proto\"\x13\n" +
+	"\x11GetSyncingRequest\".\n" +
+	"\x12GetSyncingResponse\x12\x18\n" +
+	"\asyncing\x18\x01 \x01(\bR\asyncing\"\x17\n" +
+	"\x15GetLatestBlockRequest\"\x98\x01\n" +
+	"\x16GetLatestBlockResponse\x124\n" +
+	"\bblock_id\x18\x01 \x01(\v2\x19.tendermint.types.BlockIDR\ablockId\x12B\n" +
+	"\tsdk_block\x18\x03 \x01(\v2%.cosmos.base.tendermint.v1beta1.BlockR\bsdkBlockJ\x04\b\x02\x10\x03\"G\n" +
+	"\x05Block\x12>\n" +
+	"\x06header\x18\x01 \x01(\v2&.cosmos.base.tendermint.v1beta1.HeaderR\x06header\"\xb1\x01\n" +
+	"\x06Header\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\tR\achainId\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x03R\x06height\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x19\n" +
+	"\bapp_hash\x18\v \x01(\fR\aappHash\x12)\n" +
+	"\x10proposer_address\x18\x0e \x01(\tR\x0fproposerAddress2\xff\x01\n" +
+	"\aService\x12s\n" +
+	"\n" +
+	"GetSyncing\x121.cosmos.base.tendermint.v1beta1.GetSyncingRequest\x1a2.cosmos.base.tendermint.v1beta1.GetSyncingResponse\x12\x7f\n" +
+	"\x0eGetLatestBlock\x125.cosmos.base.tendermint.v1beta1.GetLatestBlockRequest\x1a6.cosmos.base.tendermint.v1beta1.GetLatestBlockResponseB_Z]github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/tendermintproto;tendermintprotob\x06proto3"
+
+var (
+	file_cosmos_base_tendermint_v1beta1_query_proto_rawDescOnce sync.Once
+	file_cosmos_base_tendermint_v1beta1_query_proto_rawDescData []byte
+)
+
+func file_cosmos_base_tendermint_v1beta1_query_proto_rawDescGZIP() []byte {
+	file_cosmos_base_tendermint_v1beta1_query_proto_rawDescOnce.Do(func() {
+		file_cosmos_base_tendermint_v1beta1_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cosmos_base_tendermint_v1beta1_query_proto_rawDesc), len(file_cosmos_base_tendermint_v1beta1_query_proto_rawDesc)))
+	})
+	return file_cosmos_base_tendermint_v1beta1_query_proto_rawDescData
+}
+
+var file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_cosmos_base_tendermint_v1beta1_query_proto_goTypes = []any{
+	(*GetSyncingRequest)(nil),      // 0: cosmos.base.tendermint.v1beta1.GetSyncingRequest
+	(*GetSyncingResponse)(nil),     // 1: cosmos.base.tendermint.v1beta1.GetSyncingResponse
+	(*GetLatestBlockRequest)(nil),  // 2: cosmos.base.tendermint.v1beta1.GetLatestBlockRequest
+	(*GetLatestBlockResponse)(nil), // 3: cosmos.base.tendermint.v1beta1.GetLatestBlockResponse
+	(*Block)(nil),                  // 4: cosmos.base.tendermint.v1beta1.Block
+	(*Header)(nil),                 // 5: cosmos.base.tendermint.v1beta1.Header
+	(*BlockID)(nil),                // 6: tendermint.types.BlockID
+	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
+}
+var file_cosmos_base_tendermint_v1beta1_query_proto_depIdxs = []int32{
+	6, // 0: cosmos.base.tendermint.v1beta1.GetLatestBlockResponse.block_id:type_name -> tendermint.types.BlockID
+	4, // 1: cosmos.base.tendermint.v1beta1.GetLatestBlockResponse.sdk_block:type_name -> cosmos.base.tendermint.v1beta1.Block
+	5, // 2: cosmos.base.tendermint.v1beta1.Block.header:type_name -> cosmos.base.tendermint.v1beta1.Header
+	7, // 3: cosmos.base.tendermint.v1beta1.Header.time:type_name -> google.protobuf.Timestamp
+	0, // 4: cosmos.base.tendermint.v1beta1.Service.GetSyncing:input_type -> cosmos.base.tendermint.v1beta1.GetSyncingRequest
+	2, // 5: cosmos.base.tendermint.v1beta1.Service.GetLatestBlock:input_type -> cosmos.base.tendermint.v1beta1.GetLatestBlockRequest
+	1, // 6: cosmos.base.tendermint.v1beta1.Service.GetSyncing:output_type -> cosmos.base.tendermint.v1beta1.GetSyncingResponse
+	3, // 7: cosmos.base.tendermint.v1beta1.Service.GetLatestBlock:output_type -> cosmos.base.tendermint.v1beta1.GetLatestBlockResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_cosmos_base_tendermint_v1beta1_query_proto_init() }
+func file_cosmos_base_tendermint_v1beta1_query_proto_init() {
+	if File_cosmos_base_tendermint_v1beta1_query_proto != nil {
+		return
+	}
+	file_tendermint_types_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cosmos_base_tendermint_v1beta1_query_proto_rawDesc), len(file_cosmos_base_tendermint_v1beta1_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cosmos_base_tendermint_v1beta1_query_proto_goTypes,
+		DependencyIndexes: file_cosmos_base_tendermint_v1beta1_query_proto_depIdxs,
+		MessageInfos:      file_cosmos_base_tendermint_v1beta1_query_proto_msgTypes,
+	}.Build()
+	File_cosmos_base_tendermint_v1beta1_query_proto = out.File
+	file_cosmos_base_tendermint_v1beta1_query_proto_goTypes = nil
+	file_cosmos_base_tendermint_v1beta1_query_proto_depIdxs = nil
+}
@@ -0,0 +1,122 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: tendermint/types/types.proto
+
+package tendermintproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BlockID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockID) Reset() {
+	*x = BlockID{}
+	mi := &file_tendermint_types_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockID) ProtoMessage() {}
+
+func (x *BlockID) ProtoReflect() protoreflect.Message {
+	mi := &file_tendermint_types_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockID.ProtoReflect.Descriptor instead.
+func (*BlockID) Descriptor() ([]byte, []int) {
+	return file_tendermint_types_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockID) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+var File_tendermint_types_types_proto protoreflect.FileDescriptor
+
+const file_tendermint_types_types_proto_rawDesc = "" +
+	"\n" +
+	"\x1ctendermint/types/types.proto\x12\x10tendermint.types\"\x1d\n" +
+	"\aBlockID\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hashB_Z]github.com/erigontech/erigon/polygon/heimdall/heimdallv2proto/tendermintproto;tendermintprotob\x06proto3"
+
+var (
+	file_tendermint_types_types_proto_rawDescOnce sync.Once
+	file_tendermint_types_types_proto_rawDescData []byte
+)
+
+func file_tendermint_types_types_proto_rawDescGZIP() []byte {
+	file_tendermint_types_types_proto_rawDescOnce.Do(func() {
+		file_tendermint_types_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tendermint_types_types_proto_rawDesc), len(file_tendermint_types_types_proto_rawDesc)))
+	})
+	return file_tendermint_types_types_proto_rawDescData
+}
+
+var file_tendermint_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_tendermint_types_types_proto_goTypes = []any{
+	(*BlockID)(nil), // 0: tendermint.types.BlockID
+}
+var file_tendermint_types_types_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_tendermint_types_types_proto_init() }
+func file_tendermint_types_types_proto_init() {
+	if File_tendermint_types_types_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tendermint_types_types_proto_rawDesc), len(file_tendermint_types_types_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tendermint_types_types_proto_goTypes,
+		DependencyIndexes: file_tendermint_types_types_proto_depIdxs,
+		MessageInfos:      file_tendermint_types_types_proto_msgTypes,
+	}.Build()
+	File_tendermint_types_types_proto = out.File
+	file_tendermint_types_types_proto_goTypes = nil
+	file_tendermint_types_types_proto_depIdxs = nil
+}
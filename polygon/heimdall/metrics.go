@@ -18,6 +18,7 @@ package heimdall
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/erigontech/erigon-lib/metrics"
@@ -31,6 +32,14 @@ type (
 		request map[bool]metrics.Gauge
 		timer   metrics.Summary
 	}
+
+	// endpointMeter - metrics of one endpoint of the FailoverClient
+	endpointMeter struct {
+		healthy   metrics.Gauge
+		requests  map[bool]metrics.Counter
+		timer     metrics.Summary
+		failovers metrics.Counter // requests moved on to another endpoint after this one failed
+	}
 )
 
 const (
@@ -107,6 +116,31 @@ func sendMetrics(ctx context.Context, start time.Time, isSuccessful bool) {
 	meters.timer.ObserveDuration(start)
 }
 
+func newEndpointMeter(endpoint string) endpointMeter {
+	return endpointMeter{
+		healthy: metrics.GetOrCreateGauge(fmt.Sprintf(`heimdall_endpoint_healthy{endpoint=%q}`, endpoint)),
+		requests: map[bool]metrics.Counter{
+			true:  metrics.GetOrCreateCounter(fmt.Sprintf(`heimdall_endpoint_requests{endpoint=%q,result="success"}`, endpoint)),
+			false: metrics.GetOrCreateCounter(fmt.Sprintf(`heimdall_endpoint_requests{endpoint=%q,result="failure"}`, endpoint)),
+		},
+		timer:     metrics.GetOrCreateSummary(fmt.Sprintf(`heimdall_endpoint_request_duration{endpoint=%q}`, endpoint)),
+		failovers: metrics.GetOrCreateCounter(fmt.Sprintf(`heimdall_endpoint_failovers{endpoint=%q}`, endpoint)),
+	}
+}
+
+func (m endpointMeter) setHealthy(healthy bool) {
+	if healthy {
+		m.healthy.Set(1)
+	} else {
+		m.healthy.Set(0)
+	}
+}
+
+func (m endpointMeter) observeRequest(start time.Time, isSuccessful bool) {
+	m.requests[isSuccessful].Inc()
+	m.timer.ObserveDuration(start)
+}
+
 func UpdateObservedWaypointCheckpointLength(length uint64) {
 	waypointCheckpointLength.SetUint64(length)
 }
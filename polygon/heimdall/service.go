@@ -414,6 +414,11 @@ func (s *Service) IsCatchingUp(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	return isCatchingUp(status)
+}
+
+// isCatchingUp tells whether heimdall is syncing or its latest block is too old
+func isCatchingUp(status *Status) (bool, error) {
 	if status.CatchingUp {
 		return true, nil
 	}
@@ -19,7 +19,10 @@
 
 package rpc
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 var (
 	_ Error = new(methodNotFoundError)
@@ -29,6 +32,7 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(InvalidParamsError)
 	_ Error = new(CustomError)
+	_ Error = new(QuotaExceededError)
 )
 
 const defaultErrorCode = -32000
@@ -92,3 +96,19 @@ type CustomError struct {
 func (e *CustomError) ErrorCode() int { return e.Code }
 
 func (e *CustomError) Error() string { return e.Message }
+
+// the client has exhausted its quota, the code is "limit exceeded" of EIP-1474
+type QuotaExceededError struct {
+	Cost       uint64
+	Budget     uint64
+	RetryAfter time.Duration // 0 if the call costs more than the whole budget
+}
+
+func (e *QuotaExceededError) ErrorCode() int { return -32005 }
+
+func (e *QuotaExceededError) Error() string {
+	if e.RetryAfter == 0 {
+		return fmt.Sprintf("call cost %d exceeds the quota budget %d", e.Cost, e.Budget)
+	}
+	return fmt.Sprintf("quota exceeded, retry in %v", e.RetryAfter.Round(time.Millisecond))
+}
@@ -255,11 +255,12 @@ func (args *CallArgs) ToTransaction(globalGasCap uint64, baseFee *uint256.Int) (
 // if statDiff is set, all diff will be applied first and then execute the call
 // message.
 type Account struct {
-	Nonce     *hexutil.Uint64              `json:"nonce"`
-	Code      *hexutil.Bytes               `json:"code"`
-	Balance   **hexutil.Big                `json:"balance"`
-	State     *map[common.Hash]common.Hash `json:"state"`
-	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+	Nonce                   *hexutil.Uint64              `json:"nonce"`
+	Code                    *hexutil.Bytes               `json:"code"`
+	Balance                 **hexutil.Big                `json:"balance"`
+	State                   *map[common.Hash]common.Hash `json:"state"`
+	StateDiff               *map[common.Hash]common.Hash `json:"stateDiff"`
+	MovePrecompileToAddress *common.Address              `json:"movePrecompileToAddress"`
 }
 
 func NewRevertError(result *evmtypes.ExecutionResult) *RevertError {
@@ -18,6 +18,7 @@ package ethapi
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
@@ -77,3 +78,31 @@ func (overrides *BlockOverrides) Override(context evmtypes.BlockContext) error {
 	}
 	return nil
 }
+
+// OverrideHeader applies the block overrides to a copy of the given header. Fields that only
+// affect the EVM block context (e.g. BlobBaseFee) and withdrawals are left to the caller.
+func (overrides *BlockOverrides) OverrideHeader(header *types.Header) *types.Header {
+	h := types.CopyHeader(header)
+	if overrides == nil {
+		return h
+	}
+	if overrides.Number != nil {
+		h.Number = new(big.Int).Set(overrides.Number.ToInt())
+	}
+	if overrides.PrevRanDao != nil {
+		h.MixDigest = *overrides.PrevRanDao
+	}
+	if overrides.Time != nil {
+		h.Time = overrides.Time.Uint64()
+	}
+	if overrides.GasLimit != nil {
+		h.GasLimit = overrides.GasLimit.Uint64()
+	}
+	if overrides.FeeRecipient != nil {
+		h.Coinbase = *overrides.FeeRecipient
+	}
+	if overrides.BaseFeePerGas != nil {
+		h.BaseFee = new(big.Int).Set(overrides.BaseFeePerGas.ToInt())
+	}
+	return h
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
 )
 
 type StateOverrides map[common.Address]Account
@@ -73,3 +74,37 @@ func (overrides *StateOverrides) Override(state *state.IntraBlockState) error {
 
 	return nil
 }
+
+// OverridePrecompiles applies the overrides to the given set of precompiles. It has to be called before
+// Override, so that an invalid precompile override is rejected before any state is modified.
+// A precompile is dropped from the set as soon as its address is overridden. With `movePrecompileToAddress`
+// it is moved to another address instead, which can neither be overridden nor be the target of another move.
+func (overrides *StateOverrides) OverridePrecompiles(precompiles vm.PrecompiledContracts) error {
+	dirtyAddrs := make(map[common.Address]struct{})
+	for addr, account := range *overrides {
+		// If a precompile was moved to this address already, it can't be overridden.
+		if _, ok := dirtyAddrs[addr]; ok {
+			return fmt.Errorf("account %s has already been overridden by a precompile", addr.Hex())
+		}
+		p, isPrecompile := precompiles[addr]
+		if account.MovePrecompileToAddress != nil {
+			if !isPrecompile {
+				return fmt.Errorf("account %s is not a precompile", addr.Hex())
+			}
+			dst := *account.MovePrecompileToAddress
+			// Refuse to move a precompile to an address that has been or will be overridden.
+			if _, ok := (*overrides)[dst]; ok {
+				return fmt.Errorf("account %s is already overridden", dst.Hex())
+			}
+			if _, ok := dirtyAddrs[dst]; ok {
+				return fmt.Errorf("account %s is already overridden", dst.Hex())
+			}
+			precompiles[dst] = p
+			dirtyAddrs[dst] = struct{}{}
+		}
+		if isPrecompile {
+			delete(precompiles, addr)
+		}
+	}
+	return nil
+}
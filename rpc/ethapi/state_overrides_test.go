@@ -0,0 +1,89 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+func TestOverridePrecompiles(t *testing.T) {
+	var (
+		ecrecover = common.BytesToAddress([]byte{1})
+		sha256    = common.BytesToAddress([]byte{2})
+		identity  = common.BytesToAddress([]byte{4})
+		other     = common.HexToAddress("0x1234")
+		another   = common.HexToAddress("0x5678")
+		code      = hexutil.Bytes{0x00}
+		rules     = &chain.Rules{IsHomestead: true, IsByzantium: true, IsIstanbul: true, IsBerlin: true}
+	)
+
+	t.Run("move", func(t *testing.T) {
+		precompiles := vm.ActivePrecompiledContracts(rules)
+		identityContract := precompiles[identity]
+		overrides := StateOverrides{identity: {MovePrecompileToAddress: &other}}
+		require.NoError(t, overrides.OverridePrecompiles(precompiles))
+		assert.NotContains(t, precompiles, identity)
+		assert.Equal(t, identityContract, precompiles[other])
+	})
+
+	t.Run("code override drops the precompile", func(t *testing.T) {
+		precompiles := vm.ActivePrecompiledContracts(rules)
+		overrides := StateOverrides{sha256: {Code: &code}}
+		require.NoError(t, overrides.OverridePrecompiles(precompiles))
+		assert.NotContains(t, precompiles, sha256)
+		assert.Contains(t, precompiles, ecrecover)
+	})
+
+	t.Run("move of a non-precompile", func(t *testing.T) {
+		precompiles := vm.ActivePrecompiledContracts(rules)
+		overrides := StateOverrides{other: {MovePrecompileToAddress: &another}}
+		require.Error(t, overrides.OverridePrecompiles(precompiles))
+	})
+
+	t.Run("move to an overridden address", func(t *testing.T) {
+		precompiles := vm.ActivePrecompiledContracts(rules)
+		overrides := StateOverrides{
+			identity: {MovePrecompileToAddress: &other},
+			other:    {Code: &code},
+		}
+		require.Error(t, overrides.OverridePrecompiles(precompiles))
+	})
+
+	t.Run("two moves to the same address", func(t *testing.T) {
+		precompiles := vm.ActivePrecompiledContracts(rules)
+		overrides := StateOverrides{
+			identity: {MovePrecompileToAddress: &other},
+			sha256:   {MovePrecompileToAddress: &other},
+		}
+		require.Error(t, overrides.OverridePrecompiles(precompiles))
+	})
+
+	t.Run("active precompiles are not modified", func(t *testing.T) {
+		precompiles := vm.ActivePrecompiledContracts(rules)
+		overrides := StateOverrides{identity: {MovePrecompileToAddress: &other}}
+		require.NoError(t, overrides.OverridePrecompiles(precompiles))
+		assert.Contains(t, vm.ActivePrecompiledContracts(rules), identity)
+	})
+}
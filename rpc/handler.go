@@ -31,10 +31,14 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
 	"github.com/erigontech/erigon-lib/jsonstream"
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon/rpc/rpccfg"
+	"github.com/erigontech/erigon/turbo/telemetry"
 )
 
 // handler handles JSON-RPC messages. There is one handler per connection. Note that
@@ -72,6 +76,7 @@ type handler struct {
 
 	allowList     AllowList // a list of explicitly allowed methods, if empty -- everything is allowed
 	forbiddenList ForbiddenList
+	quota         Quota // charges the calls of the clients, nil if unlimited
 
 	subLock             sync.Mutex
 	serverSubs          map[ID]*Subscription
@@ -119,7 +124,7 @@ func HandleError(err error, stream jsonstream.Stream) {
 	}
 }
 
-func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, allowList AllowList, maxBatchConcurrency uint, traceRequests bool, logger log.Logger, rpcSlowLogThreshold time.Duration) *handler {
+func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, allowList AllowList, quota Quota, maxBatchConcurrency uint, traceRequests bool, logger log.Logger, rpcSlowLogThreshold time.Duration) *handler {
 	rootCtx, cancelRoot := context.WithCancel(connCtx)
 	forbiddenList := newForbiddenList()
 
@@ -136,6 +141,7 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		logger:         logger,
 		allowList:      allowList,
 		forbiddenList:  forbiddenList,
+		quota:          quota,
 
 		maxBatchConcurrency: maxBatchConcurrency,
 		traceRequests:       traceRequests,
@@ -476,8 +482,18 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream jsonstrea
 	if err != nil {
 		return msg.errorResponse(&InvalidParamsError{err.Error()})
 	}
+	if callb != h.unsubscribeCb {
+		if err := h.charge(cp.ctx, msg); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args, stream)
+	ctx, span := telemetry.Start(cp.ctx, msg.Method, attribute.String("rpc.system", "jsonrpc"), attribute.String("rpc.method", msg.Method))
+	answer := h.runMethod(ctx, msg, callb, args, stream)
+	if answer != nil && answer.Error != nil {
+		span.SetStatus(codes.Error, answer.Error.Message)
+	}
+	span.End()
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -491,6 +507,19 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream jsonstrea
 	return answer
 }
 
+// charge accounts the call to the quota of the client, it returns the error to reply with if the call
+// is rejected.
+func (h *handler) charge(ctx context.Context, msg *jsonrpcMessage) error {
+	if h.quota == nil {
+		return nil
+	}
+	err := h.quota.Charge(PeerInfoFromContext(ctx), msg.Method, msg.Params)
+	if err != nil {
+		newRPCQuotaRejectedCounter(msg.Method).Inc()
+	}
+	return err
+}
+
 // handleSubscribe processes *_subscribe method calls.
 func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage, stream jsonstream.Stream) *jsonrpcMessage {
 	if !h.allowSubscribe {
@@ -515,6 +544,9 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage, stream json
 		return msg.errorResponse(&InvalidParamsError{err.Error()})
 	}
 	args = args[1:]
+	if err := h.charge(cp.ctx, msg); err != nil {
+		return msg.errorResponse(err)
+	}
 
 	// Install notifier in context so the subscription handler can find it.
 	n := &RemoteNotifier{h: h, namespace: namespace}
@@ -32,6 +32,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon-lib/types/accounts"
 	"github.com/erigontech/erigon/core/state"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
@@ -55,6 +56,10 @@ type PrivateDebugAPI interface {
 	TraceTransaction(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
 	TraceBlockByHash(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
 	TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
+	TraceBadBlock(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
+	IntermediateRoots(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig) ([]common.Hash, error)
+	ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*ExecutionWitness, error)
+	ExecuteStateless(ctx context.Context, blockRLP hexutil.Bytes, witness ExecutionWitness) (*StatelessResult, error)
 	AccountRange(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start []byte, maxResults int, nocode, nostorage bool) (state.IteratorDump, error)
 	GetModifiedAccountsByNumber(ctx context.Context, startNum rpc.BlockNumber, endNum *rpc.BlockNumber) ([]common.Address, error)
 	GetModifiedAccountsByHash(ctx context.Context, startHash common.Hash, endHash *common.Hash) ([]common.Address, error)
@@ -75,16 +80,18 @@ type PrivateDebugAPI interface {
 // PrivateDebugAPIImpl is implementation of the PrivateDebugAPI interface based on remote Db access
 type DebugAPIImpl struct {
 	*BaseAPI
-	db     kv.TemporalRoDB
-	GasCap uint64
+	db                          kv.TemporalRoDB
+	GasCap                      uint64
+	MaxGetProofRewindBlockCount int
 }
 
 // NewPrivateDebugAPI returns PrivateDebugAPIImpl instance
-func NewPrivateDebugAPI(base *BaseAPI, db kv.TemporalRoDB, gascap uint64) *DebugAPIImpl {
+func NewPrivateDebugAPI(base *BaseAPI, db kv.TemporalRoDB, gascap uint64, maxGetProofRewindBlockCount int) *DebugAPIImpl {
 	return &DebugAPIImpl{
-		BaseAPI: base,
-		db:      db,
-		GasCap:  gascap,
+		BaseAPI:                     base,
+		db:                          db,
+		GasCap:                      gascap,
+		MaxGetProofRewindBlockCount: maxGetProofRewindBlockCount,
 	}
 }
 
@@ -482,6 +489,20 @@ func (api *DebugAPIImpl) GetBadBlocks(ctx context.Context) ([]map[string]interfa
 	return results, nil
 }
 
+// badBlock returns the recent bad block with the given hash, nil if there is none.
+func (api *DebugAPIImpl) badBlock(tx kv.Tx, hash common.Hash) (*types.Block, error) {
+	blocks, err := rawdb.GetLatestBadBlocks(tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		if block != nil && block.Hash() == hash {
+			return block, nil
+		}
+	}
+	return nil, nil
+}
+
 // GetRawTransaction implements debug_getRawTransaction - Returns an array of EIP-2718 binary-encoded transactions
 func (api *DebugAPIImpl) GetRawTransaction(ctx context.Context, txnHash common.Hash) (hexutil.Bytes, error) {
 	tx, err := api.db.BeginTemporalRo(ctx)
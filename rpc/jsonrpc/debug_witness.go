@@ -0,0 +1,261 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/stagedsync"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// ExecutionWitness is everything needed to execute a block statelessly on top of the state root of its parent.
+type ExecutionWitness struct {
+	State   []hexutil.Bytes `json:"state"`   // RLP encoded trie nodes on the paths to the touched accounts and storage slots
+	Codes   []hexutil.Bytes `json:"codes"`   // bytecodes of the touched contracts
+	Keys    []hexutil.Bytes `json:"keys"`    // touched addresses and storage slots
+	Headers []hexutil.Bytes `json:"headers"` // RLP encoded ancestors, from the oldest one reached by BLOCKHASH up to the parent
+}
+
+// Execute executes block statelessly on top of the witness, which must be the witness of the block, and returns the
+// resulting state root and receipts. See stagedsync.ExecuteStateless.
+func (w *ExecutionWitness) Execute(chainConfig *chain.Config, engine consensus.Engine, block *types.Block, logger log.Logger) (common.Hash, types.Receipts, error) {
+	headers := make([]*types.Header, len(w.Headers))
+	for i, enc := range w.Headers {
+		headers[i] = new(types.Header)
+		if err := rlp.DecodeBytes(enc, headers[i]); err != nil {
+			return common.Hash{}, nil, fmt.Errorf("decoding witness header %d: %w", i, err)
+		}
+	}
+	return stagedsync.ExecuteStateless(chainConfig, engine, block, headers, toBytesSlice(w.State), toBytesSlice(w.Codes), logger)
+}
+
+// StatelessResult is the result of debug_executeStateless.
+type StatelessResult struct {
+	StateRoot    common.Hash `json:"stateRoot"`    // state root after the block, as computed by the execution
+	ReceiptsRoot common.Hash `json:"receiptsRoot"` // root of the receipts of the execution
+	Valid        bool        `json:"valid"`        // whether they are the state and receipts roots of the block
+}
+
+// ExecuteStateless implements debug_executeStateless. Executes an RLP encoded block on top of its witness, as returned
+// by debug_executionWitness, without reading the state of the node: the block doesn't have to be known to it.
+func (api *DebugAPIImpl) ExecuteStateless(ctx context.Context, blockRLP hexutil.Bytes, witness ExecutionWitness) (*StatelessResult, error) {
+	var block types.Block
+	if err := rlp.DecodeBytes(blockRLP, &block); err != nil {
+		return nil, fmt.Errorf("decoding block: %w", err)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := api.engine().(consensus.Engine)
+	if !ok {
+		return nil, errors.New("engine is not consensus.Engine")
+	}
+
+	root, receipts, err := witness.Execute(chainConfig, engine, &block, log.Root())
+	if errors.Is(err, stagedsync.ErrStatelessRootMismatch) || errors.Is(err, stagedsync.ErrStatelessReceiptsMismatch) {
+		return &StatelessResult{StateRoot: root, ReceiptsRoot: types.DeriveSha(receipts)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &StatelessResult{StateRoot: root, ReceiptsRoot: types.DeriveSha(receipts), Valid: true}, nil
+}
+
+// ExecutionWitness implements debug_executionWitness. Returns the witness of a canonical block.
+func (api *DebugAPIImpl) ExecutionWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*ExecutionWitness, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var result *ExecutionWitness
+	err = api.buildWitness(ctx, api.db, blockNrOrHash, 0, true, api.MaxGetProofRewindBlockCount, log.Root(), func(bw *blockWitness) error {
+		result = &ExecutionWitness{State: []hexutil.Bytes{}, Codes: []hexutil.Bytes{}, Keys: []hexutil.Bytes{}, Headers: []hexutil.Bytes{}}
+		// Witness for genesis block is empty
+		if bw == nil {
+			return nil
+		}
+
+		nodes, err := bw.trie.EncodedNodes()
+		if err != nil {
+			return err
+		}
+		result.State = sortedUniqueBytes(nodes)
+
+		codes := make([][]byte, 0, len(bw.codeReads))
+		for _, code := range bw.codeReads {
+			if len(code.Code) > 0 {
+				codes = append(codes, code.Code)
+			}
+		}
+		result.Codes = sortedUniqueBytes(codes)
+
+		keys := make([][]byte, 0, len(bw.touchedPlainKeys))
+		for _, key := range bw.touchedPlainKeys {
+			keys = append(keys, key[:length.Addr])
+			if len(key) > length.Addr {
+				keys = append(keys, key[length.Addr:])
+			}
+		}
+		result.Keys = sortedUniqueBytes(keys)
+
+		parentNum := bw.block.NumberU64() - 1
+		for num := min(bw.oldestBlockHash, parentNum); num <= parentNum; num++ {
+			header, err := api._blockReader.HeaderByNumber(ctx, tx, num)
+			if err != nil {
+				return err
+			}
+			if header == nil {
+				return fmt.Errorf("header %d not found", num)
+			}
+			enc, err := rlp.EncodeToBytes(header)
+			if err != nil {
+				return err
+			}
+			result.Headers = append(result.Headers, enc)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// IntermediateRoots implements debug_intermediateRoots. Returns the state root after each transaction of a
+// canonical block. The block is re-executed statelessly on its witness, system calls at the block start are
+// included into the root of the first transaction. Of config only Timeout (bounds the whole re-execution) and
+// TxIndex (stops after the transaction with this index) are honoured.
+func (api *DebugAPIImpl) IntermediateRoots(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig) ([]common.Hash, error) {
+	timeout := api.evmCallTimeout
+	lastTxIndex := -1
+	if config != nil {
+		if config.Timeout != nil {
+			var err error
+			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+				return nil, err
+			}
+		}
+		if config.TxIndex != nil {
+			lastTxIndex = int(*config.TxIndex)
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	roots := []common.Hash{}
+	err := api.buildWitness(ctx, api.db, rpc.BlockNumberOrHashWithHash(hash, true), 0, true, api.MaxGetProofRewindBlockCount, log.Root(), func(bw *blockWitness) error {
+		// genesis block has no transactions
+		if bw == nil {
+			return nil
+		}
+
+		witnessBuffer, err := bw.encode()
+		if err != nil {
+			return err
+		}
+		witness, err := trie.NewWitnessFromReader(bytes.NewReader(witnessBuffer.Bytes()), false)
+		if err != nil {
+			return err
+		}
+		block, header := bw.block, bw.block.HeaderNoCopy()
+		stateless, err := state.NewStateless(bw.prevHeader.Root, witness, block.NumberU64()-1, false /* trace */, false /* is binary */)
+		if err != nil {
+			return err
+		}
+
+		ibs := state.New(stateless)
+		if err := core.InitializeBlockExecution(bw.engine, bw.store.ChainReader, header, bw.chainConfig, ibs, stateless, log.Root(), nil); err != nil {
+			return err
+		}
+
+		if lastTxIndex >= block.Transactions().Len() {
+			return fmt.Errorf("txIndex %d out of range, block %x has %d transactions", lastTxIndex, block.Hash(), block.Transactions().Len())
+		}
+
+		gp := new(core.GasPool).AddGas(block.GasLimit()).AddBlobGas(bw.chainConfig.GetMaxBlobGasPerBlock(block.Time()))
+		var gasUsed, usedBlobGas uint64
+		for i, txn := range block.Transactions() {
+			select {
+			default:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			ibs.SetTxContext(block.NumberU64(), i)
+			if _, _, err := core.ApplyTransaction(bw.chainConfig, bw.store.GetHashFn, bw.engine, nil, gp, ibs, stateless, header, txn, &gasUsed, &usedBlobGas, vm.Config{}); err != nil {
+				return fmt.Errorf("could not apply txn %d [%x]: %w", i, txn.Hash(), err)
+			}
+			roots = append(roots, stateless.Finalize())
+			if i == lastTxIndex {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+func sortedUniqueBytes(items [][]byte) []hexutil.Bytes {
+	slices.SortFunc(items, bytes.Compare)
+	items = slices.CompactFunc(items, bytes.Equal)
+	result := make([]hexutil.Bytes, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result
+}
+
+func toBytesSlice(items []hexutil.Bytes) [][]byte {
+	result := make([][]byte, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result
+}
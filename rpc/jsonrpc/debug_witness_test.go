@@ -0,0 +1,194 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/jsonstream"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core"
+	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// blockWithTxns returns the first block of the chain with at least two transactions, and the header of its parent.
+func blockWithTxns(t *testing.T, genesis *types.Block, chain *core.ChainPack) (*types.Block, *types.Header) {
+	t.Helper()
+	parent := genesis.Header()
+	for _, block := range chain.Blocks {
+		if block.Transactions().Len() > 1 {
+			return block, parent
+		}
+		parent = block.Header()
+	}
+	t.Fatal("test chain has no block with several transactions")
+	return nil, nil
+}
+
+func TestExecutionWitness(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, 100_000)
+	block, parent := blockWithTxns(t, m.Genesis, chain)
+
+	witness, err := api.ExecutionWitness(m.Ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), true))
+	require.NoError(t, err)
+
+	// the nodes are proven against the state root of the parent
+	var hasRoot bool
+	for _, node := range witness.State {
+		if crypto.Keccak256Hash(node) == parent.Root {
+			hasRoot = true
+		}
+	}
+	require.True(t, hasRoot, "witness doesn't contain the root node of the parent state")
+
+	sender, err := block.Transactions()[0].Sender(*types.MakeSigner(m.ChainConfig, block.NumberU64(), block.Time()))
+	require.NoError(t, err)
+	require.Contains(t, witness.Keys, hexutil.Bytes(sender.Bytes()))
+
+	require.NotEmpty(t, witness.Headers)
+	var last types.Header
+	require.NoError(t, rlp.DecodeBytes(witness.Headers[len(witness.Headers)-1], &last))
+	require.Equal(t, parent.Hash(), last.Hash())
+
+	genesisWitness, err := api.ExecutionWitness(m.Ctx, rpc.BlockNumberOrHashWithNumber(0))
+	require.NoError(t, err)
+	require.Empty(t, genesisWitness.State)
+	require.Empty(t, genesisWitness.Headers)
+}
+
+func TestIntermediateRoots(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, 100_000)
+	block, _ := blockWithTxns(t, m.Genesis, chain)
+
+	roots, err := api.IntermediateRoots(m.Ctx, block.Hash(), nil)
+	require.NoError(t, err)
+	require.Len(t, roots, block.Transactions().Len())
+	for i := 1; i < len(roots); i++ {
+		require.NotEqual(t, common.Hash{}, roots[i])
+		require.NotEqual(t, roots[i-1], roots[i])
+	}
+
+	txIndex := hexutil.Uint(0)
+	firstRoots, err := api.IntermediateRoots(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{TxIndex: &txIndex})
+	require.NoError(t, err)
+	require.Equal(t, roots[:1], firstRoots)
+
+	txIndex = hexutil.Uint(block.Transactions().Len())
+	_, err = api.IntermediateRoots(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{TxIndex: &txIndex})
+	require.ErrorContains(t, err, "out of range")
+
+	timeout := "not a duration"
+	_, err = api.IntermediateRoots(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{Timeout: &timeout})
+	require.Error(t, err)
+}
+
+func TestTraceBadBlock(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, 100_000)
+	block, _ := blockWithTxns(t, m.Genesis, chain)
+	require.Greater(t, block.NumberU64(), uint64(1))
+
+	var expected bytes.Buffer
+	s := jsonstream.New(jsoniter.NewStream(jsoniter.ConfigDefault, &expected, 4096))
+	require.NoError(t, api.TraceBlockByHash(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{}, s))
+	require.NoError(t, s.Flush())
+
+	markBad := func(from uint64) {
+		tx, err := m.DB.BeginRw(m.Ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		require.NoError(t, rawdb.TruncateCanonicalHash(tx, from, true))
+		require.NoError(t, rawdb.ResetBadBlockCache(tx, 100))
+		require.NoError(t, tx.Commit())
+	}
+
+	// the parent is canonical, the bad block is traced on top of its state
+	markBad(block.NumberU64())
+	var buf bytes.Buffer
+	s = jsonstream.New(jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096))
+	require.NoError(t, api.TraceBadBlock(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{}, s))
+	require.NoError(t, s.Flush())
+	require.Equal(t, expected.String(), buf.String())
+
+	// the parent is not canonical anymore, its state isn't available
+	markBad(block.NumberU64() - 1)
+	buf.Reset()
+	s = jsonstream.New(jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096))
+	err := api.TraceBadBlock(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{}, s)
+	require.ErrorContains(t, err, "is not available")
+}
+
+func TestExecuteStateless(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0, 100_000)
+	block, _ := blockWithTxns(t, m.Genesis, chain)
+
+	witness, err := api.ExecutionWitness(m.Ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), true))
+	require.NoError(t, err)
+	blockRLP, err := rlp.EncodeToBytes(block)
+	require.NoError(t, err)
+
+	result, err := api.ExecuteStateless(m.Ctx, blockRLP, *witness)
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+	require.Equal(t, block.Root(), result.StateRoot)
+	require.Equal(t, block.ReceiptHash(), result.ReceiptsRoot)
+
+	// the receipts are the ones of the node
+	_, receipts, err := witness.Execute(m.ChainConfig, m.Engine, block, m.Log)
+	require.NoError(t, err)
+	expected := chain.Receipts[block.NumberU64()-1]
+	require.Len(t, receipts, len(expected))
+	for i, receipt := range receipts {
+		require.Equal(t, expected[i].TxHash, receipt.TxHash)
+		require.Equal(t, expected[i].Status, receipt.Status)
+		require.Equal(t, expected[i].GasUsed, receipt.GasUsed)
+		require.Equal(t, expected[i].CumulativeGasUsed, receipt.CumulativeGasUsed)
+		require.Equal(t, expected[i].Bloom, receipt.Bloom)
+		require.Len(t, receipt.Logs, len(expected[i].Logs))
+	}
+
+	// a block claiming another state root is executed the same way, but is not valid
+	header := block.Header()
+	header.Root = common.Hash{1}
+	badRLP, err := rlp.EncodeToBytes(block.WithSeal(header))
+	require.NoError(t, err)
+	result, err = api.ExecuteStateless(m.Ctx, badRLP, *witness)
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, block.Root(), result.StateRoot)
+
+	// the witness of another block doesn't end with the parent of the block
+	require.Greater(t, block.NumberU64(), uint64(1))
+	otherWitness, err := api.ExecutionWitness(m.Ctx, rpc.BlockNumberOrHashWithNumber(1))
+	require.NoError(t, err)
+	_, err = api.ExecuteStateless(m.Ctx, blockRLP, *otherWitness)
+	require.Error(t, err)
+}
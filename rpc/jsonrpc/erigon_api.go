@@ -18,12 +18,14 @@ package jsonrpc
 
 import (
 	"context"
+	"sync"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/eth/filters"
+	"github.com/erigontech/erigon/eth/tracers/live"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
@@ -51,6 +53,9 @@ type ErigonAPI interface {
 
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error)
+
+	// Supply related (see ./erigon_supply.go)
+	GetSupplyDelta(ctx context.Context, blockRange SupplyDeltaRange) ([]*live.SupplyDelta, error)
 }
 
 // ErigonImpl is implementation of the ErigonAPI interface
@@ -58,6 +63,9 @@ type ErigonImpl struct {
 	*BaseAPI
 	db         kv.TemporalRoDB
 	ethBackend rpchelper.ApiBackend
+
+	supplyDB     kv.RoDB // opened on first use, see openSupplyDB
+	supplyDBLock sync.Mutex
 }
 
 // NewErigonAPI returns ErigonImpl instance
@@ -0,0 +1,110 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/eth/tracers/live"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// maxSupplyDeltaRange is the maximum number of blocks erigon_getSupplyDelta returns in one call
+const maxSupplyDeltaRange = 10_000
+
+// SupplyDeltaRange is the block range requested from erigon_getSupplyDelta
+type SupplyDeltaRange struct {
+	FromBlock rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   rpc.BlockNumber `json:"toBlock"`
+}
+
+// GetSupplyDelta implements erigon_getSupplyDelta. Returns ether issuance and burn of the blocks in
+// the given range, as recorded by the `supply` live tracer (--vmtrace=supply). Blocks which were
+// executed without the tracer are absent from the result.
+func (api *ErigonImpl) GetSupplyDelta(ctx context.Context, blockRange SupplyDeltaRange) ([]*live.SupplyDelta, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	from, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockRange.FromBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	to, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockRange.ToBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("fromBlock %d is greater than toBlock %d", from, to)}
+	}
+	if to-from >= maxSupplyDeltaRange {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("block range is limited to %d blocks", maxSupplyDeltaRange)}
+	}
+
+	supplyDB, err := api.openSupplyDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+	supplyTx, err := supplyDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer supplyTx.Rollback()
+	return live.ReadSupplyDeltas(supplyTx, from, to)
+}
+
+// openSupplyDB lazily opens the database of the supply tracer. Erigon creates it,
+// so it may appear after rpcdaemon has started.
+func (api *ErigonImpl) openSupplyDB(ctx context.Context) (kv.RoDB, error) {
+	api.supplyDBLock.Lock()
+	defer api.supplyDBLock.Unlock()
+	if api.supplyDB != nil {
+		return api.supplyDB, nil
+	}
+	exists, err := dir.FileExist(filepath.Join(api.dirs.Supply, "mdbx.dat"))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("supply tracer data not found: erigon must run with --vmtrace=supply")
+	}
+	db, err := live.OpenSupplyDB(ctx, api.dirs.Supply, true, log.Root())
+	if err != nil {
+		return nil, err
+	}
+	api.supplyDB = db
+	return db, nil
+}
+
+// Close releases the database of the supply tracer, if it was opened.
+func (api *ErigonImpl) Close() {
+	api.supplyDBLock.Lock()
+	defer api.supplyDBLock.Unlock()
+	if api.supplyDB != nil {
+		api.supplyDB.Close()
+		api.supplyDB = nil
+	}
+}
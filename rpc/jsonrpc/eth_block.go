@@ -22,6 +22,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/math"
@@ -445,6 +446,24 @@ func (api *APIImpl) GetBlockTransactionCountByHash(ctx context.Context, blockHas
 	return &numOfTx, nil
 }
 
+// GetBlockAccessList implements eth_getBlockAccessList. Returns the EIP-7928 block access list
+// recorded when the block was executed, or null if the block is unknown or has none.
+func (api *APIImpl) GetBlockAccessList(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (types.BlockAccessList, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		// (Compatibility) Every other node just return `null` for when the block does not exist.
+		log.Debug("eth_getBlockAccessList GetBlockNumber failed", "err", err)
+		return nil, nil
+	}
+	return rawdb.ReadBlockAccessList(tx, blockHash, blockNum)
+}
+
 func (api *APIImpl) blockByNumber(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, error) {
 	if number != rpc.PendingBlockNumber {
 		return api.blockByRPCNumber(ctx, number, tx)
@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
@@ -318,3 +320,36 @@ func TestGetBlockTransactionCountByNumber_ZeroTx(t *testing.T) {
 
 	assert.Equal(t, expectedAmount, *txCount)
 }
+
+func TestGetBlockAccessList(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	ctx := context.Background()
+
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	blockHash := common.HexToHash("0x6804117de2f3e6ee32953e78ced1db7b20214e0d8c745a03b8fecf7cc8ee76ef")
+
+	bal, err := api.GetBlockAccessList(ctx, rpc.BlockNumberOrHashWithHash(blockHash, true))
+	require.NoError(t, err)
+	require.Nil(t, bal)
+
+	expected := types.BlockAccessList{{
+		Address:        common.Address{1},
+		BalanceChanges: []types.BalanceChange{{Index: 1, Balance: *uint256.NewInt(100)}},
+	}}
+	err = m.DB.Update(ctx, func(tx kv.RwTx) error {
+		header, err := rawdb.ReadHeaderByHash(tx, blockHash)
+		if err != nil {
+			return err
+		}
+		return rawdb.WriteBlockAccessList(tx, blockHash, header.Number.Uint64(), expected)
+	})
+	require.NoError(t, err)
+
+	bal, err = api.GetBlockAccessList(ctx, rpc.BlockNumberOrHashWithHash(blockHash, true))
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), bal.Hash())
+
+	bal, err = api.GetBlockAccessList(ctx, rpc.BlockNumberOrHashWithHash(common.Hash{1}, false))
+	require.NoError(t, err)
+	require.Nil(t, bal)
+}
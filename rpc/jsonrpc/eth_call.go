@@ -27,6 +27,7 @@ import (
 	"github.com/holiman/uint256"
 	"google.golang.org/grpc"
 
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/empty"
@@ -36,12 +37,12 @@ import (
 	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/dbutils"
-	"github.com/erigontech/erigon-lib/kv/membatchwithdb"
 	"github.com/erigontech/erigon-lib/log/v3"
 	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/trie"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon-lib/types/accounts"
+	witnesstypes "github.com/erigontech/erigon-lib/types/witness"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/vm"
@@ -301,7 +302,8 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	return hexutil.Uint64(hi), nil
 }
 
-// GetProof implements eth_getProof partially; Proofs are available only with the `latest` block tag.
+// GetProof implements eth_getProof. Proofs of past blocks come from the commitment history or, without
+// it, from a trie rebuilt from the state history for blocks within MaxGetProofRewindBlockCount of the latest.
 func (api *APIImpl) GetProof(ctx context.Context, address common.Address, storageKeys []hexutil.Bytes, blockNrOrHash rpc.BlockNumberOrHash) (*accounts.AccProofResult, error) {
 	roTx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
@@ -354,17 +356,23 @@ func (api *APIImpl) getProof(ctx context.Context, roTx kv.TemporalTx, address co
 		if err != nil {
 			return nil, err
 		}
-		commitmentStartingTxNum := tx.Debug().HistoryStartFrom(kv.CommitmentDomain)
-		if lastTxnInBlock < commitmentStartingTxNum {
-			return nil, state.PrunedError
-		}
-
-		sdCtx.SetLimitReadAsOfTxNum(lastTxnInBlock, false)
-		//domains.SetTrace(true)
-		if err := domains.SeekCommitment(context.Background(), roTx); err != nil {
+		withHistory, err := hasCommitmentHistory(tx, lastTxnInBlock)
+		if err != nil {
 			return nil, err
 		}
-		domains.SetTrace(false)
+		if !withHistory {
+			// no commitment history that far: rebuild the trie from the state history
+			if err := api.rewindCommitment(ctx, tx, domains, blockNrOrHash.BlockNumber.Uint64(), latestBlock, lastTxnInBlock, header.Root); err != nil {
+				return nil, err
+			}
+		} else {
+			sdCtx.SetLimitReadAsOfTxNum(lastTxnInBlock, false)
+			//domains.SetTrace(true)
+			if err := domains.SeekCommitment(context.Background(), roTx); err != nil {
+				return nil, err
+			}
+			domains.SetTrace(false)
+		}
 	}
 
 	// touch account
@@ -518,105 +526,175 @@ func verifyExecResult(execResult *core.EphemeralExecResult, block *types.Block)
 	return nil
 }
 
-func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger) (hexutil.Bytes, error) {
-	roTx, err := db.BeginRo(ctx)
+func (api *BaseAPI) getWitness(ctx context.Context, db kv.TemporalRoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger) (hexutil.Bytes, error) {
+	var result hexutil.Bytes
+	err := api.buildWitness(ctx, db, blockNrOrHash, txIndex, fullBlock, maxGetProofRewindBlockCount, logger, func(bw *blockWitness) error {
+		// Witness for genesis block is empty
+		if bw == nil {
+			w := trie.NewWitness(make([]trie.WitnessOperator, 0))
+
+			var buf bytes.Buffer
+			if _, err := w.WriteInto(&buf); err != nil {
+				return err
+			}
+			result = buf.Bytes()
+			return nil
+		}
+
+		witnessBuffer, err := bw.encode()
+		if err != nil {
+			return err
+		}
+
+		// this is a verification step: we execute block #blockNr statelessly using the witness, and we expect to get the same state root as in the header
+		// otherwise something went wrong
+		bw.store.Tds.SetTrie(bw.trie)
+		newStateRoot, err := stagedsync.ExecuteBlockStatelessly(bw.block, bw.prevHeader, bw.store.ChainReader, bw.store.Tds, &bw.cfg, witnessBuffer, bw.store.GetHashFn, logger)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(newStateRoot.Bytes(), bw.block.Root().Bytes()) {
+			logger.Warn("[getWitness] state root mismatch after stateless execution", "block", bw.block.NumberU64(), "actual", newStateRoot, "expected", bw.block.Root())
+		}
+		result = common.CopyBytes(witnessBuffer.Bytes())
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return result, nil
+}
+
+// blockWitness is the result of the ephemeral execution of a block on top of the state of its parent:
+// everything which was touched by the block, and the merkle paths to it.
+type blockWitness struct {
+	block       *types.Block
+	prevHeader  *types.Header
+	chainConfig *chain.Config
+	engine      consensus.Engine
+	cfg         stagedsync.WitnessCfg
+	store       *stagedsync.WitnessStore
+
+	trie              *trie.Trie // state of the parent block, resolved along the touched keys
+	touchedPlainKeys  [][]byte   // addresses and address+slot storage keys
+	touchedHashedKeys [][]byte
+	codeReads         map[common.Hash]witnesstypes.CodeWithHash
+	oldestBlockHash   uint64 // lowest block number the block read with BLOCKHASH, block number if none
+}
+
+// buildWitness executes the requested block ephemerally and calls fn with its witness while the
+// underlying transactions are still open. fn is called with nil for the genesis block, whose witness
+// is empty, and is not called at all if the block is not found.
+func (api *BaseAPI) buildWitness(ctx context.Context, db kv.TemporalRoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger, fn func(bw *blockWitness) error) error {
+	roTx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
 	defer roTx.Rollback()
 
 	blockNr, hash, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, roTx, api._blockReader, api.filters) // DoCall cannot be executed on non-canonical blocks
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Witness for genesis block is empty
 	if blockNr == 0 {
-		w := trie.NewWitness(make([]trie.WitnessOperator, 0))
-
-		var buf bytes.Buffer
-		_, err = w.WriteInto(&buf)
-		if err != nil {
-			return nil, err
-		}
-
-		return buf.Bytes(), nil
+		return fn(nil)
 	}
 
 	block, err := api.blockWithSenders(ctx, roTx, hash, blockNr)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if block == nil {
-		return nil, nil
+		return nil
 	}
 
 	if !fullBlock && int(txIndex) >= len(block.Transactions()) {
-		return nil, fmt.Errorf("transaction index out of bounds: %d", txIndex)
+		return fmt.Errorf("transaction index out of bounds: %d", txIndex)
 	}
 
 	latestBlock, err := rpchelper.GetLatestBlockNumber(roTx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if latestBlock < blockNr {
 		// shouldn't happen, but check anyway
-		return nil, fmt.Errorf("block number is in the future latest=%d requested=%d", latestBlock, blockNr)
+		return fmt.Errorf("block number is in the future latest=%d requested=%d", latestBlock, blockNr)
 	}
 
 	// Compute the witness if it's for a tx or it's not present in db
 	prevHeader, err := api._blockReader.HeaderByNumber(ctx, roTx, blockNr-1)
 	if err != nil {
-		return nil, err
-	}
-
-	regenerateHash := false
-	if latestBlock-blockNr > uint64(maxGetProofRewindBlockCount) {
-		regenerateHash = true
+		return err
 	}
 
 	engine, ok := api.engine().(consensus.Engine)
 	if !ok {
-		return nil, errors.New("engine is not consensus.Engine")
+		return errors.New("engine is not consensus.Engine")
 	}
 
-	roTx2, err := db.BeginRo(ctx)
+	// Prepare witness config
+	chainConfig, err := api.chainConfig(ctx, roTx)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error loading chain config: %v", err)
 	}
-	defer roTx2.Rollback()
-	txBatch2 := membatchwithdb.NewMemoryBatch(roTx2, "", logger)
-	defer txBatch2.Rollback()
+	cfg := stagedsync.StageWitnessCfg(true, 0, chainConfig, engine, api._blockReader, api.dirs)
 
-	// Prepare witness config
-	chainConfig, err := api.chainConfig(ctx, roTx2)
+	store, err := stagedsync.PrepareForWitness(roTx, block, prevHeader.Root, &cfg, ctx, logger)
 	if err != nil {
-		return nil, fmt.Errorf("error loading chain config: %v", err)
+		return err
 	}
 
-	// Unwind to blockNr
-	cfg := stagedsync.StageWitnessCfg(true, 0, chainConfig, engine, api._blockReader, api.dirs)
-	err = stagedsync.RewindStagesForWitness(txBatch2, blockNr, latestBlock, &cfg, regenerateHash, ctx, logger)
+	domains, err := libstate.NewSharedDomains(roTx, log.New())
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer domains.Close()
+	sdCtx := domains.GetCommitmentContext()
 
-	store, err := stagedsync.PrepareForWitness(txBatch2, block, prevHeader.Root, &cfg, ctx, logger)
+	// the merkle paths are read as of the first txNum of the block, the state of its parent
+	txNum, err := api._txNumReader.Min(roTx, blockNr)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	domains, err := libstate.NewSharedDomains(txBatch2, log.New())
+	withHistory, err := hasCommitmentHistory(roTx, txNum)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if !withHistory {
+		// no commitment history that far: rebuild the trie from the state history
+		if latestBlock-blockNr >= uint64(maxGetProofRewindBlockCount) {
+			return fmt.Errorf("%w: block %d is more than %d blocks behind the latest one", state.PrunedError, blockNr-1, maxGetProofRewindBlockCount)
+		}
+		if txNum < roTx.Debug().HistoryStartFrom(kv.AccountsDomain) || txNum < roTx.Debug().HistoryStartFrom(kv.StorageDomain) {
+			return state.PrunedError
+		}
+		rewound, err := sdCtx.RewindTo(ctx, roTx, blockNr-1, txNum)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(rewound.RootHash, prevHeader.Root[:]) {
+			return fmt.Errorf("rewound state root mismatch at block %d: %x, expected %x", blockNr-1, rewound.RootHash, prevHeader.Root)
+		}
+	} else {
+		sdCtx.SetLimitReadAsOfTxNum(txNum, false)
+		if err := domains.SeekCommitment(ctx, roTx); err != nil {
+			return err
+		}
+	}
+
+	// BLOCKHASH reads are recorded, the witness has to carry the headers they reach
+	oldestBlockHash := blockNr
+	getHashFn := func(n uint64) (common.Hash, error) {
+		oldestBlockHash = min(oldestBlockHash, n)
+		return store.GetHashFn(n)
 	}
-	sdCtx := domains.GetCommitmentContext()
 
 	// execute block #blockNr ephemerally. This will use TrieStateWriter to record touches of accounts and storage keys.
-	_, err = core.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, store.GetHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
+	_, err = core.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, getHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// gather touched keys from ephemeral block execution
@@ -631,16 +709,33 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 	// generate the block witness, this works by loading the merkle paths to the touched keys (they are loaded from the state at block #blockNr-1)
 	witnessTrie, witnessRootHash, err := sdCtx.Witness(ctx, codeReads, prevHeader.Root[:], "computeWitness")
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if !bytes.Equal(witnessRootHash, prevHeader.Root[:]) {
-		return nil, fmt.Errorf("witness root hash mismatch actual(%x)!=expected(%x)", witnessRootHash, prevHeader.Root[:])
-	}
+		return fmt.Errorf("witness root hash mismatch actual(%x)!=expected(%x)", witnessRootHash, prevHeader.Root[:])
+	}
+
+	return fn(&blockWitness{
+		block:             block,
+		prevHeader:        prevHeader,
+		chainConfig:       chainConfig,
+		engine:            engine,
+		cfg:               cfg,
+		store:             store,
+		trie:              witnessTrie,
+		touchedPlainKeys:  touchedPlainKeys,
+		touchedHashedKeys: touchedHashedKeys,
+		codeReads:         codeReads,
+		oldestBlockHash:   oldestBlockHash,
+	})
+}
 
+// encode serializes the witness trie into the witness wire format.
+func (bw *blockWitness) encode() (*bytes.Buffer, error) {
 	// retain list is need for the serialization of the trie.Trie into a witness
 	retainListBuilder := trie.NewRetainListBuilder()
-	for _, key := range touchedHashedKeys {
+	for _, key := range bw.touchedHashedKeys {
 		if len(key) == 32 {
 			retainListBuilder.AddTouch(key)
 		} else {
@@ -650,37 +745,23 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 		}
 	}
 
-	for _, codeWithHash := range codeReads {
+	for _, codeWithHash := range bw.codeReads {
 		retainListBuilder.ReadCode(codeWithHash.CodeHash, codeWithHash.Code)
 	}
 
 	retainList := retainListBuilder.Build(false)
 
 	// serialize witness trie
-	witness, err := witnessTrie.ExtractWitness(true, retainList)
+	witness, err := bw.trie.ExtractWitness(true, retainList)
 	if err != nil {
 		return nil, err
 	}
 
 	var witnessBuffer bytes.Buffer
-	_, err = witness.WriteInto(&witnessBuffer)
-	if err != nil {
-		return nil, err
-	}
-
-	// this is a verification step: we execute block #blockNr statelessly using the witness, and we expect to get the same state root as in the header
-	// otherwise something went wrong
-	store.Tds.SetTrie(witnessTrie)
-	newStateRoot, err := stagedsync.ExecuteBlockStatelessly(block, prevHeader, store.ChainReader, store.Tds, &cfg, &witnessBuffer, store.GetHashFn, logger)
-	if err != nil {
+	if _, err = witness.WriteInto(&witnessBuffer); err != nil {
 		return nil, err
 	}
-	if !bytes.Equal(newStateRoot.Bytes(), block.Root().Bytes()) {
-		fmt.Printf("state root mismatch after stateless execution actual(%x) != expected(%x)\n", newStateRoot.Bytes(), block.Root().Bytes())
-	}
-	witnessBufBytes := witnessBuffer.Bytes()
-	witnessBufBytesCopy := common.CopyBytes(witnessBufBytes)
-	return witnessBufBytesCopy, nil
+	return &witnessBuffer, nil
 }
 
 func (api *APIImpl) tryBlockFromLru(hash common.Hash) *types.Block {
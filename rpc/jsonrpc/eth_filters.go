@@ -21,7 +21,10 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/eth/filters"
@@ -264,6 +267,62 @@ func (api *APIImpl) NewPendingTransactionsWithBody(ctx context.Context) (*rpc.Su
 	return rpcSub, nil
 }
 
+// TxpoolEvents send a notification each time a transaction is added to the txpool, moves between its sub-pools,
+// gets replaced or leaves it. Only transactions of given senders are notified, of all senders if none are given.
+func (api *APIImpl) TxpoolEvents(ctx context.Context, senders *[]common.Address) (*rpc.Subscription, error) {
+	if api.txPool == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	req := &txpool.EventsRequest{}
+	if senders != nil {
+		for _, sender := range *senders {
+			req.Senders = append(req.Senders, gointerfaces.ConvertAddressToH160(sender))
+		}
+	}
+	// the stream outlives the subscribe request, it's cancelled on unsubscribe
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream, err := api.txPool.Events(streamCtx, req)
+	if err != nil {
+		cancel()
+		return &rpc.Subscription{}, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		select {
+		case <-rpcSub.Err():
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
+	go func() {
+		defer debug.LogPanic()
+		defer cancel()
+
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if streamCtx.Err() == nil {
+					log.Warn("[rpc] txpool events stream was closed", "err", err)
+				}
+				return
+			}
+			if err := notifier.Notify(rpcSub.ID, newTxpoolEvent(event)); err != nil {
+				log.Warn("[rpc] error while notifying subscription", "err", err)
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // Logs send a notification each time a new log appears.
 func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
 	if api.filters == nil {
@@ -0,0 +1,86 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/core/state"
+)
+
+// proofTriesCacheSize is the number of rewound tries kept for eth_getProof: requests for the
+// accounts of a same past block mostly come in bursts
+const proofTriesCacheSize = 32
+
+// proofTrieKey identifies a trie rewound to txNum on top of the latest commitment, at baseTxNum.
+type proofTrieKey struct {
+	txNum, baseTxNum uint64
+}
+
+func newProofTriesCache() *lru.Cache[proofTrieKey, *libstate.RewoundTrie] {
+	cache, err := lru.New[proofTrieKey, *libstate.RewoundTrie](proofTriesCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return cache
+}
+
+// hasCommitmentHistory tells whether the commitment can be read as of txNum from the commitment
+// history, which is only kept when the node is configured to.
+func hasCommitmentHistory(tx kv.TemporalTx, txNum uint64) (bool, error) {
+	enabled, _, err := rawdb.ReadDBCommitmentHistoryEnabled(tx)
+	if err != nil {
+		return false, err
+	}
+	return enabled && txNum >= tx.Debug().HistoryStartFrom(kv.CommitmentDomain), nil
+}
+
+// rewindCommitment sets the commitment of domains up to prove keys as of txNum, the first txNum
+// after blockNum, when the commitment history doesn't reach it: the trie is rebuilt from the
+// accounts and storage history for blocks at most MaxGetProofRewindBlockCount behind the latest.
+func (api *APIImpl) rewindCommitment(ctx context.Context, tx kv.TemporalTx, domains *libstate.SharedDomains, blockNum, latestBlock, txNum uint64, root common.Hash) error {
+	if latestBlock-blockNum > uint64(api.MaxGetProofRewindBlockCount) {
+		return fmt.Errorf("%w: block %d is more than %d blocks behind the latest one", state.PrunedError, blockNum, api.MaxGetProofRewindBlockCount)
+	}
+	if txNum < tx.Debug().HistoryStartFrom(kv.AccountsDomain) || txNum < tx.Debug().HistoryStartFrom(kv.StorageDomain) {
+		return state.PrunedError
+	}
+
+	sdCtx := domains.GetCommitmentContext()
+	key := proofTrieKey{txNum: txNum, baseTxNum: domains.TxNum()}
+	if rewound, ok := api.proofTries.Get(key); ok {
+		return sdCtx.UseRewoundTrie(rewound)
+	}
+
+	rewound, err := sdCtx.RewindTo(ctx, tx, blockNum, txNum)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rewound.RootHash, root[:]) {
+		return fmt.Errorf("rewound state root mismatch at block %d: %x, expected %x", blockNum, rewound.RootHash, root)
+	}
+	api.proofTries.Add(key, rewound)
+	return nil
+}
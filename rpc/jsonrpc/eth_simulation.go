@@ -0,0 +1,653 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus/misc"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/transactions"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks (including the gaps filled with empty blocks)
+	// that can be simulated in a single eth_simulateV1 request.
+	maxSimulateBlocks = 256
+
+	// simulateTimestampIncrement is the default increment between the timestamps of simulated blocks.
+	simulateTimestampIncrement = 12
+)
+
+// Error codes defined by the eth_simulateV1 specification.
+const (
+	simErrCodeNonceTooLow         = -38010
+	simErrCodeNonceTooHigh        = -38011
+	simErrCodeBaseFeeTooLow       = -38012
+	simErrCodeIntrinsicGas        = -38013
+	simErrCodeInsufficientFunds   = -38014
+	simErrCodeBlockGasLimit       = -38015
+	simErrCodeBlockNumberInvalid  = -38020
+	simErrCodeBlockTimeInvalid    = -38021
+	simErrCodeSenderIsNotEOA      = -38024
+	simErrCodeMaxInitCodeExceeded = -38025
+	simErrCodeClientLimitExceeded = -38026
+	simErrCodeVMError             = -32015
+	simErrCodeReverted            = 3
+)
+
+var (
+	// simTransferTopic is the topic of the ERC-20 style log emitted for ETH transfers when traceTransfers is set.
+	simTransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	// simTransferAddress is the pseudo-address emitting the ETH transfer logs.
+	simTransferAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+)
+
+// SimulationRequest is the argument of eth_simulateV1.
+type SimulationRequest struct {
+	BlockStateCalls        []SimulatedBlock `json:"blockStateCalls"`
+	TraceTransfers         bool             `json:"traceTransfers"`
+	Validation             bool             `json:"validation"`
+	ReturnFullTransactions bool             `json:"returnFullTransactions"`
+}
+
+// SimulatedBlock is a block to simulate: overrides applied on top of the previous block and the calls to execute in it.
+type SimulatedBlock struct {
+	BlockOverrides *ethapi.BlockOverrides `json:"blockOverrides"`
+	StateOverrides *ethapi.StateOverrides `json:"stateOverrides"`
+	Calls          []ethapi.CallArgs      `json:"calls"`
+}
+
+// SimulatedCallResult is the outcome of a single call executed in a simulated block.
+type SimulatedCallResult struct {
+	ReturnValue hexutil.Bytes       `json:"returnData"`
+	Logs        []*types.Log        `json:"logs"`
+	GasUsed     hexutil.Uint64      `json:"gasUsed"`
+	Status      hexutil.Uint64      `json:"status"`
+	Error       *SimulatedCallError `json:"error,omitempty"`
+}
+
+// SimulatedCallError describes why a simulated call failed or reverted.
+type SimulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// SimulateV1 implements eth_simulateV1. It executes a sequence of calls in a chain of simulated blocks built on top
+// of the requested block, with optional block and state overrides per block.
+func (api *APIImpl) SimulateV1(ctx context.Context, req SimulationRequest, requestedBlock *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(req.BlockStateCalls) == 0 {
+		return nil, &rpc.InvalidParamsError{Message: "empty input"}
+	}
+	if len(req.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &rpc.CustomError{Code: simErrCodeClientLimitExceeded, Message: "too many blocks"}
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var blockNrOrHash rpc.BlockNumberOrHash
+	if requestedBlock != nil {
+		blockNrOrHash = *requestedBlock
+	} else {
+		blockNrOrHash = latestNumOrHash
+	}
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	header, _, err := headerByNumberOrHash(ctx, tx, blockNrOrHash, api)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("header not found")
+	}
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, api._blockReader, blockNrOrHash, 0, api.filters, api.stateCache, api._txNumReader)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(start time.Time) { log.Trace("Executing EVM simulateV1 finished", "runtime", time.Since(start)) }(time.Now())
+
+	ctx, cancel := transactions.NewCallContext(ctx, api.evmCallTimeout)
+	// Make sure the context is cancelled when the simulation has completed
+	// this makes sure resources are cleaned up.
+	defer cancel()
+
+	// A zero gas cap means the calls are not capped, as for eth_call
+	gasRemaining := api.GasCap
+	if gasRemaining == 0 {
+		gasRemaining = math.MaxUint64
+	}
+
+	sim := &simulator{
+		api:            api,
+		tx:             tx,
+		chainConfig:    chainConfig,
+		base:           header,
+		ibs:            state.New(stateReader),
+		gasRemaining:   gasRemaining,
+		traceTransfers: req.TraceTransfers,
+		validation:     req.Validation,
+		fullTx:         req.ReturnFullTransactions,
+	}
+	return sim.execute(ctx, req.BlockStateCalls)
+}
+
+// simulator holds the state carried between the blocks of an eth_simulateV1 request.
+type simulator struct {
+	api            *APIImpl
+	tx             kv.Tx
+	chainConfig    *chain.Config
+	base           *types.Header
+	ibs            *state.IntraBlockState
+	gasRemaining   uint64 // what is left of the gas cap for the whole request
+	traceTransfers bool
+	validation     bool
+	fullTx         bool
+
+	headers []*types.Header // headers of the blocks simulated so far
+}
+
+func (s *simulator) execute(ctx context.Context, blocks []SimulatedBlock) ([]map[string]interface{}, error) {
+	blocks, err := s.sanitizeChain(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single EVM is reset for every call, so that the timeout cancels whichever call is running
+	evm := vm.NewEVM(core.NewEVMBlockContext(s.base, s.getHashFn(), s.api.engine(), nil /* author */, s.chainConfig), evmtypes.TxContext{}, s.ibs, s.chainConfig, vm.Config{})
+	transactions.CancelOnDone(ctx, evm)
+
+	results := make([]map[string]interface{}, 0, len(blocks))
+	parent := s.base
+	for _, block := range blocks {
+		simulated, callResults, err := s.processBlock(ctx, evm, &block, parent)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := ethapi.RPCMarshalBlock(simulated, true, s.fullTx, map[string]interface{}{"calls": callResults})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fields)
+		parent = simulated.HeaderNoCopy()
+		s.headers = append(s.headers, parent)
+	}
+	return results, nil
+}
+
+// sanitizeChain fills in the block numbers and timestamps which were not overridden, validates that they are
+// increasing and inserts empty blocks in the gaps between non-consecutive block numbers.
+func (s *simulator) sanitizeChain(blocks []SimulatedBlock) ([]SimulatedBlock, error) {
+	var (
+		res           = make([]SimulatedBlock, 0, len(blocks))
+		baseNumber    = s.base.Number.Uint64()
+		prevNumber    = baseNumber
+		prevTimestamp = s.base.Time
+	)
+	for _, block := range blocks {
+		if block.BlockOverrides == nil {
+			block.BlockOverrides = &ethapi.BlockOverrides{}
+		}
+		if block.BlockOverrides.Number == nil {
+			block.BlockOverrides.Number = (*hexutil.Big)(new(big.Int).SetUint64(prevNumber + 1))
+		}
+		number := block.BlockOverrides.Number.ToInt()
+		if !number.IsUint64() || number.Uint64() <= prevNumber {
+			return nil, &rpc.CustomError{Code: simErrCodeBlockNumberInvalid, Message: fmt.Sprintf("block numbers must be in order: %d <= %d", number, prevNumber)}
+		}
+		if number.Uint64()-baseNumber > maxSimulateBlocks {
+			return nil, &rpc.CustomError{Code: simErrCodeClientLimitExceeded, Message: "too many blocks"}
+		}
+		// Fill the gap with empty blocks
+		for n := prevNumber + 1; n < number.Uint64(); n++ {
+			prevTimestamp += simulateTimestampIncrement
+			t := hexutil.Uint64(prevTimestamp)
+			res = append(res, SimulatedBlock{BlockOverrides: &ethapi.BlockOverrides{
+				Number: (*hexutil.Big)(new(big.Int).SetUint64(n)),
+				Time:   &t,
+			}})
+		}
+		if block.BlockOverrides.Time == nil {
+			t := hexutil.Uint64(prevTimestamp + simulateTimestampIncrement)
+			block.BlockOverrides.Time = &t
+		} else if uint64(*block.BlockOverrides.Time) <= prevTimestamp {
+			return nil, &rpc.CustomError{Code: simErrCodeBlockTimeInvalid, Message: fmt.Sprintf("block timestamps must be in order: %d <= %d", uint64(*block.BlockOverrides.Time), prevTimestamp)}
+		}
+		prevNumber, prevTimestamp = number.Uint64(), uint64(*block.BlockOverrides.Time)
+		res = append(res, block)
+	}
+	return res, nil
+}
+
+func (s *simulator) processBlock(ctx context.Context, evm *vm.EVM, block *SimulatedBlock, parent *types.Header) (*types.Block, []SimulatedCallResult, error) {
+	header := s.makeHeader(block.BlockOverrides, parent)
+	blockNum := header.Number.Uint64()
+	rules := s.chainConfig.Rules(blockNum, header.Time)
+
+	if len(block.BlockOverrides.Withdrawals) > 0 && !s.chainConfig.IsShanghai(header.Time) {
+		return nil, nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("withdrawals are not supported before Shanghai (block %d)", blockNum)}
+	}
+
+	precompiles := vm.ActivePrecompiledContracts(rules)
+	if block.StateOverrides != nil {
+		if err := block.StateOverrides.OverridePrecompiles(precompiles); err != nil {
+			return nil, nil, err
+		}
+		if err := block.StateOverrides.Override(s.ibs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	blockCtx := core.NewEVMBlockContext(header, s.getHashFn(), s.api.engine(), nil /* author */, s.chainConfig)
+	if block.BlockOverrides.BlobBaseFee != nil {
+		blobBaseFee, overflow := uint256.FromBig(block.BlockOverrides.BlobBaseFee.ToInt())
+		if overflow {
+			return nil, nil, errors.New("BlockOverrides.BlobBaseFee uint256 overflow")
+		}
+		blockCtx.BlobBaseFee = blobBaseFee
+	}
+
+	tracer := newSimulationTracer(s.traceTransfers, blockNum)
+	hooks := tracer.Hooks()
+	s.ibs.SetHooks(hooks)
+	defer s.ibs.SetHooks(nil)
+	vmConfig := vm.Config{NoBaseFee: !s.validation, Tracer: hooks}
+
+	var (
+		gp          = new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock(header.Time))
+		gasUsed     uint64
+		blobGasUsed uint64
+		txns        = make([]types.Transaction, 0, len(block.Calls))
+		receipts    = make(types.Receipts, 0, len(block.Calls))
+		callResults = make([]SimulatedCallResult, 0, len(block.Calls))
+	)
+	for i := range block.Calls {
+		call := block.Calls[i]
+		if err := s.sanitizeCall(&call, header, gasUsed); err != nil {
+			return nil, nil, err
+		}
+		msg, err := call.ToMessage(s.api.GasCap, blockCtx.BaseFee)
+		if err != nil {
+			return nil, nil, err
+		}
+		// CallArgs.ToMessage ignores the nonce of the call, a simulated transaction carries it
+		nonce := uint64(*call.Nonce)
+		msg.SetNonce(nonce)
+		msg.SetCheckNonce(s.validation)
+		txn, err := call.ToTransaction(s.api.GasCap, blockCtx.BaseFee)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := setSimulatedTxnNonce(txn, nonce); err != nil {
+			return nil, nil, err
+		}
+		txn.SetSender(msg.From())
+		txnHash := txn.Hash()
+
+		tracer.reset(txnHash, uint(i))
+		s.ibs.SetTxContext(blockNum, i)
+		evm.ResetBetweenBlocks(blockCtx, core.NewEVMTxContext(msg), s.ibs, vmConfig, rules)
+		evm.SetPrecompiles(precompiles)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, transactions.ExecutionAbortedError(s.api.evmCallTimeout)
+		}
+
+		result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, s.api.engine())
+		if err != nil {
+			return nil, nil, simulationTxnError(i, err)
+		}
+		// If the timer caused an abort, return an appropriate error message
+		if evm.Cancelled() {
+			return nil, nil, transactions.ExecutionAbortedError(s.api.evmCallTimeout)
+		}
+		if err = s.ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return nil, nil, err
+		}
+		s.gasRemaining -= result.GasUsed
+		gasUsed += result.GasUsed
+		blobGasUsed += msg.BlobGas()
+
+		receipt := &types.Receipt{
+			Type:              txn.Type(),
+			CumulativeGasUsed: gasUsed,
+			TxHash:            txnHash,
+			GasUsed:           result.GasUsed,
+			BlockNumber:       header.Number,
+			TransactionIndex:  uint(i),
+			Logs:              tracer.Logs(),
+			Status:            types.ReceiptStatusSuccessful,
+		}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+		}
+		if msg.To() == nil {
+			receipt.ContractAddress = crypto.CreateAddress(msg.From(), txn.GetNonce())
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+		callResult := SimulatedCallResult{
+			ReturnValue: result.Return(),
+			Logs:        receipt.Logs,
+			GasUsed:     hexutil.Uint64(result.GasUsed),
+			Status:      hexutil.Uint64(receipt.Status),
+		}
+		if result.Failed() {
+			if errors.Is(result.Err, vm.ErrExecutionReverted) {
+				revertErr := ethapi.NewRevertError(result)
+				callResult.Error = &SimulatedCallError{Code: simErrCodeReverted, Message: revertErr.Error(), Data: hexutil.Encode(result.Revert())}
+			} else {
+				callResult.Error = &SimulatedCallError{Code: simErrCodeVMError, Message: result.Err.Error()}
+			}
+		}
+		if callResult.Logs == nil {
+			callResult.Logs = []*types.Log{}
+		}
+
+		txns = append(txns, txn)
+		receipts = append(receipts, receipt)
+		callResults = append(callResults, callResult)
+	}
+
+	var withdrawals types.Withdrawals
+	if s.chainConfig.IsShanghai(header.Time) {
+		withdrawals = make(types.Withdrawals, 0, len(block.BlockOverrides.Withdrawals))
+	}
+	for _, w := range block.BlockOverrides.Withdrawals {
+		amount := new(uint256.Int).Mul(uint256.NewInt(w.Amount), uint256.NewInt(common.GWei))
+		if err := s.ibs.AddBalance(w.Address, *amount, tracing.BalanceIncreaseWithdrawal); err != nil {
+			return nil, nil, err
+		}
+		withdrawals = append(withdrawals, w)
+	}
+
+	header.GasUsed = gasUsed
+	if s.chainConfig.IsCancun(header.Time) {
+		header.BlobGasUsed = &blobGasUsed
+	}
+	simulated := types.NewBlock(header, txns, nil /* uncles */, receipts, withdrawals)
+
+	// The block hash is only known once all the calls are executed
+	blockHash := simulated.Hash()
+	for _, receipt := range receipts {
+		receipt.BlockHash = blockHash
+		for _, l := range receipt.Logs {
+			l.BlockHash = blockHash
+		}
+	}
+	return simulated, callResults, nil
+}
+
+// makeHeader derives the header of a simulated block from its parent and the block overrides.
+// Note that the post-state root is not computed for simulated blocks: stateRoot is left empty, and
+// the block hash (hence parentHash and the blockHash of receipts and logs) is a placeholder which
+// doesn't match the one other clients return for the same simulation.
+func (s *simulator) makeHeader(overrides *ethapi.BlockOverrides, parent *types.Header) *types.Header {
+	header := overrides.OverrideHeader(&types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  empty.UncleHash,
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		GasLimit:   parent.GasLimit,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       parent.Time + simulateTimestampIncrement,
+	})
+	if s.chainConfig.IsLondon(header.Number.Uint64()) && header.BaseFee == nil {
+		if s.validation {
+			header.BaseFee = misc.CalcBaseFee(s.chainConfig, parent)
+		} else {
+			header.BaseFee = new(big.Int)
+		}
+	}
+	if s.chainConfig.IsCancun(header.Time) {
+		excessBlobGas := misc.CalcExcessBlobGas(s.chainConfig, parent, header.Time)
+		header.ExcessBlobGas = &excessBlobGas
+		header.ParentBeaconBlockRoot = &common.Hash{}
+	}
+	if s.chainConfig.IsPrague(header.Time) {
+		requestsHash := empty.RequestsHash
+		header.RequestsHash = &requestsHash
+	}
+	return header
+}
+
+// sanitizeCall fills in the defaults of a call: the sender nonce, the gas limit (bounded by the gas left
+// in the block and in the request gas cap) and the chain id.
+func (s *simulator) sanitizeCall(args *ethapi.CallArgs, header *types.Header, gasUsed uint64) error {
+	if args.Nonce == nil {
+		var from common.Address
+		if args.From != nil {
+			from = *args.From
+		}
+		nonce, err := s.ibs.GetNonce(from)
+		if err != nil {
+			return err
+		}
+		args.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+	if header.GasLimit < gasUsed {
+		return &rpc.CustomError{Code: simErrCodeBlockGasLimit, Message: fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, header.GasLimit)}
+	}
+	blockGasRemaining := header.GasLimit - gasUsed
+	if args.Gas == nil {
+		gas := min(blockGasRemaining, s.gasRemaining)
+		args.Gas = (*hexutil.Uint64)(&gas)
+	} else {
+		if uint64(*args.Gas) > blockGasRemaining {
+			return &rpc.CustomError{Code: simErrCodeBlockGasLimit, Message: fmt.Sprintf("block gas limit reached: %d >= %d", uint64(*args.Gas), blockGasRemaining)}
+		}
+		if uint64(*args.Gas) > s.gasRemaining {
+			return &rpc.CustomError{Code: simErrCodeClientLimitExceeded, Message: fmt.Sprintf("gas cap exceeded: %d > %d", uint64(*args.Gas), s.gasRemaining)}
+		}
+	}
+	if args.ChainID == nil {
+		args.ChainID = (*hexutil.Big)(s.chainConfig.ChainID)
+	}
+	return nil
+}
+
+// getHashFn resolves block hashes of both the simulated and the canonical blocks.
+func (s *simulator) getHashFn() func(uint64) (common.Hash, error) {
+	canonicalHash := transactions.MakeHeaderGetter(true /* requireCanonical */, s.tx, s.api._blockReader)
+	return func(n uint64) (common.Hash, error) {
+		baseNumber := s.base.Number.Uint64()
+		if n == baseNumber {
+			return s.base.Hash(), nil
+		}
+		if n > baseNumber {
+			for _, h := range s.headers {
+				if h.Number.Uint64() == n {
+					return h.Hash(), nil
+				}
+			}
+			return common.Hash{}, nil
+		}
+		return canonicalHash(n)
+	}
+}
+
+// setSimulatedTxnNonce sets the nonce of a transaction built by CallArgs.ToTransaction.
+func setSimulatedTxnNonce(txn types.Transaction, nonce uint64) error {
+	switch t := txn.(type) {
+	case *types.LegacyTx:
+		t.Nonce = nonce
+	case *types.AccessListTx:
+		t.Nonce = nonce
+	case *types.DynamicFeeTransaction:
+		t.Nonce = nonce
+	case *types.BlobTx:
+		t.Nonce = nonce
+	case *types.BlobTxWrapper:
+		t.Tx.Nonce = nonce
+	case *types.SetCodeTransaction:
+		t.Nonce = nonce
+	default:
+		return fmt.Errorf("unsupported simulated transaction type %d", txn.Type())
+	}
+	return nil
+}
+
+// simulationTxnError maps the errors which make a simulated transaction invalid to the codes of the specification.
+func simulationTxnError(txIndex int, err error) error {
+	code := simErrCodeVMError
+	switch {
+	case errors.Is(err, core.ErrNonceTooLow):
+		code = simErrCodeNonceTooLow
+	case errors.Is(err, core.ErrNonceTooHigh):
+		code = simErrCodeNonceTooHigh
+	case errors.Is(err, core.ErrFeeCapTooLow):
+		code = simErrCodeBaseFeeTooLow
+	case errors.Is(err, core.ErrIntrinsicGas):
+		code = simErrCodeIntrinsicGas
+	case errors.Is(err, core.ErrInsufficientFunds):
+		code = simErrCodeInsufficientFunds
+	case errors.Is(err, core.ErrGasLimitReached):
+		code = simErrCodeBlockGasLimit
+	case errors.Is(err, core.ErrSenderNoEOA):
+		code = simErrCodeSenderIsNotEOA
+	case errors.Is(err, core.ErrMaxInitCodeSizeExceeded):
+		code = simErrCodeMaxInitCodeExceeded
+	}
+	return &rpc.CustomError{Code: code, Message: fmt.Sprintf("transaction %d: %v", txIndex, err)}
+}
+
+// simulationTracer collects the logs of a simulated transaction, dropping the ones emitted by reverted
+// frames, and optionally adds an ERC-20 style Transfer log for every ETH value transfer.
+type simulationTracer struct {
+	// logs keeps the logs of every call frame currently on the stack
+	logs           [][]*types.Log
+	count          uint
+	traceTransfers bool
+	blockNumber    uint64
+	txHash         common.Hash
+	txIndex        uint
+}
+
+func newSimulationTracer(traceTransfers bool, blockNumber uint64) *simulationTracer {
+	return &simulationTracer{traceTransfers: traceTransfers, blockNumber: blockNumber}
+}
+
+func (t *simulationTracer) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter: t.onEnter,
+		OnExit:  t.onExit,
+		OnLog:   t.onLog,
+	}
+}
+
+func (t *simulationTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.logs = append(t.logs, make([]*types.Log, 0))
+	if vm.OpCode(typ) != vm.DELEGATECALL && value != nil && !value.IsZero() {
+		t.captureTransfer(from, to, value)
+	}
+}
+
+func (t *simulationTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if depth == 0 {
+		if reverted {
+			t.count -= uint(len(t.logs[0]))
+			t.logs[0] = nil
+		}
+		return
+	}
+	size := len(t.logs)
+	if size <= 1 {
+		return
+	}
+	// pop the call frame and merge its logs into the parent frame unless it reverted
+	call := t.logs[size-1]
+	t.logs = t.logs[:size-1]
+	size--
+	if reverted {
+		// the logs of a reverted frame don't take up log indexes
+		t.count -= uint(len(call))
+	} else {
+		t.logs[size-1] = append(t.logs[size-1], call...)
+	}
+}
+
+func (t *simulationTracer) onLog(l *types.Log) {
+	t.captureLog(l.Address, l.Topics, l.Data)
+}
+
+func (t *simulationTracer) captureLog(address common.Address, topics []common.Hash, data []byte) {
+	t.logs[len(t.logs)-1] = append(t.logs[len(t.logs)-1], &types.Log{
+		Address:     address,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: t.blockNumber,
+		TxHash:      t.txHash,
+		TxIndex:     t.txIndex,
+		Index:       t.count,
+	})
+	t.count++
+}
+
+func (t *simulationTracer) captureTransfer(from, to common.Address, value *uint256.Int) {
+	if !t.traceTransfers {
+		return
+	}
+	topics := []common.Hash{
+		simTransferTopic,
+		common.BytesToHash(from.Bytes()),
+		common.BytesToHash(to.Bytes()),
+	}
+	t.captureLog(simTransferAddress, topics, common.BigToHash(value.ToBig()).Bytes())
+}
+
+// reset prepares the tracer for the next transaction of the block.
+func (t *simulationTracer) reset(txHash common.Hash, txIndex uint) {
+	t.logs = nil
+	t.txHash = txHash
+	t.txIndex = txIndex
+}
+
+// Logs returns the logs of the last transaction which were not reverted.
+func (t *simulationTracer) Logs() []*types.Log {
+	if len(t.logs) == 0 {
+		return nil
+	}
+	return t.logs[0]
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+var (
+	// PUSH1 0 PUSH1 0 LOG0 STOP
+	simLogEmitterCode = hexutil.MustDecode("0x60006000a000")
+	// PUSH1 0 PUSH1 0 LOG0 PUSH1 0 PUSH1 0 REVERT
+	simLogReverterCode = hexutil.MustDecode("0x60006000a060006000fd")
+)
+
+// simCallerCode emits a log and then calls the given address, ignoring whether the call succeeded.
+func simCallerCode(callee common.Address) hexutil.Bytes {
+	code := hexutil.MustDecode("0x60006000a06000600060006000600073")
+	code = append(code, callee.Bytes()...)
+	// GAS CALL POP STOP
+	return append(code, 0x5a, 0xf1, 0x50, 0x00)
+}
+
+func simulationCalls(t *testing.T, res []map[string]interface{}, block int) []SimulatedCallResult {
+	t.Helper()
+	calls, ok := res[block]["calls"].([]SimulatedCallResult)
+	require.True(t, ok, "block %d has no call results", block)
+	return calls
+}
+
+func requireSimulationErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	require.Error(t, err)
+	var customErr *rpc.CustomError
+	require.True(t, errors.As(err, &customErr), "unexpected error type %T: %v", err, err)
+	assert.Equal(t, code, customErr.Code, customErr.Message)
+}
+
+func TestSimulateV1GapFilling(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	to := common.HexToAddress("0x1234")
+
+	res, err := api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{
+		{Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to}}},
+		{BlockOverrides: &ethapi.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(8))}, Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to}}},
+	}, Validation: true}, &latest)
+	require.NoError(t, err)
+	require.Len(t, res, 5)
+	for i, block := range res {
+		assert.Equal(t, big.NewInt(int64(4+i)), block["number"].(*hexutil.Big).ToInt())
+	}
+	// the blocks filling the gap are empty
+	assert.Len(t, simulationCalls(t, res, 0), 1)
+	for i := 1; i < 4; i++ {
+		assert.Empty(t, simulationCalls(t, res, i))
+	}
+	assert.Len(t, simulationCalls(t, res, 4), 1)
+	// the nonce of the sender carries over the simulated blocks
+	assert.Equal(t, hexutil.Uint64(1), simulationCalls(t, res, 4)[0].Status)
+}
+
+func TestSetSimulatedTxnNonce(t *testing.T) {
+	txns := []types.Transaction{
+		&types.LegacyTx{},
+		&types.AccessListTx{},
+		&types.DynamicFeeTransaction{},
+		&types.BlobTx{},
+		&types.BlobTxWrapper{},
+		&types.SetCodeTransaction{},
+	}
+	for _, txn := range txns {
+		require.NoError(t, setSimulatedTxnNonce(txn, 7))
+		assert.Equal(t, uint64(7), txn.GetNonce(), "type %d", txn.Type())
+	}
+}
+
+func TestSimulateV1BlockOrdering(t *testing.T) {
+	m, _, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	_, err := api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{
+		{BlockOverrides: &ethapi.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(10))}},
+		{BlockOverrides: &ethapi.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(5))}},
+	}}, &latest)
+	requireSimulationErrorCode(t, err, simErrCodeBlockNumberInvalid)
+
+	time := hexutil.Uint64(1)
+	_, err = api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{
+		{BlockOverrides: &ethapi.BlockOverrides{Time: &time}},
+	}}, &latest)
+	requireSimulationErrorCode(t, err, simErrCodeBlockTimeInvalid)
+}
+
+func TestSimulateV1MovePrecompile(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	identity := common.BytesToAddress([]byte{4})
+	moved := common.HexToAddress("0x1234")
+	data := hexutil.Bytes{0xde, 0xad, 0xbe, 0xef}
+
+	res, err := api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{{
+		StateOverrides: &ethapi.StateOverrides{identity: {MovePrecompileToAddress: &moved}},
+		Calls: []ethapi.CallArgs{
+			{From: &bankAddress, To: &moved, Input: &data},
+			{From: &bankAddress, To: &identity, Input: &data},
+		},
+	}}}, &latest)
+	require.NoError(t, err)
+	calls := simulationCalls(t, res, 0)
+	require.Len(t, calls, 2)
+	assert.Equal(t, data, calls[0].ReturnValue)
+	// the original address doesn't hold the precompile anymore
+	assert.Empty(t, calls[1].ReturnValue)
+
+	_, err = api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{{
+		StateOverrides: &ethapi.StateOverrides{moved: {MovePrecompileToAddress: &identity}},
+	}}}, &latest)
+	require.Error(t, err)
+}
+
+func TestSimulateV1RevertedFrameLogs(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	var (
+		caller   = common.HexToAddress("0xc000")
+		emitter  = common.HexToAddress("0xe000")
+		reverter = common.HexToAddress("0xf000")
+
+		callerCode   = simCallerCode(reverter)
+		emitterCode  = hexutil.Bytes(simLogEmitterCode)
+		reverterCode = hexutil.Bytes(simLogReverterCode)
+	)
+	res, err := api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{{
+		StateOverrides: &ethapi.StateOverrides{
+			caller:   {Code: &callerCode},
+			emitter:  {Code: &emitterCode},
+			reverter: {Code: &reverterCode},
+		},
+		Calls: []ethapi.CallArgs{
+			{From: &bankAddress, To: &emitter},
+			{From: &bankAddress, To: &caller},
+			{From: &bankAddress, To: &reverter},
+		},
+	}}}, &latest)
+	require.NoError(t, err)
+	calls := simulationCalls(t, res, 0)
+	require.Len(t, calls, 3)
+
+	require.Len(t, calls[0].Logs, 1)
+	assert.Equal(t, emitter, calls[0].Logs[0].Address)
+
+	// the log of the reverted inner call is dropped
+	assert.Equal(t, hexutil.Uint64(1), calls[1].Status)
+	require.Len(t, calls[1].Logs, 1)
+	assert.Equal(t, caller, calls[1].Logs[0].Address)
+	assert.Equal(t, uint(1), calls[1].Logs[0].Index)
+
+	assert.Equal(t, hexutil.Uint64(0), calls[2].Status)
+	assert.Empty(t, calls[2].Logs)
+	require.NotNil(t, calls[2].Error)
+	assert.Equal(t, simErrCodeReverted, calls[2].Error.Code)
+}
+
+func TestSimulateV1TraceTransfers(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	to := common.HexToAddress("0x1234")
+	value := (*hexutil.Big)(big.NewInt(1000))
+	res, err := api.SimulateV1(context.Background(), SimulationRequest{
+		TraceTransfers:  true,
+		BlockStateCalls: []SimulatedBlock{{Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to, Value: value}}}},
+	}, &latest)
+	require.NoError(t, err)
+	calls := simulationCalls(t, res, 0)
+	require.Len(t, calls, 1)
+	require.Len(t, calls[0].Logs, 1)
+
+	transfer := calls[0].Logs[0]
+	assert.Equal(t, simTransferAddress, transfer.Address)
+	require.Len(t, transfer.Topics, 3)
+	assert.Equal(t, simTransferTopic, transfer.Topics[0])
+	assert.Equal(t, common.BytesToHash(bankAddress.Bytes()), transfer.Topics[1])
+	assert.Equal(t, common.BytesToHash(to.Bytes()), transfer.Topics[2])
+	assert.Equal(t, common.BigToHash(value.ToInt()).Bytes(), transfer.Data)
+}
+
+func TestSimulateV1NonceValidation(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	to := common.HexToAddress("0x1234")
+
+	for _, tt := range []struct {
+		nonce hexutil.Uint64
+		code  int
+	}{
+		{nonce: 0, code: simErrCodeNonceTooLow},
+		{nonce: 10, code: simErrCodeNonceTooHigh},
+	} {
+		nonce := tt.nonce
+		_, err := api.SimulateV1(context.Background(), SimulationRequest{
+			Validation:      true,
+			BlockStateCalls: []SimulatedBlock{{Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to, Nonce: &nonce}}}},
+		}, &latest)
+		requireSimulationErrorCode(t, err, tt.code)
+	}
+
+	// without validation the nonce isn't checked
+	nonce := hexutil.Uint64(10)
+	_, err := api.SimulateV1(context.Background(), SimulationRequest{
+		BlockStateCalls: []SimulatedBlock{{Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to, Nonce: &nonce}}}},
+	}, &latest)
+	require.NoError(t, err)
+}
+
+func TestSimulateV1GasCap(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 100_000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	to := common.HexToAddress("0x1234")
+	gas := hexutil.Uint64(90_000)
+
+	// the gas cap applies to the whole request, not to every call
+	_, err := api.SimulateV1(context.Background(), SimulationRequest{BlockStateCalls: []SimulatedBlock{
+		{Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to, Gas: &gas}}},
+		{Calls: []ethapi.CallArgs{{From: &bankAddress, To: &to, Gas: &gas}}},
+	}}, &latest)
+	requireSimulationErrorCode(t, err, simErrCodeClientLimitExceeded)
+}
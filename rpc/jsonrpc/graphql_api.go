@@ -21,11 +21,17 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	typesproto "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/eth/ethutils"
+	"github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/ethapi"
 	"github.com/erigontech/erigon/rpc/rpchelper"
@@ -33,21 +39,51 @@ import (
 
 type GraphQLAPI interface {
 	GetBlockDetails(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error)
+	GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error)
+	GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error)
 	GetChainID(ctx context.Context) (*big.Int, error)
+	GasPrice(ctx context.Context) (*hexutil.Big, error)
+	MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error)
+	Syncing(ctx context.Context) (interface{}, error)
+	SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error)
 }
 
 type GraphQLAPIImpl struct {
 	*BaseAPI
-	db kv.TemporalRoDB
+	db     kv.TemporalRoDB
+	eth    EthAPI
+	txPool txpool.TxpoolClient
 }
 
-func NewGraphQLAPI(base *BaseAPI, db kv.TemporalRoDB) *GraphQLAPIImpl {
+func NewGraphQLAPI(base *BaseAPI, db kv.TemporalRoDB, eth EthAPI, txPool txpool.TxpoolClient) *GraphQLAPIImpl {
 	return &GraphQLAPIImpl{
 		BaseAPI: base,
 		db:      db,
+		eth:     eth,
+		txPool:  txPool,
 	}
 }
 
+func (api *GraphQLAPIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error) {
+	return api.eth.GetLogs(ctx, crit)
+}
+
+func (api *GraphQLAPIImpl) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	return api.eth.GasPrice(ctx)
+}
+
+func (api *GraphQLAPIImpl) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	return api.eth.MaxPriorityFeePerGas(ctx)
+}
+
+func (api *GraphQLAPIImpl) Syncing(ctx context.Context) (interface{}, error) {
+	return api.eth.Syncing(ctx)
+}
+
+func (api *GraphQLAPIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	return api.eth.SendRawTransaction(ctx, encodedTx)
+}
+
 func (api *GraphQLAPIImpl) GetChainID(ctx context.Context) (*big.Int, error) {
 	tx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
@@ -96,13 +132,7 @@ func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.
 	result := make([]map[string]interface{}, 0, len(receipts))
 	for _, receipt := range receipts {
 		txn := block.Transactions()[receipt.TransactionIndex]
-
-		transaction := ethutils.MarshalReceipt(receipt, txn, chainConfig, block.HeaderNoCopy(), txn.Hash(), true)
-		transaction["nonce"] = txn.GetNonce()
-		transaction["value"] = txn.GetValue()
-		transaction["data"] = txn.GetData()
-		transaction["logs"] = receipt.Logs
-		result = append(result, transaction)
+		result = append(result, marshalGraphQLTransaction(receipt, txn, chainConfig, block.HeaderNoCopy()))
 	}
 
 	response := map[string]interface{}{}
@@ -126,6 +156,114 @@ func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.
 	return response, nil
 }
 
+// GetTransactionDetails returns the details of a mined transaction, in the same format as the receipts of
+// GetBlockDetails, or of a transaction of the pool, without the block and receipt fields. It returns nil
+// if the transaction is unknown.
+func (api *GraphQLAPIImpl) GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, _, ok, err := api.txnLookup(ctx, tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return api.pendingTransactionDetails(ctx, tx, hash)
+	}
+
+	block, err := api.blockByNumberWithSenders(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := api.getReceipts(ctx, tx, block)
+	if err != nil {
+		return nil, fmt.Errorf("getReceipts error: %w", err)
+	}
+
+	for i, txn := range block.Transactions() {
+		if txn.Hash() != hash {
+			continue
+		}
+		if i >= len(receipts) {
+			break
+		}
+		return marshalGraphQLTransaction(receipts[i], txn, chainConfig, block.HeaderNoCopy()), nil
+	}
+	// e.g. a bor state sync transaction, which isn't part of the block body
+	return nil, nil
+}
+
+// pendingTransactionDetails returns the details of the transaction from the pool, nil if it isn't there either.
+func (api *GraphQLAPIImpl) pendingTransactionDetails(ctx context.Context, tx kv.TemporalTx, hash common.Hash) (map[string]interface{}, error) {
+	reply, err := api.txPool.Transactions(ctx, &txpool.TransactionsRequest{Hashes: []*typesproto.H256{gointerfaces.ConvertHashToH256(hash)}})
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.RlpTxs) == 0 || len(reply.RlpTxs[0]) == 0 {
+		return nil, nil
+	}
+	txn, err := types.DecodeWrappedTransaction(reply.RlpTxs[0])
+	if err != nil {
+		return nil, err
+	}
+	txn = txn.Unwrap()
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	rpcTxn := newRPCPendingTransaction(txn, rawdb.ReadCurrentHeader(tx), chainConfig)
+
+	transaction := map[string]interface{}{
+		"transactionHash":     hash,
+		"from":                rpcTxn.From,
+		"to":                  txn.GetTo(),
+		"type":                hexutil.Uint(txn.Type()),
+		"effectiveGasPrice":   rpcTxn.GasPrice,
+		"nonce":               txn.GetNonce(),
+		"value":               txn.GetValue(),
+		"data":                txn.GetData(),
+		"logs":                types.Logs{},
+		"blobVersionedHashes": txn.GetBlobHashes(),
+	}
+	switch t := txn.(type) {
+	case *types.BlobTx:
+		transaction["maxFeePerBlobGas"] = t.MaxFeePerBlobGas
+	case *types.SetCodeTransaction:
+		transaction["authorizationList"] = t.GetAuthorizations()
+	}
+	return transaction, nil
+}
+
+// marshalGraphQLTransaction merges the receipt of a transaction with the transaction fields GraphQL exposes.
+func marshalGraphQLTransaction(receipt *types.Receipt, txn types.Transaction, chainConfig *chain.Config, header *types.Header) map[string]interface{} {
+	transaction := ethutils.MarshalReceipt(receipt, txn, chainConfig, header, txn.Hash(), true)
+	transaction["nonce"] = txn.GetNonce()
+	transaction["value"] = txn.GetValue()
+	transaction["data"] = txn.GetData()
+	transaction["logs"] = receipt.Logs
+	transaction["blobVersionedHashes"] = txn.GetBlobHashes()
+	switch t := txn.(type) {
+	case *types.BlobTx:
+		transaction["maxFeePerBlobGas"] = t.MaxFeePerBlobGas
+	case *types.SetCodeTransaction:
+		transaction["authorizationList"] = t.GetAuthorizations()
+	}
+	return transaction
+}
+
 func (api *GraphQLAPIImpl) getBlockWithSenders(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, []common.Address, error) {
 	if number == rpc.PendingBlockNumber {
 		return api.pendingBlock(), nil, nil
@@ -28,6 +28,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/jsonstream"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/core"
@@ -73,11 +74,69 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 		return fmt.Errorf("invalid arguments; block with hash %x not found", hash)
 	}
 
+	gasUsed, err := api.traceBlockTxs(ctx, tx, block, config, stream)
+	if err != nil {
+		return err
+	}
+
+	if dbg.AssertEnabled {
+		var refunds = true
+		if config != nil && config.NoRefunds != nil && *config.NoRefunds {
+			refunds = false
+		}
+
+		if refunds == true && block.GasUsed() != gasUsed {
+			panic(fmt.Errorf("assert: block.GasUsed() %d != gasUsed %d. blockNum=%d", block.GasUsed(), gasUsed, blockNumber))
+		}
+	}
+
+	return nil
+}
+
+// TraceBadBlock implements debug_traceBadBlock. Returns Geth style traces of the transactions of a block
+// which failed validation, the block is looked up among the recent bad blocks (debug_getBadBlocks).
+// The transactions are executed on top of the state of the parent of the bad block, which has to be canonical:
+// state of non-canonical blocks isn't kept.
+func (api *DebugAPIImpl) TraceBadBlock(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	block, err := api.badBlock(tx, hash)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		stream.WriteNil()
+		return fmt.Errorf("invalid arguments; bad block with hash %x not found", hash)
+	}
+	var parentHash common.Hash
+	var ok bool
+	if block.NumberU64() > 0 {
+		parentHash, ok, err = api._blockReader.CanonicalHash(ctx, tx, block.NumberU64()-1)
+		if err != nil {
+			stream.WriteNil()
+			return err
+		}
+	}
+	if !ok || parentHash != block.ParentHash() {
+		stream.WriteNil()
+		return fmt.Errorf("state of parent %x of bad block %x is not available", block.ParentHash(), hash)
+	}
+
+	_, err = api.traceBlockTxs(ctx, tx, block, config, stream)
+	return err
+}
+
+// traceBlockTxs writes the traces of all transactions of block as a JSON array into stream, and returns the gas they used.
+func (api *DebugAPIImpl) traceBlockTxs(ctx context.Context, tx kv.TemporalTx, block *types.Block, config *tracersConfig.TraceConfig, stream jsonstream.Stream) (uint64, error) {
 	// if we've pruned this history away for this block then just return early
 	// to save any red herring errors
-	err = api.BaseAPI.checkPruneHistory(ctx, tx, block.NumberU64())
+	err := api.BaseAPI.checkPruneHistory(ctx, tx, block.NumberU64())
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if config == nil {
@@ -91,13 +150,13 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 
 	chainConfig, err := api.chainConfig(ctx, tx)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	engine := api.engine()
 
 	ibs, blockCtx, _, rules, signer, err := transactions.ComputeBlockContext(ctx, engine, block.HeaderNoCopy(), chainConfig, api._blockReader, api._txNumReader, tx, 0)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	stream.WriteArrayStart()
@@ -116,7 +175,7 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 			_, ok, err = api._blockReader.EventLookup(ctx, tx, borStateSyncTxHash)
 		}
 		if err != nil {
-			return err
+			return 0, err
 		}
 		if ok {
 			borStateSyncTxn = bortypes.NewBorTransaction()
@@ -142,7 +201,7 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 		select {
 		default:
 		case <-ctx.Done():
-			return ctx.Err()
+			return 0, ctx.Err()
 		}
 		ibs.SetTxContext(blockCtx.BlockNumber, txnIndex)
 		msg, _ := txn.AsMessage(*signer, block.BaseFee(), rules)
@@ -156,9 +215,9 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 
 		if isBorStateSyncTxn {
 			var stateSyncEvents []*types.Message
-			stateSyncEvents, err = api.stateSyncEvents(ctx, tx, block.Hash(), blockNumber, chainConfig)
+			stateSyncEvents, err = api.stateSyncEvents(ctx, tx, block.Hash(), block.NumberU64(), chainConfig)
 			if err != nil {
-				return err
+				return 0, err
 			}
 
 			var _gasUsed uint64
@@ -198,27 +257,16 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 		}
 
 		if err := stream.Flush(); err != nil {
-			return err
-		}
-	}
-
-	if dbg.AssertEnabled {
-		var refunds = true
-		if config.NoRefunds != nil && *config.NoRefunds {
-			refunds = false
-		}
-
-		if refunds == true && block.GasUsed() != gasUsed {
-			panic(fmt.Errorf("assert: block.GasUsed() %d != gasUsed %d. blockNum=%d", block.GasUsed(), gasUsed, blockNumber))
+			return 0, err
 		}
 	}
 
 	stream.WriteArrayEnd()
 	if err := stream.Flush(); err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return gasUsed, nil
 }
 
 // TraceTransaction implements debug_traceTransaction. Returns Geth style transaction traces.
@@ -18,8 +18,10 @@ package jsonrpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
@@ -28,13 +30,65 @@ import (
 	proto_txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/ethapi"
 )
 
 // TxPoolAPI the interface for the txpool_ RPC commands
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*ethapi.RPCTransaction, error)
-	ContentFrom(ctx context.Context, addr common.Address) (map[string]map[string]*ethapi.RPCTransaction, error)
+	ContentFrom(ctx context.Context, addr common.Address, offset *hexutil.Uint, limit *hexutil.Uint) (map[string]map[string]*SubPoolTransaction, error)
+	Inspect(ctx context.Context) (map[string]map[string]map[string]string, error)
+}
+
+// contentFromMaxLimit is the maximum page size of txpool_contentFrom
+const contentFromMaxLimit = 1000
+
+// SubPoolTransaction is a pool transaction along with the sub-pool marker that explains its placement.
+// SubPool bits (high to low): no nonce gaps, enough balance, not too much gas, enough fee cap for the pending block, local.
+type SubPoolTransaction struct {
+	*ethapi.RPCTransaction
+	SubPool hexutil.Uint `json:"subPool"`
+}
+
+// TxpoolEvent is a transaction lifecycle event of the txpoolEvents subscription.
+// SubPool is set for added, promoted and demoted events, Reason for replaced, evicted and mined ones.
+type TxpoolEvent struct {
+	Kind        string         `json:"kind"`
+	Hash        common.Hash    `json:"hash"`
+	From        common.Address `json:"from"`
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	SubPool     string         `json:"subPool,omitempty"`
+	Reason      string         `json:"reason,omitempty"`
+	ReplacedBy  *common.Hash   `json:"replacedBy,omitempty"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+func newTxpoolEvent(event *proto_txpool.Event) *TxpoolEvent {
+	res := &TxpoolEvent{
+		Kind:        strings.ToLower(event.Kind.String()),
+		Hash:        gointerfaces.ConvertH256ToHash(event.Hash),
+		From:        gointerfaces.ConvertH160toAddress(event.Sender),
+		Nonce:       hexutil.Uint64(event.Nonce),
+		Reason:      event.Reason,
+		BlockNumber: hexutil.Uint64(event.BlockNum),
+	}
+	switch event.Kind {
+	case proto_txpool.Event_ADDED, proto_txpool.Event_PROMOTED, proto_txpool.Event_DEMOTED:
+		switch event.SubPool {
+		case proto_txpool.AllReply_PENDING:
+			res.SubPool = "pending"
+		case proto_txpool.AllReply_BASE_FEE:
+			res.SubPool = "baseFee"
+		case proto_txpool.AllReply_QUEUED:
+			res.SubPool = "queued"
+		}
+	}
+	if event.ReplacedBy != nil {
+		replacedBy := common.Hash(gointerfaces.ConvertH256ToHash(event.ReplacedBy))
+		res.ReplacedBy = &replacedBy
+	}
+	return res
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -134,39 +188,22 @@ func (api *TxPoolAPIImpl) Content(ctx context.Context) (map[string]map[string]ma
 	return content, nil
 }
 
-func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr common.Address) (map[string]map[string]*ethapi.RPCTransaction, error) {
-	reply, err := api.pool.All(ctx, &proto_txpool.AllRequest{})
-	if err != nil {
-		return nil, err
-	}
-
-	content := map[string]map[string]*ethapi.RPCTransaction{
-		"pending": make(map[string]*ethapi.RPCTransaction),
-		"baseFee": make(map[string]*ethapi.RPCTransaction),
-		"queued":  make(map[string]*ethapi.RPCTransaction),
+// ContentFrom returns the transactions of addr by sub-pool and nonce, along with their sub-pool markers.
+// offset and limit select a page of the transactions ordered by nonce, all of them are returned if limit is not set.
+func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr common.Address, offset *hexutil.Uint, limit *hexutil.Uint) (map[string]map[string]*SubPoolTransaction, error) {
+	req := &proto_txpool.AllRequest{Sender: gointerfaces.ConvertAddressToH160(addr)}
+	if offset != nil {
+		req.Offset = uint64(*offset)
 	}
-
-	pending := make([]types.Transaction, 0, 4)
-	baseFee := make([]types.Transaction, 0, 4)
-	queued := make([]types.Transaction, 0, 4)
-	for i := range reply.Txs {
-		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
-		if err != nil {
-			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
-		}
-		sender := gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)
-		if sender != addr {
-			continue
-		}
-
-		switch reply.Txs[i].TxnType {
-		case proto_txpool.AllReply_PENDING:
-			pending = append(pending, txn)
-		case proto_txpool.AllReply_BASE_FEE:
-			baseFee = append(baseFee, txn)
-		case proto_txpool.AllReply_QUEUED:
-			queued = append(queued, txn)
+	if limit != nil {
+		if *limit == 0 || *limit > contentFromMaxLimit {
+			return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("limit must be between 1 and %d", contentFromMaxLimit)}
 		}
+		req.Limit = uint64(*limit)
+	}
+	reply, err := api.pool.All(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	tx, err := api.db.BeginTemporalRo(ctx)
@@ -181,26 +218,35 @@ func (api *TxPoolAPIImpl) ContentFrom(ctx context.Context, addr common.Address)
 
 	curHeader := rawdb.ReadCurrentHeader(tx)
 	if curHeader == nil {
-		return nil, nil
-	}
-	// Flatten the pending transactions
-	dump := make(map[string]*ethapi.RPCTransaction, len(pending))
-	for _, txn := range pending {
-		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+		return nil, errors.New("current header not found")
 	}
-	content["pending"] = dump
-	// Flatten the baseFee transactions
-	dump = make(map[string]*ethapi.RPCTransaction, len(baseFee))
-	for _, txn := range baseFee {
-		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+
+	content := map[string]map[string]*SubPoolTransaction{
+		"pending": make(map[string]*SubPoolTransaction),
+		"baseFee": make(map[string]*SubPoolTransaction),
+		"queued":  make(map[string]*SubPoolTransaction),
 	}
-	content["baseFee"] = dump
-	// Flatten the queued transactions
-	dump = make(map[string]*ethapi.RPCTransaction, len(queued))
-	for _, txn := range queued {
-		dump[strconv.FormatUint(txn.GetNonce(), 10)] = newRPCPendingTransaction(txn, curHeader, cc)
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
+		}
+		var subPool string
+		switch reply.Txs[i].TxnType {
+		case proto_txpool.AllReply_PENDING:
+			subPool = "pending"
+		case proto_txpool.AllReply_BASE_FEE:
+			subPool = "baseFee"
+		case proto_txpool.AllReply_QUEUED:
+			subPool = "queued"
+		default:
+			continue
+		}
+		content[subPool][strconv.FormatUint(txn.GetNonce(), 10)] = &SubPoolTransaction{
+			RPCTransaction: newRPCPendingTransaction(txn, curHeader, cc),
+			SubPool:        hexutil.Uint(reply.Txs[i].SubPool),
+		}
 	}
-	content["queued"] = dump
 	return content, nil
 }
 
@@ -217,40 +263,50 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
-/*
-
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
-func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+func (api *TxPoolAPIImpl) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	reply, err := api.pool.All(ctx, &proto_txpool.AllRequest{})
+	if err != nil {
+		return nil, err
+	}
+
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
+		"baseFee": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
 
 	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	format := func(txn types.Transaction) string {
+		if to := txn.GetTo(); to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), txn.GetValue(), txn.GetGasLimit(), txn.GetFeeCap())
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", txn.GetValue(), txn.GetGasLimit(), txn.GetFeeCap())
 	}
-	// Flatten the pending transactions
-	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, txn := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
 		}
-		content["pending"][account.Hex()] = dump
-	}
-	// Flatten the queued transactions
-	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, txn := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+		var subPool string
+		switch reply.Txs[i].TxnType {
+		case proto_txpool.AllReply_PENDING:
+			subPool = "pending"
+		case proto_txpool.AllReply_BASE_FEE:
+			subPool = "baseFee"
+		case proto_txpool.AllReply_QUEUED:
+			subPool = "queued"
+		default:
+			continue
 		}
-		content["queued"][account.Hex()] = dump
+		account := common.Address(gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)).Hex()
+		dump, ok := content[subPool][account]
+		if !ok {
+			dump = make(map[string]string, 4)
+			content[subPool][account] = dump
+		}
+		dump[strconv.FormatUint(txn.GetNonce(), 10)] = format(txn)
 	}
-	return content
+	return content, nil
 }
-*/
@@ -78,3 +78,74 @@ func TestTxPoolContent(t *testing.T) {
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
 }
+
+func TestTxPoolInspectAndContentFrom(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	require.NoError(err)
+	err = m.InsertChain(chain)
+	require.NoError(err)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+	api := NewTxPoolAPI(NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, txPool)
+
+	// nonces 0..2 are executable, nonce 5 sits behind a nonce gap
+	rlpTxs := make([][]byte, 0, 4)
+	for _, nonce := range []uint64{0, 1, 2, 5} {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		rlpTxs = append(rlpTxs, buf.Bytes())
+	}
+	reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: rlpTxs})
+	require.NoError(err)
+	for _, res := range reply.Imported {
+		require.Equal(txpool.ImportResult_SUCCESS, res, fmt.Sprintf("%s", reply.Errors))
+	}
+
+	sender := m.Address.String()
+	inspect, err := api.Inspect(ctx)
+	require.NoError(err)
+	require.Len(inspect["pending"][sender], 3)
+	require.Len(inspect["queued"][sender], 1)
+	require.Equal(fmt.Sprintf("%s: 1234 wei + %d gas × %d wei", common.Address{1}.Hex(), params.TxGas, uint64(10*common.GWei)), inspect["pending"][sender]["1"])
+
+	content, err := api.ContentFrom(ctx, m.Address, nil, nil)
+	require.NoError(err)
+	require.Len(content["pending"], 3)
+	require.Empty(content["baseFee"])
+	require.Len(content["queued"], 1)
+	require.NotZero(content["pending"]["0"].SubPool & 0b010000)
+	require.Zero(content["queued"]["5"].SubPool & 0b010000)
+
+	// pages are ordered by nonce
+	limit, offset := hexutil.Uint(2), hexutil.Uint(0)
+	content, err = api.ContentFrom(ctx, m.Address, &offset, &limit)
+	require.NoError(err)
+	require.Len(content["pending"], 2)
+	require.Contains(content["pending"], "0")
+	require.Contains(content["pending"], "1")
+	require.Empty(content["queued"])
+
+	offset = 2
+	content, err = api.ContentFrom(ctx, m.Address, &offset, &limit)
+	require.NoError(err)
+	require.Len(content["pending"], 1)
+	require.Contains(content["pending"], "2")
+	require.Len(content["queued"], 1)
+	require.Equal(hexutil.Uint64(5), content["queued"]["5"].Nonce)
+
+	content, err = api.ContentFrom(ctx, common.Address{2}, nil, nil)
+	require.NoError(err)
+	require.Empty(content["pending"])
+	require.Empty(content["queued"])
+
+	limit = 0
+	_, err = api.ContentFrom(ctx, m.Address, nil, &limit)
+	require.Error(err)
+}
@@ -31,6 +31,7 @@ var (
 	rpcMetricsLabels   = map[bool]map[string]string{}
 	rpcRequestGauge    = metrics.GetOrCreateCounter("rpc_total")
 	failedReqeustGauge = metrics.GetOrCreateCounter("rpc_failure")
+	rpcQuotaClients    = metrics.GetOrCreateGauge("rpc_quota_clients")
 )
 
 // PreAllocateRPCMetricLabels pre-allocates labels for all rpc methods inside API List
@@ -96,3 +97,11 @@ func newRPCServingTimerMS(method string, valid bool) metrics.Summary {
 
 	return metrics.GetOrCreateSummary(label)
 }
+
+func newRPCQuotaCostCounter(method string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_quota_cost{method="%s"}`, method))
+}
+
+func newRPCQuotaRejectedCounter(method string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_quota_rejected{method="%s"}`, method))
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// APIKeyHeader is the HTTP header clients identify themselves with to be charged by the API key
+// instead of their IP address, see PeerInfo.
+const APIKeyHeader = "X-Api-Key"
+
+// Quota limits the usage of the server by its clients. It is shared by all connections of the server,
+// so implementations must be safe for concurrent use.
+type Quota interface {
+	// Charge accounts the call of the method with the params by the peer. It returns an error, which
+	// is sent to the client instead of the result, if the call must be rejected.
+	Charge(peer PeerInfo, method string, params json.RawMessage) error
+}
+
+// MethodCost is the cost of a method call in quota units.
+type MethodCost struct {
+	Cost     uint64 `json:"cost"`     // static cost of a call
+	PerBlock uint64 `json:"perBlock"` // cost of every block of the range requested with fromBlock and toBlock
+}
+
+// QuotaConfig is the configuration of CostQuota.
+type QuotaConfig struct {
+	Budget      uint64                // number of units a client can spend per period
+	Period      time.Duration         // time it takes to refill the spent budget
+	DefaultCost uint64                // cost of the methods, which are not configured
+	MaxBlocks   uint64                // number of blocks charged for the ranges open to a block tag, as the head is not known
+	Methods     map[string]MethodCost // costs of the methods by name
+	Keys        map[string]uint64     // budgets of the known API keys, 0 for the default one
+}
+
+// CostQuota is the Quota, which gives every client a budget of cost units refilled continuously over
+// the period. Clients are identified by the API key sent in the APIKeyHeader, if it is a known one,
+// and by the remote IP address otherwise.
+type CostQuota struct {
+	cfg QuotaConfig
+	now func() time.Time
+
+	lock        sync.Mutex
+	clients     map[string]*quotaBucket
+	lastCleanup time.Time
+}
+
+type quotaBucket struct {
+	budget    uint64
+	available float64
+	updated   time.Time
+}
+
+func NewCostQuota(cfg QuotaConfig) (*CostQuota, error) {
+	if cfg.Budget == 0 || cfg.Period <= 0 {
+		return nil, errors.New("quota budget and period must be positive")
+	}
+	return &CostQuota{cfg: cfg, now: time.Now, clients: make(map[string]*quotaBucket)}, nil
+}
+
+// Cost returns the cost of the call of the method with the params.
+func (q *CostQuota) Cost(method string, params json.RawMessage) uint64 {
+	c, ok := q.cfg.Methods[method]
+	if !ok {
+		return q.cfg.DefaultCost
+	}
+	if c.PerBlock == 0 {
+		return c.Cost
+	}
+	span := blockSpan(params, q.cfg.MaxBlocks)
+	if span > (math.MaxUint64-c.Cost)/c.PerBlock {
+		return math.MaxUint64
+	}
+	return c.Cost + c.PerBlock*span
+}
+
+func (q *CostQuota) Charge(peer PeerInfo, method string, params json.RawMessage) error {
+	cost := q.Cost(method, params)
+	if cost == 0 {
+		return nil
+	}
+	client, budget := q.client(peer)
+	now := q.now()
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.cleanup(now)
+	b, ok := q.clients[client]
+	if !ok {
+		b = &quotaBucket{budget: budget, available: float64(budget), updated: now}
+		q.clients[client] = b
+		rpcQuotaClients.SetInt(len(q.clients))
+	}
+	b.refill(now, q.cfg.Period)
+	if b.available < float64(cost) {
+		err := &QuotaExceededError{Cost: cost, Budget: b.budget}
+		if cost <= b.budget {
+			err.RetryAfter = time.Duration((float64(cost) - b.available) / float64(b.budget) * float64(q.cfg.Period))
+		}
+		return err
+	}
+	b.available -= float64(cost)
+	newRPCQuotaCostCounter(method).AddUint64(cost)
+	return nil
+}
+
+// client returns the name the calls of the peer are charged to and its budget.
+func (q *CostQuota) client(peer PeerInfo) (string, uint64) {
+	if budget, ok := q.cfg.Keys[peer.HTTP.APIKey]; ok && peer.HTTP.APIKey != "" {
+		if budget == 0 {
+			budget = q.cfg.Budget
+		}
+		return "key:" + peer.HTTP.APIKey, budget
+	}
+	if host, _, err := net.SplitHostPort(peer.RemoteAddr); err == nil {
+		return "ip:" + host, q.cfg.Budget
+	}
+	// IPC connections have no address to tell the clients apart
+	return peer.Transport + ":" + peer.RemoteAddr, q.cfg.Budget
+}
+
+// cleanup forgets the clients, which budgets are refilled completely, once per period.
+func (q *CostQuota) cleanup(now time.Time) {
+	if now.Sub(q.lastCleanup) < q.cfg.Period {
+		return
+	}
+	for client, b := range q.clients {
+		if now.Sub(b.updated) >= q.cfg.Period {
+			delete(q.clients, client)
+		}
+	}
+	q.lastCleanup = now
+	rpcQuotaClients.SetInt(len(q.clients))
+}
+
+func (b *quotaBucket) refill(now time.Time, period time.Duration) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.available = min(float64(b.budget), b.available+float64(b.budget)*float64(elapsed)/float64(period))
+		b.updated = now
+	}
+}
+
+// blockSpan returns the number of blocks in the range requested with the fromBlock and toBlock fields of
+// the first parameter, like in eth_getLogs and trace_filter. Ranges open to a block tag other than earliest
+// count as maxBlocks, as the head is not known here.
+func blockSpan(params json.RawMessage, maxBlocks uint64) uint64 {
+	var args []json.RawMessage
+	if err := json.Unmarshal(params, &args); err != nil || len(args) == 0 {
+		return 1
+	}
+	var filter struct {
+		FromBlock *BlockNumber `json:"fromBlock"`
+		ToBlock   *BlockNumber `json:"toBlock"`
+	}
+	if err := json.Unmarshal(args[0], &filter); err != nil {
+		return 1
+	}
+	from, fromOk := blockSpanBound(filter.FromBlock)
+	to, toOk := blockSpanBound(filter.ToBlock)
+	switch {
+	case !fromOk && !toOk: // both bounds are the same tag, or it is a block hash query
+		return 1
+	case !fromOk || !toOk:
+		return max(maxBlocks, 1)
+	case to < from:
+		return 1
+	default:
+		return uint64(to-from) + 1
+	}
+}
+
+func blockSpanBound(bn *BlockNumber) (BlockNumber, bool) {
+	if bn == nil || *bn < EarliestBlockNumber {
+		return 0, false
+	}
+	return *bn, true
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestBlockSpan(t *testing.T) {
+	for _, tt := range []struct {
+		params string
+		span   uint64
+	}{
+		{`[]`, 1},
+		{`["0x1", true]`, 1},
+		{`[{"fromBlock": "0x10", "toBlock": "0x1f"}]`, 16},
+		{`[{"fromBlock": "earliest", "toBlock": "0x9"}]`, 10},
+		{`[{"fromBlock": "0x10", "toBlock": "0x1"}]`, 1},
+		{`[{"blockHash": "0x01"}]`, 1},
+		{`[{"fromBlock": "latest", "toBlock": "latest"}]`, 1},
+		{`[{"fromBlock": "0x10"}]`, 100},
+		{`[{"fromBlock": "earliest", "toBlock": "finalized"}]`, 100},
+	} {
+		require.Equal(t, tt.span, blockSpan(json.RawMessage(tt.params), 100), tt.params)
+	}
+}
+
+func TestCostQuota(t *testing.T) {
+	q, err := NewCostQuota(QuotaConfig{
+		Budget:      100,
+		Period:      time.Minute,
+		DefaultCost: 10,
+		MaxBlocks:   1000,
+		Methods: map[string]MethodCost{
+			"eth_blockNumber": {},
+			"eth_getLogs":     {Cost: 5, PerBlock: 1},
+			"trace_filter":    {Cost: 5, PerBlock: 2},
+		},
+		Keys: map[string]uint64{"premium": 1000, "default": 0},
+	})
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	q.now = func() time.Time { return now }
+
+	require.Equal(t, uint64(10), q.Cost("eth_call", nil))
+	require.Equal(t, uint64(0), q.Cost("eth_blockNumber", nil))
+	require.Equal(t, uint64(5+16), q.Cost("eth_getLogs", json.RawMessage(`[{"fromBlock": "0x10", "toBlock": "0x1f"}]`)))
+	require.Equal(t, uint64(5+1<<63), q.Cost("eth_getLogs", json.RawMessage(`[{"fromBlock": "0x0", "toBlock": "0x7fffffffffffffff"}]`)))
+	// the cost saturates instead of overflowing
+	require.Equal(t, uint64(math.MaxUint64-2), q.Cost("trace_filter", json.RawMessage(`[{"fromBlock": "0x4", "toBlock": "0x7fffffffffffffff"}]`)))
+	require.Equal(t, uint64(math.MaxUint64), q.Cost("trace_filter", json.RawMessage(`[{"fromBlock": "0x0", "toBlock": "0x7fffffffffffffff"}]`)))
+
+	peer := PeerInfo{Transport: "http", RemoteAddr: "10.0.0.1:1234"}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, q.Charge(peer, "eth_call", nil))
+	}
+	// free methods are always served
+	require.NoError(t, q.Charge(peer, "eth_blockNumber", nil))
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, q.Charge(peer, "eth_call", nil), &quotaErr)
+	require.Equal(t, -32005, quotaErr.ErrorCode())
+	require.Equal(t, 6*time.Second, quotaErr.RetryAfter)
+
+	// other ports of the same address share the budget, other addresses and known keys have their own
+	require.Error(t, q.Charge(PeerInfo{Transport: "ws", RemoteAddr: "10.0.0.1:4321"}, "eth_call", nil))
+	require.NoError(t, q.Charge(PeerInfo{Transport: "http", RemoteAddr: "10.0.0.2:1234"}, "eth_call", nil))
+	premium := peer
+	premium.HTTP.APIKey = "premium"
+	require.NoError(t, q.Charge(premium, "eth_getLogs", json.RawMessage(`[{"fromBlock": "0x0", "toBlock": "0x1f3"}]`)))
+	defaultKey := peer
+	defaultKey.HTTP.APIKey = "default"
+	require.NoError(t, q.Charge(defaultKey, "eth_call", nil))
+	// unknown keys are charged by the address
+	unknownKey := peer
+	unknownKey.HTTP.APIKey = "unknown"
+	require.Error(t, q.Charge(unknownKey, "eth_call", nil))
+
+	// calls costing more than the budget are never served
+	require.ErrorAs(t, q.Charge(PeerInfo{Transport: "ipc"}, "eth_getLogs", json.RawMessage(`[{"fromBlock": "0x0"}]`)), &quotaErr)
+	require.Zero(t, quotaErr.RetryAfter)
+
+	// the budget is refilled over the period
+	now = now.Add(6 * time.Second)
+	require.NoError(t, q.Charge(peer, "eth_call", nil))
+	require.Error(t, q.Charge(peer, "eth_call", nil))
+
+	// idle clients are forgotten
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, q.Charge(PeerInfo{Transport: "http", RemoteAddr: "10.0.0.3:1234"}, "eth_call", nil))
+	require.Len(t, q.clients, 1)
+}
+
+func TestServerQuota(t *testing.T) {
+	logger := log.New()
+	s := newTestServer(logger)
+	defer s.Stop()
+	q, err := NewCostQuota(QuotaConfig{Budget: 2, Period: time.Hour, DefaultCost: 1})
+	require.NoError(t, err)
+	s.SetQuota(q)
+
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	c, err := Dial(ts.URL, logger)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var res echoResult
+	require.NoError(t, c.Call(&res, "test_echo", "x", 1))
+	require.NoError(t, c.Call(&res, "test_echo", "x", 1))
+	err = c.Call(&res, "test_echo", "x", 1)
+	var rpcErr Error
+	require.True(t, errors.As(err, &rpcErr), err)
+	require.Equal(t, -32005, rpcErr.ErrorCode())
+
+	// the quota is shared by the connections of the client
+	wsts := httptest.NewServer(s.WebsocketHandler([]string{"*"}, nil, false, logger))
+	defer wsts.Close()
+	wsc, err := DialWebsocket(context.Background(), "ws"+wsts.URL[len("http"):], "", logger)
+	require.NoError(t, err)
+	defer wsc.Close()
+	require.Error(t, wsc.Call(&res, "test_echo", "x", 1))
+}
@@ -51,6 +51,7 @@ const (
 type Server struct {
 	services        serviceRegistry
 	methodAllowList AllowList
+	quota           Quota
 	idgen           func() ID
 	run             int32
 	codecs          mapset.Set // mapset.Set[ServerCodec] requires go 1.20
@@ -80,6 +81,11 @@ func (s *Server) SetAllowList(allowList AllowList) {
 	s.methodAllowList = allowList
 }
 
+// SetQuota sets the quota the method calls of the clients are charged to
+func (s *Server) SetQuota(quota Quota) {
+	s.quota = quota
+}
+
 // SetBatchLimit sets limit of number of requests in a batch
 func (s *Server) SetBatchLimit(limit int) {
 	s.batchLimit = limit
@@ -110,7 +116,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services, s.logger)
+	c := initClient(codec, s.idgen, &s.services, s.quota, s.logger)
 	<-codec.closed()
 	c.Close()
 }
@@ -124,7 +130,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec, stre
 		return nil
 	}
 
-	h := newHandler(ctx, codec, s.idgen, &s.services, s.methodAllowList, s.batchConcurrency, s.traceRequests, s.logger, s.rpcSlowLogThreshold)
+	h := newHandler(ctx, codec, s.idgen, &s.services, s.methodAllowList, s.quota, s.batchConcurrency, s.traceRequests, s.logger, s.rpcSlowLogThreshold)
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
@@ -199,6 +205,8 @@ type PeerInfo struct {
 		UserAgent string
 		Origin    string
 		Host      string
+		// API key sent in the APIKeyHeader, the calls are charged to if it is known to the quota.
+		APIKey string
 	}
 }
 
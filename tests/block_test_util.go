@@ -45,6 +45,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/eth/tracers"
 	"github.com/erigontech/erigon/execution/stages/mock"
 	"github.com/erigontech/erigon/execution/testutil"
 	"github.com/erigontech/erigon/turbo/services"
@@ -117,14 +118,25 @@ type btHeaderMarshaling struct {
 	ExcessBlobGas *math.HexOrDecimal64
 }
 
+// Network returns the fork the test is filled for.
+func (bt *BlockTest) Network() string {
+	return bt.json.Network
+}
+
 func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
+	return bt.RunWithTracer(t, checkStateRoot, nil)
+}
+
+// RunWithTracer runs the test with the tracer hooked into block execution, tracer may be nil.
+// tb may be nil too, for running the test outside of go test, e.g. from evm blocktest.
+func (bt *BlockTest) RunWithTracer(tb testing.TB, checkStateRoot bool, tracer *tracers.Tracer) error {
 	config, ok := testutil.Forks[bt.json.Network]
 	if !ok {
 		return testutil.UnsupportedForkError{Name: bt.json.Network}
 	}
 
 	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.New())
-	m := mock.MockWithGenesisEngine(t, bt.genesis(config), engine, false, checkStateRoot)
+	m := mock.MockWithGenesisEngineTracer(tb, bt.genesis(config), engine, false, checkStateRoot, tracer)
 	defer m.Close()
 
 	bt.br = m.BlockReader
@@ -0,0 +1,102 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv/backup"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var (
+	backupDirFlag = cli.StringFlag{
+		Name:  "backup.dir",
+		Usage: "Directory holding the backups, each one in a timestamped subdirectory",
+	}
+	backupFromFlag = cli.StringFlag{
+		Name:     "backup.from",
+		Usage:    "Backup to restore or verify: a timestamped subdirectory of --backup.dir",
+		Required: true,
+	}
+	backupLinkFlag = cli.BoolFlag{
+		Name:  "backup.link",
+		Usage: "Restore by hard-linking the files of the backup instead of copying them (same file system only)",
+		Value: false,
+	}
+)
+
+var backupCommand = cli.Command{
+	Name:  "backup",
+	Usage: "Consistent, incremental backup of chaindata and snapshot files, also of a running node",
+	Description: `Copies chaindata under a single read transaction and captures the immutable snapshot files
+while it is open. Files already in the latest backup are hard-linked from it, so only new files are
+copied. A manifest with the sha256 of every file is written last; an interrupted backup is resumed
+by the next run.`,
+	Before: func(cliCtx *cli.Context) error {
+		_, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+		return err
+	},
+	Action: func(cliCtx *cli.Context) error {
+		// not a required flag, which would be required by the subcommands too
+		if !cliCtx.IsSet(backupDirFlag.Name) {
+			return fmt.Errorf("--%s is required", backupDirFlag.Name)
+		}
+		dirs := datadir.Open(cliCtx.String(utils.DataDirFlag.Name))
+		_, err := backup.Hot(cliCtx.Context, dirs, cliCtx.String(backupDirFlag.Name), log.Root())
+		return err
+	},
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&backupDirFlag,
+	}),
+	Subcommands: []*cli.Command{
+		{
+			Name:  "restore",
+			Usage: "Verify a backup and restore it into a fresh datadir",
+			Action: func(cliCtx *cli.Context) error {
+				dirs := datadir.Open(cliCtx.String(utils.DataDirFlag.Name))
+				return backup.Restore(cliCtx.Context, cliCtx.String(backupFromFlag.Name), dirs, cliCtx.Bool(backupLinkFlag.Name), log.Root())
+			},
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&backupFromFlag,
+				&backupLinkFlag,
+			}),
+		},
+		{
+			Name:  "verify",
+			Usage: "Check the files of a backup against its manifest",
+			Action: func(cliCtx *cli.Context) error {
+				m, err := backup.Verify(cliCtx.Context, cliCtx.String(backupFromFlag.Name), log.Root())
+				if err != nil {
+					return err
+				}
+				log.Info("[backup] Backup is intact", "files", len(m.Files), "created", m.CreatedAt)
+				return nil
+			},
+			Flags: joinFlags([]cli.Flag{
+				&backupFromFlag,
+			}),
+		},
+	},
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-db/downloader"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var (
+	repairDryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only report the damaged files",
+	}
+	repairWorkersFlag = cli.IntFlag{
+		Name:  "workers",
+		Usage: "Number of files verified in parallel",
+		Value: runtime.NumCPU(),
+	}
+	repairIntegrityFlag = cli.BoolFlag{
+		Name:  "integrity",
+		Usage: "Run the integrity checks once all files are repaired, see `seg integrity`",
+	}
+)
+
+func doRepair(cliCtx *cli.Context, dirs datadir.Dirs) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+
+	damages, err := downloader.VerifySnapshots(ctx, dirs, max(cliCtx.Int(repairWorkersFlag.Name), 1), logger)
+	if err != nil {
+		return err
+	}
+	if len(damages) == 0 {
+		logger.Info("[repair] All snapshot files verified")
+		if cliCtx.Bool(repairIntegrityFlag.Name) {
+			return doIntegrity(cliCtx)
+		}
+		return nil
+	}
+	if cliCtx.Bool(repairDryRunFlag.Name) {
+		for _, d := range damages {
+			logger.Warn("[repair] Damaged file", "damage", d)
+		}
+		return fmt.Errorf("%d damaged snapshot files", len(damages))
+	}
+
+	pending, err := repairSnapshots(ctx, cliCtx, dirs, damages, logger)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		logger.Info("[repair] Start the node to let the downloader fetch the remaining pieces and files, it then builds their accessors", "files", pending)
+		return nil
+	}
+	if cliCtx.Bool(repairIntegrityFlag.Name) {
+		return doIntegrity(cliCtx)
+	}
+	return nil
+}
+
+// repairSnapshots repairs the damaged files and returns how many are left for the downloader to
+// fetch. Accessors are rebuilt once none is.
+func repairSnapshots(ctx context.Context, cliCtx *cli.Context, dirs datadir.Dirs, damages []downloader.Damage, logger log.Logger) (pending int, err error) {
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+	chainName := fromdb.ChainConfig(chainDB).ChainName
+
+	webseeds := repairWebseeds(append(common.CliString2Array(cliCtx.String(utils.WebSeedsFlag.Name)), snapcfg.KnownWebseeds[chainName]...), logger)
+	client := &http.Client{}
+	var rebuild bool
+	for _, d := range damages {
+		if d.Accessor {
+			if _, err := downloader.Quarantine(dirs, d.Name); err != nil {
+				return pending, err
+			}
+			rebuild = true
+			continue
+		}
+
+		if len(d.Pieces) > 0 {
+			remaining, err := downloader.RepairPieces(ctx, client, webseeds, dirs.Snap, d.Name, d.Pieces, logger)
+			if err != nil {
+				return pending, fmt.Errorf("repair %s: %w", d.Name, err)
+			}
+			if len(remaining) > 0 {
+				logger.Info("[repair] Pieces left for the downloader", "file", d.Name, "pieces", len(remaining))
+				pending++
+				continue
+			}
+			logger.Info("[repair] Repaired", "file", d.Name, "pieces", len(d.Pieces))
+			continue
+		}
+
+		// the .torrent describes the damaged content, or there is none: the downloader fetches the
+		// preverified file again
+		for _, name := range []string{d.Name, d.Name + ".torrent"} {
+			if _, err := downloader.Quarantine(dirs, name); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return pending, err
+			}
+		}
+		logger.Info("[repair] Quarantined", "file", d.Name, "to", filepath.Join(dirs.DataDir, downloader.QuarantineDir))
+		pending++
+	}
+
+	if rebuild && pending == 0 {
+		logger.Info("[repair] Rebuilding accessors")
+		if err := buildMissedAccessors(ctx, dirs, chainDB, logger); err != nil {
+			return pending, err
+		}
+	}
+	return pending, nil
+}
+
+// repairWebseeds parses the webseed urls the way the downloader does, with an optional v1: marker.
+func repairWebseeds(webseeds []string, logger log.Logger) []*url.URL {
+	var res []*url.URL
+	for _, webseed := range webseeds {
+		if strings.HasPrefix(webseed, "v") {
+			var ok bool
+			if webseed, ok = strings.CutPrefix(webseed, "v1:"); !ok {
+				continue
+			}
+		}
+		u, err := url.ParseRequestURI(webseed)
+		if err != nil {
+			logger.Warn("[repair] Can't parse webseed url", "url", webseed, "err", err)
+			continue
+		}
+		res = append(res, u)
+	}
+	return res
+}
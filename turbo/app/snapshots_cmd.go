@@ -353,6 +353,32 @@ var snapshotCommand = cli.Command{
 				&cli.Uint64Flag{Name: "fromStep", Value: 0, Usage: "skip files before given step"},
 			}),
 		},
+		{
+			Name: "repair",
+			Action: func(cliCtx *cli.Context) error {
+				dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+				if err != nil {
+					return err
+				}
+				defer l.Unlock()
+				return doRepair(cliCtx, dirs)
+			},
+			Usage: "Verify all snapshot files in parallel and repair the damaged ones",
+			Description: `Checks the pieces of every file against its .torrent, that segments decompress to their
+word count and that accessors are consistent with their segment. Bad pieces are re-requested from the
+webseeds, or left in a .part file for the downloader to fetch on the next start; files which can't be
+repaired in place are moved to <datadir>/quarantine and redownloaded. Accessors are rebuilt locally.`,
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&utils.WebSeedsFlag,
+				&repairDryRunFlag,
+				&repairWorkersFlag,
+				&repairIntegrityFlag,
+				&cli.StringFlag{Name: "check", Usage: fmt.Sprintf("with --integrity, one of: %s", integrity.AllChecks)},
+				&cli.BoolFlag{Name: "failFast", Value: true, Usage: "to stop after 1st problem or print WARN log and continue check"},
+				&cli.Uint64Flag{Name: "fromStep", Value: 0, Usage: "skip files before given step"},
+			}),
+		},
 		{
 			Name: "publishable",
 			Action: func(cliCtx *cli.Context) error {
@@ -1407,6 +1433,11 @@ func doIndicesCommand(cliCtx *cli.Context, dirs datadir.Dirs) error {
 		return err
 	}
 
+	return buildMissedAccessors(ctx, dirs, chainDB, logger)
+}
+
+// buildMissedAccessors builds the indices of all block, caplin and state files which lack them.
+func buildMissedAccessors(ctx context.Context, dirs datadir.Dirs, chainDB kv.RwDB, logger log.Logger) error {
 	chainConfig := fromdb.ChainConfig(chainDB)
 	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
 
@@ -20,6 +20,7 @@
 package debug
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -33,6 +34,7 @@ import (
 	"github.com/urfave/cli/v2"
 	"gopkg.in/yaml.v2"
 
+	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/common/fdlimit"
 	"github.com/erigontech/erigon-lib/common/mem"
@@ -40,6 +42,7 @@ import (
 	"github.com/erigontech/erigon-lib/metrics"
 	"github.com/erigontech/erigon/eth/tracers"
 	"github.com/erigontech/erigon/turbo/logging"
+	"github.com/erigontech/erigon/turbo/telemetry"
 )
 
 var (
@@ -95,12 +98,33 @@ var (
 		Name:  "trace",
 		Usage: "Write execution trace to the given file",
 	}
+	otelEndpointFlag = cli.StringFlag{
+		Name:  "otel.endpoint",
+		Usage: "OTLP gRPC collector (host:port) to export the OpenTelemetry spans to, tracing is disabled if empty",
+	}
+	otelInsecureFlag = cli.BoolFlag{
+		Name:  "otel.insecure",
+		Usage: "Connect to the OTLP collector without TLS",
+	}
+	otelSampleRatioFlag = cli.Float64Flag{
+		Name:  "otel.sample-ratio",
+		Usage: "Fraction of the requests to trace, the callers propagating a trace context decide for their requests",
+		Value: 1,
+	}
 )
 
+// dataDirFlagName is the name of the datadir flag of the erigon commands. The flag is defined in
+// cmd/utils, which this package does not import. The live tracers keep their databases in the datadir.
+const dataDirFlagName = "datadir"
+
+// telemetryShutdown flushes the pending spans on Exit
+var telemetryShutdown func(context.Context) error
+
 // Flags holds all command-line flags required for debugging.
 var Flags = []cli.Flag{
 	&pprofFlag, &pprofAddrFlag, &pprofPortFlag,
 	&cpuprofileFlag, &traceFlag, &vmTraceFlag, &vmTraceJsonConfigFlag,
+	&otelEndpointFlag, &otelInsecureFlag, &otelSampleRatioFlag,
 }
 
 // SetupCobra sets up logging, profiling and tracing for cobra commands
@@ -138,6 +162,26 @@ func SetupCobra(cmd *cobra.Command, filePrefix string) log.Logger {
 		}
 	}
 
+	otelEndpoint, err := flags.GetString(otelEndpointFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	otelInsecure, err := flags.GetBool(otelInsecureFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	otelSampleRatio, err := flags.GetFloat64(otelSampleRatioFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	if err := setupTelemetry(cmd.Context(), telemetry.Config{Endpoint: otelEndpoint, Insecure: otelInsecure, SampleRatio: otelSampleRatio, ServiceName: filePrefix}, logger); err != nil {
+		log.Error("failed to setup OpenTelemetry tracing", "err", err)
+		panic(err)
+	}
+
 	go ListenSignals(nil, logger)
 	pprof, err := flags.GetBool(pprofFlag.Name)
 	if err != nil {
@@ -205,7 +249,11 @@ func SetupTracerCtx(ctx *cli.Context) (*tracers.Tracer, error) {
 
 	cfg := ctx.String(vmTraceJsonConfigFlag.Name)
 
-	return tracers.New(tracerName, &tracers.Context{}, []byte(cfg))
+	var dirs datadir.Dirs
+	if dataDir := ctx.String(dataDirFlagName); dataDir != "" {
+		dirs = datadir.Open(dataDir)
+	}
+	return tracers.New(tracerName, &tracers.Context{Dirs: dirs}, []byte(cfg))
 }
 
 // Setup initializes profiling and logging based on the CLI flags.
@@ -235,6 +283,16 @@ func Setup(ctx *cli.Context, rootLogger bool) (log.Logger, *tracers.Tracer, *htt
 			return logger, tracer, nil, nil, err
 		}
 	}
+
+	otelCfg := telemetry.Config{
+		Endpoint:    ctx.String(otelEndpointFlag.Name),
+		Insecure:    ctx.Bool(otelInsecureFlag.Name),
+		SampleRatio: ctx.Float64(otelSampleRatioFlag.Name),
+		ServiceName: "erigon",
+	}
+	if err := setupTelemetry(ctx.Context, otelCfg, logger); err != nil {
+		return logger, tracer, nil, nil, err
+	}
 	pprofEnabled := ctx.Bool(pprofFlag.Name)
 	metricsEnabled := ctx.Bool(metricsEnabledFlag.Name)
 	metricsAddr := ctx.String(metricsAddrFlag.Name)
@@ -263,6 +321,19 @@ func Setup(ctx *cli.Context, rootLogger bool) (log.Logger, *tracers.Tracer, *htt
 	return logger, tracer, metricsMux, nil, nil
 }
 
+// setupTelemetry starts exporting the OpenTelemetry spans if the collector endpoint is set.
+func setupTelemetry(ctx context.Context, cfg telemetry.Config, logger log.Logger) error {
+	shutdown, err := telemetry.Setup(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	telemetryShutdown = shutdown
+	if cfg.Endpoint != "" {
+		logger.Info("Exporting OpenTelemetry spans", "endpoint", cfg.Endpoint, "sampleRatio", cfg.SampleRatio)
+	}
+	return nil
+}
+
 func StartPProf(address string, metricsMux *http.ServeMux) *http.ServeMux {
 	cpuMsg := fmt.Sprintf("go tool pprof -lines -http=: http://%s/%s", address, "debug/pprof/profile?seconds=20")
 	heapMsg := fmt.Sprintf("go tool pprof -lines -http=: http://%s/%s", address, "debug/pprof/heap")
@@ -302,10 +373,13 @@ func StartPProf(address string, metricsMux *http.ServeMux) *http.ServeMux {
 }
 
 // Exit stops all running profiles, flushing their output to the
-// respective file.
+// respective file, and flushes the pending OpenTelemetry spans.
 func Exit() {
 	_ = Handler.StopCPUProfile()
 	_ = Handler.StopGoTrace()
+	if telemetryShutdown != nil {
+		_ = telemetryShutdown(context.Background())
+	}
 }
 
 // RaiseFdLimit raises out the number of allowed file handles per process
@@ -0,0 +1,114 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package telemetry
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// collector is a stand-in for the OTLP collector, it keeps the exported spans.
+type collector struct {
+	collectortrace.UnimplementedTraceServiceServer
+	lock  sync.Mutex
+	spans []*tracev1.Span
+}
+
+func (c *collector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func serve(t *testing.T, srv *grpc.Server) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestTraceAcrossGrpc(t *testing.T) {
+	ctx := context.Background()
+	col := &collector{}
+	colSrv := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(colSrv, col)
+	shutdown, err := Setup(ctx, Config{Endpoint: serve(t, colSrv), Insecure: true, SampleRatio: 1, ServiceName: "test"})
+	require.NoError(t, err)
+
+	// the service on the other side of the gRPC boundary, e.g. the remote KV
+	srv := grpc.NewServer(ServerOption())
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	conn, err := grpc.NewClient(serve(t, srv), grpc.WithTransportCredentials(insecure.NewCredentials()), DialOption())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	reqCtx, span := Start(ctx, "eth_call")
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(reqCtx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	span.End()
+	require.NoError(t, shutdown(ctx))
+
+	col.lock.Lock()
+	defer col.lock.Unlock()
+	require.Len(t, col.spans, 3)
+	spans := map[tracev1.Span_SpanKind]*tracev1.Span{}
+	for _, s := range col.spans {
+		spans[s.Kind] = s
+	}
+	root, client, server := spans[tracev1.Span_SPAN_KIND_INTERNAL], spans[tracev1.Span_SPAN_KIND_CLIENT], spans[tracev1.Span_SPAN_KIND_SERVER]
+	require.NotNil(t, root)
+	require.NotNil(t, client)
+	require.NotNil(t, server)
+	require.Equal(t, "eth_call", root.Name)
+	require.Empty(t, root.ParentSpanId)
+	require.Equal(t, root.TraceId, client.TraceId)
+	require.Equal(t, root.TraceId, server.TraceId)
+	require.Equal(t, root.SpanId, client.ParentSpanId)
+	require.Equal(t, client.SpanId, server.ParentSpanId)
+}
+
+func TestExtractHTTP(t *testing.T) {
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	sc := trace.SpanContextFromContext(ExtractHTTP(context.Background(), header))
+	require.True(t, sc.IsRemote())
+	require.True(t, sc.IsSampled())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", sc.SpanID().String())
+
+	sc = trace.SpanContextFromContext(ExtractHTTP(context.Background(), http.Header{}))
+	require.False(t, sc.IsValid())
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/holiman/uint256"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
@@ -37,6 +38,7 @@ import (
 	"github.com/erigontech/erigon/rpc"
 	ethapi2 "github.com/erigontech/erigon/rpc/ethapi"
 	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/telemetry"
 )
 
 func DoCall(
@@ -70,15 +72,7 @@ func DoCall(
 		}
 	}
 
-	// Setup context so it may be cancelled the call has completed
-	// or, in case of unmetered gas, setup a context with a timeout.
-	var cancel context.CancelFunc
-	if callTimeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, callTimeout)
-	} else {
-		ctx, cancel = context.WithCancel(ctx)
-	}
-
+	ctx, cancel := NewCallContext(ctx, callTimeout)
 	// Make sure the context is cancelled when the call has completed
 	// this makes sure resources are cleaned up.
 	defer cancel()
@@ -100,27 +94,46 @@ func DoCall(
 	txCtx := core.NewEVMTxContext(msg)
 
 	evm := vm.NewEVM(blockCtx, txCtx, state, chainConfig, vm.Config{NoBaseFee: true})
-
-	// Wait for the context to be done and cancel the evm. Even if the
-	// EVM has finished, cancelling may be done (repeatedly)
-	go func() {
-		<-ctx.Done()
-		evm.Cancel()
-	}()
+	CancelOnDone(ctx, evm)
 
 	gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+	_, span := telemetry.Start(ctx, "evm.call", attribute.Int64("evm.gas", int64(msg.Gas())))
 	result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, engine)
+	telemetry.End(span, err)
 	if err != nil {
 		return nil, err
 	}
 
 	// If the timer caused an abort, return an appropriate error message
 	if evm.Cancelled() {
-		return nil, fmt.Errorf("execution aborted (timeout = %v)", callTimeout)
+		return nil, ExecutionAbortedError(callTimeout)
 	}
 	return result, nil
 }
 
+// NewCallContext sets up a context which is cancelled once the call has completed
+// or, in case of unmetered gas, once the call timeout is reached.
+func NewCallContext(ctx context.Context, callTimeout time.Duration) (context.Context, context.CancelFunc) {
+	if callTimeout > 0 {
+		return context.WithTimeout(ctx, callTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// CancelOnDone waits for the context to be done and cancels the evm. Even if the
+// EVM has finished, cancelling may be done (repeatedly)
+func CancelOnDone(ctx context.Context, evm *vm.EVM) {
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+}
+
+// ExecutionAbortedError is returned when a call is aborted because of the call timeout.
+func ExecutionAbortedError(callTimeout time.Duration) error {
+	return fmt.Errorf("execution aborted (timeout = %v)", callTimeout)
+}
+
 func NewEVMBlockContext(engine consensus.EngineReader, header *types.Header, requireCanonical bool, tx kv.Getter,
 	headerReader services.HeaderReader, config *chain.Config) evmtypes.BlockContext {
 	blockHashFunc := MakeHeaderGetter(requireCanonical, tx, headerReader)
@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/jsonstream"
@@ -40,6 +42,7 @@ import (
 	"github.com/erigontech/erigon/execution/consensus"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/telemetry"
 )
 
 type BlockGetter interface {
@@ -115,7 +118,9 @@ func TraceTx(
 		if tracer != nil && tracer.OnTxStart != nil {
 			tracer.OnTxStart(evm.GetVMContext(), tx, message.From())
 		}
+		_, span := telemetry.Start(ctx, "evm.trace", attribute.String("evm.txHash", txCtx.TxHash.Hex()))
 		result, err := core.ApplyMessage(evm, message, gp, refunds, false /* gasBailout */, engine)
+		telemetry.End(span, err)
 		if err != nil {
 			if tracer != nil && tracer.OnTxEnd != nil {
 				tracer.OnTxEnd(nil, err)
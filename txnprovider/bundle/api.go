@@ -0,0 +1,97 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// SendBundleArgs are the arguments of eth_sendBundle, as defined by flashbots.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp      *uint64         `json:"minTimestamp,omitempty"`
+	MaxTimestamp      *uint64         `json:"maxTimestamp,omitempty"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes,omitempty"`
+	ReplacementUuid   string          `json:"replacementUuid,omitempty"`
+}
+
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+type CancelBundleArgs struct {
+	ReplacementUuid string `json:"replacementUuid"`
+}
+
+// API serves the bundle endpoints of the eth namespace.
+type API struct {
+	pool   *Pool
+	signer *types.Signer
+}
+
+func NewAPI(pool *Pool, chainConfig *chain.Config) *API {
+	return &API{
+		pool:   pool,
+		signer: types.LatestSigner(chainConfig),
+	}
+}
+
+// SendBundle implements eth_sendBundle. Submitting a bundle with the replacement uuid of a
+// pending one replaces it.
+func (api *API) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	txns := make([]types.Transaction, len(args.Txs))
+	for i, encoded := range args.Txs {
+		txn, err := types.DecodeTransaction(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decoding txn %d: %w", i, err)
+		}
+		// the sender is cached in the transaction for the block builder
+		if _, err := txn.Sender(*api.signer); err != nil {
+			return nil, fmt.Errorf("recovering sender of txn %d: %w", i, err)
+		}
+		txns[i] = txn
+	}
+
+	var minTimestamp, maxTimestamp uint64
+	if args.MinTimestamp != nil {
+		minTimestamp = *args.MinTimestamp
+	}
+	if args.MaxTimestamp != nil {
+		maxTimestamp = *args.MaxTimestamp
+	}
+	b, err := NewBundle(txns, uint64(args.BlockNumber), minTimestamp, maxTimestamp, args.RevertingTxHashes, args.ReplacementUuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.pool.AddBundle(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return &SendBundleResult{BundleHash: b.Hash()}, nil
+}
+
+// CancelBundle implements eth_cancelBundle.
+func (api *API) CancelBundle(ctx context.Context, args CancelBundleArgs) error {
+	return api.pool.CancelBundle(args.ReplacementUuid)
+}
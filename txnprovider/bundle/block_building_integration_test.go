@@ -0,0 +1,500 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/jinzhu/copier"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	params2 "github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/race"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/testlog"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/eth"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/execution/engineapi"
+	enginetypes "github.com/erigontech/erigon/execution/engineapi/engine_types"
+	"github.com/erigontech/erigon/node"
+	"github.com/erigontech/erigon/node/nodecfg"
+	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/requests"
+	"github.com/erigontech/erigon/txnprovider/bundle"
+	"github.com/erigontech/erigon/txnprovider/bundle/bundlecfg"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// revertingContract reverts on any call: PUSH1 0 PUSH1 0 REVERT
+var revertingContract = common.HexToAddress("0x00000000000000000000000000000000000bad00")
+
+func TestBundleBlockBuilding(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	//goland:noinspection GoBoolExpressions
+	if race.Enabled && runtime.GOOS == "darwin" {
+		// We run race detector for medium tests which fails on macOS.
+		t.Skip("issue #15007")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	uni := initBlockBuildingUniverse(ctx, t)
+	gwei := uint64(common.GWei)
+
+	t.Run("most profitable of competing bundles is included ahead of public txns", func(t *testing.T) {
+		sender := uni.accounts[0]
+		blockNum := uni.nextBlockNum(t)
+		publicTxn := uni.submitPublicTxn(t, uni.accounts[1], 1*gwei)
+		// both bundles spend the same nonce of the sender: only one of them can make it
+		lowTxn := uni.signTxn(t, sender, 0, 2*gwei, uni.receiver)
+		highTxn := uni.signTxn(t, sender, 0, 5*gwei, revertingContract)
+		uni.sendBundle(ctx, t, bundle.SendBundleArgs{Txs: uni.encode(t, lowTxn), BlockNumber: hexutil.Uint64(blockNum)})
+		uni.sendBundle(ctx, t, bundle.SendBundleArgs{
+			Txs:               uni.encode(t, highTxn),
+			BlockNumber:       hexutil.Uint64(blockNum),
+			RevertingTxHashes: []common.Hash{highTxn.Hash()},
+		})
+
+		payload, err := uni.cl.BuildBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{highTxn.Hash(), publicTxn.Hash()}, txnHashes(t, payload))
+	})
+
+	t.Run("bundle with a txn reverting without being allowed to is not included", func(t *testing.T) {
+		blockNum := uni.nextBlockNum(t)
+		revertingTxn := uni.signTxn(t, uni.accounts[2], 0, 10*gwei, revertingContract)
+		uni.sendBundle(ctx, t, bundle.SendBundleArgs{Txs: uni.encode(t, revertingTxn), BlockNumber: hexutil.Uint64(blockNum)})
+		transfer := uni.signTxn(t, uni.accounts[3], 0, 3*gwei, uni.receiver)
+		uni.sendBundle(ctx, t, bundle.SendBundleArgs{Txs: uni.encode(t, transfer), BlockNumber: hexutil.Uint64(blockNum)})
+
+		payload, err := uni.cl.BuildBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{transfer.Hash()}, txnHashes(t, payload))
+	})
+
+	t.Run("bundles wait for their target block and can be cancelled", func(t *testing.T) {
+		blockNum := uni.nextBlockNum(t)
+		sender := uni.accounts[4]
+		txn1 := uni.signTxn(t, sender, 0, 2*gwei, uni.receiver)
+		txn2 := uni.signTxn(t, sender, 1, 2*gwei, uni.receiver)
+		future := uni.sendBundle(ctx, t, bundle.SendBundleArgs{Txs: uni.encode(t, txn1, txn2), BlockNumber: hexutil.Uint64(blockNum + 1)})
+		cancelled := uni.signTxn(t, uni.accounts[5], 0, 20*gwei, uni.receiver)
+		uni.sendBundle(ctx, t, bundle.SendBundleArgs{
+			Txs:             uni.encode(t, cancelled),
+			BlockNumber:     hexutil.Uint64(blockNum),
+			ReplacementUuid: "to-cancel",
+		})
+		err := uni.rpcClient.CallContext(ctx, nil, "eth_cancelBundle", bundle.CancelBundleArgs{ReplacementUuid: "to-cancel"})
+		require.NoError(t, err)
+
+		payload, err := uni.cl.BuildBlock(ctx)
+		require.NoError(t, err)
+		require.Empty(t, txnHashes(t, payload))
+
+		payload, err = uni.cl.BuildBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Hash{txn1.Hash(), txn2.Hash()}, txnHashes(t, payload))
+		b, err := bundle.NewBundle([]types.Transaction{txn1, txn2}, blockNum+1, 0, 0, nil, "")
+		require.NoError(t, err)
+		require.Equal(t, b.Hash(), future.BundleHash)
+	})
+}
+
+type blockBuildingUniverse struct {
+	rpcApiClient requests.RequestGenerator
+	rpcClient    *rpc.Client
+	cl           *mockCl
+	chainId      *big.Int
+	accounts     []*ecdsa.PrivateKey
+	receiver     common.Address
+}
+
+func (uni blockBuildingUniverse) nextBlockNum(t *testing.T) uint64 {
+	blockNum, err := uni.rpcApiClient.BlockNumber()
+	require.NoError(t, err)
+	return blockNum + 1
+}
+
+// signTxn signs a 50k gas call paying the given tip per gas, with a fee cap well above the base fee.
+func (uni blockBuildingUniverse) signTxn(t *testing.T, from *ecdsa.PrivateKey, nonce uint64, tip uint64, to common.Address) types.Transaction {
+	txn := &types.DynamicFeeTransaction{
+		CommonTx: types.CommonTx{
+			Nonce:    nonce,
+			GasLimit: 50_000,
+			To:       &to,
+			Value:    uint256.NewInt(1),
+		},
+		ChainID: uint256.MustFromBig(uni.chainId),
+		TipCap:  uint256.NewInt(tip),
+		FeeCap:  uint256.NewInt(100 * common.GWei),
+	}
+	signedTxn, err := types.SignTx(txn, *types.LatestSignerForChainID(uni.chainId), from)
+	require.NoError(t, err)
+	return signedTxn
+}
+
+func (uni blockBuildingUniverse) submitPublicTxn(t *testing.T, from *ecdsa.PrivateKey, tip uint64) types.Transaction {
+	nonce, err := uni.rpcApiClient.GetTransactionCount(crypto.PubkeyToAddress(from.PublicKey), rpc.PendingBlock)
+	require.NoError(t, err)
+	txn := uni.signTxn(t, from, nonce.Uint64(), tip, uni.receiver)
+	_, err = uni.rpcApiClient.SendTransaction(txn)
+	require.NoError(t, err)
+	return txn
+}
+
+func (uni blockBuildingUniverse) encode(t *testing.T, txns ...types.Transaction) []hexutil.Bytes {
+	encoded := make([]hexutil.Bytes, len(txns))
+	for i, txn := range txns {
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		encoded[i] = buf.Bytes()
+	}
+	return encoded
+}
+
+func (uni blockBuildingUniverse) sendBundle(ctx context.Context, t *testing.T, args bundle.SendBundleArgs) bundle.SendBundleResult {
+	var res bundle.SendBundleResult
+	err := uni.rpcClient.CallContext(ctx, &res, "eth_sendBundle", args)
+	require.NoError(t, err)
+	return res
+}
+
+func txnHashes(t *testing.T, payload *enginetypes.ExecutionPayload) []common.Hash {
+	hashes := make([]common.Hash, len(payload.Transactions))
+	for i, encoded := range payload.Transactions {
+		txn, err := types.DecodeTransaction(encoded)
+		require.NoError(t, err)
+		hashes[i] = txn.Hash()
+	}
+	return hashes
+}
+
+func initBlockBuildingUniverse(ctx context.Context, t *testing.T) blockBuildingUniverse {
+	logger := testlog.Logger(t, log.LvlDebug)
+	dataDir := t.TempDir()
+	dirs := datadir.New(dataDir)
+	sentryPort := freePort(t)
+	engineApiPort := freePort(t)
+	jsonRpcPort := freePort(t)
+
+	const localhost = "127.0.0.1"
+	httpConfig := httpcfg.HttpCfg{
+		Enabled:                  true,
+		HttpServerEnabled:        true,
+		HttpListenAddress:        localhost,
+		HttpPort:                 jsonRpcPort,
+		API:                      []string{"eth"},
+		AuthRpcHTTPListenAddress: localhost,
+		AuthRpcPort:              engineApiPort,
+		JWTSecretPath:            path.Join(dataDir, "jwt.hex"),
+		ReturnDataLimit:          100_000,
+	}
+
+	nodeKeyConfig := p2p.NodeKeyConfig{}
+	nodeKey, err := nodeKeyConfig.LoadOrGenerateAndSave(nodeKeyConfig.DefaultPath(dataDir))
+	require.NoError(t, err)
+	nodeConfig := nodecfg.Config{
+		Dirs: dirs,
+		Http: httpConfig,
+		P2P: p2p.Config{
+			ListenAddr:      fmt.Sprintf("127.0.0.1:%d", sentryPort),
+			MaxPeers:        1,
+			MaxPendingPeers: 1,
+			NoDiscovery:     true,
+			NoDial:          true,
+			ProtocolVersion: []uint{direct.ETH68},
+			AllowedPorts:    []uint{uint(sentryPort)},
+			PrivateKey:      nodeKey,
+		},
+	}
+
+	txPoolConfig := txpoolcfg.DefaultConfig
+	txPoolConfig.DBDir = dirs.TxPool
+	ethConfig := ethconfig.Config{
+		Dirs: dirs,
+		Snapshot: ethconfig.BlocksFreezing{
+			NoDownloader: true,
+		},
+		TxPool: txPoolConfig,
+		Miner: params.MiningConfig{
+			EnabledPOS: true,
+		},
+		Bundles: bundlecfg.Config{
+			Enabled:    true,
+			MaxBundles: 100,
+		},
+	}
+
+	ethNode, err := node.New(ctx, &nodeConfig, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := ethNode.Close()
+		if errors.Is(err, node.ErrNodeStopped) {
+			return
+		}
+		require.NoError(t, err)
+	})
+
+	chainId := big.NewInt(987656790)
+	var chainConfig chain.Config
+	copier.Copy(&chainConfig, chainspec.ChiadoChainConfig)
+	chainConfig.ChainName = "bundles-devnet"
+	chainConfig.ChainID = chainId
+	chainConfig.TerminalTotalDifficulty = big.NewInt(0)
+	chainConfig.ShanghaiTime = big.NewInt(0)
+	chainConfig.CancunTime = big.NewInt(0)
+	chainConfig.PragueTime = big.NewInt(0)
+	genesis := chainspec.ChiadoGenesisBlock()
+	genesis.Timestamp = uint64(time.Now().Unix() - 1)
+	genesis.Config = &chainConfig
+	genesis.Alloc[params2.ConsolidationRequestAddress] = types.GenesisAccount{
+		Code:    []byte{0}, // Can't be empty
+		Storage: make(map[common.Hash]common.Hash, 0),
+		Balance: big.NewInt(0),
+		Nonce:   0,
+	}
+	genesis.Alloc[params2.WithdrawalRequestAddress] = types.GenesisAccount{
+		Code:    []byte{0}, // Can't be empty
+		Storage: make(map[common.Hash]common.Hash, 0),
+		Balance: big.NewInt(0),
+		Nonce:   0,
+	}
+	genesis.Alloc[revertingContract] = types.GenesisAccount{
+		Code:    common.FromHex("0x60006000fd"),
+		Storage: make(map[common.Hash]common.Hash, 0),
+		Balance: big.NewInt(0),
+	}
+	// the accounts of the test are funded at genesis, 1 ETH each
+	accounts := make([]*ecdsa.PrivateKey, 6)
+	oneEth := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	for i := range accounts {
+		accounts[i], err = crypto.GenerateKey()
+		require.NoError(t, err)
+		genesis.Alloc[crypto.PubkeyToAddress(accounts[i].PublicKey)] = types.GenesisAccount{Balance: oneEth}
+	}
+	chainDB, err := node.OpenDatabase(ctx, ethNode.Config(), kv.ChainDB, "", false, logger)
+	require.NoError(t, err)
+	_, genesisBlock, err := core.CommitGenesisBlock(chainDB, genesis, ethNode.Config().Dirs, logger)
+	require.NoError(t, err)
+	chainDB.Close()
+
+	// note we need to create jwt secret before calling ethBackend.Init to avoid race conditions
+	jwtSecret, err := cli.ObtainJWTSecret(&httpConfig, logger)
+	require.NoError(t, err)
+	ethBackend, err := eth.New(ctx, ethNode, &ethConfig, logger, nil)
+	require.NoError(t, err)
+	err = ethBackend.Init(ethNode, &ethConfig, &chainConfig)
+	require.NoError(t, err)
+	err = ethNode.Start()
+	require.NoError(t, err)
+
+	rpcDaemonHttpUrl := fmt.Sprintf("%s:%d", httpConfig.HttpListenAddress, httpConfig.HttpPort)
+	rpcApiClient := requests.NewRequestGenerator(rpcDaemonHttpUrl, logger)
+	//goland:noinspection HttpUrlsUsage
+	rpcClient, err := rpc.DialContext(ctx, "http://"+rpcDaemonHttpUrl, logger)
+	require.NoError(t, err)
+	t.Cleanup(rpcClient.Close)
+	//goland:noinspection HttpUrlsUsage
+	engineApiUrl := fmt.Sprintf("http://%s:%d", httpConfig.AuthRpcHTTPListenAddress, httpConfig.AuthRpcPort)
+	engineApiClient, err := engineapi.DialJsonRpcClient(
+		engineApiUrl,
+		jwtSecret,
+		logger,
+		// requests should not take more than 5 secs in a test env, yet we can spam frequently
+		engineapi.WithJsonRpcClientRetryBackOff(50*time.Millisecond),
+		engineapi.WithJsonRpcClientMaxRetries(100),
+	)
+	require.NoError(t, err)
+	// the fee recipient doesn't send txns, its balance increase is the profit of the block
+	feeRecipientPrivKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	cl := newMockCl(engineApiClient, crypto.PubkeyToAddress(feeRecipientPrivKey.PublicKey), genesisBlock)
+	_, err = cl.BuildBlock(ctx)
+	require.NoError(t, err)
+
+	receiverPrivKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return blockBuildingUniverse{
+		rpcApiClient: rpcApiClient,
+		rpcClient:    rpcClient,
+		cl:           cl,
+		chainId:      chainId,
+		accounts:     accounts,
+		receiver:     crypto.PubkeyToAddress(receiverPrivKey.PublicKey),
+	}
+}
+
+// mockCl drives block building through the engine API, one block per second.
+type mockCl struct {
+	engineApiClient       *engineapi.JsonRpcClient
+	suggestedFeeRecipient common.Address
+	prevBlockHash         common.Hash
+	prevTimestamp         uint64
+	prevRandao            *big.Int
+	prevBeaconBlockRoot   *big.Int
+}
+
+func newMockCl(elClient *engineapi.JsonRpcClient, feeRecipient common.Address, elGenesis *types.Block) *mockCl {
+	return &mockCl{
+		engineApiClient:       elClient,
+		suggestedFeeRecipient: feeRecipient,
+		prevBlockHash:         elGenesis.Hash(),
+		prevTimestamp:         elGenesis.Time(),
+		prevRandao:            big.NewInt(0),
+		prevBeaconBlockRoot:   big.NewInt(10_000),
+	}
+}
+
+func (cl *mockCl) BuildBlock(ctx context.Context) (*enginetypes.ExecutionPayload, error) {
+	timestamp := max(uint64(time.Now().Unix()), cl.prevTimestamp+1)
+	forkChoiceState := enginetypes.ForkChoiceState{
+		FinalizedBlockHash: cl.prevBlockHash,
+		SafeBlockHash:      cl.prevBlockHash,
+		HeadHash:           cl.prevBlockHash,
+	}
+	parentBeaconBlockRoot := common.BigToHash(cl.prevBeaconBlockRoot)
+	payloadAttributes := enginetypes.PayloadAttributes{
+		Timestamp:             hexutil.Uint64(timestamp),
+		PrevRandao:            common.BigToHash(cl.prevRandao),
+		SuggestedFeeRecipient: cl.suggestedFeeRecipient,
+		Withdrawals:           make([]*types.Withdrawal, 0),
+		ParentBeaconBlockRoot: &parentBeaconBlockRoot,
+	}
+
+	// start block building process
+	fcuRes, err := cl.forkchoiceUpdated(ctx, &forkChoiceState, &payloadAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	// give block builder time to build a block
+	if err := common.Sleep(ctx, time.Second); err != nil {
+		return nil, err
+	}
+
+	payloadRes, err := cl.engineApiClient.GetPayloadV4(ctx, *fcuRes.PayloadId)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadStatus, err := retryEngineSyncing(ctx, func() (*enginetypes.PayloadStatus, enginetypes.EngineStatus, error) {
+		r, err := cl.engineApiClient.NewPayloadV4(ctx, payloadRes.ExecutionPayload, []common.Hash{}, &parentBeaconBlockRoot, []hexutil.Bytes{})
+		if err != nil {
+			return nil, "", err
+		}
+		return r, r.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if payloadStatus.Status != enginetypes.ValidStatus {
+		return nil, fmt.Errorf("payload status of new payload is not valid: %s", payloadStatus.Status)
+	}
+
+	// set the newly built block as canonical
+	newHash := payloadRes.ExecutionPayload.BlockHash
+	forkChoiceState = enginetypes.ForkChoiceState{
+		FinalizedBlockHash: newHash,
+		SafeBlockHash:      newHash,
+		HeadHash:           newHash,
+	}
+	if _, err := cl.forkchoiceUpdated(ctx, &forkChoiceState, nil); err != nil {
+		return nil, err
+	}
+
+	cl.prevBlockHash = newHash
+	cl.prevTimestamp = timestamp
+	cl.prevRandao.Add(cl.prevRandao, big.NewInt(1))
+	cl.prevBeaconBlockRoot.Add(cl.prevBeaconBlockRoot, big.NewInt(1))
+	return payloadRes.ExecutionPayload, nil
+}
+
+func (cl *mockCl) forkchoiceUpdated(
+	ctx context.Context,
+	forkChoiceState *enginetypes.ForkChoiceState,
+	payloadAttributes *enginetypes.PayloadAttributes,
+) (*enginetypes.ForkChoiceUpdatedResponse, error) {
+	fcuRes, err := retryEngineSyncing(ctx, func() (*enginetypes.ForkChoiceUpdatedResponse, enginetypes.EngineStatus, error) {
+		r, err := cl.engineApiClient.ForkchoiceUpdatedV3(ctx, forkChoiceState, payloadAttributes)
+		if err != nil {
+			return nil, "", err
+		}
+		return r, r.PayloadStatus.Status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fcuRes.PayloadStatus.Status != enginetypes.ValidStatus {
+		return nil, fmt.Errorf("payload status of fcu is not valid: %s", fcuRes.PayloadStatus.Status)
+	}
+	return fcuRes, nil
+}
+
+func retryEngineSyncing[T any](ctx context.Context, f func() (*T, enginetypes.EngineStatus, error)) (*T, error) {
+	// don't retry for too long
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	for {
+		res, status, err := f()
+		if err != nil {
+			return nil, err
+		}
+		if status != enginetypes.SyncingStatus {
+			return res, nil
+		}
+		if err := common.Sleep(ctx, 50*time.Millisecond); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return port
+}
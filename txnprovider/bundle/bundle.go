@@ -0,0 +1,115 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+var (
+	ErrEmptyBundle        = errors.New("bundle has no transactions")
+	ErrBlobTxnInBundle    = errors.New("blob transactions are not supported in bundles")
+	ErrInvalidBlockNumber = errors.New("bundle block number must be set")
+	ErrInvalidTimestamps  = errors.New("bundle min timestamp is greater than its max timestamp")
+	ErrDuplicateTxn       = errors.New("bundle has duplicate transactions")
+)
+
+// Bundle is an ordered list of transactions which must be included in the given block, one after
+// the other and all of them or none. Only the transactions listed in RevertingTxnHashes may revert.
+type Bundle struct {
+	Txns               []types.Transaction
+	BlockNumber        uint64
+	MinTimestamp       uint64 // 0 for no lower bound
+	MaxTimestamp       uint64 // 0 for no upper bound
+	RevertingTxnHashes []common.Hash
+	ReplacementUuid    string // allows to replace or cancel the bundle, may be empty
+	hash               common.Hash
+}
+
+func NewBundle(
+	txns []types.Transaction,
+	blockNumber uint64,
+	minTimestamp uint64,
+	maxTimestamp uint64,
+	revertingTxnHashes []common.Hash,
+	replacementUuid string,
+) (*Bundle, error) {
+	if len(txns) == 0 {
+		return nil, ErrEmptyBundle
+	}
+	if blockNumber == 0 {
+		return nil, ErrInvalidBlockNumber
+	}
+	if maxTimestamp != 0 && minTimestamp > maxTimestamp {
+		return nil, ErrInvalidTimestamps
+	}
+
+	hashes := make([]byte, 0, len(txns)*len(common.Hash{}))
+	seen := make(map[common.Hash]struct{}, len(txns))
+	for _, txn := range txns {
+		if txn.Type() == types.BlobTxType {
+			return nil, ErrBlobTxnInBundle
+		}
+		hash := txn.Hash()
+		if _, ok := seen[hash]; ok {
+			return nil, fmt.Errorf("%w: %x", ErrDuplicateTxn, hash)
+		}
+		seen[hash] = struct{}{}
+		hashes = append(hashes, hash[:]...)
+	}
+
+	return &Bundle{
+		Txns:               txns,
+		BlockNumber:        blockNumber,
+		MinTimestamp:       minTimestamp,
+		MaxTimestamp:       maxTimestamp,
+		RevertingTxnHashes: revertingTxnHashes,
+		ReplacementUuid:    replacementUuid,
+		// same as the flashbots bundle hash: the hash of the concatenated transaction hashes
+		hash: crypto.Keccak256Hash(hashes),
+	}, nil
+}
+
+func (b *Bundle) Hash() common.Hash {
+	return b.hash
+}
+
+// Eligible tells whether the bundle may be included in the block with the given number and time.
+func (b *Bundle) Eligible(blockNum uint64, blockTime uint64) bool {
+	if b.BlockNumber != blockNum {
+		return false
+	}
+	if b.MinTimestamp != 0 && blockTime < b.MinTimestamp {
+		return false
+	}
+	if b.MaxTimestamp != 0 && blockTime > b.MaxTimestamp {
+		return false
+	}
+	return true
+}
+
+// CanRevert tells whether the transaction with the given hash is allowed to revert without
+// invalidating the bundle.
+func (b *Bundle) CanRevert(txnHash common.Hash) bool {
+	return slices.Contains(b.RevertingTxnHashes, txnHash)
+}
@@ -0,0 +1,29 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundlecfg
+
+type Config struct {
+	Enabled bool
+	// MaxBundles caps the number of bundles waiting for their target block, submissions beyond
+	// it are rejected until bundles get included or expire.
+	MaxBundles int
+}
+
+var DefaultConfig = Config{
+	Enabled:    false,
+	MaxBundles: 1024,
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/txnprovider"
+	"github.com/erigontech/erigon/txnprovider/bundle/bundlecfg"
+)
+
+var (
+	ErrPoolFull        = errors.New("bundle pool is full")
+	ErrBundleTooOld    = errors.New("bundle block number is not in the future")
+	ErrUnknownBundle   = errors.New("unknown bundle")
+	ErrDuplicateBundle = errors.New("bundle already known")
+)
+
+type currentBlockNumReader func(ctx context.Context) (*uint64, error)
+
+var _ txnprovider.TxnProvider = (*Pool)(nil)
+
+// Pool keeps the bundles submitted for upcoming blocks and provides them to the block builder
+// along with the transactions of a base provider, ordered by what they pay to the coinbase.
+type Pool struct {
+	logger                log.Logger
+	config                bundlecfg.Config
+	baseTxnProvider       txnprovider.TxnProvider
+	simulator             Simulator
+	currentBlockNumReader currentBlockNumReader
+	mu                    sync.Mutex
+	bundles               map[common.Hash]pooledBundle
+	byReplacementUuid     map[string]common.Hash
+	nextSeq               uint64
+}
+
+type pooledBundle struct {
+	*Bundle
+	seq uint64 // arrival order, to break ties between bundles paying the same
+}
+
+func NewPool(
+	logger log.Logger,
+	config bundlecfg.Config,
+	baseTxnProvider txnprovider.TxnProvider,
+	simulator Simulator,
+	currentBlockNumReader currentBlockNumReader,
+) *Pool {
+	return &Pool{
+		logger:                logger.New("component", "bundles"),
+		config:                config,
+		baseTxnProvider:       baseTxnProvider,
+		simulator:             simulator,
+		currentBlockNumReader: currentBlockNumReader,
+		bundles:               make(map[common.Hash]pooledBundle),
+		byReplacementUuid:     make(map[string]common.Hash),
+	}
+}
+
+// AddBundle keeps the bundle until its block is built, replacing the bundle with the same
+// replacement uuid if any.
+func (p *Pool) AddBundle(ctx context.Context, b *Bundle) error {
+	currentBlockNum, err := p.currentBlockNumReader(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if currentBlockNum != nil {
+		if b.BlockNumber <= *currentBlockNum {
+			return fmt.Errorf("%w: %d, current block %d", ErrBundleTooOld, b.BlockNumber, *currentBlockNum)
+		}
+		p.prune(*currentBlockNum)
+	}
+	if _, ok := p.bundles[b.Hash()]; ok {
+		return ErrDuplicateBundle
+	}
+
+	replaced := false
+	if b.ReplacementUuid != "" {
+		if hash, ok := p.byReplacementUuid[b.ReplacementUuid]; ok {
+			delete(p.bundles, hash)
+			replaced = true
+		}
+	}
+	if !replaced && len(p.bundles) >= p.config.MaxBundles {
+		return ErrPoolFull
+	}
+
+	p.bundles[b.Hash()] = pooledBundle{Bundle: b, seq: p.nextSeq}
+	p.nextSeq++
+	if b.ReplacementUuid != "" {
+		p.byReplacementUuid[b.ReplacementUuid] = b.Hash()
+	}
+
+	p.logger.Debug("bundle added", "hash", b.Hash(), "blockNum", b.BlockNumber, "txns", len(b.Txns), "replaced", replaced)
+	return nil
+}
+
+// CancelBundle drops the bundle with the given replacement uuid.
+func (p *Pool) CancelBundle(replacementUuid string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hash, ok := p.byReplacementUuid[replacementUuid]
+	if !ok {
+		return ErrUnknownBundle
+	}
+
+	delete(p.bundles, hash)
+	delete(p.byReplacementUuid, replacementUuid)
+	p.logger.Debug("bundle cancelled", "hash", hash, "replacementUuid", replacementUuid)
+	return nil
+}
+
+// ProvideTxns merges the bundles targeting the block with the transactions of the base provider,
+// by effective gas price: the profit of a bundle per unit of gas against the effective tip of a
+// public transaction. Bundles are simulated one after the other, on top of the public transactions
+// placed before them, and left out when they fail there, so that the block builder can include
+// them as a whole. Bundles already yielded for the block, i.e. whose transactions are in the ids
+// filter, are not provided again.
+func (p *Pool) ProvideTxns(ctx context.Context, opts ...txnprovider.ProvideOption) ([]types.Transaction, error) {
+	provideOpts := txnprovider.ApplyProvideOptions(opts...)
+	blockNum := provideOpts.ParentBlockNum + 1
+	bundles := p.eligibleBundles(provideOpts)
+	if len(bundles) == 0 {
+		return p.baseTxnProvider.ProvideTxns(ctx, opts...)
+	}
+
+	sim, err := p.simulator.NewSimulation(ctx, provideOpts.ParentBlockNum, provideOpts.BlockTime, provideOpts.Coinbase)
+	if err != nil {
+		p.logger.Warn("can't simulate bundles, falling back to base txn provider", "blockNum", blockNum, "err", err)
+		return p.baseTxnProvider.ProvideTxns(ctx, opts...)
+	}
+	defer sim.Close()
+
+	candidates, err := p.simulateEach(sim, bundles, provideOpts.GasTarget)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return p.baseTxnProvider.ProvideTxns(ctx, opts...)
+	}
+
+	// leave room for the bundles, and keep the base provider from yielding their transactions
+	var bundlesGas uint64
+	var bundlesTxns, bundlesRlpSize int
+	for _, candidate := range candidates {
+		bundlesGas += candidate.GasUsed
+		bundlesTxns += len(candidate.Bundle.Txns)
+		for _, txn := range candidate.Bundle.Txns {
+			bundlesRlpSize += txn.EncodingSize()
+			provideOpts.TxnIdsFilter.Add(txn.Hash())
+		}
+	}
+
+	var publicTxns []types.Transaction
+	if provideOpts.GasTarget > bundlesGas && provideOpts.Amount > bundlesTxns && provideOpts.AvailableRlpSpace > bundlesRlpSize {
+		opts = append(opts, // overrides options
+			txnprovider.WithGasTarget(provideOpts.GasTarget-bundlesGas),
+			txnprovider.WithAmount(provideOpts.Amount-bundlesTxns),
+			txnprovider.WithAvailableRlpSpace(provideOpts.AvailableRlpSpace-bundlesRlpSize),
+		)
+		publicTxns, err = p.baseTxnProvider.ProvideTxns(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := sim.Reset(); err != nil {
+		return nil, err
+	}
+
+	baseFee := sim.BaseFee()
+	txns := make([]types.Transaction, 0, bundlesTxns+len(publicTxns))
+	var included int
+	for i, j := 0, 0; i < len(candidates) || j < len(publicTxns); {
+		if i < len(candidates) && (j == len(publicTxns) || candidates[i].EffectiveGasPrice().Cmp(publicTxns[j].GetEffectiveGasTip(baseFee)) >= 0) {
+			b := candidates[i].Bundle
+			i++
+			if _, err := sim.ApplyBundle(b); err != nil {
+				p.logger.Debug("bundle dropped", "hash", b.Hash(), "blockNum", blockNum, "err", err)
+				for _, txn := range b.Txns {
+					provideOpts.TxnIdsFilter.Remove(txn.Hash())
+				}
+				continue
+			}
+			txns = append(txns, b.Txns...)
+			included++
+			continue
+		}
+
+		// public transactions are provided as they are, the block builder skips the failing ones
+		sim.ApplyTxn(publicTxns[j])
+		txns = append(txns, publicTxns[j])
+		j++
+	}
+
+	p.logger.Debug("providing bundles", "blockNum", blockNum, "bundles", included, "eligible", len(bundles), "publicTxns", len(publicTxns))
+	return txns, nil
+}
+
+// eligibleBundles returns the bundles which may be included in the block being built, in arrival
+// order, and forgets about the ones targeting past blocks.
+func (p *Pool) eligibleBundles(opts txnprovider.ProvideOptions) []*Bundle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune(opts.ParentBlockNum)
+
+	var eligible []pooledBundle
+	for _, b := range p.bundles {
+		if !b.Eligible(opts.ParentBlockNum+1, opts.BlockTime) {
+			continue
+		}
+		yielded := slices.ContainsFunc(b.Txns, func(txn types.Transaction) bool {
+			return opts.TxnIdsFilter.Contains(txn.Hash())
+		})
+		if !yielded {
+			eligible = append(eligible, b)
+		}
+	}
+
+	slices.SortFunc(eligible, func(a, b pooledBundle) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	bundles := make([]*Bundle, len(eligible))
+	for i, b := range eligible {
+		bundles[i] = b.Bundle
+	}
+	return bundles
+}
+
+// simulateEach simulates every bundle alone on top of the pending state, and returns the ones
+// which succeed sorted by decreasing effective gas price.
+func (p *Pool) simulateEach(sim Simulation, bundles []*Bundle, gasTarget uint64) ([]*SimulatedBundle, error) {
+	simulated := make([]*SimulatedBundle, 0, len(bundles))
+	for _, b := range bundles {
+		sb, err := sim.ApplyBundle(b)
+		if resetErr := sim.Reset(); resetErr != nil {
+			return nil, resetErr
+		}
+		if err != nil {
+			p.logger.Debug("bundle simulation failed", "hash", b.Hash(), "err", err)
+			continue
+		}
+		simulated = append(simulated, sb)
+	}
+
+	slices.SortStableFunc(simulated, func(a, b *SimulatedBundle) int {
+		return b.EffectiveGasPrice().Cmp(a.EffectiveGasPrice())
+	})
+
+	// keep the most profitable bundles which fit in the gas target together
+	var gas uint64
+	fitting := simulated[:0]
+	for _, sb := range simulated {
+		if gas+sb.GasUsed > gasTarget {
+			continue
+		}
+		gas += sb.GasUsed
+		fitting = append(fitting, sb)
+	}
+	return fitting, nil
+}
+
+func (p *Pool) prune(builtBlockNum uint64) {
+	for hash, b := range p.bundles {
+		if b.BlockNumber > builtBlockNum {
+			continue
+		}
+		delete(p.bundles, hash)
+		if b.ReplacementUuid != "" && p.byReplacementUuid[b.ReplacementUuid] == hash {
+			delete(p.byReplacementUuid, b.ReplacementUuid)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/testlog"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/txnprovider"
+	"github.com/erigontech/erigon/txnprovider/bundle"
+	"github.com/erigontech/erigon/txnprovider/bundle/bundlecfg"
+)
+
+func TestPoolProvideTxns(t *testing.T) {
+	t.Parallel()
+
+	alice := common.HexToAddress("0xa")
+	bob := common.HexToAddress("0xb")
+	carol := common.HexToAddress("0xc")
+	publicHigh := newTxn(alice, 0, 30)
+	publicLow := newTxn(alice, 1, 5)
+
+	t.Run("merges bundles with public txns by effective gas price", func(t *testing.T) {
+		pool := newPool(t, 10, publicHigh, publicLow)
+		b := newBundle(t, 11, "", newTxn(bob, 0, 10), newTxn(bob, 1, 10))
+		require.NoError(t, pool.AddBundle(context.Background(), b))
+
+		txns, err := pool.ProvideTxns(context.Background(), provideOpts(10, mapset.NewSet[[32]byte]())...)
+		require.NoError(t, err)
+		require.Equal(t, []types.Transaction{publicHigh, b.Txns[0], b.Txns[1], publicLow}, txns)
+	})
+
+	t.Run("competing bundles: the most profitable one wins", func(t *testing.T) {
+		pool := newPool(t, 10)
+		low := newBundle(t, 11, "", newTxn(bob, 0, 10))
+		high := newBundle(t, 11, "", newTxn(bob, 0, 20), newTxn(carol, 0, 20))
+		require.NoError(t, pool.AddBundle(context.Background(), low))
+		require.NoError(t, pool.AddBundle(context.Background(), high))
+
+		yielded := mapset.NewSet[[32]byte]()
+		txns, err := pool.ProvideTxns(context.Background(), provideOpts(10, yielded)...)
+		require.NoError(t, err)
+		require.Equal(t, high.Txns, txns)
+		require.False(t, yielded.Contains(low.Txns[0].Hash()))
+	})
+
+	t.Run("bundle broken by a better paying public txn is dropped", func(t *testing.T) {
+		pool := newPool(t, 10, publicHigh)
+		b := newBundle(t, 11, "", newTxn(alice, 0, 10))
+		require.NoError(t, pool.AddBundle(context.Background(), b))
+
+		txns, err := pool.ProvideTxns(context.Background(), provideOpts(10, mapset.NewSet[[32]byte]())...)
+		require.NoError(t, err)
+		require.Equal(t, []types.Transaction{publicHigh}, txns)
+	})
+
+	t.Run("bundles are provided once per block, for their block and time only", func(t *testing.T) {
+		pool := newPool(t, 10)
+		b := newBundle(t, 11, "", newTxn(bob, 0, 10))
+		timed, err := bundle.NewBundle([]types.Transaction{newTxn(carol, 0, 10)}, 11, 200, 300, nil, "")
+		require.NoError(t, err)
+		future := newBundle(t, 12, "", newTxn(carol, 1, 10))
+		require.NoError(t, pool.AddBundle(context.Background(), b))
+		require.NoError(t, pool.AddBundle(context.Background(), timed))
+		require.NoError(t, pool.AddBundle(context.Background(), future))
+
+		yielded := mapset.NewSet[[32]byte]()
+		txns, err := pool.ProvideTxns(context.Background(), provideOpts(10, yielded)...)
+		require.NoError(t, err)
+		require.Equal(t, b.Txns, txns)
+		txns, err = pool.ProvideTxns(context.Background(), provideOpts(10, yielded)...)
+		require.NoError(t, err)
+		require.Empty(t, txns)
+	})
+}
+
+func TestPoolAddAndCancelBundle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sender := common.HexToAddress("0xa")
+	pool := newPool(t, 1)
+
+	tooOld := newBundle(t, 10, "", newTxn(sender, 0, 1))
+	require.ErrorIs(t, pool.AddBundle(ctx, tooOld), bundle.ErrBundleTooOld)
+
+	first := newBundle(t, 11, "uuid", newTxn(sender, 0, 1))
+	require.NoError(t, pool.AddBundle(ctx, first))
+	require.ErrorIs(t, pool.AddBundle(ctx, first), bundle.ErrDuplicateBundle)
+	// the pool is full, but replacing a bundle doesn't take more room
+	second := newBundle(t, 11, "uuid", newTxn(sender, 0, 2))
+	require.NoError(t, pool.AddBundle(ctx, second))
+	require.ErrorIs(t, pool.AddBundle(ctx, newBundle(t, 11, "", newTxn(sender, 0, 3))), bundle.ErrPoolFull)
+
+	txns, err := pool.ProvideTxns(ctx, provideOpts(10, mapset.NewSet[[32]byte]())...)
+	require.NoError(t, err)
+	require.Equal(t, second.Txns, txns)
+
+	require.NoError(t, pool.CancelBundle("uuid"))
+	require.ErrorIs(t, pool.CancelBundle("uuid"), bundle.ErrUnknownBundle)
+	txns, err = pool.ProvideTxns(ctx, provideOpts(10, mapset.NewSet[[32]byte]())...)
+	require.NoError(t, err)
+	require.Empty(t, txns)
+}
+
+func TestNewBundle(t *testing.T) {
+	t.Parallel()
+
+	sender := common.HexToAddress("0xa")
+	txn := newTxn(sender, 0, 1)
+	_, err := bundle.NewBundle(nil, 1, 0, 0, nil, "")
+	require.ErrorIs(t, err, bundle.ErrEmptyBundle)
+	_, err = bundle.NewBundle([]types.Transaction{txn}, 0, 0, 0, nil, "")
+	require.ErrorIs(t, err, bundle.ErrInvalidBlockNumber)
+	_, err = bundle.NewBundle([]types.Transaction{txn}, 1, 2, 1, nil, "")
+	require.ErrorIs(t, err, bundle.ErrInvalidTimestamps)
+	_, err = bundle.NewBundle([]types.Transaction{txn, txn}, 1, 0, 0, nil, "")
+	require.ErrorIs(t, err, bundle.ErrDuplicateTxn)
+	_, err = bundle.NewBundle([]types.Transaction{&types.BlobTx{}}, 1, 0, 0, nil, "")
+	require.ErrorIs(t, err, bundle.ErrBlobTxnInBundle)
+
+	b1, err := bundle.NewBundle([]types.Transaction{txn}, 1, 0, 0, nil, "")
+	require.NoError(t, err)
+	b2, err := bundle.NewBundle([]types.Transaction{txn}, 2, 0, 0, nil, "other")
+	require.NoError(t, err)
+	require.Equal(t, b1.Hash(), b2.Hash())
+}
+
+func newPool(t *testing.T, maxBundles int, publicTxns ...types.Transaction) *bundle.Pool {
+	logger := testlog.Logger(t, log.LvlDebug)
+	config := bundlecfg.Config{Enabled: true, MaxBundles: maxBundles}
+	currentBlockNumReader := func(ctx context.Context) (*uint64, error) {
+		blockNum := uint64(10)
+		return &blockNum, nil
+	}
+	return bundle.NewPool(logger, config, &publicTxnProvider{txns: publicTxns}, nonceSimulator{}, currentBlockNumReader)
+}
+
+func newBundle(t *testing.T, blockNum uint64, replacementUuid string, txns ...types.Transaction) *bundle.Bundle {
+	b, err := bundle.NewBundle(txns, blockNum, 0, 0, nil, replacementUuid)
+	require.NoError(t, err)
+	return b
+}
+
+// newTxn creates a 21000 gas txn from the sender paying the coinbase the given tip per gas.
+func newTxn(sender common.Address, nonce uint64, tip uint64) types.Transaction {
+	txn := &types.DynamicFeeTransaction{
+		CommonTx: types.CommonTx{Nonce: nonce, GasLimit: 21_000, To: &sender, Value: uint256.NewInt(1)},
+		ChainID:  uint256.NewInt(1),
+		TipCap:   uint256.NewInt(tip),
+		FeeCap:   uint256.NewInt(tip),
+	}
+	txn.SetSender(sender)
+	return txn
+}
+
+func provideOpts(parentBlockNum uint64, yielded mapset.Set[[32]byte]) []txnprovider.ProvideOption {
+	return []txnprovider.ProvideOption{
+		txnprovider.WithParentBlockNum(parentBlockNum),
+		txnprovider.WithBlockTime(100),
+		txnprovider.WithGasTarget(1_000_000),
+		txnprovider.WithTxnIdsFilter(yielded),
+	}
+}
+
+type publicTxnProvider struct {
+	txns []types.Transaction
+}
+
+func (p *publicTxnProvider) ProvideTxns(_ context.Context, opts ...txnprovider.ProvideOption) ([]types.Transaction, error) {
+	provideOpts := txnprovider.ApplyProvideOptions(opts...)
+	var txns []types.Transaction
+	for _, txn := range p.txns {
+		if len(txns) == provideOpts.Amount {
+			break
+		}
+		if provideOpts.TxnIdsFilter.Add(txn.Hash()) {
+			txns = append(txns, txn)
+		}
+	}
+	return txns, nil
+}
+
+// nonceSimulator runs txns which only pay their tip to the coinbase, and fail when their nonce is
+// not the next one of their sender.
+type nonceSimulator struct{}
+
+func (nonceSimulator) NewSimulation(context.Context, uint64, uint64, common.Address) (bundle.Simulation, error) {
+	return &nonceSimulation{nonces: map[common.Address]uint64{}}, nil
+}
+
+type nonceSimulation struct {
+	nonces map[common.Address]uint64
+}
+
+func (s *nonceSimulation) BaseFee() *uint256.Int { return new(uint256.Int) }
+
+func (s *nonceSimulation) ApplyBundle(b *bundle.Bundle) (*bundle.SimulatedBundle, error) {
+	nonces := make(map[common.Address]uint64, len(s.nonces))
+	for sender, nonce := range s.nonces {
+		nonces[sender] = nonce
+	}
+	sb := &bundle.SimulatedBundle{Bundle: b}
+	for _, txn := range b.Txns {
+		sender, _ := txn.GetSender()
+		if nonces[sender] != txn.GetNonce() {
+			return nil, errors.New("bad nonce")
+		}
+		nonces[sender]++
+		sb.GasUsed += txn.GetGasLimit()
+		sb.Profit.Add(&sb.Profit, new(uint256.Int).Mul(txn.GetTipCap(), uint256.NewInt(txn.GetGasLimit())))
+	}
+	s.nonces = nonces
+	return sb, nil
+}
+
+func (s *nonceSimulation) ApplyTxn(txn types.Transaction) {
+	sender, _ := txn.GetSender()
+	if s.nonces[sender] == txn.GetNonce() {
+		s.nonces[sender]++
+	}
+}
+
+func (s *nonceSimulation) Reset() error {
+	s.nonces = map[common.Address]uint64{}
+	return nil
+}
+
+func (s *nonceSimulation) Close() {}
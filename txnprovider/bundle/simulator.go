@@ -0,0 +1,280 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus/misc"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+var (
+	ErrStateNotAtParent = errors.New("pending state is not at the parent block")
+	ErrBundleReverted   = errors.New("bundle transaction reverted")
+)
+
+// SimulatedBundle is the outcome of the execution of a bundle on top of the pending state.
+type SimulatedBundle struct {
+	Bundle  *Bundle
+	GasUsed uint64
+	// Profit is the increase of the coinbase balance: priority fees plus direct payments.
+	Profit uint256.Int
+}
+
+// EffectiveGasPrice is what the bundle pays the coinbase per unit of gas, to be compared with the
+// effective tip of public transactions.
+func (sb *SimulatedBundle) EffectiveGasPrice() *uint256.Int {
+	if sb.GasUsed == 0 {
+		return new(uint256.Int)
+	}
+	return new(uint256.Int).Div(&sb.Profit, uint256.NewInt(sb.GasUsed))
+}
+
+type Simulator interface {
+	// NewSimulation starts executing transactions on top of the state after the parent block, in
+	// a block with the given time and coinbase. The simulation must be closed.
+	NewSimulation(ctx context.Context, parentBlockNum uint64, blockTime uint64, coinbase common.Address) (Simulation, error)
+}
+
+type Simulation interface {
+	// BaseFee is the base fee of the simulated block, nil before London.
+	BaseFee() *uint256.Int
+	// ApplyBundle executes the transactions of the bundle after the ones applied so far. When one
+	// of them fails, or reverts without being allowed to, none of them is kept and an error is
+	// returned.
+	ApplyBundle(b *Bundle) (*SimulatedBundle, error)
+	// ApplyTxn executes a public transaction after the ones applied so far. It is skipped when it
+	// fails, the same way the block builder skips it.
+	ApplyTxn(txn types.Transaction)
+	// Reset drops all the transactions applied so far.
+	Reset() error
+	Close()
+}
+
+var _ Simulator = (*StateSimulator)(nil)
+
+// StateSimulator simulates bundles on the latest state of the database, which is the state the
+// mining stages build on. The block header is derived from the parent: the prev randao and the
+// beacon root of the payload attributes are not known here, nor are the system calls at the start
+// of the block executed, so bundles depending on those may be mispriced.
+type StateSimulator struct {
+	db          kv.TemporalRoDB
+	chainConfig *chain.Config
+	blockReader services.HeaderReader
+}
+
+func NewStateSimulator(db kv.TemporalRoDB, chainConfig *chain.Config, blockReader services.HeaderReader) *StateSimulator {
+	return &StateSimulator{
+		db:          db,
+		chainConfig: chainConfig,
+		blockReader: blockReader,
+	}
+}
+
+func (s *StateSimulator) NewSimulation(ctx context.Context, parentBlockNum uint64, blockTime uint64, coinbase common.Address) (Simulation, error) {
+	tx, err := s.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sim, err := s.newSimulation(ctx, tx, parentBlockNum, blockTime, coinbase)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return sim, nil
+}
+
+func (s *StateSimulator) newSimulation(ctx context.Context, tx kv.TemporalTx, parentBlockNum uint64, blockTime uint64, coinbase common.Address) (*stateSimulation, error) {
+	executed, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return nil, err
+	}
+	if executed != parentBlockNum {
+		return nil, fmt.Errorf("%w: state at %d, parent %d", ErrStateNotAtParent, executed, parentBlockNum)
+	}
+
+	parent, err := s.blockReader.HeaderByNumber(ctx, tx, parentBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("parent header %d not found", parentBlockNum)
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   coinbase,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		Number:     new(big.Int).SetUint64(parentBlockNum + 1),
+		GasLimit:   parent.GasLimit,
+		Time:       blockTime,
+	}
+	if s.chainConfig.IsLondon(parentBlockNum + 1) {
+		header.BaseFee = misc.CalcBaseFee(s.chainConfig, parent)
+	}
+	if s.chainConfig.IsCancun(blockTime) {
+		excessBlobGas := misc.CalcExcessBlobGas(s.chainConfig, parent, blockTime)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+	}
+
+	getHeader := func(hash common.Hash, number uint64) (*types.Header, error) {
+		return s.blockReader.Header(ctx, tx, hash, number)
+	}
+	sim := &stateSimulation{
+		tx:          tx,
+		chainConfig: s.chainConfig,
+		header:      header,
+		getHash:     core.GetHashFn(header, getHeader),
+	}
+	if err := sim.Reset(); err != nil {
+		return nil, err
+	}
+
+	return sim, nil
+}
+
+type stateSimulation struct {
+	tx          kv.TemporalTx
+	chainConfig *chain.Config
+	header      *types.Header
+	getHash     func(n uint64) (common.Hash, error)
+	ibs         *state.IntraBlockState
+	gasPool     *core.GasPool
+	gasUsed     uint64
+	blobGasUsed uint64
+	txnIndex    int
+	// applied are the transactions kept so far. The state can't be reverted across transactions,
+	// so it is rebuilt out of them when a bundle fails half way.
+	applied []types.Transaction
+}
+
+func (s *stateSimulation) BaseFee() *uint256.Int {
+	if s.header.BaseFee == nil {
+		return nil
+	}
+	baseFee, _ := uint256.FromBig(s.header.BaseFee)
+	return baseFee
+}
+
+func (s *stateSimulation) ApplyBundle(b *Bundle) (*SimulatedBundle, error) {
+	before, err := s.ibs.GetBalance(s.header.Coinbase)
+	if err != nil {
+		return nil, err
+	}
+
+	var gasUsed uint64
+	for _, txn := range b.Txns {
+		receipt, err := s.apply(txn)
+		if err == nil && receipt.Status == types.ReceiptStatusFailed && !b.CanRevert(txn.Hash()) {
+			err = fmt.Errorf("%w: %x", ErrBundleReverted, txn.Hash())
+		}
+		if err != nil {
+			if rebuildErr := s.rebuild(); rebuildErr != nil {
+				return nil, rebuildErr
+			}
+			return nil, err
+		}
+		gasUsed += receipt.GasUsed
+	}
+
+	after, err := s.ibs.GetBalance(s.header.Coinbase)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applied = append(s.applied, b.Txns...)
+	sb := &SimulatedBundle{Bundle: b, GasUsed: gasUsed}
+	if after.Gt(&before) {
+		sb.Profit.Sub(&after, &before)
+	}
+	return sb, nil
+}
+
+func (s *stateSimulation) ApplyTxn(txn types.Transaction) {
+	if _, err := s.apply(txn); err == nil {
+		s.applied = append(s.applied, txn)
+	}
+}
+
+func (s *stateSimulation) Reset() error {
+	s.applied = nil
+	return s.rebuild()
+}
+
+func (s *stateSimulation) Close() {
+	s.tx.Rollback()
+}
+
+func (s *stateSimulation) rebuild() error {
+	s.ibs = state.New(state.NewReaderV3(s.tx))
+	s.gasPool = new(core.GasPool).AddGas(s.header.GasLimit).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock(s.header.Time))
+	s.gasUsed = 0
+	s.blobGasUsed = 0
+	s.txnIndex = 0
+	applied := s.applied
+	s.applied = nil
+	for _, txn := range applied {
+		// replaying transactions which were applied before can only fail if the database changed
+		if _, err := s.apply(txn); err != nil {
+			return fmt.Errorf("replaying txn %x: %w", txn.Hash(), err)
+		}
+		s.applied = append(s.applied, txn)
+	}
+	return nil
+}
+
+func (s *stateSimulation) apply(txn types.Transaction) (*types.Receipt, error) {
+	s.ibs.SetTxContext(s.header.Number.Uint64(), s.txnIndex)
+	snapshot := s.ibs.Snapshot()
+	receipt, _, err := core.ApplyTransaction(
+		s.chainConfig,
+		s.getHash,
+		nil, // engine: the author is given
+		&s.header.Coinbase,
+		s.gasPool,
+		s.ibs,
+		state.NewNoopWriter(),
+		s.header,
+		txn,
+		&s.gasUsed,
+		&s.blobGasUsed,
+		vm.Config{},
+	)
+	if err != nil {
+		s.ibs.RevertToSnapshot(snapshot, err)
+		return nil, err
+	}
+	s.txnIndex++
+	return receipt, nil
+}
@@ -22,6 +22,7 @@ import (
 
 	mapset "github.com/deckarep/golang-set/v2"
 
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/types"
 )
 
@@ -33,6 +34,7 @@ type TxnProvider interface {
 	//   - WithBlobGasTarget
 	//   - WithTxnIdsFilter
 	//   - WithAvailableRlpSpace
+	//   - WithCoinbase
 	ProvideTxns(ctx context.Context, opts ...ProvideOption) ([]types.Transaction, error)
 }
 
@@ -80,6 +82,12 @@ func WithAvailableRlpSpace(size int) ProvideOption {
 	}
 }
 
+func WithCoinbase(coinbase common.Address) ProvideOption {
+	return func(opt *ProvideOptions) {
+		opt.Coinbase = coinbase
+	}
+}
+
 type ProvideOptions struct {
 	BlockTime         uint64
 	ParentBlockNum    uint64
@@ -88,6 +96,7 @@ type ProvideOptions struct {
 	BlobGasTarget     uint64
 	TxnIdsFilter      mapset.Set[[32]byte]
 	AvailableRlpSpace int
+	Coinbase          common.Address
 }
 
 func ApplyProvideOptions(opts ...ProvideOption) ProvideOptions {
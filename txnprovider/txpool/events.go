@@ -0,0 +1,150 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// eventsSubscriberBuffer - events a subscriber may fall behind before it gets unsubscribed
+const eventsSubscriberBuffer = 8192
+
+// EventsStreams - fans out transaction lifecycle events to subscribers.
+// The pool emits events while holding its lock, so broadcasting never blocks: a subscriber which can't keep up
+// is unsubscribed and its channel is closed. It resubscribes and re-reads the pool state instead of silently
+// missing events.
+type EventsStreams struct {
+	subs  map[uint]*eventsSubscriber
+	mu    sync.Mutex
+	id    uint
+	count atomic.Int32 // lets the pool skip building events when nobody listens
+}
+
+type eventsSubscriber struct {
+	senders map[common.Address]struct{} // empty - all senders
+	ch      chan *txpool_proto.Event
+}
+
+func (s *EventsStreams) Add(senders []common.Address) (events <-chan *txpool_proto.Event, remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs == nil {
+		s.subs = make(map[uint]*eventsSubscriber)
+	}
+	sub := &eventsSubscriber{
+		senders: make(map[common.Address]struct{}, len(senders)),
+		ch:      make(chan *txpool_proto.Event, eventsSubscriberBuffer),
+	}
+	for _, sender := range senders {
+		sub.senders[sender] = struct{}{}
+	}
+	s.id++
+	id := s.id
+	s.subs[id] = sub
+	s.count.Add(1)
+	return sub.ch, func() { s.remove(id) }
+}
+
+func (s *EventsStreams) HasSubscribers() bool {
+	return s.count.Load() > 0
+}
+
+func (s *EventsStreams) Broadcast(event *txpool_proto.Event, sender common.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subs {
+		if len(sub.senders) > 0 {
+			if _, ok := sub.senders[sender]; !ok {
+				continue
+			}
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			s.removeLocked(id)
+		}
+	}
+}
+
+func (s *EventsStreams) remove(id uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(id)
+}
+
+func (s *EventsStreams) removeLocked(id uint) {
+	sub, ok := s.subs[id]
+	if !ok { // double-unsubscribe support
+		return
+	}
+	delete(s.subs, id)
+	close(sub.ch)
+	s.count.Add(-1)
+}
+
+// SubscribeEvents - lifecycle events of transactions of given senders, of all senders if empty.
+// The channel is closed when the subscriber lags behind, remove must be called when done.
+func (p *TxPool) SubscribeEvents(senders []common.Address) (events <-chan *txpool_proto.Event, remove func()) {
+	return p.events.Add(senders)
+}
+
+// emitMovedLocked - mt was added to, or moved between, sub-pools and now is in subPool
+func (p *TxPool) emitMovedLocked(kind txpool_proto.Event_Kind, mt *metaTxn, subPool SubPoolType) {
+	if !p.events.HasSubscribers() {
+		return
+	}
+	event, sender := p.newEventLocked(kind, mt)
+	event.SubPool = convertSubPoolType(subPool)
+	p.events.Broadcast(event, sender)
+}
+
+// emitDiscardedLocked - mt left the pool, replacedBy is the new transaction for ReplacedByHigherTip
+func (p *TxPool) emitDiscardedLocked(mt *metaTxn, reason txpoolcfg.DiscardReason, replacedBy *metaTxn) {
+	if !p.events.HasSubscribers() {
+		return
+	}
+	kind := txpool_proto.Event_EVICTED
+	switch reason {
+	case txpoolcfg.Mined:
+		kind = txpool_proto.Event_MINED
+	case txpoolcfg.ReplacedByHigherTip:
+		kind = txpool_proto.Event_REPLACED
+	}
+	event, sender := p.newEventLocked(kind, mt)
+	event.Reason = reason.String()
+	if replacedBy != nil {
+		event.ReplacedBy = gointerfaces.ConvertHashToH256(replacedBy.TxnSlot.IDHash)
+	}
+	p.events.Broadcast(event, sender)
+}
+
+func (p *TxPool) newEventLocked(kind txpool_proto.Event_Kind, mt *metaTxn) (*txpool_proto.Event, common.Address) {
+	sender := p.senders.senderID2Addr[mt.TxnSlot.SenderID]
+	return &txpool_proto.Event{
+		Kind:     kind,
+		Hash:     gointerfaces.ConvertHashToH256(mt.TxnSlot.IDHash),
+		Sender:   gointerfaces.ConvertAddressToH160(sender),
+		Nonce:    mt.TxnSlot.Nonce,
+		BlockNum: p.eventsBlockNum,
+	}, sender
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+func TestEvents(t *testing.T) {
+	ctx := context.Background()
+	tp := newLocalsTestPools(t, txpoolcfg.DefaultConfig)
+	pool := tp.newPool()
+	tp.onNewBlock(pool, 0, 0)
+
+	events, remove := pool.SubscribeEvents([]common.Address{tp.addr})
+	defer remove()
+	others, removeOthers := pool.SubscribeEvents([]common.Address{{1}})
+	defer removeOthers()
+
+	txnSlots := tp.signedTxns(0, 1)
+	_, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(t, err)
+	first, second := txnSlots.Txns[0].IDHash, txnSlots.Txns[1].IDHash
+	got := drainEvents(events)
+	require.Len(t, got, 4)
+	requireEvent(t, got, txpool_proto.Event_ADDED, first, txpool_proto.AllReply_QUEUED, "")
+	requireEvent(t, got, txpool_proto.Event_ADDED, second, txpool_proto.AllReply_QUEUED, "")
+	requireEvent(t, got, txpool_proto.Event_PROMOTED, first, txpool_proto.AllReply_PENDING, "")
+	requireEvent(t, got, txpool_proto.Event_PROMOTED, second, txpool_proto.AllReply_PENDING, "")
+	require.Equal(t, tp.addr, common.Address(gointerfaces.ConvertH160toAddress(got[0].Sender)))
+
+	replacement := tp.signedTxnsWithPrice(400_000, 1)
+	reasons, err := pool.AddLocalTxns(ctx, replacement)
+	require.NoError(t, err)
+	require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	third := replacement.Txns[0].IDHash
+	got = drainEvents(events)
+	require.Len(t, got, 3)
+	replaced := requireEvent(t, got, txpool_proto.Event_REPLACED, second, txpool_proto.AllReply_PENDING, txpoolcfg.ReplacedByHigherTip.String())
+	require.Equal(t, common.Hash(third), common.Hash(gointerfaces.ConvertH256ToHash(replaced.ReplacedBy)))
+	require.Equal(t, uint64(1), replaced.Nonce)
+	requireEvent(t, got, txpool_proto.Event_ADDED, third, txpool_proto.AllReply_QUEUED, "")
+	requireEvent(t, got, txpool_proto.Event_PROMOTED, third, txpool_proto.AllReply_PENDING, "")
+
+	var mined TxnSlots
+	mined.Append(txnSlots.Txns[0], txnSlots.Senders.At(0), true)
+	tp.onNewBlockMined(pool, 1, 1, mined)
+	got = drainEvents(events)
+	require.Len(t, got, 1)
+	minedEvent := requireEvent(t, got, txpool_proto.Event_MINED, first, txpool_proto.AllReply_PENDING, txpoolcfg.Mined.String())
+	require.Equal(t, uint64(1), minedEvent.BlockNum)
+
+	require.Empty(t, drainEvents(others))
+}
+
+func TestEventsStreamsUnsubscribesLagging(t *testing.T) {
+	s := &EventsStreams{}
+	events, remove := s.Add(nil)
+	defer remove()
+	require.True(t, s.HasSubscribers())
+
+	for i := 0; i < eventsSubscriberBuffer; i++ {
+		s.Broadcast(&txpool_proto.Event{Nonce: uint64(i)}, common.Address{})
+	}
+	require.True(t, s.HasSubscribers())
+	s.Broadcast(&txpool_proto.Event{}, common.Address{})
+	require.False(t, s.HasSubscribers())
+
+	// buffered events are still delivered before the channel is closed
+	require.Len(t, drainEvents(events), eventsSubscriberBuffer)
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+// drainEvents returns the events already sent to the channel, the pool sends them synchronously
+func drainEvents(events <-chan *txpool_proto.Event) []*txpool_proto.Event {
+	var res []*txpool_proto.Event
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return res
+			}
+			res = append(res, event)
+		default:
+			return res
+		}
+	}
+}
+
+func requireEvent(t *testing.T, events []*txpool_proto.Event, kind txpool_proto.Event_Kind, hash common.Hash, subPool txpool_proto.AllReply_TxnType, reason string) *txpool_proto.Event {
+	t.Helper()
+	for _, event := range events {
+		if event.Kind == kind && common.Hash(gointerfaces.ConvertH256ToHash(event.Hash)) == hash {
+			require.Equal(t, subPool, event.SubPool)
+			require.Equal(t, reason, event.Reason)
+			return event
+		}
+	}
+	require.Failf(t, "event not found", "%s %x", kind, hash)
+	return nil
+}
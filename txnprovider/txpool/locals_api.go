@@ -0,0 +1,44 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+// LocalsAPI serves txpool_locals and txpool_dropLocal out of the local transactions journal. The
+// other txpool_ methods are served through the txpool gRPC interface.
+type LocalsAPI struct {
+	pool *TxPool
+}
+
+func NewLocalsAPI(pool *TxPool) *LocalsAPI {
+	return &LocalsAPI{pool: pool}
+}
+
+// Locals implements txpool_locals: the journaled local transactions, by sender and nonce.
+func (api *LocalsAPI) Locals(_ context.Context) ([]*LocalTxn, error) {
+	return api.pool.Locals(), nil
+}
+
+// DropLocal implements txpool_dropLocal: it stops keeping and re-broadcasting a local transaction,
+// and discards it from the pool. It returns false when the transaction is not journaled.
+func (api *LocalsAPI) DropLocal(ctx context.Context, hash common.Hash) (bool, error) {
+	return api.pool.DropLocal(ctx, hash)
+}
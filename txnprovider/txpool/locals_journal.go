@@ -0,0 +1,417 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// localsJournal keeps the local transactions until they are mined, dropped or outlive the configured
+// lifetime. It is committed to the pool db along with the pool, but is not subject to the sub-pools
+// limits: local transactions evicted from the pool are put back into it, and the ones still waiting to
+// be mined are re-broadcast every few blocks, in case peers dropped them.
+type localsJournal struct {
+	lifetime time.Duration
+	entries  map[string]*localTxn // txn_hash => journaled local txn
+	added    map[string]*localTxn // txn_hash => journaled since the last commit
+	deleted  map[string]struct{}  // txn_hash : forgotten since the last commit
+}
+
+type localTxn struct {
+	hash    common.Hash
+	sender  common.Address
+	nonce   uint64
+	rlp     []byte // as received: blob txns are wrapped with their blobs
+	addedAt time.Time
+	// lastSent is the block at which the txn was last broadcast or put back into the pool, not persisted
+	lastSent uint64
+}
+
+func newLocalsJournal(lifetime time.Duration) *localsJournal {
+	return &localsJournal{
+		lifetime: lifetime,
+		entries:  map[string]*localTxn{},
+		added:    map[string]*localTxn{},
+		deleted:  map[string]struct{}{},
+	}
+}
+
+func (j *localsJournal) add(lt *localTxn) {
+	hashS := string(lt.hash[:])
+	j.entries[hashS] = lt
+	j.added[hashS] = lt
+	delete(j.deleted, hashS)
+}
+
+func (j *localsJournal) remove(hashS string) bool {
+	if _, ok := j.entries[hashS]; !ok {
+		return false
+	}
+	delete(j.entries, hashS)
+	delete(j.added, hashS)
+	j.deleted[hashS] = struct{}{}
+	return true
+}
+
+func (j *localsJournal) contains(hashS string) bool {
+	_, ok := j.entries[hashS]
+	return ok
+}
+
+// expired tells if the txn outlived the journal lifetime. When the journal is disabled, the entries
+// of a previous run are all expired.
+func (j *localsJournal) expired(lt *localTxn, now time.Time) bool {
+	return j.lifetime == 0 || now.Sub(lt.addedAt) > j.lifetime
+}
+
+// load reads the journal out of the pool db, forgetting the entries which expired meanwhile.
+func (j *localsJournal) load(tx kv.Tx, now time.Time) error {
+	it, err := tx.Range(kv.PoolLocalTransaction, nil, nil, order.Asc, kv.Unlim)
+	if err != nil {
+		return err
+	}
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return err
+		}
+		lt, err := decodeLocalTxn(k, v)
+		if err != nil {
+			return err
+		}
+		if j.expired(lt, now) {
+			j.deleted[string(k)] = struct{}{}
+			continue
+		}
+		j.entries[string(k)] = lt
+	}
+	return nil
+}
+
+// flush writes the entries added and deletes the ones forgotten since the last commit.
+func (j *localsJournal) flush(tx kv.RwTx) error {
+	for hashS := range j.deleted {
+		if err := tx.Delete(kv.PoolLocalTransaction, []byte(hashS)); err != nil {
+			return err
+		}
+	}
+	for hashS, lt := range j.added {
+		if err := tx.Put(kv.PoolLocalTransaction, []byte(hashS), encodeLocalTxn(lt)); err != nil {
+			return err
+		}
+	}
+	clear(j.deleted)
+	clear(j.added)
+	return nil
+}
+
+// encodeLocalTxn encodes the txn as added_at_u64 + sender + tx_rlp, the nonce is parsed out of the rlp
+func encodeLocalTxn(lt *localTxn) []byte {
+	v := make([]byte, 8+20+len(lt.rlp))
+	binary.BigEndian.PutUint64(v, uint64(lt.addedAt.Unix()))
+	copy(v[8:], lt.sender[:])
+	copy(v[28:], lt.rlp)
+	return v
+}
+
+func decodeLocalTxn(k, v []byte) (*localTxn, error) {
+	if len(k) != 32 || len(v) < 8+20 {
+		return nil, fmt.Errorf("invalid local txn journal entry %x: %d bytes", k, len(v))
+	}
+	lt := &localTxn{
+		hash:    common.BytesToHash(k),
+		sender:  common.BytesToAddress(v[8:28]),
+		rlp:     common.CopyBytes(v[28:]),
+		addedAt: time.Unix(int64(binary.BigEndian.Uint64(v)), 0),
+	}
+	return lt, nil
+}
+
+// parseLocalTxn parses the journaled txn, and sets its nonce.
+func parseLocalTxn(parseCtx *TxnParseContext, lt *localTxn) (*TxnSlot, error) {
+	txn := &TxnSlot{}
+	if _, err := parseCtx.ParseTransaction(lt.rlp, 0, txn, nil, false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+		return nil, fmt.Errorf("parsing local txn %x: %w", lt.hash, err)
+	}
+	lt.nonce = txn.Nonce
+	return txn, nil
+}
+
+func accountNonce(cacheView kvcache.CacheView, addr common.Address) (uint64, error) {
+	encoded, err := cacheView.Get(addr.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	if len(encoded) == 0 {
+		return 0, nil
+	}
+	acc := accounts.Account{}
+	if err := accounts.DeserialiseV3(&acc, encoded); err != nil {
+		return 0, err
+	}
+	return acc.Nonce, nil
+}
+
+// journalLocalsLocked journals the local txns which made it into the pool, they are written to the
+// pool db at the next commit.
+func (p *TxPool) journalLocalsLocked(newTxns TxnSlots) {
+	if p.cfg.LocalsLifetime == 0 {
+		return
+	}
+	now := time.Now()
+	blockNum := p.lastSeenBlock.Load()
+	for i, txn := range newTxns.Txns {
+		// txns without rlp can't be restored, they only come from tests
+		if !newTxns.IsLocal[i] || txn.Rlp == nil {
+			continue
+		}
+		hashS := string(txn.IDHash[:])
+		if mt, ok := p.byHash[hashS]; !ok || mt.TxnSlot != txn || p.locals.contains(hashS) {
+			continue
+		}
+		lt := &localTxn{
+			hash:     txn.IDHash,
+			sender:   newTxns.Senders.AddressAt(i),
+			nonce:    txn.Nonce,
+			rlp:      common.CopyBytes(txn.Rlp),
+			addedAt:  now,
+			lastSent: blockNum,
+		}
+		// the txn replaces the journaled one with the same nonce, if any
+		for otherHashS, other := range p.locals.entries {
+			if other.sender == lt.sender && other.nonce == lt.nonce {
+				p.locals.remove(otherHashS)
+			}
+		}
+		p.locals.add(lt)
+	}
+}
+
+// localsFromDB loads the journal and returns its txns, to be added to the pool as local ones.
+func (p *TxPool) localsFromDB(tx kv.Tx, parseCtx *TxnParseContext, cacheView kvcache.CacheView) (TxnSlots, error) {
+	var txns TxnSlots
+	if err := p.locals.load(tx, time.Now()); err != nil {
+		return txns, err
+	}
+	lastSeenBlock := p.lastSeenBlock.Load()
+	for hashS, lt := range p.locals.entries {
+		txn, err := parseLocalTxn(parseCtx, lt)
+		if err != nil {
+			p.logger.Warn("[txpool] fromDB: local txn", "err", err)
+			p.locals.remove(hashS)
+			continue
+		}
+		lt.lastSent = lastSeenBlock
+		txn.SenderID, txn.Traced = p.senders.getOrCreateID(lt.sender, p.logger)
+		switch reason := p.validateTx(txn, true, cacheView); reason {
+		case txpoolcfg.Success, txpoolcfg.NotSet:
+		case txpoolcfg.NonceTooLow:
+			// mined while the node was down
+			p.locals.remove(hashS)
+			continue
+		default:
+			// kept in the journal, it is put back into the pool later if it becomes valid again
+			p.logger.Debug("[txpool] fromDB: local txn not valid", "hash", lt.hash, "reason", reason)
+			continue
+		}
+		txns.Append(txn, lt.sender[:], true)
+	}
+	return txns, nil
+}
+
+// localsBroadcast holds the local txns to re-broadcast, the rlps are those of the txns which can be
+// broadcast in full: blob txns are only announced.
+type localsBroadcast struct {
+	types  []byte
+	sizes  []uint32
+	hashes Hashes
+	rlps   [][]byte
+}
+
+// onNewBlockLocalsLocked forgets the journaled txns which were mined, replaced by a mined txn, or
+// expired. The other ones are, every cfg.LocalsRebroadcastBlocks blocks, put back into the pool when
+// they were evicted from it, and re-broadcast when they are pending.
+func (p *TxPool) onNewBlockLocalsLocked(blockNum uint64, cacheView kvcache.CacheView, now time.Time) (Announcements, *localsBroadcast, error) {
+	var announcements Announcements
+	broadcast := &localsBroadcast{}
+	var evicted TxnSlots
+	parseCtx := NewTxnParseContext(p.chainID)
+	parseCtx.WithSender(false)
+	for hashS, lt := range p.locals.entries {
+		nonce, err := accountNonce(cacheView, lt.sender)
+		if err != nil {
+			return announcements, nil, err
+		}
+		if lt.nonce < nonce || p.locals.expired(lt, now) {
+			p.locals.remove(hashS)
+			continue
+		}
+		if p.cfg.LocalsRebroadcastBlocks == 0 || blockNum < lt.lastSent+p.cfg.LocalsRebroadcastBlocks {
+			continue
+		}
+
+		mt, ok := p.byHash[hashS]
+		if !ok {
+			txn, err := parseLocalTxn(parseCtx, lt)
+			if err != nil {
+				p.logger.Warn("[txpool] local txn", "err", err)
+				p.locals.remove(hashS)
+				continue
+			}
+			lt.lastSent = blockNum
+			evicted.Append(txn, lt.sender[:], true)
+			continue
+		}
+		if mt.currentSubPool != PendingSubPool {
+			continue
+		}
+		lt.lastSent = blockNum
+		broadcast.types = append(broadcast.types, mt.TxnSlot.Type)
+		broadcast.sizes = append(broadcast.sizes, mt.TxnSlot.Size)
+		broadcast.hashes = append(broadcast.hashes, lt.hash[:]...)
+		// "Nodes MUST NOT automatically broadcast blob transactions to their peers" - EIP-4844
+		if mt.TxnSlot.Type != BlobTxnType {
+			if rlpTxn, err := types.UnwrapTxPlayloadRlp(lt.rlp); err == nil {
+				broadcast.rlps = append(broadcast.rlps, rlpTxn)
+			}
+		}
+	}
+
+	if len(evicted.Txns) == 0 {
+		return announcements, broadcast, nil
+	}
+	if err := p.senders.registerNewSenders(&evicted, p.logger); err != nil {
+		return announcements, nil, err
+	}
+	_, evicted, err := p.validateTxns(&evicted, cacheView)
+	if err != nil {
+		return announcements, nil, err
+	}
+	announcements, _, err = p.addTxns(blockNum, cacheView, p.senders, evicted,
+		p.pendingBaseFee.Load(), p.pendingBlobFee.Load(), p.blockGasLimit.Load(), true, p.logger)
+	if err != nil {
+		return announcements, nil, err
+	}
+	p.logger.Debug("[txpool] Local txns put back into the pool", "count", len(evicted.Txns), "block", blockNum)
+	return announcements, broadcast, nil
+}
+
+func (p *TxPool) rebroadcastLocals(broadcast *localsBroadcast) {
+	txnSentTo := p.p2pSender.BroadcastPooledTxns(broadcast.rlps, localTxnsBroadcastMaxPeers)
+	hashSentTo := p.p2pSender.AnnouncePooledTxns(broadcast.types, broadcast.sizes, broadcast.hashes, localTxnsBroadcastMaxPeers*2)
+	p.logger.Debug("[txpool] Local txns re-broadcast", "count", len(broadcast.types), "broadcast", len(txnSentTo), "announced", len(hashSentTo))
+}
+
+// LocalTxn is a journaled local transaction, as returned by txpool_locals.
+type LocalTxn struct {
+	Hash    common.Hash    `json:"hash"`
+	From    common.Address `json:"from"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+	AddedAt hexutil.Uint64 `json:"addedAt"` // unix time
+	// SubPool is the sub-pool holding the transaction, empty when it was evicted from the pool
+	SubPool string `json:"subPool"`
+}
+
+// Locals returns the journaled local transactions, by sender and nonce.
+func (p *TxPool) Locals() []*LocalTxn {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	locals := make([]*LocalTxn, 0, len(p.locals.entries))
+	for hashS, lt := range p.locals.entries {
+		local := &LocalTxn{
+			Hash:    lt.hash,
+			From:    lt.sender,
+			Nonce:   hexutil.Uint64(lt.nonce),
+			AddedAt: hexutil.Uint64(lt.addedAt.Unix()),
+		}
+		if mt, ok := p.byHash[hashS]; ok && mt.currentSubPool != 0 {
+			local.SubPool = mt.currentSubPool.String()
+		}
+		locals = append(locals, local)
+	}
+	sort.Slice(locals, func(i, j int) bool {
+		if locals[i].From != locals[j].From {
+			return locals[i].From.Cmp(locals[j].From) < 0
+		}
+		return locals[i].Nonce < locals[j].Nonce
+	})
+	return locals
+}
+
+// DropLocal forgets a journaled local transaction and discards it from the pool, so that it is
+// neither re-broadcast nor put back into the pool anymore. Peers which received it may still keep
+// it. It returns false when the transaction is not journaled.
+func (p *TxPool) DropLocal(ctx context.Context, hash common.Hash) (bool, error) {
+	coreDb, cache := p.chainDB()
+	coreTx, err := coreDb.BeginTemporalRo(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer coreTx.Rollback()
+
+	cacheView, err := cache.View(ctx, coreTx)
+	if err != nil {
+		return false, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	hashS := string(hash[:])
+	if !p.locals.remove(hashS) {
+		return false, nil
+	}
+	p.isLocalLRU.Remove(hashS)
+	if mt, ok := p.byHash[hashS]; ok {
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt, "drop-local", p.logger)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt, "drop-local", p.logger)
+		case QueuedSubPool:
+			p.queued.Remove(mt, "drop-local", p.logger)
+		default:
+			//already removed
+		}
+		p.discardLocked(mt, txpoolcfg.DroppedLocal)
+
+		// the txns of the sender with higher nonces now have a gap
+		senderID := mt.TxnSlot.SenderID
+		nonce, balance, err := p.senders.info(cacheView, senderID)
+		if err != nil {
+			return false, err
+		}
+		p.onSenderStateChange(senderID, nonce, balance, p.blockGasLimit.Load(), p.logger)
+		var announcements Announcements
+		p.promote(p.pendingBaseFee.Load(), p.pendingBlobFee.Load(), &announcements, p.logger)
+		p.pending.EnforceBestInvariants()
+	}
+	return true, nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	accounts3 "github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+func TestLocalsJournalSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	tp := newLocalsTestPools(t, txpoolcfg.DefaultConfig)
+	pool := tp.newPool()
+	tp.onNewBlock(pool, 0, 0)
+
+	txnSlots := tp.signedTxns(0, 1)
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(t, err)
+	require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success, txpoolcfg.Success}, reasons)
+	require.Len(t, pool.Locals(), 2)
+
+	_, err = pool.flush(ctx)
+	require.NoError(t, err)
+
+	// the first txn is mined, its journal entry is deleted at the next commit
+	tp.onNewBlock(pool, 1, 1)
+	require.Len(t, pool.Locals(), 1)
+	_, err = pool.flush(ctx)
+	require.NoError(t, err)
+
+	restarted := tp.newPool()
+	require.NoError(t, restarted.start(ctx))
+	locals := restarted.Locals()
+	require.Len(t, locals, 1)
+	require.Equal(t, common.Hash(txnSlots.Txns[1].IDHash), locals[0].Hash)
+	require.Equal(t, tp.addr, locals[0].From)
+	require.Equal(t, PendingSubPool.String(), locals[0].SubPool)
+	require.True(t, restarted.IsLocal(txnSlots.Txns[1].IDHash[:]))
+}
+
+func TestLocalsJournalPutsBackEvicted(t *testing.T) {
+	ctx := context.Background()
+	cfg := txpoolcfg.DefaultConfig
+	cfg.LocalsRebroadcastBlocks = 2
+	tp := newLocalsTestPools(t, cfg)
+	pool := tp.newPool()
+	tp.onNewBlock(pool, 0, 0)
+
+	txnSlots := tp.signedTxns(0)
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(t, err)
+	require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	hashS := string(txnSlots.Txns[0].IDHash[:])
+
+	pool.lock.Lock()
+	mt := pool.byHash[hashS]
+	require.NotNil(t, mt)
+	require.Equal(t, PendingSubPool, mt.currentSubPool)
+	pool.pending.Remove(mt, "test", pool.logger)
+	pool.discardLocked(mt, txpoolcfg.PendingPoolOverflow)
+	pool.lock.Unlock()
+
+	tp.onNewBlock(pool, 1, 0)
+	require.NotContains(t, pool.byHash, hashS)
+	require.Equal(t, "", pool.Locals()[0].SubPool)
+
+	tp.onNewBlock(pool, 2, 0)
+	require.Contains(t, pool.byHash, hashS)
+	require.True(t, pool.IsLocal(txnSlots.Txns[0].IDHash[:]))
+	require.Equal(t, PendingSubPool.String(), pool.Locals()[0].SubPool)
+
+	tp.onNewBlock(pool, 3, 1)
+	require.Empty(t, pool.Locals())
+}
+
+func TestDropLocal(t *testing.T) {
+	ctx := context.Background()
+	tp := newLocalsTestPools(t, txpoolcfg.DefaultConfig)
+	pool := tp.newPool()
+	tp.onNewBlock(pool, 0, 0)
+
+	txnSlots := tp.signedTxns(0, 1)
+	_, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(t, err)
+	dropped := common.Hash(txnSlots.Txns[0].IDHash)
+
+	ok, err := pool.DropLocal(ctx, dropped)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotContains(t, pool.byHash, string(dropped[:]))
+	locals := pool.Locals()
+	require.Len(t, locals, 1)
+	// the remaining txn has a nonce gap now
+	require.Equal(t, QueuedSubPool.String(), locals[0].SubPool)
+
+	ok, err = pool.DropLocal(ctx, dropped)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, err = pool.flush(ctx)
+	require.NoError(t, err)
+
+	restarted := tp.newPool()
+	require.NoError(t, restarted.start(ctx))
+	locals = restarted.Locals()
+	require.Len(t, locals, 1)
+	require.Equal(t, common.Hash(txnSlots.Txns[1].IDHash), locals[0].Hash)
+}
+
+// localsTestPools creates pools sharing their dbs and state cache, as a pool restarted would.
+type localsTestPools struct {
+	t      *testing.T
+	cfg    txpoolcfg.Config
+	db     kv.RwDB
+	coreDB kv.TemporalRwDB
+	cache  kvcache.Cache
+	key    *ecdsa.PrivateKey
+	addr   common.Address
+}
+
+func newLocalsTestPools(t *testing.T, cfg txpoolcfg.Config) *localsTestPools {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &localsTestPools{
+		t:      t,
+		cfg:    cfg,
+		db:     memdb.NewTestPoolDB(t),
+		coreDB: temporaltest.NewTestDB(t, datadir.New(t.TempDir())),
+		cache:  kvcache.New(kvcache.DefaultCoherentConfig),
+		key:    key,
+		addr:   crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+func (tp *localsTestPools) newPool() *TxPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	tp.t.Cleanup(cancel)
+	ch := make(chan Announcements, 100)
+	pool, err := New(ctx, ch, tp.db, tp.coreDB, tp.cfg, tp.cache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(tp.t, err)
+	return pool
+}
+
+// onNewBlock notifies the pool of a block after which the sender has the given nonce.
+func (tp *localsTestPools) onNewBlock(pool *TxPool, blockNum uint64, nonce uint64) {
+	tp.onNewBlockMined(pool, blockNum, nonce, TxnSlots{})
+}
+
+func (tp *localsTestPools) onNewBlockMined(pool *TxPool, blockNum uint64, nonce uint64, minedTxns TxnSlots) {
+	acc := accounts3.Account{
+		Nonce:       nonce,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200_000,
+		BlockGasLimit:       1_000_000,
+		ChangeBatch: []*remote.StateChange{
+			{
+				BlockHeight: blockNum,
+				BlockHash:   gointerfaces.ConvertHashToH256([32]byte{}),
+				Changes: []*remote.AccountChange{
+					{
+						Action:  remote.Action_UPSERT,
+						Address: gointerfaces.ConvertAddressToH160(tp.addr),
+						Data:    accounts3.SerialiseV3(&acc),
+					},
+				},
+			},
+		},
+	}
+	require.NoError(tp.t, pool.OnNewBlock(context.Background(), change, TxnSlots{}, TxnSlots{}, minedTxns))
+}
+
+// signedTxns returns signed txns of the sender, parsed the way the txpool gRPC server does.
+func (tp *localsTestPools) signedTxns(nonces ...uint64) TxnSlots {
+	return tp.signedTxnsWithPrice(300_000, nonces...)
+}
+
+func (tp *localsTestPools) signedTxnsWithPrice(gasPrice uint64, nonces ...uint64) TxnSlots {
+	chainID, _ := uint256.FromBig(chain.TestChainConfig.ChainID)
+	signer := types.LatestSignerForChainID(chain.TestChainConfig.ChainID)
+	parseCtx := NewTxnParseContext(*chainID)
+	var txnSlots TxnSlots
+	for i, nonce := range nonces {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{1}, uint256.NewInt(1), 21_000, uint256.NewInt(gasPrice), nil), *signer, tp.key)
+		require.NoError(tp.t, err)
+		var buf bytes.Buffer
+		require.NoError(tp.t, txn.MarshalBinary(&buf))
+
+		txnSlots.Resize(uint(i + 1))
+		txnSlots.Txns[i] = &TxnSlot{}
+		txnSlots.IsLocal[i] = true
+		_, err = parseCtx.ParseTransaction(buf.Bytes(), 0, txnSlots.Txns[i], txnSlots.Senders.At(i), false /* hasEnvelope */, true /* wrappedWithBlobs */, nil)
+		require.NoError(tp.t, err)
+	}
+	return txnSlots
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -119,6 +120,7 @@ type TxPool struct {
 	minedBlobTxnsByBlock    map[uint64][]*metaTxn            // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxnsByHash     map[string]*metaTxn              // (hash => mt): map of recently mined blobs
 	isLocalLRU              *simplelru.LRU[string, struct{}] // txn_hash => is_local : to restore isLocal flag of unwinded transactions
+	locals                  *localsJournal                   // local txns kept until mined : survive restarts and evictions
 	newPendingTxns          chan Announcements               // notifications about new txns in Pending sub-pool
 	all                     *BySenderAndNonce                // senderID => (sorted map of txn nonce => *metaTxn)
 	deletedTxns             []*metaTxn                       // list of discarded txns since last db commit
@@ -150,6 +152,8 @@ type TxPool struct {
 	p2pFetcher              *Fetch
 	p2pSender               *Send
 	newSlotsStreams         *NewSlotsStreams
+	events                  *EventsStreams // lifecycle events of txns for the Events subscribers
+	eventsBlockNum          uint64         // block the pool is at, or is applying, for events
 	ethBackend              remote.ETHBACKENDClient
 	builderNotifyNewTxns    func()
 	logger                  log.Logger
@@ -217,6 +221,7 @@ func New(
 		lastSeenCond:            sync.NewCond(lock),
 		byHash:                  map[string]*metaTxn{},
 		isLocalLRU:              localsHistory,
+		locals:                  newLocalsJournal(cfg.LocalsLifetime),
 		discardReasonsLRU:       discardHistory,
 		all:                     byNonce,
 		recentlyConnectedPeers:  &recentlyConnectedPeers{},
@@ -239,6 +244,7 @@ func New(
 		ethBackend:              ethBackend,
 		builderNotifyNewTxns:    builderNotifyNewTxns,
 		newSlotsStreams:         newSlotsStreams,
+		events:                  &EventsStreams{},
 		logger:                  logger,
 		auths:                   make(map[AuthAndNonce]*metaTxn),
 		blobHashToTxn: make(map[common.Hash]struct {
@@ -353,6 +359,7 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	}
 
 	p.lock.Lock()
+	p.eventsBlockNum = block
 	defer func() {
 		if err == nil {
 			p.lastSeenBlock.Store(block)
@@ -476,6 +483,16 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	p.queued.EnforceInvariants()
 	p.promote(pendingBaseFee, pendingBlobFee, &announcements, p.logger)
 	p.pending.EnforceBestInvariants()
+
+	localsAnnouncements, broadcast, err := p.onNewBlockLocalsLocked(block, cacheView, time.Now())
+	if err != nil {
+		return err
+	}
+	announcements.AppendOther(localsAnnouncements)
+	if len(broadcast.types) > 0 && !p.cfg.NoGossip {
+		go p.rebroadcastLocals(broadcast)
+	}
+
 	p.promoted.Reset()
 	p.promoted.AppendOther(announcements)
 
@@ -1451,6 +1468,7 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 	} else {
 		return nil, err
 	}
+	p.journalLocalsLocked(newTxns)
 	p.promoted.Reset()
 	p.promoted.AppendOther(announcements)
 
@@ -1691,6 +1709,7 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 			//already removed
 		}
 
+		p.emitDiscardedLocked(found, txpoolcfg.ReplacedByHigherTip, mt)
 		p.discardLocked(found, txpoolcfg.ReplacedByHigherTip)
 	}
 
@@ -1747,6 +1766,7 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 			Hash:        mt.TxnSlot.IDHash,
 		},
 	})
+	p.emitMovedLocked(txpoolproto.Event_ADDED, mt, QueuedSubPool)
 	if mt.TxnSlot.Type == BlobTxnType {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t + (uint64(len(mt.TxnSlot.BlobHashes))))
@@ -1771,6 +1791,9 @@ func (p *TxPool) discardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason) {
 	p.deletedTxns = append(p.deletedTxns, mt)
 	p.all.delete(mt, reason, p.logger)
 	p.discardReasonsLRU.Add(hashStr, reason)
+	if reason != txpoolcfg.ReplacedByHigherTip { // addLocked emits replacements, it knows the new txn
+		p.emitDiscardedLocked(mt, reason, nil)
+	}
 	if mt.TxnSlot.Type == BlobTxnType {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t - uint64(len(mt.TxnSlot.BlobHashes)))
@@ -2091,6 +2114,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 					Hash:        tx.TxnSlot.IDHash,
 				},
 			})
+			p.emitMovedLocked(txpoolproto.Event_DEMOTED, tx, BaseFeeSubPool)
 		} else {
 			p.queued.Add(tx, "demote-pending", logger)
 			sendChangeBatchEventToDiagnostics("Queued", "add", []diagnostics.TxnHashOrder{
@@ -2099,6 +2123,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 					Hash:        tx.TxnSlot.IDHash,
 				},
 			})
+			p.emitMovedLocked(txpoolproto.Event_DEMOTED, tx, QueuedSubPool)
 		}
 	}
 
@@ -2107,6 +2132,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 		tx := p.baseFee.PopBest()
 		announcements.Append(tx.TxnSlot.Type, tx.TxnSlot.Size, tx.TxnSlot.IDHash[:])
 		p.pending.Add(tx, logger)
+		p.emitMovedLocked(txpoolproto.Event_PROMOTED, tx, PendingSubPool)
 	}
 
 	// Demote worst transactions that do not qualify for base fee pool anymore, to queued sub pool, or discard
@@ -2119,6 +2145,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 				Hash:        tx.TxnSlot.IDHash,
 			},
 		})
+		p.emitMovedLocked(txpoolproto.Event_DEMOTED, tx, QueuedSubPool)
 	}
 
 	// Promote best transactions from the queued pool to either pending or base fee pool, while they qualify
@@ -2127,6 +2154,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 		if best.minFeeCap.Cmp(uint256.NewInt(pendingBaseFee)) >= 0 {
 			announcements.Append(tx.TxnSlot.Type, tx.TxnSlot.Size, tx.TxnSlot.IDHash[:])
 			p.pending.Add(tx, logger)
+			p.emitMovedLocked(txpoolproto.Event_PROMOTED, tx, PendingSubPool)
 		} else {
 			p.baseFee.Add(tx, "promote-queued", logger)
 			sendChangeBatchEventToDiagnostics("BaseFee", "add", []diagnostics.TxnHashOrder{
@@ -2135,6 +2163,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 					Hash:        tx.TxnSlot.IDHash,
 				},
 			})
+			p.emitMovedLocked(txpoolproto.Event_PROMOTED, tx, BaseFeeSubPool)
 		}
 	}
 
@@ -2144,7 +2173,7 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 	// Discard worst transactions from pending pool until it is within capacity limit
 	for p.pending.Len() > p.pending.limit {
 		tx := p.pending.PopWorst()
-		p.discardLocked(p.pending.PopWorst(), txpoolcfg.PendingPoolOverflow)
+		p.discardLocked(tx, txpoolcfg.PendingPoolOverflow)
 		sendChangeBatchEventToDiagnostics("Pending", "remove", []diagnostics.TxnHashOrder{
 			{
 				OrderMarker: uint8(tx.subPool),
@@ -2178,6 +2207,8 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 	}
 }
 
+const localTxnsBroadcastMaxPeers uint64 = 10
+
 // Run - does:
 // send pending byHash to p2p:
 //   - new byHash
@@ -2329,7 +2360,6 @@ func (p *TxPool) Run(ctx context.Context) error {
 				}
 
 				// broadcast local transactions
-				const localTxnsBroadcastMaxPeers uint64 = 10
 				txnSentTo := p.p2pSender.BroadcastPooledTxns(localTxnRlps, localTxnsBroadcastMaxPeers)
 				for i, peer := range txnSentTo {
 					p.logger.Trace("Local txn broadcast", "txHash", hex.EncodeToString(broadcastHashes.At(i)), "to peer", peer)
@@ -2452,6 +2482,10 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 		}
 	}
 
+	if err := p.locals.flush(tx); err != nil {
+		return err
+	}
+
 	v := make([]byte, 0, 1024)
 	for txHash, metaTx := range p.byHash {
 		if metaTx.TxnSlot.Rlp == nil {
@@ -2529,6 +2563,50 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 	if err != nil {
 		return err
 	}
+
+	var pendingBaseFee, pendingBlobFee, minBlobGasPrice, blockGasLimit uint64
+
+	if p.feeCalculator != nil {
+		pendingBaseFee, pendingBlobFee, minBlobGasPrice, blockGasLimit, err = p.feeCalculator.CurrentFees(p.chainConfig, coreTx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if pendingBaseFee == 0 {
+		v, err := tx.GetOne(kv.PoolInfo, PoolPendingBaseFeeKey)
+		if err != nil {
+			return err
+		}
+		if len(v) > 0 {
+			pendingBaseFee = binary.BigEndian.Uint64(v)
+		}
+	}
+
+	if pendingBlobFee == 0 {
+		v, err := tx.GetOne(kv.PoolInfo, PoolPendingBlobFeeKey)
+		if err != nil {
+			return err
+		}
+		if len(v) > 0 {
+			pendingBlobFee = binary.BigEndian.Uint64(v)
+		}
+	}
+
+	if pendingBlobFee == 0 {
+		pendingBlobFee = minBlobGasPrice
+	}
+
+	if blockGasLimit == 0 {
+		if p.chainConfig.DefaultBlockGasLimit != nil {
+			blockGasLimit = *p.chainConfig.DefaultBlockGasLimit
+		} else {
+			blockGasLimit = ethconfig.DefaultBlockGasLimit
+		}
+	}
+	// validateTx checks the txns gas against it
+	p.blockGasLimit.Store(blockGasLimit)
+
 	it, err := tx.Range(kv.RecentLocalTransaction, nil, nil, order.Asc, kv.Unlim)
 	if err != nil {
 		return err
@@ -2541,11 +2619,16 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 		p.isLocalLRU.Add(string(v), struct{}{})
 	}
 
-	txns := TxnSlots{}
 	parseCtx := NewTxnParseContext(p.chainID)
 	parseCtx.WithSender(false)
 
-	i := 0
+	// journaled local txns take precedence over their copy in PoolTransaction, which may have lost its local flag
+	txns, err := p.localsFromDB(tx, parseCtx, cacheView)
+	if err != nil {
+		return err
+	}
+
+	i := len(txns.Txns)
 	it, err = tx.Range(kv.PoolTransaction, nil, nil, order.Asc, kv.Unlim)
 	if err != nil {
 		return err
@@ -2555,6 +2638,9 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 		if err != nil {
 			return err
 		}
+		if p.locals.contains(string(k)) {
+			continue
+		}
 		addr, txnRlp := *(*[20]byte)(v[:20]), v[20:]
 		txn := &TxnSlot{}
 
@@ -2571,7 +2657,8 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 		isLocalTx := p.isLocalLRU.Contains(string(k))
 
 		if reason := p.validateTx(txn, isLocalTx, cacheView); reason != txpoolcfg.NotSet && reason != txpoolcfg.Success {
-			return nil // TODO: Clarify - if one of the txns has the wrong reason, no pooled txns!
+			// e.g. mined while the node was down: skip it rather than dropping all pooled txns
+			continue
 		}
 		txns.Resize(uint(i + 1))
 		txns.Txns[i] = txn
@@ -2580,47 +2667,6 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 		i++
 	}
 
-	var pendingBaseFee, pendingBlobFee, minBlobGasPrice, blockGasLimit uint64
-
-	if p.feeCalculator != nil {
-		pendingBaseFee, pendingBlobFee, minBlobGasPrice, blockGasLimit, err = p.feeCalculator.CurrentFees(p.chainConfig, coreTx)
-		if err != nil {
-			return err
-		}
-	}
-
-	if pendingBaseFee == 0 {
-		v, err := tx.GetOne(kv.PoolInfo, PoolPendingBaseFeeKey)
-		if err != nil {
-			return err
-		}
-		if len(v) > 0 {
-			pendingBaseFee = binary.BigEndian.Uint64(v)
-		}
-	}
-
-	if pendingBlobFee == 0 {
-		v, err := tx.GetOne(kv.PoolInfo, PoolPendingBlobFeeKey)
-		if err != nil {
-			return err
-		}
-		if len(v) > 0 {
-			pendingBlobFee = binary.BigEndian.Uint64(v)
-		}
-	}
-
-	if pendingBlobFee == 0 {
-		pendingBlobFee = minBlobGasPrice
-	}
-
-	if blockGasLimit == 0 {
-		if p.chainConfig.DefaultBlockGasLimit != nil {
-			blockGasLimit = *p.chainConfig.DefaultBlockGasLimit
-		} else {
-			blockGasLimit = ethconfig.DefaultBlockGasLimit
-		}
-	}
-
 	err = p.senders.registerNewSenders(&txns, p.logger)
 	if err != nil {
 		return err
@@ -2631,7 +2677,6 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.TemporalTx) err
 	}
 	p.pendingBaseFee.Store(pendingBaseFee)
 	p.pendingBlobFee.Store(pendingBlobFee)
-	p.blockGasLimit.Store(blockGasLimit)
 	return nil
 }
 
@@ -2676,21 +2721,45 @@ func (p *TxPool) logStats() {
 	queuedSubCounter.SetInt(p.queued.Len())
 }
 
+// forEachFilter narrows down the transactions visited by deprecatedForEach, the zero value visits all of them
+type forEachFilter struct {
+	sender   *common.Address // only transactions of this sender
+	subPools []SubPoolType   // only transactions of these sub-pools, all if empty
+	offset   int             // matching transactions to skip, they are ordered by sender and nonce
+	limit    int             // maximum number of transactions to visit, unlimited if zero
+}
+
 // Deprecated need switch to streaming-like
-func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx) {
+func (p *TxPool) deprecatedForEach(_ context.Context, filter forEachFilter, f func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker), tx kv.Tx) {
 	var txns []*metaTxn
 	var senders []common.Address
 
 	p.lock.Lock()
 
-	p.all.ascendAll(func(mt *metaTxn) bool {
-		if sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]; found {
-			txns = append(txns, mt)
-			senders = append(senders, sender)
+	skip := filter.offset
+	visit := func(mt *metaTxn) bool {
+		if len(filter.subPools) > 0 && !slices.Contains(filter.subPools, mt.currentSubPool) {
+			return true
 		}
-
-		return true
-	})
+		sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]
+		if !found {
+			return true
+		}
+		if skip > 0 {
+			skip--
+			return true
+		}
+		txns = append(txns, mt)
+		senders = append(senders, sender)
+		return filter.limit == 0 || len(txns) < filter.limit
+	}
+	if filter.sender != nil {
+		if senderID, found := p.senders.getID(*filter.sender); found {
+			p.all.ascend(senderID, visit)
+		}
+	} else {
+		p.all.ascendAll(visit)
+	}
 
 	p.lock.Unlock()
 
@@ -2709,7 +2778,7 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 			slotRlp = v[20:]
 		}
 
-		f(slotRlp, senders[i], txns[i].currentSubPool)
+		f(slotRlp, senders[i], txns[i].currentSubPool, txns[i].subPool)
 	}
 }
 
@@ -37,6 +37,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/turbo/telemetry"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 
 	"github.com/erigontech/erigon-lib/common"
@@ -55,11 +56,12 @@ type txPool interface {
 	PeekBest(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64, availableRlpSpace int) (bool, error)
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
 	AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error)
-	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx)
+	deprecatedForEach(_ context.Context, filter forEachFilter, f func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker), tx kv.Tx)
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
 	GetBlobs(blobhashes []common.Hash) (blobBundles []PoolBlobBundle)
+	SubscribeEvents(senders []common.Address) (events <-chan *txpool_proto.Event, remove func())
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -67,6 +69,9 @@ var _ txpool_proto.TxpoolServer = (*GrpcDisabled)(nil) // compile-time interface
 
 var ErrPoolDisabled = errors.New("TxPool Disabled")
 
+// ErrEventsLagging - the Events subscriber didn't keep up and was unsubscribed, it may resubscribe
+var ErrEventsLagging = errors.New("txpool events subscriber is lagging behind")
+
 type GrpcDisabled struct {
 	txpool_proto.UnimplementedTxpoolServer
 }
@@ -98,6 +103,9 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) Events(request *txpool_proto.EventsRequest, server txpool_proto.Txpool_EventsServer) error {
+	return ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -129,7 +137,34 @@ func convertSubPoolType(t SubPoolType) txpool_proto.AllReply_TxnType {
 		panic("unknown")
 	}
 }
-func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpool_proto.AllReply, error) {
+
+func convertTxnType(t txpool_proto.AllReply_TxnType) (SubPoolType, error) {
+	switch t {
+	case txpool_proto.AllReply_PENDING:
+		return PendingSubPool, nil
+	case txpool_proto.AllReply_BASE_FEE:
+		return BaseFeeSubPool, nil
+	case txpool_proto.AllReply_QUEUED:
+		return QueuedSubPool, nil
+	default:
+		return 0, fmt.Errorf("unknown txn type: %d", t)
+	}
+}
+
+func (s *GrpcServer) All(ctx context.Context, in *txpool_proto.AllRequest) (*txpool_proto.AllReply, error) {
+	filter := forEachFilter{offset: int(in.Offset), limit: int(in.Limit)}
+	if in.Sender != nil {
+		sender := common.Address(gointerfaces.ConvertH160toAddress(in.Sender))
+		filter.sender = &sender
+	}
+	for _, t := range in.TxnTypes {
+		subPool, err := convertTxnType(t)
+		if err != nil {
+			return nil, err
+		}
+		filter.subPools = append(filter.subPools, subPool)
+	}
+
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
 		return nil, err
@@ -137,11 +172,12 @@ func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpo
 	defer tx.Rollback()
 	reply := &txpool_proto.AllReply{}
 	reply.Txs = make([]*txpool_proto.AllReply_Tx, 0, 32)
-	s.txPool.deprecatedForEach(ctx, func(rlp []byte, sender common.Address, t SubPoolType) {
+	s.txPool.deprecatedForEach(ctx, filter, func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker) {
 		reply.Txs = append(reply.Txs, &txpool_proto.AllReply_Tx{
 			Sender:  gointerfaces.ConvertAddressToH160(sender),
 			TxnType: convertSubPoolType(t),
 			RlpTx:   common.Copy(rlp),
+			SubPool: uint32(marker),
 		})
 	}, tx)
 	return reply, nil
@@ -286,6 +322,31 @@ func (s *GrpcServer) OnAdd(req *txpool_proto.OnAddRequest, stream txpool_proto.T
 	}
 }
 
+func (s *GrpcServer) Events(req *txpool_proto.EventsRequest, stream txpool_proto.Txpool_EventsServer) error {
+	senders := make([]common.Address, len(req.Senders))
+	for i, sender := range req.Senders {
+		senders[i] = gointerfaces.ConvertH160toAddress(sender)
+	}
+	s.logger.Debug("New txpool events subscriber joined", "senders", len(senders))
+	events, remove := s.txPool.SubscribeEvents(senders)
+	defer remove()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return ErrEventsLagging
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
+
 func (s *GrpcServer) Transactions(ctx context.Context, in *txpool_proto.TransactionsRequest) (*txpool_proto.TransactionsReply, error) {
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
@@ -405,6 +466,7 @@ func StartGrpc(txPoolServer txpool_proto.TxpoolServer, miningServer txpool_proto
 		}),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		telemetry.ServerOption(),
 	}
 	if creds == nil {
 		// no specific opts
@@ -56,6 +56,10 @@ type Config struct {
 
 	NoGossip bool // this mode doesn't broadcast any txns, and if receive remote-txn - skip it
 
+	// local transactions journal
+	LocalsLifetime          time.Duration // How long local transactions are journaled while not mined, 0 disables the journal
+	LocalsRebroadcastBlocks uint64        // Blocks after which a local transaction not mined yet is re-broadcast, 0 disables it
+
 	// Account Abstraction
 	AllowAA bool
 }
@@ -79,6 +83,9 @@ var DefaultConfig = Config{
 
 	NoGossip:     false,
 	MdbxWriteMap: false,
+
+	LocalsLifetime:          24 * time.Hour,
+	LocalsRebroadcastBlocks: 10,
 }
 
 type DiscardReason uint8
@@ -121,6 +128,7 @@ const (
 	ErrAuthorityReserved DiscardReason = 34 // EIP-7702 transaction with authority already reserved
 	InvalidAA            DiscardReason = 35 // Invalid RIP-7560 transaction
 	ErrGetCode           DiscardReason = 36 // Error getting code during AA validation
+	DroppedLocal         DiscardReason = 37 // Local transaction dropped through txpool_dropLocal
 )
 
 func (r DiscardReason) String() string {
@@ -199,6 +207,8 @@ func (r DiscardReason) String() string {
 		return "RIP-7560 transaction failed validation"
 	case ErrGetCode:
 		return "error getting account code during RIP-7560 validation"
+	case DroppedLocal:
+		return "local transaction dropped"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}